@@ -20,9 +20,29 @@
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 //
+// @securityDefinitions.apikey ProviderSignature
+// @in header
+// @name X-Dispatcher-Signature
+// @description Provider callbacks only: "sha256=" followed by the hex HMAC-SHA256 of "<X-Dispatcher-Timestamp>.<raw body>" keyed with webhook.callbacks.secret.
+//
 // @tag.name messages
 // @tag.description Message management operations
 //
+// @tag.name templates
+// @tag.description Message template management
+//
+// @tag.name suppressions
+// @tag.description Recipient opt-out (suppression) list
+//
+// @tag.name inbound
+// @tag.description Replies from recipients and opt-out keywords
+//
+// @tag.name delivery-receipts
+// @tag.description Carrier delivery reports for sent messages
+//
+// @tag.name subscriptions
+// @tag.description Signed status-change callbacks to client endpoints
+//
 // @tag.name messaging
 // @tag.description Message processing operations
 //
@@ -47,8 +67,11 @@ import (
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/routes"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/repositories/postgres"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/services/cache"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/services/callback"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/services/webhook"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	usecasePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 	schedulerServices "github.com/svbnbyrk/go-message-dispatcher/internal/core/services"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/config"
@@ -102,8 +125,18 @@ func main() {
 	defer postgres.CloseConnectionPool(pool)
 	logger.Info("Database connection established successfully")
 
-	// Initialize message repository
+	// Initialize repositories
 	messageRepo := postgres.NewMessageRepository(pool)
+	idempotencyRepo := postgres.NewIdempotencyRepository(pool)
+	attemptRepo := postgres.NewMessageAttemptRepository(pool)
+	importRepo := postgres.NewImportJobRepository(pool)
+	templateRepo := postgres.NewTemplateRepository(pool)
+	suppressionRepo := postgres.NewSuppressionRepository(pool)
+	inboundRepo := postgres.NewInboundMessageRepository(pool)
+	receiptRepo := postgres.NewDeliveryReceiptRepository(pool)
+	subscriptionRepo := postgres.NewSubscriptionRepository(pool)
+	eventDeliveryRepo := postgres.NewEventDeliveryRepository(pool)
+	statusEventRepo := postgres.NewStatusEventRepository(pool)
 
 	// Initialize cache service
 	logger.Info("Initializing cache service", zap.String("type", "redis"))
@@ -117,26 +150,148 @@ func main() {
 	cacheService := cache.NewRedisService(cacheConfig)
 	logger.Info("Cache service initialized successfully")
 
-	// Initialize webhook service
-	logger.Info("Initializing webhook service", zap.String("url", cfg.Webhook.URL))
-	webhookConfig := services.WebhookConfig{
-		URL:              cfg.Webhook.URL,
-		AuthToken:        cfg.Webhook.AuthToken,
-		Timeout:          cfg.Webhook.Timeout,
-		MaxRetries:       cfg.Webhook.MaxRetries,
-		RetryBackoffBase: cfg.Webhook.RetryBackoffBase,
+	// Providers limiting their throughput share one Redis client, each with its own bucket
+	webhookRateLimiter := cache.NewRedisRateLimiter(cacheConfig)
+
+	// Initialize webhook service, one client per provider behind a failover router
+	var webhookProviders []schedulerServices.WebhookProvider
+	for _, provider := range cfg.Webhook.ProviderList() {
+		logger.Info("Initializing webhook provider", zap.String("provider", provider.Name), zap.String("url", provider.URL))
+		rateLimit := cfg.Webhook.ProviderRateLimit(provider)
+		webhookConfig := services.WebhookConfig{
+			Provider:         provider.Name,
+			URL:              provider.URL,
+			AuthToken:        provider.AuthToken,
+			Timeout:          cfg.Webhook.Timeout,
+			MaxRetries:       cfg.Webhook.MaxRetries,
+			RetryBackoffBase: cfg.Webhook.RetryBackoffBase,
+			RetryBackoffMax:  cfg.Webhook.RetryBackoffMax,
+			RateLimit: services.RateLimit{
+				Rate:          rateLimit.Rate,
+				Burst:         rateLimit.Burst,
+				FallbackShare: rateLimit.FallbackShare,
+			},
+			RateLimitMaxWait: rateLimit.MaxWait,
+		}
+
+		webhookProvider := schedulerServices.WebhookProvider{
+			Name:    provider.Name,
+			Service: webhook.NewWebhookService(webhookConfig, attemptRepo),
+		}
+		if rateLimit.Enabled {
+			logger.Info("Limiting webhook throughput",
+				zap.String("provider", provider.Name),
+				zap.Float64("rate", rateLimit.Rate),
+				zap.Int("burst", rateLimit.Burst),
+			)
+			webhookProvider.Service = webhook.NewRateLimitedWebhookService(webhookConfig, attemptRepo, webhookRateLimiter)
+		}
+		if cfg.Webhook.CircuitBreaker.Enabled {
+			breaker := schedulerServices.NewWebhookCircuitBreaker(webhookProvider.Service, schedulerServices.CircuitBreakerConfig{
+				Name:             provider.Name,
+				FailureThreshold: cfg.Webhook.CircuitBreaker.FailureThreshold,
+				OpenDuration:     cfg.Webhook.CircuitBreaker.OpenDuration,
+				HalfOpenRequests: cfg.Webhook.CircuitBreaker.HalfOpenRequests,
+			})
+			webhookProvider.Service = breaker
+			webhookProvider.Circuit = breaker
+		}
+		webhookProviders = append(webhookProviders, webhookProvider)
 	}
-	webhookService := webhook.NewWebhookService(webhookConfig)
-	logger.Info("Webhook service initialized successfully")
+
+	webhookRouter := schedulerServices.NewWebhookRouter(webhookProviders)
+	var webhookCircuit services.CircuitBreaker
+	if cfg.Webhook.CircuitBreaker.Enabled {
+		webhookCircuit = webhookRouter
+	}
+	logger.Info("Webhook service initialized successfully", zap.Int("providers", len(webhookProviders)))
+
+	// Initialize status-change callback service
+	callbackService := callback.NewEventCallbackService(services.EventCallbackConfig{
+		Timeout: cfg.Subscriptions.Timeout,
+	})
 
 	// Initialize use cases
 	logger.Info("Initializing use cases")
-	messageMgmtUseCase := usecases.NewMessageManagementService(messageRepo, cacheService)
+	if region := cfg.Messages.DefaultRegion; region != "" && !message.IsKnownRegion(region) {
+		logger.Fatal("Unknown default phone number region", zap.String("default_region", region))
+	}
+	managementConfig := usecasePorts.ManagementConfig{
+		IdempotencyRetention: cfg.Messages.IdempotencyRetention,
+		BatchMaxItems:        cfg.Messages.BatchMaxItems,
+		ImportChunkSize:      cfg.Messages.ImportChunkSize,
+		ImportStaleAfter:     cfg.Messages.ImportStaleAfter,
+		MaxSegments:          cfg.Messages.MaxSegments,
+		DefaultRegion:        cfg.Messages.DefaultRegion,
+	}
+	messageMgmtUseCase := usecases.NewMessageManagementService(
+		messageRepo,
+		idempotencyRepo,
+		attemptRepo,
+		importRepo,
+		templateRepo,
+		suppressionRepo,
+		cacheService,
+		managementConfig,
+	)
+	// Imports interrupted by an earlier stop would otherwise stay processing forever
+	if failed, err := messageMgmtUseCase.RecoverImportJobs(ctx); err != nil {
+		logger.Warn("Failed to recover interrupted imports", zap.Error(err))
+	} else if failed > 0 {
+		logger.Info("Marked interrupted imports as failed", zap.Int64("jobs", failed))
+	}
+	templateUseCase := usecases.NewTemplateManagementService(templateRepo)
+	suppressionUseCase := usecases.NewSuppressionManagementService(suppressionRepo, usecasePorts.SuppressionConfig{
+		DefaultRegion:  cfg.Messages.DefaultRegion,
+		ImportMaxItems: cfg.Messages.SuppressionImportMaxItems,
+	})
+	optOutKeywords, optInKeywords := cfg.Messages.OptOutKeywords, cfg.Messages.OptInKeywords
+	if len(optOutKeywords) == 0 {
+		optOutKeywords = message.DefaultOptOutKeywords
+	}
+	if len(optInKeywords) == 0 {
+		optInKeywords = message.DefaultOptInKeywords
+	}
+	keywords, err := message.NewKeywords(optOutKeywords, optInKeywords)
+	if err != nil {
+		logger.Fatal("Invalid opt-out keywords", zap.Error(err))
+	}
+	inboundUseCase := usecases.NewInboundMessageService(messageRepo, inboundRepo, suppressionRepo, usecasePorts.InboundConfig{
+		DefaultRegion: cfg.Messages.DefaultRegion,
+		Keywords:      keywords,
+	})
+	receiptUseCase := usecases.NewDeliveryReceiptService(messageRepo, receiptRepo, cacheService)
+	processingConfig := usecasePorts.ProcessingConfig{
+		RetryBackoffBase: cfg.Scheduler.RetryBackoffBase,
+		RetryBackoffMax:  cfg.Scheduler.RetryBackoffMax,
+		WorkerID:         cfg.Scheduler.WorkerID,
+		LeaseDuration:    cfg.Scheduler.LeaseDuration,
+		Concurrency:      cfg.Scheduler.Concurrency,
+		MaxBatchSize:     cfg.Scheduler.MaxBatchSize,
+		BatchTimeout:     cfg.Scheduler.BatchTimeout,
+
+		UrgentReservedShare: cfg.Scheduler.UrgentReservedShare,
+		UrgentPollInterval:  cfg.Scheduler.UrgentPollInterval,
+	}
 	messageProcessingUseCase := usecases.NewMessageProcessingService(
 		messageRepo,
-		webhookService,
+		suppressionRepo,
+		receiptRepo,
+		webhookRouter,
 		cacheService,
+		processingConfig,
 	)
+	subscriptionUseCase := usecases.NewSubscriptionManagementService(subscriptionRepo, eventDeliveryRepo, usecasePorts.SubscriptionConfig{
+		ReplayMaxEvents: cfg.Subscriptions.ReplayMaxEvents,
+	})
+	eventDeliveryUseCase := usecases.NewEventDeliveryService(subscriptionRepo, eventDeliveryRepo, callbackService, usecasePorts.EventDeliveryConfig{
+		MaxAttempts:      cfg.Subscriptions.MaxAttempts,
+		RetryBackoffBase: cfg.Subscriptions.RetryBackoffBase,
+		RetryBackoffMax:  cfg.Subscriptions.RetryBackoffMax,
+		WorkerID:         cfg.Scheduler.WorkerID,
+		LeaseDuration:    cfg.Subscriptions.LeaseDuration,
+		Concurrency:      cfg.Subscriptions.Concurrency,
+	})
 	logger.Info("Use cases initialized successfully")
 
 	// Initialize scheduler
@@ -149,21 +304,42 @@ func main() {
 	}
 	scheduler := schedulerServices.NewProcessingScheduler(
 		messageProcessingUseCase,
+		webhookCircuit,
 		schedulerConfig,
 	)
+	eventDispatcher := schedulerServices.NewEventDispatcher(eventDeliveryUseCase, schedulerServices.EventDispatcherConfig{
+		Interval:  cfg.Subscriptions.Interval,
+		BatchSize: cfg.Subscriptions.BatchSize,
+	})
+	statusEventHub := schedulerServices.NewStatusEventHub(statusEventRepo, postgres.NewStatusEventListener(pool), schedulerServices.StatusEventHubConfig{
+		DefaultRegion: cfg.Messages.DefaultRegion,
+		ResumeWindow:  cfg.Stream.ResumeWindow,
+		PollInterval:  cfg.Stream.PollInterval,
+		BufferSize:    cfg.Stream.BufferSize,
+		GapTimeout:    cfg.Stream.GapTimeout,
+	})
 	logger.Info("Background scheduler initialized successfully")
 
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler()
+	healthHandler := handlers.NewHealthHandler(webhookCircuit)
 	messageHandler := handlers.NewMessageHandler(messageMgmtUseCase, messageProcessingUseCase)
+	messageStreamHandler := handlers.NewMessageStreamHandler(statusEventHub, cfg.Stream.HeartbeatInterval)
+	templateHandler := handlers.NewTemplateHandler(templateUseCase)
+	suppressionHandler := handlers.NewSuppressionHandler(suppressionUseCase)
+	inboundHandler := handlers.NewInboundHandler(inboundUseCase)
+	receiptHandler := handlers.NewDeliveryReceiptHandler(receiptUseCase)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionUseCase)
 	schedulerHandler := handlers.NewSchedulerHandler(scheduler, cfg.Scheduler.Interval, cfg.Scheduler.BatchSize)
 
 	// Setup routes
 	logger.Info("Setting up HTTP routes")
 	routerConfig := routes.RouterConfig{
-		APIKey: cfg.App.APIKey,
+		APIKey:                     cfg.App.APIKey,
+		CallbackSecret:             cfg.Webhook.Callbacks.Secret,
+		CallbackSignatureTolerance: cfg.Webhook.Callbacks.SignatureTolerance,
+		CallbackRequestsPerMinute:  cfg.Webhook.Callbacks.RequestsPerMinute,
 	}
-	router := routes.SetupRouter(routerConfig, messageHandler, healthHandler, schedulerHandler)
+	router := routes.SetupRouter(routerConfig, messageHandler, messageStreamHandler, templateHandler, suppressionHandler, inboundHandler, receiptHandler, subscriptionHandler, healthHandler, schedulerHandler)
 
 	// Create HTTP server
 	server := &http.Server{
@@ -179,6 +355,12 @@ func main() {
 	if err := scheduler.Start(context.Background()); err != nil {
 		logger.Fatal("Failed to start scheduler", zap.Error(err))
 	}
+	if err := eventDispatcher.Start(context.Background()); err != nil {
+		logger.Fatal("Failed to start event dispatcher", zap.Error(err))
+	}
+	if err := statusEventHub.Start(context.Background()); err != nil {
+		logger.Fatal("Failed to start status event hub", zap.Error(err))
+	}
 
 	// Start server in a goroutine
 	go func() {
@@ -211,6 +393,13 @@ func main() {
 	} else {
 		logger.Info("Background scheduler stopped successfully")
 	}
+	if err := eventDispatcher.Stop(); err != nil {
+		logger.Error("Error stopping event dispatcher", err)
+	}
+	// Open message streams only end once their clients are disconnected
+	if err := statusEventHub.Stop(); err != nil {
+		logger.Error("Error stopping status event hub", err)
+	}
 
 	// Shutdown server
 	logger.Info("Shutting down HTTP server")
@@ -220,5 +409,10 @@ func main() {
 		logger.Info("HTTP server stopped successfully")
 	}
 
+	// In-flight deliveries are done once the scheduler has stopped
+	if err := webhookRateLimiter.Close(); err != nil {
+		logger.Error("Error closing webhook rate limiter", err)
+	}
+
 	logger.Info("💤 Message Dispatcher shutdown complete")
 }
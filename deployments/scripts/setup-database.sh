@@ -44,13 +44,16 @@ PGPASSWORD=$POSTGRES_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $POSTGRES_USER -d
 
 # Run migrations
 echo "🔄 Running database migrations..."
-MIGRATION_FILE="../../internal/adapters/secondary/repositories/postgres/migrations/001_create_messages.up.sql"
+MIGRATION_DIR="../../internal/adapters/secondary/repositories/postgres/migrations"
 
-if [ -f "$MIGRATION_FILE" ]; then
-    PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f "$MIGRATION_FILE"
+if ls "$MIGRATION_DIR"/*.up.sql > /dev/null 2>&1; then
+    for MIGRATION_FILE in "$MIGRATION_DIR"/*.up.sql; do
+        echo "   Applying $(basename "$MIGRATION_FILE")"
+        PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f "$MIGRATION_FILE"
+    done
     echo "✅ Migrations applied successfully"
 else
-    echo "⚠️  Migration file not found: $MIGRATION_FILE"
+    echo "⚠️  Migration files not found in: $MIGRATION_DIR"
     echo "   Please run migrations manually"
 fi
 
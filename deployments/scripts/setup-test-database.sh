@@ -63,13 +63,16 @@ docker exec $CONTAINER_NAME psql -U postgres -d $DB_NAME -c "ALTER DEFAULT PRIVI
 
 # Run migrations
 echo "🔄 Running database migrations..."
-MIGRATION_FILE="internal/adapters/secondary/repositories/postgres/migrations/001_create_messages.up.sql"
+MIGRATION_DIR="internal/adapters/secondary/repositories/postgres/migrations"
 
-if [ -f "$MIGRATION_FILE" ]; then
-    docker exec -i $CONTAINER_NAME psql -U $DB_USER -d $DB_NAME < "$MIGRATION_FILE"
+if ls "$MIGRATION_DIR"/*.up.sql > /dev/null 2>&1; then
+    for MIGRATION_FILE in "$MIGRATION_DIR"/*.up.sql; do
+        echo "   Applying $(basename "$MIGRATION_FILE")"
+        docker exec -i $CONTAINER_NAME psql -U $DB_USER -d $DB_NAME < "$MIGRATION_FILE"
+    done
     echo "✅ Migrations applied successfully"
 else
-    echo "⚠️  Migration file not found: $MIGRATION_FILE"
+    echo "⚠️  Migration files not found in: $MIGRATION_DIR"
     echo "   Please ensure you're running this script from the project root"
 fi
 
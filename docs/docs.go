@@ -24,43 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
-            "get": {
-                "description": "Get the health status of the API server",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.HealthResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/messages": {
+        "/conversations/{phoneNumber}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of messages with optional filtering and pagination",
+                "description": "Retrieve the outbound messages and replies of a phone number as one timeline, most recent first, together with its suppression list entry. Any spelling of the number is accepted; URL-encode the leading + as %2B.",
                 "consumes": [
                     "application/json"
                 ],
@@ -68,27 +39,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "inbound"
                 ],
-                "summary": "List messages with pagination",
+                "summary": "Get the conversation with a phone number",
                 "parameters": [
                     {
-                        "enum": [
-                            "pending",
-                            "sent",
-                            "failed"
-                        ],
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Phone number",
+                        "name": "phoneNumber",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "maximum": 100,
                         "minimum": 1,
                         "type": "integer",
                         "default": 20,
-                        "description": "Number of messages to return (1-100)",
+                        "description": "Number of entries to return (1-100)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -96,7 +63,7 @@ const docTemplate = `{
                         "minimum": 0,
                         "type": "integer",
                         "default": 0,
-                        "description": "Number of messages to skip",
+                        "description": "Number of entries to skip",
                         "name": "offset",
                         "in": "query"
                     }
@@ -105,20 +72,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.MessageResponse"
-                            }
-                        },
-                        "headers": {
-                            "X-Total-Count": {
-                                "type": "string",
-                                "description": "Total number of messages"
-                            }
+                            "$ref": "#/definitions/dto.ConversationResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid parameters",
+                        "description": "Bad request - invalid phone number",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -142,14 +100,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/delivery-receipts": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ProviderSignature": []
                     }
                 ],
-                "description": "Create a new message to be sent via webhook with automatic retry mechanism",
+                "description": "Provider callback reporting whether a sent message reached the handset. The message is found by the externalId the provider returned when accepting it and moves from SENT to DELIVERED or UNDELIVERED, keeping the carrier error code. A receipt that arrives before the message is marked SENT is stored and applied as soon as it is (202). Sending the same receipt again is harmless: the first receipt for an externalId stays on record. Authenticated by the provider's X-Dispatcher-Signature of the body and X-Dispatcher-Timestamp, not by the client API key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,35 +117,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "delivery-receipts"
                 ],
-                "summary": "Create a new message",
+                "summary": "Receive a delivery receipt",
                 "parameters": [
                     {
-                        "description": "Message data",
-                        "name": "message",
+                        "description": "Delivery report forwarded by the provider",
+                        "name": "receipt",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateMessageRequest"
+                            "$ref": "#/definitions/dto.DeliveryReceiptRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Applied to the message",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/dto.DeliveryReceiptResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Stored; applied once the message is marked SENT",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeliveryReceiptResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid input",
+                        "description": "Bad request - missing externalId or invalid status",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - missing or invalid API key",
+                        "description": "Unauthorized - missing, stale or invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The message cannot take a delivery outcome",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -205,14 +177,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/messages/{id}": {
+        "/health": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a specific message by its unique identifier",
+                "description": "Get the health status of the API server. The status is degraded, still with HTTP 200, while the webhook circuit breaker is open and deliveries are held back.",
                 "consumes": [
                     "application/json"
                 ],
@@ -220,47 +187,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
-                ],
-                "summary": "Get a message by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Message ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "health"
                 ],
+                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request - invalid message ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Message not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "429": {
-                        "description": "Rate limit exceeded",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.HealthResponse"
                         }
                     },
                     "500": {
@@ -272,14 +206,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/messaging/process": {
+        "/inbound": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ProviderSignature": []
                     }
                 ],
-                "description": "Manually trigger message processing for testing purposes",
+                "description": "Provider callback for a reply sent by a recipient. The reply is stored with the recipient's phone number. A reply that is exactly an opt-out keyword (STOP, UNSUBSCRIBE, IPTAL, ...) puts the number on the suppression list; an opt-in keyword (START, ...) lifts an earlier keyword opt-out. Callbacks delivered again with the same messageId return the stored reply without side effects. Authenticated by the provider's X-Dispatcher-Signature of the body and X-Dispatcher-Timestamp, not by the client API key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,29 +221,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messaging"
+                    "inbound"
                 ],
-                "summary": "Process messages manually",
+                "summary": "Receive a reply",
                 "parameters": [
                     {
-                        "maximum": 10,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 2,
-                        "description": "Number of messages to process (1-10)",
-                        "name": "batch_size",
-                        "in": "query"
+                        "description": "Reply forwarded by the provider",
+                        "name": "reply",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProcessingResultResponse"
+                            "$ref": "#/definitions/dto.InboundMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid phone number or content",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing, stale or invalid signature",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -329,14 +269,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/messaging/status": {
+        "/messages": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the current message processing status and statistics",
+                "description": "Retrieve a list of messages with optional filtering and pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -344,14 +284,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messaging"
+                    "messages"
+                ],
+                "summary": "List messages with pagination",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "processing",
+                            "sent",
+                            "failed",
+                            "expired",
+                            "cancelled",
+                            "suppressed",
+                            "delivered",
+                            "undelivered"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only pending messages whose send time is in the future",
+                        "name": "scheduled",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of messages to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of messages to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get processing status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProcessingStatusResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MessageResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of messages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -373,16 +370,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/scheduler/start": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Start the background message processing scheduler",
+                "description": "Create a new message to be sent via webhook with automatic retry mechanism. Set sendAt to deliver it at a later time. Send either content or a templateId with the variables its placeholders need. Content may span up to messages.max_segments SMS parts (153 GSM-7 or 67 UCS-2 characters each). A message to a suppressed phone number is stored as SUPPRESSED and never sent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,27 +385,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "messages"
+                ],
+                "summary": "Create a new message",
+                "parameters": [
+                    {
+                        "description": "Message data",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMessageRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request; replays return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Start scheduler",
                 "responses": {
-                    "200": {
-                        "description": "Scheduler started successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing or invalid API key",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Scheduler already running",
+                        "description": "Request with the same idempotency key is still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key already used with a different request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -430,14 +451,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/scheduler/status": {
-            "get": {
+        "/messages/batch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the current status and statistics of the background message scheduler",
+                "description": "Create up to messages.batch_max_items messages in one request. Each message is validated on its own: valid ones are created together and invalid ones are reported per item without failing the request.",
                 "consumes": [
                     "application/json"
                 ],
@@ -445,14 +466,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "messages"
                 ],
-                "summary": "Get scheduler status",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SchedulerStatusResponse"
+                "summary": "Create messages in bulk",
+                "parameters": [
+                    {
+                        "description": "Messages to create",
+                        "name": "messages",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMessagesBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "All messages created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMessagesBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some or all messages were rejected",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMessagesBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid body, no messages or too many messages",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -476,14 +520,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/scheduler/stop": {
-            "post": {
+        "/messages/dead-letter": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Stop the background message processing scheduler",
+                "description": "Retrieve FAILED messages with their last error, error class, HTTP status and attempt count",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,17 +535,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "messages"
+                ],
+                "summary": "List permanently failed messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by phone number",
+                        "name": "phone_number",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "timeout",
+                            "network",
+                            "http_4xx",
+                            "http_5xx",
+                            "invalid_response",
+                            "unknown"
+                        ],
+                        "type": "string",
+                        "description": "Filter by class of the last error",
+                        "name": "error_class",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only messages that failed at or after this time (RFC 3339)",
+                        "name": "failed_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only messages that failed before this time (RFC 3339)",
+                        "name": "failed_to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of messages to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of messages to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Stop scheduler",
                 "responses": {
                     "200": {
-                        "description": "Scheduler stopped successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MessageResponse"
                             }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of failed messages matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -510,8 +617,86 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Scheduler not running",
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a CSV or NDJSON file and create a message per row in the background. CSV files need a header with a phoneNumber (or phone) column and either a content column, a templateId column or the templateId query parameter; they may add sendAt, expiresAt and ttl columns, and any other column is a template variable. NDJSON lines use the create message request fields. The format is taken from the format query parameter, the Content-Type (text/csv, application/x-ndjson) or the extension of a multipart \"file\" part. Rows are validated one by one; rejected rows are listed in the error report.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Import messages from a file",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format, overrides the Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template for rows with neither content nor a templateId of their own",
+                        "name": "templateId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to import when uploading as multipart/form-data",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Import started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - unknown format or template, empty file or missing CSV columns",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File larger than 50 MB",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -530,76 +715,2877 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateMessageRequest": {
-            "type": "object",
-            "required": [
-                "content",
-                "phoneNumber"
-            ],
-            "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Hello World! This is a test message."
-                },
-                "phoneNumber": {
-                    "type": "string",
-                    "example": "+905551234567"
+        },
+        "/messages/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the status and row counts of a message file import",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get the progress of an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid import job ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Validation failed"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "phoneNumber is required"
+        "/messages/imports/{id}/errors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every row rejected so far by an import as a CSV file with row, error and data columns. Row numbers count data rows from 1, not including a CSV header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Download the error report of an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV error report",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid import job ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/requeue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move all FAILED messages matching the filter back to PENDING. An empty body requeues every failed message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Requeue failed messages in bulk",
+                "parameters": [
+                    {
+                        "description": "Failed message filter",
+                        "name": "filter",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequeueMessagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequeueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-sent event stream of messages being created (event message.created) and changing status (event message.status_changed), on any instance. Each event's data is JSON in the shape of a subscription callback body and its id is the event ID. Reconnecting with the Last-Event-ID header (or last_event_id) first sends the changes missed since that event, as far back as the stream's resume window reaches. Clients too slow to keep up are disconnected and resume the same way. A comment line is sent periodically to keep the connection open.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Stream message status changes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only stream changes to these statuses (comma-separated or repeated)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only stream changes of messages to this phone number",
+                        "name": "phone_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID; the Last-Event-ID header takes precedence",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageStreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid status, phone number or event ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Message stream is not running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific message by its unique identifier, including its status history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get a message by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}/attempts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every webhook request made for a message, oldest first, with its duration, HTTP status, response excerpt and error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List delivery attempts of a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of attempts to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of attempts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttemptResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of attempts of the message"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a PENDING or scheduled message so it is never sent. Messages already picked up for delivery cannot be cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Cancel a pending message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Message was claimed for delivery while being cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Message is not in PENDING status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}/requeue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a FAILED message back to PENDING with a fresh retry budget",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Requeue a failed message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Message is not in FAILED status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messaging/process": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Manually trigger message processing for testing purposes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messaging"
+                ],
+                "summary": "Process messages manually",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 2,
+                        "description": "Number of messages to process, capped at scheduler.max_batch_size",
+                        "name": "batch_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessingResultResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messaging/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current message processing status and statistics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messaging"
+                ],
+                "summary": "Get processing status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessingStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start the background message processing scheduler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Start scheduler",
+                "responses": {
+                    "200": {
+                        "description": "Scheduler started successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scheduler already running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current status and statistics of the background message scheduler, including the webhook circuit breaker that pauses claiming while the endpoint is down",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Get scheduler status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SchedulerStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop the background message processing scheduler",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Stop scheduler",
+                "responses": {
+                    "200": {
+                        "description": "Scheduler stopped successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scheduler not running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve status-change subscriptions, oldest first. Secrets are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List subscriptions",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of subscriptions to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of subscriptions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SubscriptionResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of subscriptions"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register an endpoint that receives a signed POST for every matching message status change. Each callback carries X-Dispatcher-Event-Id, X-Dispatcher-Timestamp (unix seconds) and X-Dispatcher-Signature: \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret. The secret is only returned in this response. Callbacks not answered with 2xx are retried with exponential backoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Subscribe to message status changes",
+                "parameters": [
+                    {
+                        "description": "Endpoint and status filter",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid URL, description or status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a status-change subscription without its secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the endpoint, description and status filter of a subscription, or pause it with active=false. A paused subscription queues no new status changes and holds its pending callbacks until it is resumed. The secret stays the same.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Update a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New endpoint and status filter",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid subscription ID, URL, description or status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop delivering status changes to an endpoint. Its pending callbacks and delivery log are removed.",
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Delete a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Subscription deleted"
+                    },
+                    "400": {
+                        "description": "Bad request - invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the delivery log of a subscription, most recent first: one entry per status change queued for the endpoint, with its attempts and latest outcome",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List the callbacks of a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by delivery state",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of deliveries to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.EventDeliveryResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of matching deliveries"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid subscription ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue every status change in [from, to) that the subscription matches for delivery again, e.g. after the endpoint was down. Replayed callbacks keep their event ID and carry \"replay\": true, so already processed events can be recognized. Ranges holding more events than the configured maximum are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Replay status changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time range to replay",
+                        "name": "replay",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplayEventsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplayEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid subscription ID or range, or too many events in range",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppressions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the suppression list, most recently suppressed first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "List suppressed phone numbers",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of entries to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SuppressionResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of suppressed phone numbers"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put a phone number that opted out on the suppression list. New messages to it are stored as SUPPRESSED and pending ones are withheld instead of sent. Suppressing a number twice keeps and returns the original entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Suppress a phone number",
+                "parameters": [
+                    {
+                        "description": "Phone number to suppress",
+                        "name": "suppression",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddSuppressionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuppressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid phone number or reason",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppressions/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put many phone numbers on the suppression list at once. Send a JSON body, or a CSV file (raw text/csv body or a multipart \"file\" part) with a phoneNumber column and an optional reason column. Invalid entries are reported without stopping the others.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Suppress many phone numbers",
+                "parameters": [
+                    {
+                        "description": "Phone numbers to suppress",
+                        "name": "suppressions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportSuppressionsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for CSV rows without a reason column",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportSuppressionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - unreadable body or too many entries",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppressions/{phoneNumber}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the suppression list entry of a phone number. Any spelling of the number is accepted; URL-encode the leading + as %2B.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Check whether a phone number is suppressed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phoneNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The number is suppressed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuppressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid phone number",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The number is not suppressed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allow messages to a phone number again, e.g. after it opted back in. Messages already SUPPRESSED stay suppressed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Remove a phone number from the suppression list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phoneNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Removed"
+                    },
+                    "400": {
+                        "description": "Bad request - invalid phone number",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The number is not suppressed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve templates ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List message templates",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of templates to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of templates to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TemplateResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "string",
+                                "description": "Total number of templates"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named message body with {{variable}} placeholders. Messages created with its templateId get the body rendered with their variables.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a message template",
+                "parameters": [
+                    {
+                        "description": "Template data",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid name or body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A template with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current version of a template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a message template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid template ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the body of a template. A changed body becomes a new version; messages already created keep the version they were rendered from. The name cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Update a message template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New template body",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid template ID or body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Template was updated concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a template. Messages already rendered from it are kept and still reference it.",
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete a message template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Template deleted"
+                    },
+                    "400": {
+                        "description": "Bad request - invalid template ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddSuppressionRequest": {
+            "type": "object",
+            "required": [
+                "phoneNumber"
+            ],
+            "properties": {
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Replied STOP"
+                }
+            }
+        },
+        "dto.AttemptResponse": {
+            "type": "object",
+            "properties": {
+                "attemptNumber": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "durationMs": {
+                    "type": "integer",
+                    "example": 245
+                },
+                "error": {
+                    "type": "string",
+                    "example": "webhook returned HTTP 503: upstream unavailable"
+                },
+                "errorClass": {
+                    "type": "string",
+                    "enum": [
+                        "timeout",
+                        "network",
+                        "http_4xx",
+                        "http_5xx",
+                        "invalid_response",
+                        "unknown"
+                    ],
+                    "example": "http_5xx"
+                },
+                "httpStatus": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9b2f0c1e-6a4d-4f3b-8c55-2f7d3e1a9b10"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "backup"
+                },
+                "responseBody": {
+                    "type": "string",
+                    "example": "upstream unavailable"
+                },
+                "retryCount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:00Z"
+                }
+            }
+        },
+        "dto.BatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid phone number format: 12345"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "$ref": "#/definitions/dto.MessageResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "failed"
+                    ],
+                    "example": "created"
+                }
+            }
+        },
+        "dto.CircuitBreakerResponse": {
+            "type": "object",
+            "properties": {
+                "consecutiveFailures": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "openedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "retryAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:30Z"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "closed",
+                        "open",
+                        "half_open"
+                    ],
+                    "example": "closed"
+                }
+            }
+        },
+        "dto.ConversationEntryResponse": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "outbound",
+                        "inbound"
+                    ],
+                    "example": "inbound"
+                },
+                "inbound": {
+                    "$ref": "#/definitions/dto.InboundMessageResponse"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "outbound": {
+                    "$ref": "#/definitions/dto.MessageResponse"
+                }
+            }
+        },
+        "dto.ConversationResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConversationEntryResponse"
+                    }
+                },
+                "hasMore": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "suppression": {
+                    "$ref": "#/definitions/dto.SuppressionResponse"
+                }
+            }
+        },
+        "dto.CreateMessageRequest": {
+            "type": "object",
+            "required": [
+                "phoneNumber"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Hello World! This is a test message."
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:15:00Z"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "critical",
+                        "high",
+                        "normal",
+                        "bulk"
+                    ],
+                    "example": "critical"
+                },
+                "sendAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:00:00Z"
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90"
+                },
+                "ttl": {
+                    "type": "string",
+                    "example": "15m"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateMessagesBatchRequest": {
+            "type": "object",
+            "required": [
+                "messages"
+            ],
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateMessageRequest"
+                    }
+                }
+            }
+        },
+        "dto.CreateMessagesBatchResponse": {
+            "type": "object",
+            "properties": {
+                "createdCount": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "failedCount": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BatchItemResponse"
+                    }
+                }
+            }
+        },
+        "dto.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "CRM delivery sync"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SENT",
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/message-status"
+                }
+            }
+        },
+        "dto.CreateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "name"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Hi {{name}}, your order {{order_id}} has shipped."
+                },
+                "name": {
+                    "type": "string",
+                    "example": "order-shipped"
+                }
+            }
+        },
+        "dto.DeliveryReceiptRequest": {
+            "type": "object",
+            "required": [
+                "externalId",
+                "status"
+            ],
+            "properties": {
+                "errorCode": {
+                    "type": "string",
+                    "example": "30005"
+                },
+                "externalId": {
+                    "type": "string",
+                    "example": "whatsapp_msg_12345"
+                },
+                "reportedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:05Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ],
+                    "example": "DELIVERED"
+                }
+            }
+        },
+        "dto.DeliveryReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "appliedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:06Z"
+                },
+                "duplicate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errorCode": {
+                    "type": "string",
+                    "example": "30005"
+                },
+                "externalId": {
+                    "type": "string",
+                    "example": "whatsapp_msg_12345"
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "receivedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:06Z"
+                },
+                "reportedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:05Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ],
+                    "example": "DELIVERED"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "phoneNumber is required"
+                }
+            }
+        },
+        "dto.EventDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:05Z"
+                },
+                "deliveredAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:06Z"
+                },
+                "eventId": {
+                    "type": "integer",
+                    "example": 58213
+                },
+                "from": {
+                    "type": "string",
+                    "example": "SENT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1042
+                },
+                "lastAttemptAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:06Z"
+                },
+                "lastError": {
+                    "type": "string",
+                    "example": "endpoint returned HTTP 503: upstream unavailable"
+                },
+                "lastStatusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:33:05Z"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:05Z"
+                },
+                "replay": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "DELIVERED",
+                        "FAILED"
+                    ],
+                    "example": "DELIVERED"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "DELIVERED"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "degraded",
+                        "error"
+                    ],
+                    "example": "ok"
+                },
+                "uptime": {
+                    "type": "string",
+                    "example": "2h30m45s"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "webhookCircuit": {
+                    "$ref": "#/definitions/dto.CircuitBreakerResponse"
+                }
+            }
+        },
+        "dto.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:09Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "createdCount": {
+                    "type": "integer",
+                    "example": 1497
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to read import file: bufio.Scanner: token too long"
+                },
+                "errorsUrl": {
+                    "type": "string",
+                    "example": "/api/v1/messages/imports/9b2f6c1e-3d4a-4f5b-8c7d-1e2f3a4b5c6d/errors"
+                },
+                "failedCount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "ndjson"
+                    ],
+                    "example": "csv"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9b2f6c1e-3d4a-4f5b-8c7d-1e2f3a4b5c6d"
+                },
+                "processedRows": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "processing",
+                        "completed",
+                        "failed"
+                    ],
+                    "example": "processing"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:05Z"
+                }
+            }
+        },
+        "dto.ImportSuppressionsRequest": {
+            "type": "object",
+            "required": [
+                "suppressions"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Carrier opt-out list"
+                },
+                "suppressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AddSuppressionRequest"
+                    }
+                }
+            }
+        },
+        "dto.ImportSuppressionsResponse": {
+            "type": "object",
+            "properties": {
+                "addedCount": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "duplicateCount": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rejectedCount": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rejectedEntries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SuppressionImportErrorEntry"
+                    }
+                }
+            }
+        },
+        "dto.InboundMessageRequest": {
+            "type": "object",
+            "required": [
+                "from"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "STOP"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "inbound_msg_12345"
+                },
+                "receivedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                }
+            }
+        },
+        "dto.InboundMessageResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "STOP"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:01Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "keywordAction": {
+                    "type": "string",
+                    "enum": [
+                        "opt_out",
+                        "opt_in"
+                    ],
+                    "example": "opt_out"
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "inbound_msg_12345"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "receivedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                }
+            }
+        },
+        "dto.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "attemptCount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 4
+                },
+                "carrierErrorCode": {
+                    "type": "string",
+                    "example": "30005"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Hello World! This is a test message."
+                },
+                "countryCode": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "deliveryReportedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:05Z"
+                },
+                "encoding": {
+                    "type": "string",
+                    "enum": [
+                        "GSM-7",
+                        "UCS-2"
+                    ],
+                    "example": "GSM-7"
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:15:00Z"
+                },
+                "externalId": {
+                    "type": "string",
+                    "example": "whatsapp_msg_12345"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "lastError": {
+                    "type": "string",
+                    "example": "webhook returned HTTP 503: upstream unavailable"
+                },
+                "lastErrorClass": {
+                    "type": "string",
+                    "enum": [
+                        "timeout",
+                        "network",
+                        "http_4xx",
+                        "http_5xx",
+                        "invalid_response",
+                        "unknown"
+                    ],
+                    "example": "http_5xx"
+                },
+                "lastHttpStatus": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:02:00Z"
+                },
+                "numberType": {
+                    "type": "string",
+                    "enum": [
+                        "mobile",
+                        "fixed_line",
+                        "fixed_line_or_mobile",
+                        "toll_free",
+                        "unknown"
+                    ],
+                    "example": "mobile"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "critical",
+                        "high",
+                        "normal",
+                        "bulk"
+                    ],
+                    "example": "normal"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "primary"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "TR"
+                },
+                "retryCount": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "segments": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "sendAt": {
+                    "type": "string",
+                    "example": "2024-01-15T09:00:00Z"
+                },
+                "sentAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "processing",
+                        "sent",
+                        "failed",
+                        "expired",
+                        "cancelled",
+                        "suppressed",
+                        "delivered",
+                        "undelivered"
+                    ],
+                    "example": "pending"
+                },
+                "statusHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StatusChangeResponse"
+                    }
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90"
+                },
+                "templateVersion": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
                 }
             }
         },
-        "dto.HealthResponse": {
+        "dto.MessageStreamEvent": {
             "type": "object",
             "properties": {
-                "status": {
+                "data": {
+                    "$ref": "#/definitions/dto.MessageStreamEventData"
+                },
+                "id": {
                     "type": "string",
-                    "enum": [
-                        "ok",
-                        "error"
-                    ],
-                    "example": "ok"
+                    "example": "58213"
                 },
-                "uptime": {
+                "occurredAt": {
                     "type": "string",
-                    "example": "2h30m45s"
+                    "example": "2024-01-15T10:32:05Z"
                 },
-                "version": {
+                "type": {
                     "type": "string",
-                    "example": "1.0.0"
+                    "enum": [
+                        "message.created",
+                        "message.status_changed"
+                    ],
+                    "example": "message.status_changed"
                 }
             }
         },
-        "dto.MessageResponse": {
+        "dto.MessageStreamEventData": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Hello World! This is a test message."
-                },
-                "createdAt": {
-                    "type": "string",
-                    "example": "2024-01-15T10:30:00Z"
-                },
-                "externalId": {
+                "from": {
                     "type": "string",
-                    "example": "whatsapp_msg_12345"
+                    "example": "PENDING"
                 },
-                "id": {
+                "messageId": {
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
@@ -607,34 +3593,23 @@ const docTemplate = `{
                     "type": "string",
                     "example": "+905551234567"
                 },
-                "retryCount": {
-                    "type": "integer",
-                    "maximum": 3,
-                    "minimum": 0,
-                    "example": 0
-                },
-                "sentAt": {
-                    "type": "string",
-                    "example": "2024-01-15T10:32:00Z"
-                },
-                "status": {
+                "to": {
                     "type": "string",
-                    "enum": [
-                        "pending",
-                        "sent",
-                        "failed"
-                    ],
-                    "example": "pending"
+                    "example": "SENT"
                 },
-                "updatedAt": {
+                "trigger": {
                     "type": "string",
-                    "example": "2024-01-15T10:30:00Z"
+                    "example": "scheduler"
                 }
             }
         },
         "dto.ProcessingResultResponse": {
             "type": "object",
             "properties": {
+                "deferredCount": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "errors": {
                     "type": "array",
                     "items": {
@@ -644,6 +3619,10 @@ const docTemplate = `{
                         "[\"webhook timeout for message 123\"]"
                     ]
                 },
+                "expiredCount": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "failedCount": {
                     "type": "integer",
                     "example": 1
@@ -652,9 +3631,21 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 5
                 },
+                "reclaimedCount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "retriedCount": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "successCount": {
                     "type": "integer",
                     "example": 4
+                },
+                "suppressedCount": {
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
@@ -684,6 +3675,74 @@ const docTemplate = `{
                 "processedToday": {
                     "type": "integer",
                     "example": 25
+                },
+                "processingCount": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.ReplayEventsRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-16T00:00:00Z"
+                }
+            }
+        },
+        "dto.ReplayEventsResponse": {
+            "type": "object",
+            "properties": {
+                "queued": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "dto.RequeueMessagesRequest": {
+            "type": "object",
+            "properties": {
+                "errorClass": {
+                    "type": "string",
+                    "enum": [
+                        "timeout",
+                        "network",
+                        "http_4xx",
+                        "http_5xx",
+                        "invalid_response",
+                        "unknown"
+                    ],
+                    "example": "http_5xx"
+                },
+                "failedFrom": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "failedTo": {
+                    "type": "string",
+                    "example": "2024-01-16T00:00:00Z"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                }
+            }
+        },
+        "dto.RequeueResponse": {
+            "type": "object",
+            "properties": {
+                "requeuedCount": {
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
@@ -714,6 +3773,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "1m30s"
                 },
+                "skippedRuns": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "totalExpired": {
+                    "type": "integer",
+                    "example": 3
+                },
                 "totalFailed": {
                     "type": "integer",
                     "example": 10
@@ -722,9 +3789,240 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 150
                 },
+                "totalRetried": {
+                    "type": "integer",
+                    "example": 4
+                },
                 "totalSuccessful": {
                     "type": "integer",
                     "example": 140
+                },
+                "totalSuppressed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "webhookCircuit": {
+                    "$ref": "#/definitions/dto.CircuitBreakerResponse"
+                }
+            }
+        },
+        "dto.StatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "PROCESSING",
+                        "SENT",
+                        "FAILED",
+                        "EXPIRED",
+                        "CANCELLED",
+                        "SUPPRESSED",
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ],
+                    "example": "PROCESSING"
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:00Z"
+                },
+                "to": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "PROCESSING",
+                        "SENT",
+                        "FAILED",
+                        "EXPIRED",
+                        "CANCELLED",
+                        "SUPPRESSED",
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ],
+                    "example": "SENT"
+                },
+                "trigger": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "scheduler",
+                        "manual_process",
+                        "requeue",
+                        "import",
+                        "system"
+                    ],
+                    "example": "scheduler"
+                }
+            }
+        },
+        "dto.SubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "CRM delivery sync"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3c9e1f7a-2b4d-4e6f-8a1c-5d7e9f0b2c4a"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SENT",
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/message-status"
+                }
+            }
+        },
+        "dto.SuppressionImportErrorEntry": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid phone number format: 12ab"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "12ab"
+                }
+            }
+        },
+        "dto.SuppressionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551234567"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Replied STOP"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "import",
+                        "keyword"
+                    ],
+                    "example": "api"
+                }
+            }
+        },
+        "dto.TemplateResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Hi {{name}}, your order {{order_id}} has shipped."
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "order-shipped"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "order_id"
+                    ]
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.UpdateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "CRM delivery sync"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SENT",
+                        "DELIVERED",
+                        "UNDELIVERED"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/message-status"
+                }
+            }
+        },
+        "dto.UpdateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Hi {{name}}, order {{order_id}} is on its way."
                 }
             }
         }
@@ -735,6 +4033,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "ProviderSignature": {
+            "description": "Provider callbacks only: \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003cX-Dispatcher-Timestamp\u003e.\u003craw body\u003e\" keyed with webhook.callbacks.secret.",
+            "type": "apiKey",
+            "name": "X-Dispatcher-Signature",
+            "in": "header"
         }
     }
 }`
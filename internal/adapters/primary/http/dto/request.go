@@ -1,14 +1,96 @@
 package dto
 
+import "time"
+
 // CreateMessageRequest represents request to create a new message
 type CreateMessageRequest struct {
-	PhoneNumber string `json:"phoneNumber" validate:"required" example:"+905551234567" doc:"Phone number in international format (E.164)"`
-	Content     string `json:"content" validate:"required" example:"Hello World! This is a test message." doc:"Message content to be sent"`
+	PhoneNumber string            `json:"phoneNumber" validate:"required" example:"+905551234567" doc:"Phone number in international format, or a national number of messages.default_region; stored normalized to E.164"`
+	Content     string            `json:"content,omitempty" example:"Hello World! This is a test message." doc:"Message content to be sent; required unless templateId is set"`
+	TemplateID  string            `json:"templateId,omitempty" example:"5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90" doc:"Render the content from this template instead of sending raw content"`
+	Variables   map[string]string `json:"variables,omitempty" doc:"Values for the template placeholders, e.g. {\"name\": \"Ayse\"}"`
+	SendAt      *time.Time        `json:"sendAt,omitempty" example:"2024-01-15T09:00:00Z" doc:"Optional time to send the message at (sent on the next processing cycle if omitted)"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty" example:"2024-01-15T09:15:00Z" doc:"Optional time after which the message must not be sent"`
+	TTL         string            `json:"ttl,omitempty" example:"15m" doc:"Optional time-to-live counted from the send time (Go duration, e.g. 90s, 15m); mutually exclusive with expiresAt"`
+	Priority    string            `json:"priority,omitempty" example:"critical" enums:"critical,high,normal,bulk" doc:"Sending priority (default normal); due messages are sent most urgent first, then oldest first"`
+}
+
+// CreateMessagesBatchRequest represents a request to create many messages at once
+type CreateMessagesBatchRequest struct {
+	Messages []CreateMessageRequest `json:"messages" validate:"required" doc:"Messages to create, validated and reported on individually"`
 }
 
 // ListMessagesRequest represents query parameters for listing messages
 type ListMessagesRequest struct {
-	Status string `json:"status,omitempty" example:"pending" enums:"pending,sent,failed" doc:"Filter messages by status"`
-	Limit  int    `json:"limit,omitempty" example:"10" minimum:"1" maximum:"100" doc:"Number of messages to return (1-100)"`
-	Offset int    `json:"offset,omitempty" example:"0" minimum:"0" doc:"Number of messages to skip for pagination"`
+	Status    string `json:"status,omitempty" example:"pending" enums:"pending,processing,sent,failed,expired,cancelled,suppressed,delivered,undelivered" doc:"Filter messages by status"`
+	Scheduled bool   `json:"scheduled,omitempty" example:"false" doc:"Only return pending messages whose send time is in the future"`
+	Limit     int    `json:"limit,omitempty" example:"10" minimum:"1" maximum:"100" doc:"Number of messages to return (1-100)"`
+	Offset    int    `json:"offset,omitempty" example:"0" minimum:"0" doc:"Number of messages to skip for pagination"`
+}
+
+// RequeueMessagesRequest selects failed messages to requeue; omitted fields match all failed messages
+type RequeueMessagesRequest struct {
+	PhoneNumber string     `json:"phoneNumber,omitempty" example:"+905551234567" doc:"Only requeue messages to this phone number"`
+	ErrorClass  string     `json:"errorClass,omitempty" example:"http_5xx" enums:"timeout,network,http_4xx,http_5xx,invalid_response,unknown" doc:"Only requeue messages whose last error has this class"`
+	FailedFrom  *time.Time `json:"failedFrom,omitempty" example:"2024-01-15T00:00:00Z" doc:"Only requeue messages that failed at or after this time"`
+	FailedTo    *time.Time `json:"failedTo,omitempty" example:"2024-01-16T00:00:00Z" doc:"Only requeue messages that failed before this time"`
+}
+
+// CreateTemplateRequest represents a request to create a message template
+type CreateTemplateRequest struct {
+	Name string `json:"name" validate:"required" example:"order-shipped" doc:"Unique template name"`
+	Body string `json:"body" validate:"required" example:"Hi {{name}}, your order {{order_id}} has shipped." doc:"Message body with {{variable}} placeholders"`
+}
+
+// UpdateTemplateRequest represents a request to revise a message template
+type UpdateTemplateRequest struct {
+	Body string `json:"body" validate:"required" example:"Hi {{name}}, order {{order_id}} is on its way." doc:"New message body; a changed body creates a new version"`
+}
+
+// AddSuppressionRequest represents a request to put a phone number on the suppression list
+type AddSuppressionRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required" example:"+905551234567" doc:"Phone number that opted out, normalized to E.164 like message recipients"`
+	Reason      string `json:"reason,omitempty" example:"Replied STOP" doc:"Optional reason, e.g. how the opt-out was received (max 255 characters)"`
+}
+
+// ImportSuppressionsRequest represents a request to put many phone numbers on the suppression list
+type ImportSuppressionsRequest struct {
+	Suppressions []AddSuppressionRequest `json:"suppressions" validate:"required" doc:"Phone numbers to suppress, validated and reported on individually"`
+	Reason       string                  `json:"reason,omitempty" example:"Carrier opt-out list" doc:"Reason for entries that do not carry their own"`
+}
+
+// InboundMessageRequest represents a reply forwarded by the provider
+type InboundMessageRequest struct {
+	From       string     `json:"from" validate:"required" example:"+905551234567" doc:"Phone number that sent the reply"`
+	Content    string     `json:"content" example:"STOP" doc:"Text of the reply. A reply that is exactly an opt-out or opt-in keyword updates the suppression list"`
+	MessageID  string     `json:"messageId,omitempty" example:"inbound_msg_12345" doc:"Provider message ID, used to recognize callbacks delivered more than once"`
+	ReceivedAt *time.Time `json:"receivedAt,omitempty" example:"2024-01-15T10:30:00Z" doc:"When the provider received the reply (defaults to now)"`
+}
+
+// DeliveryReceiptRequest represents a delivery report forwarded by the provider
+type DeliveryReceiptRequest struct {
+	ExternalID string     `json:"externalId" validate:"required" example:"whatsapp_msg_12345" doc:"Message ID the provider returned when it accepted the message"`
+	Status     string     `json:"status" validate:"required" example:"DELIVERED" enums:"DELIVERED,UNDELIVERED" doc:"Final delivery outcome reported by the carrier (case-insensitive)"`
+	ErrorCode  string     `json:"errorCode,omitempty" example:"30005" doc:"Carrier error code of an undelivered message (max 50 characters)"`
+	ReportedAt *time.Time `json:"reportedAt,omitempty" example:"2024-01-15T10:32:05Z" doc:"When the carrier reported the outcome (defaults to now)"`
+}
+
+// CreateSubscriptionRequest represents a request to register a status-change callback endpoint
+type CreateSubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required" example:"https://crm.example.com/hooks/message-status" doc:"Absolute http or https URL that receives a signed POST for every matching status change"`
+	Description string   `json:"description,omitempty" example:"CRM delivery sync" doc:"Optional note on what the endpoint is for (max 255 characters)"`
+	Statuses    []string `json:"statuses,omitempty" example:"SENT,DELIVERED,UNDELIVERED" doc:"Statuses whose changes are delivered (case-insensitive); omit or leave empty for every status, including ones added later"`
+}
+
+// UpdateSubscriptionRequest represents a request to change a subscription
+type UpdateSubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required" example:"https://crm.example.com/hooks/message-status" doc:"Absolute http or https URL that receives the callbacks"`
+	Description string   `json:"description,omitempty" example:"CRM delivery sync" doc:"Optional note on what the endpoint is for (max 255 characters)"`
+	Statuses    []string `json:"statuses,omitempty" example:"SENT,DELIVERED,UNDELIVERED" doc:"Statuses whose changes are delivered; empty for every status"`
+	Active      *bool    `json:"active,omitempty" example:"true" doc:"Whether new status changes are queued and pending callbacks sent (defaults to true)"`
+}
+
+// ReplayEventsRequest represents a request to deliver the status changes of a time range again
+type ReplayEventsRequest struct {
+	From time.Time `json:"from" validate:"required" example:"2024-01-15T00:00:00Z" doc:"Start of the range (inclusive)"`
+	To   time.Time `json:"to" validate:"required" example:"2024-01-16T00:00:00Z" doc:"End of the range (exclusive)"`
 }
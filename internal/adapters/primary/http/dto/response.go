@@ -4,15 +4,110 @@ import "time"
 
 // MessageResponse represents a message in API responses
 type MessageResponse struct {
-	ID          string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000" doc:"Unique message identifier"`
-	PhoneNumber string     `json:"phoneNumber" example:"+905551234567" doc:"Phone number in international format"`
-	Content     string     `json:"content" example:"Hello World! This is a test message." doc:"Message content"`
-	Status      string     `json:"status" example:"pending" enums:"pending,sent,failed" doc:"Current message status"`
-	ExternalID  *string    `json:"externalId,omitempty" example:"whatsapp_msg_12345" doc:"External service message ID (set when sent)"`
-	RetryCount  int        `json:"retryCount" example:"0" minimum:"0" maximum:"3" doc:"Number of retry attempts"`
-	CreatedAt   time.Time  `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"Message creation timestamp"`
-	UpdatedAt   time.Time  `json:"updatedAt" example:"2024-01-15T10:30:00Z" doc:"Last update timestamp"`
-	SentAt      *time.Time `json:"sentAt,omitempty" example:"2024-01-15T10:32:00Z" doc:"Message sent timestamp (if sent)"`
+	ID             string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000" doc:"Unique message identifier"`
+	PhoneNumber    string     `json:"phoneNumber" example:"+905551234567" doc:"Phone number normalized to E.164"`
+	CountryCode    int        `json:"countryCode" example:"90" doc:"International calling code of the phone number"`
+	Region         string     `json:"region,omitempty" example:"TR" doc:"ISO 3166-1 alpha-2 region of the phone number (if known)"`
+	NumberType     string     `json:"numberType" example:"mobile" enums:"mobile,fixed_line,fixed_line_or_mobile,toll_free,unknown" doc:"Line type of the phone number, as far as its numbering plan is known"`
+	Content        string     `json:"content" example:"Hello World! This is a test message." doc:"Message content"`
+	Status         string     `json:"status" example:"pending" enums:"pending,processing,sent,failed,expired,cancelled,suppressed,delivered,undelivered" doc:"Current message status"`
+	Priority       string     `json:"priority" example:"normal" enums:"critical,high,normal,bulk" doc:"Sending priority"`
+	ExternalID     *string    `json:"externalId,omitempty" example:"whatsapp_msg_12345" doc:"External service message ID (set when sent)"`
+	Provider       *string    `json:"provider,omitempty" example:"primary" doc:"Webhook provider that accepted the message (set when sent)"`
+	RetryCount     int        `json:"retryCount" example:"0" minimum:"0" maximum:"3" doc:"Number of retry attempts"`
+	SendAt         *time.Time `json:"sendAt,omitempty" example:"2024-01-15T09:00:00Z" doc:"Scheduled send time (if scheduled)"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty" example:"2024-01-15T09:15:00Z" doc:"Time after which the message is no longer sent (if set)"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty" example:"2024-01-15T09:02:00Z" doc:"Earliest time of the next delivery attempt after a failed one (if set)"`
+	AttemptCount   int        `json:"attemptCount" example:"4" minimum:"0" doc:"Total number of delivery attempts, including those before a requeue"`
+	LastError      *string    `json:"lastError,omitempty" example:"webhook returned HTTP 503: upstream unavailable" doc:"Error of the latest failed delivery attempt, or why a SUPPRESSED message was withheld (if any)"`
+	LastErrorClass *string    `json:"lastErrorClass,omitempty" example:"http_5xx" enums:"timeout,network,http_4xx,http_5xx,invalid_response,unknown" doc:"Category of the latest delivery error (if any)"`
+	LastHTTPStatus *int       `json:"lastHttpStatus,omitempty" example:"503" doc:"HTTP status of the latest failed delivery attempt (if a response was received)"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"Message creation timestamp"`
+	UpdatedAt      time.Time  `json:"updatedAt" example:"2024-01-15T10:30:00Z" doc:"Last update timestamp"`
+	SentAt         *time.Time `json:"sentAt,omitempty" example:"2024-01-15T10:32:00Z" doc:"Message sent timestamp (if sent)"`
+
+	CarrierErrorCode   *string    `json:"carrierErrorCode,omitempty" example:"30005" doc:"Carrier error code of an UNDELIVERED message (if the carrier gave one)"`
+	DeliveryReportedAt *time.Time `json:"deliveryReportedAt,omitempty" example:"2024-01-15T10:32:05Z" doc:"When the carrier reported the message DELIVERED or UNDELIVERED"`
+
+	TemplateID      *string `json:"templateId,omitempty" example:"5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90" doc:"Template the content was rendered from (if any)"`
+	TemplateVersion *int    `json:"templateVersion,omitempty" example:"2" doc:"Version of the template used (if any)"`
+
+	Encoding string `json:"encoding" example:"GSM-7" enums:"GSM-7,UCS-2" doc:"SMS encoding of the content, UCS-2 when it has characters outside the GSM 03.38 alphabet"`
+	Segments int    `json:"segments" example:"1" minimum:"1" doc:"Number of SMS parts the content is sent as"`
+
+	StatusHistory []StatusChangeResponse `json:"statusHistory,omitempty" doc:"Status transitions, oldest first (only when a single message is fetched)"`
+}
+
+// CreateMessagesBatchResponse represents the per-item outcome of a batch create
+type CreateMessagesBatchResponse struct {
+	Items        []BatchItemResponse `json:"items" doc:"One result per submitted message, in request order"`
+	CreatedCount int                 `json:"createdCount" example:"2" doc:"Number of messages created"`
+	FailedCount  int                 `json:"failedCount" example:"1" doc:"Number of messages rejected"`
+}
+
+// BatchItemResponse represents the outcome of a single message in a batch create
+type BatchItemResponse struct {
+	Index   int              `json:"index" example:"0" doc:"Position of the message in the request"`
+	Status  string           `json:"status" example:"created" enums:"created,failed" doc:"Whether the message was created"`
+	Message *MessageResponse `json:"message,omitempty" doc:"The created message (if created)"`
+	Error   string           `json:"error,omitempty" example:"invalid phone number format: 12345" doc:"Why the message was rejected (if failed)"`
+}
+
+// StatusChangeResponse represents a single status transition in a message timeline
+type StatusChangeResponse struct {
+	From       *string   `json:"from,omitempty" example:"PROCESSING" enums:"PENDING,PROCESSING,SENT,FAILED,EXPIRED,CANCELLED,SUPPRESSED,DELIVERED,UNDELIVERED" doc:"Previous status (omitted for the initial status)"`
+	To         string    `json:"to" example:"SENT" enums:"PENDING,PROCESSING,SENT,FAILED,EXPIRED,CANCELLED,SUPPRESSED,DELIVERED,UNDELIVERED" doc:"New status"`
+	Trigger    string    `json:"trigger" example:"scheduler" enums:"api,scheduler,manual_process,requeue,import,system" doc:"What caused the transition"`
+	OccurredAt time.Time `json:"occurredAt" example:"2024-01-15T10:32:00Z" doc:"Time of the transition"`
+}
+
+// TemplateResponse represents a message template in API responses
+type TemplateResponse struct {
+	ID        string    `json:"id" example:"5f0c8a2e-7b1d-4c3e-9a6f-2d4b8e1c7a90" doc:"Unique template identifier"`
+	Name      string    `json:"name" example:"order-shipped" doc:"Unique template name"`
+	Body      string    `json:"body" example:"Hi {{name}}, your order {{order_id}} has shipped." doc:"Message body with {{variable}} placeholders"`
+	Version   int       `json:"version" example:"1" doc:"Current version, incremented whenever the body changes"`
+	Variables []string  `json:"variables" example:"name,order_id" doc:"Variables the body needs, sorted by name"`
+	CreatedAt time.Time `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"Template creation timestamp"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2024-01-15T10:30:00Z" doc:"Timestamp of the current version"`
+}
+
+// ImportJobResponse represents the progress of a message file import
+type ImportJobResponse struct {
+	ID            string     `json:"id" example:"9b2f6c1e-3d4a-4f5b-8c7d-1e2f3a4b5c6d" doc:"Unique import job identifier"`
+	Format        string     `json:"format" example:"csv" enums:"csv,ndjson" doc:"Format of the uploaded file"`
+	Status        string     `json:"status" example:"processing" enums:"processing,completed,failed" doc:"Current import status"`
+	ProcessedRows int        `json:"processedRows" example:"1500" doc:"Number of data rows processed so far"`
+	CreatedCount  int        `json:"createdCount" example:"1497" doc:"Number of messages created so far"`
+	FailedCount   int        `json:"failedCount" example:"3" doc:"Number of rows rejected so far"`
+	Error         *string    `json:"error,omitempty" example:"failed to read import file: bufio.Scanner: token too long" doc:"Why the import stopped before the end of the file (if failed)"`
+	ErrorsURL     string     `json:"errorsUrl" example:"/api/v1/messages/imports/9b2f6c1e-3d4a-4f5b-8c7d-1e2f3a4b5c6d/errors" doc:"Where to download the per-row error report as CSV"`
+	CreatedAt     time.Time  `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"Import start timestamp"`
+	UpdatedAt     time.Time  `json:"updatedAt" example:"2024-01-15T10:30:05Z" doc:"Last progress update timestamp"`
+	CompletedAt   *time.Time `json:"completedAt,omitempty" example:"2024-01-15T10:30:09Z" doc:"Time the import finished (if finished)"`
+}
+
+// SuppressionResponse represents a suppression list entry in API responses
+type SuppressionResponse struct {
+	PhoneNumber string    `json:"phoneNumber" example:"+905551234567" doc:"Suppressed phone number in E.164 format"`
+	Reason      string    `json:"reason,omitempty" example:"Replied STOP" doc:"Reason given when the number was suppressed (if any)"`
+	Source      string    `json:"source" example:"api" enums:"api,import,keyword" doc:"How the number was added to the suppression list (keyword: the recipient replied with an opt-out keyword)"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"When the number was suppressed"`
+}
+
+// ImportSuppressionsResponse represents the outcome of a bulk suppression import
+type ImportSuppressionsResponse struct {
+	AddedCount      int64                         `json:"addedCount" example:"98" doc:"Number of phone numbers added to the suppression list"`
+	DuplicateCount  int                           `json:"duplicateCount" example:"1" doc:"Number of entries already suppressed or repeated in the import"`
+	RejectedCount   int                           `json:"rejectedCount" example:"1" doc:"Number of entries that could not be suppressed"`
+	RejectedEntries []SuppressionImportErrorEntry `json:"rejectedEntries" doc:"Entries that could not be suppressed"`
+}
+
+// SuppressionImportErrorEntry describes an import entry that could not be suppressed
+type SuppressionImportErrorEntry struct {
+	Index       int    `json:"index" example:"3" doc:"0-based position of the entry in the request"`
+	PhoneNumber string `json:"phoneNumber" example:"12ab" doc:"Phone number as given in the request"`
+	Error       string `json:"error" example:"invalid phone number format: 12ab" doc:"Why the entry was rejected"`
 }
 
 // ProcessingStatusResponse represents the current processing status
@@ -20,6 +115,7 @@ type ProcessingStatusResponse struct {
 	IsProcessing     bool       `json:"isProcessing" example:"false" doc:"Whether system is currently processing messages"`
 	LastProcessedAt  *time.Time `json:"lastProcessedAt,omitempty" example:"2024-01-15T10:30:00Z" doc:"Last processing timestamp"`
 	PendingCount     int64      `json:"pendingCount" example:"5" doc:"Number of pending messages"`
+	ProcessingCount  int64      `json:"processingCount" example:"2" doc:"Number of messages currently claimed by a worker"`
 	ProcessedToday   int64      `json:"processedToday" example:"25" doc:"Messages processed today"`
 	FailedToday      int64      `json:"failedToday" example:"2" doc:"Messages failed today"`
 	NextProcessingAt *time.Time `json:"nextProcessingAt,omitempty" example:"2024-01-15T10:32:00Z" doc:"Next scheduled processing time"`
@@ -27,10 +123,15 @@ type ProcessingStatusResponse struct {
 
 // ProcessingResultResponse represents the result of manual message processing
 type ProcessingResultResponse struct {
-	ProcessedCount int      `json:"processedCount" example:"5" doc:"Total number of messages processed"`
-	SuccessCount   int      `json:"successCount" example:"4" doc:"Number of successfully sent messages"`
-	FailedCount    int      `json:"failedCount" example:"1" doc:"Number of failed messages"`
-	Errors         []string `json:"errors,omitempty" example:"[\"webhook timeout for message 123\"]" doc:"List of error messages"`
+	ProcessedCount  int      `json:"processedCount" example:"5" doc:"Total number of messages processed"`
+	SuccessCount    int      `json:"successCount" example:"4" doc:"Number of successfully sent messages"`
+	FailedCount     int      `json:"failedCount" example:"1" doc:"Number of failed messages"`
+	ExpiredCount    int      `json:"expiredCount" example:"0" doc:"Number of messages expired before sending"`
+	RetriedCount    int      `json:"retriedCount" example:"1" doc:"Number of failed deliveries rescheduled for another attempt"`
+	DeferredCount   int      `json:"deferredCount" example:"0" doc:"Number of claimed messages returned to the queue when the batch deadline passed or the provider throughput limit was reached"`
+	SuppressedCount int      `json:"suppressedCount" example:"0" doc:"Number of messages withheld because their recipient opted out"`
+	ReclaimedCount  int64    `json:"reclaimedCount" example:"0" doc:"Number of messages recovered from expired worker leases"`
+	Errors          []string `json:"errors,omitempty" example:"[\"webhook timeout for message 123\"]" doc:"List of error messages"`
 }
 
 // SchedulerStatusResponse represents the background scheduler status
@@ -40,10 +141,24 @@ type SchedulerStatusResponse struct {
 	TotalProcessed        int64     `json:"totalProcessed" example:"150" doc:"Total messages processed since start"`
 	TotalSuccessful       int64     `json:"totalSuccessful" example:"140" doc:"Total successful messages"`
 	TotalFailed           int64     `json:"totalFailed" example:"10" doc:"Total failed messages"`
+	TotalExpired          int64     `json:"totalExpired" example:"3" doc:"Total messages expired before sending"`
+	TotalRetried          int64     `json:"totalRetried" example:"4" doc:"Total failed deliveries rescheduled for retry"`
+	TotalSuppressed       int64     `json:"totalSuppressed" example:"1" doc:"Total messages withheld because their recipient opted out"`
 	LastProcessingTime    time.Time `json:"lastProcessingTime" example:"2024-01-15T10:30:00Z" doc:"Last processing timestamp"`
 	NextProcessingIn      string    `json:"nextProcessingIn" example:"1m30s" doc:"Time until next processing"`
 	Interval              string    `json:"interval" example:"2m" doc:"Processing interval"`
 	BatchSize             int       `json:"batchSize" example:"2" doc:"Number of messages processed per batch"`
+	SkippedRuns           int64     `json:"skippedRuns" example:"0" doc:"Runs that claimed no messages because the webhook circuit was open"`
+
+	WebhookCircuit *CircuitBreakerResponse `json:"webhookCircuit,omitempty" doc:"State of the webhook circuit breaker, omitted when it is disabled"`
+}
+
+// CircuitBreakerResponse represents the state of the webhook circuit breaker
+type CircuitBreakerResponse struct {
+	State               string     `json:"state" example:"closed" enums:"closed,open,half_open" doc:"closed sends requests, open holds deliveries back, half_open sends trial requests"`
+	ConsecutiveFailures int        `json:"consecutiveFailures" example:"0" doc:"Transient webhook failures in a row"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty" example:"2024-01-15T10:30:00Z" doc:"When the circuit last opened"`
+	RetryAt             *time.Time `json:"retryAt,omitempty" example:"2024-01-15T10:30:30Z" doc:"When the open circuit lets trial requests through"`
 }
 
 // ErrorResponse represents an error response
@@ -54,7 +169,120 @@ type ErrorResponse struct {
 
 // HealthResponse represents a health check response
 type HealthResponse struct {
-	Status  string `json:"status" example:"ok" enums:"ok,error" doc:"Health status"`
+	Status  string `json:"status" example:"ok" enums:"ok,degraded,error" doc:"Health status, degraded while the webhook circuit is open"`
 	Uptime  string `json:"uptime" example:"2h30m45s" doc:"Server uptime"`
 	Version string `json:"version" example:"1.0.0" doc:"Application version"`
+
+	WebhookCircuit *CircuitBreakerResponse `json:"webhookCircuit,omitempty" doc:"State of the webhook circuit breaker, omitted when it is disabled"`
+}
+
+// RequeueResponse represents the result of a bulk requeue
+type RequeueResponse struct {
+	RequeuedCount int64 `json:"requeuedCount" example:"12" doc:"Number of failed messages moved back to pending"`
+}
+
+// AttemptResponse represents a single webhook request made to deliver a message
+type AttemptResponse struct {
+	ID            string    `json:"id" example:"9b2f0c1e-6a4d-4f3b-8c55-2f7d3e1a9b10" doc:"Unique attempt identifier"`
+	Provider      *string   `json:"provider,omitempty" example:"backup" doc:"Webhook provider the request was sent to (if known)"`
+	RetryCount    int       `json:"retryCount" example:"1" minimum:"0" doc:"Message-level retry the attempt belongs to (0 for the first delivery)"`
+	AttemptNumber int       `json:"attemptNumber" example:"2" minimum:"1" doc:"Webhook request number within the delivery"`
+	StartedAt     time.Time `json:"startedAt" example:"2024-01-15T10:32:00Z" doc:"Time the request was sent"`
+	DurationMs    int64     `json:"durationMs" example:"245" doc:"Time until the response or error, in milliseconds"`
+	HTTPStatus    *int      `json:"httpStatus,omitempty" example:"503" doc:"HTTP status returned by the webhook (if a response was received)"`
+	ResponseBody  *string   `json:"responseBody,omitempty" example:"upstream unavailable" doc:"Excerpt of the response body (if a response was received)"`
+	Error         *string   `json:"error,omitempty" example:"webhook returned HTTP 503: upstream unavailable" doc:"Why the attempt failed (if it failed)"`
+	ErrorClass    *string   `json:"errorClass,omitempty" example:"http_5xx" enums:"timeout,network,http_4xx,http_5xx,invalid_response,unknown" doc:"Category of the error (if it failed)"`
+}
+
+// InboundMessageResponse represents a reply from a recipient in API responses
+type InboundMessageResponse struct {
+	ID            string    `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7" doc:"Unique inbound message identifier"`
+	PhoneNumber   string    `json:"phoneNumber" example:"+905551234567" doc:"Phone number that sent the reply, normalized to E.164"`
+	Content       string    `json:"content" example:"STOP" doc:"Text of the reply"`
+	MessageID     *string   `json:"messageId,omitempty" example:"inbound_msg_12345" doc:"Provider message ID (if given)"`
+	KeywordAction string    `json:"keywordAction,omitempty" example:"opt_out" enums:"opt_out,opt_in" doc:"Set when the reply was an opt-out or opt-in keyword"`
+	ReceivedAt    time.Time `json:"receivedAt" example:"2024-01-15T10:30:00Z" doc:"When the provider received the reply"`
+	CreatedAt     time.Time `json:"createdAt" example:"2024-01-15T10:30:01Z" doc:"When the reply was stored"`
+}
+
+// ConversationEntryResponse represents an outbound message or an inbound reply in a conversation
+type ConversationEntryResponse struct {
+	Direction  string                  `json:"direction" example:"inbound" enums:"outbound,inbound" doc:"Whether the entry was sent to or received from the phone number"`
+	OccurredAt time.Time               `json:"occurredAt" example:"2024-01-15T10:30:00Z" doc:"Creation time of an outbound message, receipt time of a reply"`
+	Outbound   *MessageResponse        `json:"outbound,omitempty" doc:"The outbound message (outbound entries only)"`
+	Inbound    *InboundMessageResponse `json:"inbound,omitempty" doc:"The reply (inbound entries only)"`
+}
+
+// ConversationResponse represents the messages exchanged with a phone number
+type ConversationResponse struct {
+	PhoneNumber string                      `json:"phoneNumber" example:"+905551234567" doc:"Phone number normalized to E.164"`
+	Suppression *SuppressionResponse        `json:"suppression,omitempty" doc:"Suppression list entry, present while the number is opted out"`
+	Entries     []ConversationEntryResponse `json:"entries" doc:"Outbound messages and replies, most recent first"`
+	HasMore     bool                        `json:"hasMore" example:"false" doc:"Whether older entries exist beyond this page"`
+}
+
+// DeliveryReceiptResponse represents a stored delivery receipt in API responses
+type DeliveryReceiptResponse struct {
+	ExternalID string     `json:"externalId" example:"whatsapp_msg_12345" doc:"Message ID the provider returned when it accepted the message"`
+	Status     string     `json:"status" example:"DELIVERED" enums:"DELIVERED,UNDELIVERED" doc:"Delivery outcome on record"`
+	ErrorCode  *string    `json:"errorCode,omitempty" example:"30005" doc:"Carrier error code (if given)"`
+	ReportedAt time.Time  `json:"reportedAt" example:"2024-01-15T10:32:05Z" doc:"When the carrier reported the outcome"`
+	ReceivedAt time.Time  `json:"receivedAt" example:"2024-01-15T10:32:06Z" doc:"When the receipt was first received"`
+	MessageID  *string    `json:"messageId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" doc:"Message the receipt was applied to (once applied)"`
+	AppliedAt  *time.Time `json:"appliedAt,omitempty" example:"2024-01-15T10:32:06Z" doc:"When the receipt was applied; absent while the message is not marked SENT yet"`
+	Duplicate  bool       `json:"duplicate" example:"false" doc:"Whether the receipt had been received before; the receipt on record is returned unchanged"`
+}
+
+// SubscriptionResponse represents a status-change subscription in API responses
+type SubscriptionResponse struct {
+	ID          string    `json:"id" example:"3c9e1f7a-2b4d-4e6f-8a1c-5d7e9f0b2c4a" doc:"Unique subscription identifier"`
+	URL         string    `json:"url" example:"https://crm.example.com/hooks/message-status" doc:"Endpoint receiving the callbacks"`
+	Description string    `json:"description" example:"CRM delivery sync" doc:"Note on what the endpoint is for"`
+	Statuses    []string  `json:"statuses" example:"SENT,DELIVERED,UNDELIVERED" doc:"Statuses whose changes are delivered; empty means every status"`
+	Secret      string    `json:"secret,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" doc:"Key for verifying callback signatures; only returned when the subscription is created"`
+	Active      bool      `json:"active" example:"true" doc:"Whether new status changes are queued and pending callbacks sent"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-15T10:30:00Z" doc:"Subscription creation timestamp"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-01-15T10:30:00Z" doc:"Last change timestamp"`
+}
+
+// EventDeliveryResponse represents a status-change callback in a subscription's delivery log
+type EventDeliveryResponse struct {
+	ID             int64      `json:"id" example:"1042" doc:"Unique delivery identifier"`
+	EventID        int64      `json:"eventId" example:"58213" doc:"Event identifier, sent as X-Dispatcher-Event-Id; the same for replays of the event"`
+	MessageID      string     `json:"messageId" example:"123e4567-e89b-12d3-a456-426614174000" doc:"Message whose status changed"`
+	From           *string    `json:"from,omitempty" example:"SENT" doc:"Previous status (omitted for the initial status)"`
+	To             string     `json:"to" example:"DELIVERED" doc:"New status"`
+	OccurredAt     time.Time  `json:"occurredAt" example:"2024-01-15T10:32:05Z" doc:"Time of the status change"`
+	Status         string     `json:"status" example:"DELIVERED" enums:"PENDING,DELIVERED,FAILED" doc:"Delivery state: PENDING until the endpoint answers 2xx (DELIVERED) or every attempt failed (FAILED)"`
+	Replay         bool       `json:"replay" example:"false" doc:"Whether the delivery was queued by a replay"`
+	Attempts       int        `json:"attempts" example:"1" doc:"Number of callbacks made"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty" example:"2024-01-15T10:33:05Z" doc:"When the next callback is due (only while pending)"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt,omitempty" example:"2024-01-15T10:32:06Z" doc:"Time of the latest callback"`
+	LastStatusCode *int       `json:"lastStatusCode,omitempty" example:"200" doc:"HTTP status of the latest callback (omitted if no response was received)"`
+	LastError      *string    `json:"lastError,omitempty" example:"endpoint returned HTTP 503: upstream unavailable" doc:"Why the latest callback failed"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty" example:"2024-01-15T10:32:06Z" doc:"When the endpoint acknowledged the event"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-01-15T10:32:05Z" doc:"When the delivery was queued"`
+}
+
+// ReplayEventsResponse represents the outcome of a replay request
+type ReplayEventsResponse struct {
+	Queued int64 `json:"queued" example:"120" doc:"Number of status changes queued for delivery again"`
+}
+
+// MessageStreamEvent is the data of a message stream event, in the shape of a status-change callback body
+type MessageStreamEvent struct {
+	ID         string                 `json:"id" example:"58213" doc:"Event identifier, also sent as the SSE id; the same as in subscription callbacks"`
+	Type       string                 `json:"type" example:"message.status_changed" enums:"message.created,message.status_changed" doc:"message.created for a new message, message.status_changed for any later change"`
+	OccurredAt time.Time              `json:"occurredAt" example:"2024-01-15T10:32:05Z" doc:"Time of the status change"`
+	Data       MessageStreamEventData `json:"data"`
+}
+
+// MessageStreamEventData describes the status change of a message in the stream
+type MessageStreamEventData struct {
+	MessageID   string  `json:"messageId" example:"123e4567-e89b-12d3-a456-426614174000" doc:"Message whose status changed"`
+	PhoneNumber string  `json:"phoneNumber" example:"+905551234567" doc:"Recipient of the message"`
+	From        *string `json:"from,omitempty" example:"PENDING" doc:"Previous status (omitted for the initial status)"`
+	To          string  `json:"to" example:"SENT" doc:"New status"`
+	Trigger     string  `json:"trigger" example:"scheduler" doc:"What caused the change"`
 }
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// ListDeadLetters handles GET /api/v1/messages/dead-letter
+// @Summary      List permanently failed messages
+// @Description  Retrieve FAILED messages with their last error, error class, HTTP status and attempt count
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        phone_number  query     string  false  "Filter by phone number"
+// @Param        error_class   query     string  false  "Filter by class of the last error"  Enums(timeout, network, http_4xx, http_5xx, invalid_response, unknown)
+// @Param        failed_from   query     string  false  "Only messages that failed at or after this time (RFC 3339)"
+// @Param        failed_to     query     string  false  "Only messages that failed before this time (RFC 3339)"
+// @Param        limit         query     int     false  "Number of messages to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset        query     int     false  "Number of messages to skip"  minimum(0)  default(0)
+// @Success      200           {array}   dto.MessageResponse
+// @Header       200           {string}  X-Total-Count  "Total number of failed messages matching the filter"
+// @Failure      400           {object}  dto.ErrorResponse "Bad request - invalid parameters"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429           {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500           {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/dead-letter [get]
+func (h *MessageHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query := usecases.DeadLetterQuery{
+		Limit:  20, // Default limit
+		Offset: 0,  // Default offset
+	}
+
+	params := r.URL.Query()
+
+	if limitStr := params.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := params.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	query.Filter.PhoneNumber = params.Get("phone_number")
+	query.Filter.ErrorClass = params.Get("error_class")
+
+	var err error
+	if query.Filter.FailedFrom, err = parseTimeParam(params.Get("failed_from")); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid failed_from: must be an RFC 3339 timestamp")
+		return
+	}
+	if query.Filter.FailedTo, err = parseTimeParam(params.Get("failed_to")); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid failed_to: must be an RFC 3339 timestamp")
+		return
+	}
+
+	result, err := h.messageManagement.ListDeadLetters(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.MessageResponse, len(result.Messages))
+	for i, msg := range result.Messages {
+		responses[i] = toMessageResponse(&msg)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
+
+// RequeueMessage handles POST /api/v1/messages/{id}/requeue
+// @Summary      Requeue a failed message
+// @Description  Move a FAILED message back to PENDING with a fresh retry budget
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Message ID (UUID)"
+// @Success      200  {object}  dto.MessageResponse
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid message ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Message not found"
+// @Failure      422  {object}  dto.ErrorResponse "Message is not in FAILED status"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/{id}/requeue [post]
+func (h *MessageHandler) RequeueMessage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	result, err := h.messageManagement.RequeueMessage(r.Context(), id)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toMessageResponse(result))
+}
+
+// RequeueMessages handles POST /api/v1/messages/requeue
+// @Summary      Requeue failed messages in bulk
+// @Description  Move all FAILED messages matching the filter back to PENDING. An empty body requeues every failed message.
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        filter  body      dto.RequeueMessagesRequest  false  "Failed message filter"
+// @Success      200     {object}  dto.RequeueResponse
+// @Failure      400     {object}  dto.ErrorResponse "Bad request - invalid filter"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/requeue [post]
+func (h *MessageHandler) RequeueMessages(w http.ResponseWriter, r *http.Request) {
+	var req dto.RequeueMessagesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	filter := usecases.DeadLetterFilter{
+		PhoneNumber: req.PhoneNumber,
+		ErrorClass:  req.ErrorClass,
+		FailedFrom:  req.FailedFrom,
+		FailedTo:    req.FailedTo,
+	}
+
+	result, err := h.messageManagement.RequeueFailedMessages(r.Context(), filter)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.RequeueResponse{RequeuedCount: result.RequeuedCount})
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// DeliveryReceiptHandler handles HTTP requests for delivery reports forwarded by the provider
+type DeliveryReceiptHandler struct {
+	deliveryReceipts usecases.DeliveryReceiptUseCase
+}
+
+// NewDeliveryReceiptHandler creates a new delivery receipt handler
+func NewDeliveryReceiptHandler(deliveryReceipts usecases.DeliveryReceiptUseCase) *DeliveryReceiptHandler {
+	return &DeliveryReceiptHandler{
+		deliveryReceipts: deliveryReceipts,
+	}
+}
+
+// ReceiveDeliveryReceipt handles POST /api/v1/delivery-receipts
+// @Summary      Receive a delivery receipt
+// @Description  Provider callback reporting whether a sent message reached the handset. The message is found by the externalId the provider returned when accepting it and moves from SENT to DELIVERED or UNDELIVERED, keeping the carrier error code. A receipt that arrives before the message is marked SENT is stored and applied as soon as it is (202). Sending the same receipt again is harmless: the first receipt for an externalId stays on record. Authenticated by the provider's X-Dispatcher-Signature of the body and X-Dispatcher-Timestamp, not by the client API key.
+// @Tags         delivery-receipts
+// @Accept       json
+// @Produce      json
+// @Param        receipt  body      dto.DeliveryReceiptRequest   true  "Delivery report forwarded by the provider"
+// @Success      200      {object}  dto.DeliveryReceiptResponse  "Applied to the message"
+// @Success      202      {object}  dto.DeliveryReceiptResponse  "Stored; applied once the message is marked SENT"
+// @Failure      400      {object}  dto.ErrorResponse "Bad request - missing externalId or invalid status"
+// @Failure      401      {object}  dto.ErrorResponse "Unauthorized - missing, stale or invalid signature"
+// @Failure      422      {object}  dto.ErrorResponse "The message cannot take a delivery outcome"
+// @Failure      429      {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500      {object}  dto.ErrorResponse "Internal server error"
+// @Security     ProviderSignature
+// @Router       /delivery-receipts [post]
+func (h *DeliveryReceiptHandler) ReceiveDeliveryReceipt(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeliveryReceiptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := usecases.ReceiveDeliveryReceiptCommand{
+		ExternalID: req.ExternalID,
+		Status:     req.Status,
+		ErrorCode:  req.ErrorCode,
+	}
+	if req.ReportedAt != nil {
+		cmd.ReportedAt = *req.ReportedAt
+	}
+
+	result, err := h.deliveryReceipts.ReceiveDeliveryReceipt(r.Context(), cmd)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.DeliveryReceiptResponse{
+		ExternalID: result.ExternalID,
+		Status:     result.Status,
+		ErrorCode:  result.ErrorCode,
+		ReportedAt: result.ReportedAt,
+		ReceivedAt: result.ReceivedAt,
+		AppliedAt:  result.AppliedAt,
+		Duplicate:  result.Duplicate,
+	}
+	if result.MessageID != nil {
+		messageID := result.MessageID.String()
+		response.MessageID = &messageID
+	}
+
+	statusCode := http.StatusOK
+	if result.AppliedAt == nil {
+		statusCode = http.StatusAccepted
+	}
+
+	writeJSONResponse(w, statusCode, response)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
 	"go.uber.org/zap"
 )
@@ -13,18 +14,20 @@ import (
 // HealthHandler handles health check requests
 type HealthHandler struct {
 	startTime time.Time
+	circuit   services.CircuitBreaker
 }
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
+// NewHealthHandler creates a new health handler reporting the webhook circuit, which may be nil
+func NewHealthHandler(circuit services.CircuitBreaker) *HealthHandler {
 	return &HealthHandler{
 		startTime: time.Now(),
+		circuit:   circuit,
 	}
 }
 
 // Health handles the basic health check endpoint
 // @Summary      Health check
-// @Description  Get the health status of the API server
+// @Description  Get the health status of the API server. The status is degraded, still with HTTP 200, while the webhook circuit breaker is open and deliveries are held back.
 // @Tags         health
 // @Accept       json
 // @Produce      json
@@ -42,9 +45,27 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 		Version: "1.0.0",
 	}
 
+	if h.circuit != nil {
+		status := h.circuit.Status()
+		response.WebhookCircuit = toCircuitBreakerResponse(status)
+		if status.IsOpen() {
+			response.Status = "degraded"
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 
-	logger.DebugCtx(ctx, "Health check completed", zap.String("status", "ok"))
+	logger.DebugCtx(ctx, "Health check completed", zap.String("status", response.Status))
+}
+
+// toCircuitBreakerResponse converts a circuit breaker status to its DTO
+func toCircuitBreakerResponse(status services.CircuitStatus) *dto.CircuitBreakerResponse {
+	return &dto.CircuitBreakerResponse{
+		State:               string(status.State),
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		OpenedAt:            status.OpenedAt,
+		RetryAt:             status.RetryAt,
+	}
 }
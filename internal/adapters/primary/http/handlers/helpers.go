@@ -39,12 +39,15 @@ func handleUseCaseError(w http.ResponseWriter, err error) {
 	var validationErr domainErrors.ValidationError
 	var notFoundErr domainErrors.NotFoundError
 	var businessErr domainErrors.BusinessError
+	var conflictErr domainErrors.ConflictError
 
 	switch {
 	case errors.As(err, &validationErr):
 		writeJSONError(w, http.StatusBadRequest, err.Error())
 	case errors.As(err, &notFoundErr):
 		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &conflictErr):
+		writeJSONError(w, http.StatusConflict, err.Error())
 	case errors.As(err, &businessErr):
 		writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
 	default:
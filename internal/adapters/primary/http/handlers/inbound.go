@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// InboundHandler handles HTTP requests for replies forwarded by the provider
+type InboundHandler struct {
+	inboundMessages usecases.InboundMessageUseCase
+}
+
+// NewInboundHandler creates a new inbound message handler
+func NewInboundHandler(inboundMessages usecases.InboundMessageUseCase) *InboundHandler {
+	return &InboundHandler{
+		inboundMessages: inboundMessages,
+	}
+}
+
+// ReceiveInboundMessage handles POST /api/v1/inbound
+// @Summary      Receive a reply
+// @Description  Provider callback for a reply sent by a recipient. The reply is stored with the recipient's phone number. A reply that is exactly an opt-out keyword (STOP, UNSUBSCRIBE, IPTAL, ...) puts the number on the suppression list; an opt-in keyword (START, ...) lifts an earlier keyword opt-out. Callbacks delivered again with the same messageId return the stored reply without side effects. Authenticated by the provider's X-Dispatcher-Signature of the body and X-Dispatcher-Timestamp, not by the client API key.
+// @Tags         inbound
+// @Accept       json
+// @Produce      json
+// @Param        reply  body      dto.InboundMessageRequest  true  "Reply forwarded by the provider"
+// @Success      200    {object}  dto.InboundMessageResponse
+// @Failure      400    {object}  dto.ErrorResponse "Bad request - invalid phone number or content"
+// @Failure      401    {object}  dto.ErrorResponse "Unauthorized - missing, stale or invalid signature"
+// @Failure      429    {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500    {object}  dto.ErrorResponse "Internal server error"
+// @Security     ProviderSignature
+// @Router       /inbound [post]
+func (h *InboundHandler) ReceiveInboundMessage(w http.ResponseWriter, r *http.Request) {
+	var req dto.InboundMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := usecases.ReceiveInboundMessageCommand{
+		From:       req.From,
+		Content:    req.Content,
+		ExternalID: req.MessageID,
+	}
+	if req.ReceivedAt != nil {
+		cmd.ReceivedAt = *req.ReceivedAt
+	}
+
+	result, err := h.inboundMessages.ReceiveInboundMessage(r.Context(), cmd)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toInboundMessageResponse(result))
+}
+
+// GetConversation handles GET /api/v1/conversations/{phoneNumber}
+// @Summary      Get the conversation with a phone number
+// @Description  Retrieve the outbound messages and replies of a phone number as one timeline, most recent first, together with its suppression list entry. Any spelling of the number is accepted; URL-encode the leading + as %2B.
+// @Tags         inbound
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  path      string  true   "Phone number"
+// @Param        limit        query     int     false  "Number of entries to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset       query     int     false  "Number of entries to skip"  minimum(0)  default(0)
+// @Success      200          {object}  dto.ConversationResponse
+// @Failure      400          {object}  dto.ErrorResponse "Bad request - invalid phone number"
+// @Failure      401          {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429          {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500          {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /conversations/{phoneNumber} [get]
+func (h *InboundHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	phoneNumber, err := phoneNumberParam(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid phone number")
+		return
+	}
+
+	query := usecases.ConversationQuery{
+		PhoneNumber: phoneNumber,
+		Limit:       20, // Default limit
+		Offset:      0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.inboundMessages.GetConversation(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ConversationResponse{
+		PhoneNumber: result.PhoneNumber,
+		Entries:     make([]dto.ConversationEntryResponse, len(result.Entries)),
+		HasMore:     result.HasMore,
+	}
+	if result.Suppression != nil {
+		suppression := toSuppressionResponse(result.Suppression)
+		response.Suppression = &suppression
+	}
+
+	for i, entry := range result.Entries {
+		response.Entries[i] = dto.ConversationEntryResponse{
+			Direction:  entry.Direction,
+			OccurredAt: entry.OccurredAt,
+		}
+		if entry.Outbound != nil {
+			outbound := toMessageResponse(entry.Outbound)
+			response.Entries[i].Outbound = &outbound
+		}
+		if entry.Inbound != nil {
+			inbound := toInboundMessageResponse(entry.Inbound)
+			response.Entries[i].Inbound = &inbound
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// toInboundMessageResponse converts a use case inbound message to its API representation
+func toInboundMessageResponse(msg *usecases.InboundMessageResponse) dto.InboundMessageResponse {
+	return dto.InboundMessageResponse{
+		ID:            msg.ID.String(),
+		PhoneNumber:   msg.PhoneNumber,
+		Content:       msg.Content,
+		MessageID:     msg.ExternalID,
+		KeywordAction: msg.KeywordAction,
+		ReceivedAt:    msg.ReceivedAt,
+		CreatedAt:     msg.CreatedAt,
+	}
+}
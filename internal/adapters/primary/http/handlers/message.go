@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -32,16 +34,19 @@ func NewMessageHandler(
 
 // CreateMessage handles POST /api/v1/messages
 // @Summary      Create a new message
-// @Description  Create a new message to be sent via webhook with automatic retry mechanism
+// @Description  Create a new message to be sent via webhook with automatic retry mechanism. Set sendAt to deliver it at a later time. Send either content or a templateId with the variables its placeholders need. Content may span up to messages.max_segments SMS parts (153 GSM-7 or 67 UCS-2 characters each). A message to a suppressed phone number is stored as SUPPRESSED and never sent.
 // @Tags         messages
 // @Accept       json
 // @Produce      json
-// @Param        message  body      dto.CreateMessageRequest  true  "Message data"
-// @Success      201      {object}  dto.MessageResponse
-// @Failure      400      {object}  dto.ErrorResponse "Bad request - invalid input"
-// @Failure      401      {object}  dto.ErrorResponse "Unauthorized - missing or invalid API key"
-// @Failure      429      {object}  dto.ErrorResponse "Rate limit exceeded"
-// @Failure      500      {object}  dto.ErrorResponse "Internal server error"
+// @Param        message          body      dto.CreateMessageRequest  true   "Message data"
+// @Param        Idempotency-Key  header    string                    false  "Unique key to safely retry the request; replays return the original response"
+// @Success      201              {object}  dto.MessageResponse
+// @Failure      400              {object}  dto.ErrorResponse "Bad request - invalid input"
+// @Failure      401              {object}  dto.ErrorResponse "Unauthorized - missing or invalid API key"
+// @Failure      409              {object}  dto.ErrorResponse "Request with the same idempotency key is still in progress"
+// @Failure      422              {object}  dto.ErrorResponse "Idempotency key already used with a different request"
+// @Failure      429              {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500              {object}  dto.ErrorResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /messages [post]
 func (h *MessageHandler) CreateMessage(w http.ResponseWriter, r *http.Request) {
@@ -51,10 +56,12 @@ func (h *MessageHandler) CreateMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cmd := usecases.CreateMessageCommand{
-		PhoneNumber: req.PhoneNumber,
-		Content:     req.Content,
+	cmd, err := toCreateMessageCommand(req)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
 	}
+	cmd.IdempotencyKey = r.Header.Get("Idempotency-Key")
 
 	result, err := h.messageManagement.CreateMessage(r.Context(), cmd)
 	if err != nil {
@@ -62,24 +69,121 @@ func (h *MessageHandler) CreateMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := dto.MessageResponse{
-		ID:          result.ID.String(),
-		PhoneNumber: result.PhoneNumber,
-		Content:     result.Content,
-		Status:      result.Status,
-		ExternalID:  result.ExternalID,
-		RetryCount:  result.RetryCount,
-		CreatedAt:   result.CreatedAt,
-		UpdatedAt:   result.UpdatedAt,
-		SentAt:      result.SentAt,
-	}
+	response := toMessageResponse(result)
 
 	writeJSONResponse(w, http.StatusCreated, response)
 }
 
+// CreateMessagesBatch handles POST /api/v1/messages/batch
+// @Summary      Create messages in bulk
+// @Description  Create up to messages.batch_max_items messages in one request. Each message is validated on its own: valid ones are created together and invalid ones are reported per item without failing the request.
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        messages  body      dto.CreateMessagesBatchRequest  true  "Messages to create"
+// @Success      201       {object}  dto.CreateMessagesBatchResponse "All messages created"
+// @Success      207       {object}  dto.CreateMessagesBatchResponse "Some or all messages were rejected"
+// @Failure      400       {object}  dto.ErrorResponse "Bad request - invalid body, no messages or too many messages"
+// @Failure      401       {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429       {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500       {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/batch [post]
+func (h *MessageHandler) CreateMessagesBatch(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateMessagesBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Items that cannot be converted are reported as failed, the rest go to the use case
+	items := make([]dto.BatchItemResponse, len(req.Messages))
+	cmds := make([]usecases.CreateMessageCommand, 0, len(req.Messages))
+	indexes := make([]int, 0, len(req.Messages))
+	for i, item := range req.Messages {
+		items[i] = dto.BatchItemResponse{Index: i}
+
+		cmd, err := toCreateMessageCommand(item)
+		if err != nil {
+			items[i].Status = "failed"
+			items[i].Error = err.Error()
+			continue
+		}
+
+		cmds = append(cmds, cmd)
+		indexes = append(indexes, i)
+	}
+
+	response := dto.CreateMessagesBatchResponse{
+		Items:       items,
+		FailedCount: len(req.Messages) - len(cmds),
+	}
+
+	if len(cmds) > 0 || len(req.Messages) == 0 {
+		result, err := h.messageManagement.CreateMessages(r.Context(), cmds)
+		if err != nil {
+			handleUseCaseError(w, err)
+			return
+		}
+
+		for i, itemResult := range result.Items {
+			item := &response.Items[indexes[i]]
+			if itemResult.Error != nil {
+				item.Status = "failed"
+				item.Error = itemResult.Error.Error()
+				continue
+			}
+
+			created := toMessageResponse(itemResult.Message)
+			item.Status = "created"
+			item.Message = &created
+		}
+
+		response.CreatedCount = result.CreatedCount
+		response.FailedCount += result.FailedCount
+	}
+
+	status := http.StatusCreated
+	if response.FailedCount > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	writeJSONResponse(w, status, response)
+}
+
+// toCreateMessageCommand converts a create message request into a use case command
+func toCreateMessageCommand(req dto.CreateMessageRequest) (usecases.CreateMessageCommand, error) {
+	cmd := usecases.CreateMessageCommand{
+		PhoneNumber: req.PhoneNumber,
+		Content:     req.Content,
+		Variables:   req.Variables,
+		SendAt:      req.SendAt,
+		ExpiresAt:   req.ExpiresAt,
+		Priority:    req.Priority,
+	}
+
+	if req.TemplateID != "" {
+		templateID, err := uuid.Parse(req.TemplateID)
+		if err != nil {
+			return cmd, fmt.Errorf("invalid templateId: must be a UUID")
+		}
+		cmd.TemplateID = &templateID
+	}
+
+	if req.TTL != "" {
+		ttl, err := time.ParseDuration(req.TTL)
+		if err != nil {
+			return cmd, fmt.Errorf("invalid ttl: must be a duration such as 15m")
+		}
+		cmd.TTL = ttl
+	}
+
+	return cmd, nil
+}
+
 // GetMessage handles GET /api/v1/messages/{id}
 // @Summary      Get a message by ID
-// @Description  Retrieve a specific message by its unique identifier
+// @Description  Retrieve a specific message by its unique identifier, including its status history
 // @Tags         messages
 // @Accept       json
 // @Produce      json
@@ -106,36 +210,60 @@ func (h *MessageHandler) GetMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := dto.MessageResponse{
-		ID:          result.ID.String(),
-		PhoneNumber: result.PhoneNumber,
-		Content:     result.Content,
-		Status:      result.Status,
-		ExternalID:  result.ExternalID,
-		RetryCount:  result.RetryCount,
-		CreatedAt:   result.CreatedAt,
-		UpdatedAt:   result.UpdatedAt,
-		SentAt:      result.SentAt,
-	}
+	response := toMessageResponse(result)
 
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+// CancelMessage handles POST /api/v1/messages/{id}/cancel
+// @Summary      Cancel a pending message
+// @Description  Cancel a PENDING or scheduled message so it is never sent. Messages already picked up for delivery cannot be cancelled.
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Message ID (UUID)"
+// @Success      200  {object}  dto.MessageResponse
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid message ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Message not found"
+// @Failure      409  {object}  dto.ErrorResponse "Message was claimed for delivery while being cancelled"
+// @Failure      422  {object}  dto.ErrorResponse "Message is not in PENDING status"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/{id}/cancel [post]
+func (h *MessageHandler) CancelMessage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	result, err := h.messageManagement.CancelMessage(r.Context(), id)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toMessageResponse(result))
+}
+
 // ListMessages handles GET /api/v1/messages
 // @Summary      List messages with pagination
 // @Description  Retrieve a list of messages with optional filtering and pagination
 // @Tags         messages
 // @Accept       json
 // @Produce      json
-// @Param        status  query     string  false  "Filter by status"  Enums(pending, sent, failed)
-// @Param        limit   query     int     false  "Number of messages to return (1-100)"  minimum(1)  maximum(100)  default(20)
-// @Param        offset  query     int     false  "Number of messages to skip"  minimum(0)  default(0)
-// @Success      200     {array}   dto.MessageResponse
-// @Header       200     {string}  X-Total-Count  "Total number of messages"
-// @Failure      400     {object}  dto.ErrorResponse "Bad request - invalid parameters"
-// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
-// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
-// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Param        status     query     string  false  "Filter by status"  Enums(pending, processing, sent, failed, expired, cancelled, suppressed, delivered, undelivered)
+// @Param        scheduled  query     bool    false  "Only pending messages whose send time is in the future"
+// @Param        limit      query     int     false  "Number of messages to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset     query     int     false  "Number of messages to skip"  minimum(0)  default(0)
+// @Success      200        {array}   dto.MessageResponse
+// @Header       200        {string}  X-Total-Count  "Total number of messages"
+// @Failure      400        {object}  dto.ErrorResponse "Bad request - invalid parameters"
+// @Failure      401        {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429        {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500        {object}  dto.ErrorResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /messages [get]
 func (h *MessageHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
@@ -164,6 +292,12 @@ func (h *MessageHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if scheduledStr := r.URL.Query().Get("scheduled"); scheduledStr != "" {
+		if scheduled, err := strconv.ParseBool(scheduledStr); err == nil {
+			query.Scheduled = scheduled
+		}
+	}
+
 	result, err := h.messageManagement.ListMessages(r.Context(), query)
 	if err != nil {
 		handleUseCaseError(w, err)
@@ -173,17 +307,7 @@ func (h *MessageHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
 	// Convert to response DTOs
 	responses := make([]dto.MessageResponse, len(result.Messages))
 	for i, msg := range result.Messages {
-		responses[i] = dto.MessageResponse{
-			ID:          msg.ID.String(),
-			PhoneNumber: msg.PhoneNumber,
-			Content:     msg.Content,
-			Status:      msg.Status,
-			ExternalID:  msg.ExternalID,
-			RetryCount:  msg.RetryCount,
-			CreatedAt:   msg.CreatedAt,
-			UpdatedAt:   msg.UpdatedAt,
-			SentAt:      msg.SentAt,
-		}
+		responses[i] = toMessageResponse(&msg)
 	}
 
 	// Set total count in header as requested
@@ -214,6 +338,7 @@ func (h *MessageHandler) GetProcessingStatus(w http.ResponseWriter, r *http.Requ
 		IsProcessing:     result.IsProcessing,
 		LastProcessedAt:  result.LastProcessedAt,
 		PendingCount:     result.PendingCount,
+		ProcessingCount:  result.ProcessingCount,
 		ProcessedToday:   result.ProcessedToday,
 		FailedToday:      result.FailedToday,
 		NextProcessingAt: result.NextProcessingAt,
@@ -228,7 +353,7 @@ func (h *MessageHandler) GetProcessingStatus(w http.ResponseWriter, r *http.Requ
 // @Tags         messaging
 // @Accept       json
 // @Produce      json
-// @Param        batch_size  query     int  false  "Number of messages to process (1-10)"  minimum(1)  maximum(10)  default(2)
+// @Param        batch_size  query     int  false  "Number of messages to process, capped at scheduler.max_batch_size"  minimum(1)  default(2)
 // @Success      200         {object}  dto.ProcessingResultResponse
 // @Failure      401         {object}  dto.ErrorResponse "Unauthorized"
 // @Failure      429         {object}  dto.ErrorResponse "Rate limit exceeded"
@@ -239,22 +364,28 @@ func (h *MessageHandler) ProcessMessages(w http.ResponseWriter, r *http.Request)
 	// Get batch size from query param, default to 2
 	batchSize := 2
 	if batchStr := r.URL.Query().Get("batch_size"); batchStr != "" {
-		if size, err := strconv.Atoi(batchStr); err == nil && size > 0 && size <= 10 {
+		if size, err := strconv.Atoi(batchStr); err == nil && size > 0 {
 			batchSize = size
 		}
 	}
 
-	result, err := h.messageProcessing.ProcessPendingMessages(r.Context(), batchSize)
+	ctx := message.WithTrigger(r.Context(), message.TriggerManualProcess)
+	result, err := h.messageProcessing.ProcessPendingMessages(ctx, batchSize)
 	if err != nil {
 		handleUseCaseError(w, err)
 		return
 	}
 
 	response := dto.ProcessingResultResponse{
-		ProcessedCount: result.ProcessedCount,
-		SuccessCount:   result.SuccessCount,
-		FailedCount:    result.FailedCount,
-		Errors:         make([]string, len(result.Errors)),
+		ProcessedCount:  result.ProcessedCount,
+		SuccessCount:    result.SuccessCount,
+		FailedCount:     result.FailedCount,
+		ExpiredCount:    result.ExpiredCount,
+		RetriedCount:    result.RetriedCount,
+		DeferredCount:   result.DeferredCount,
+		SuppressedCount: result.SuppressedCount,
+		ReclaimedCount:  result.ReclaimedCount,
+		Errors:          make([]string, len(result.Errors)),
 	}
 
 	// Convert errors to strings
@@ -264,3 +395,62 @@ func (h *MessageHandler) ProcessMessages(w http.ResponseWriter, r *http.Request)
 
 	writeJSONResponse(w, http.StatusOK, response)
 }
+
+// toMessageResponse converts a use case message response to its API representation
+func toMessageResponse(msg *usecases.MessageResponse) dto.MessageResponse {
+	var templateID *string
+	if msg.TemplateID != nil {
+		id := msg.TemplateID.String()
+		templateID = &id
+	}
+
+	return dto.MessageResponse{
+		ID:                 msg.ID.String(),
+		PhoneNumber:        msg.PhoneNumber,
+		CountryCode:        msg.CountryCode,
+		Region:             msg.Region,
+		NumberType:         msg.NumberType,
+		Content:            msg.Content,
+		Status:             msg.Status,
+		Priority:           msg.Priority,
+		ExternalID:         msg.ExternalID,
+		Provider:           msg.Provider,
+		RetryCount:         msg.RetryCount,
+		SendAt:             msg.SendAt,
+		ExpiresAt:          msg.ExpiresAt,
+		NextAttemptAt:      msg.NextAttemptAt,
+		AttemptCount:       msg.AttemptCount,
+		LastError:          msg.LastError,
+		LastErrorClass:     msg.LastErrorClass,
+		LastHTTPStatus:     msg.LastHTTPStatus,
+		CreatedAt:          msg.CreatedAt,
+		UpdatedAt:          msg.UpdatedAt,
+		SentAt:             msg.SentAt,
+		CarrierErrorCode:   msg.CarrierErrorCode,
+		DeliveryReportedAt: msg.DeliveryReportedAt,
+		TemplateID:         templateID,
+		TemplateVersion:    msg.TemplateVersion,
+		Encoding:           msg.Encoding,
+		Segments:           msg.Segments,
+		StatusHistory:      toStatusChangeResponses(msg.StatusHistory),
+	}
+}
+
+// toStatusChangeResponses converts a use case status timeline to its API representation
+func toStatusChangeResponses(history []usecases.StatusChangeResponse) []dto.StatusChangeResponse {
+	if len(history) == 0 {
+		return nil
+	}
+
+	responses := make([]dto.StatusChangeResponse, len(history))
+	for i, change := range history {
+		responses[i] = dto.StatusChangeResponse{
+			From:       change.From,
+			To:         change.To,
+			Trigger:    change.Trigger,
+			OccurredAt: change.OccurredAt,
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// ListMessageAttempts handles GET /api/v1/messages/{id}/attempts
+// @Summary      List delivery attempts of a message
+// @Description  Retrieve every webhook request made for a message, oldest first, with its duration, HTTP status, response excerpt and error
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true   "Message ID (UUID)"
+// @Param        limit   query     int     false  "Number of attempts to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset  query     int     false  "Number of attempts to skip"  minimum(0)  default(0)
+// @Success      200     {array}   dto.AttemptResponse
+// @Header       200     {string}  X-Total-Count  "Total number of attempts of the message"
+// @Failure      400     {object}  dto.ErrorResponse "Bad request - invalid message ID"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404     {object}  dto.ErrorResponse "Message not found"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/{id}/attempts [get]
+func (h *MessageHandler) ListMessageAttempts(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid message ID")
+		return
+	}
+
+	query := usecases.MessageAttemptsQuery{
+		MessageID: id,
+		Limit:     20, // Default limit
+		Offset:    0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.messageManagement.ListMessageAttempts(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.AttemptResponse, len(result.Attempts))
+	for i, attempt := range result.Attempts {
+		responses[i] = dto.AttemptResponse{
+			ID:            attempt.ID.String(),
+			Provider:      attempt.Provider,
+			RetryCount:    attempt.RetryCount,
+			AttemptNumber: attempt.AttemptNumber,
+			StartedAt:     attempt.StartedAt,
+			DurationMs:    attempt.DurationMs,
+			HTTPStatus:    attempt.HTTPStatus,
+			ResponseBody:  attempt.ResponseBody,
+			Error:         attempt.Error,
+			ErrorClass:    attempt.ErrorClass,
+		}
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
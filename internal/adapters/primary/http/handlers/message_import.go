@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+const (
+	// maxImportBytes bounds the size of an uploaded import file
+	maxImportBytes = 50 << 20
+
+	// importErrorsPageSize is the number of row errors fetched per page while writing an error report
+	importErrorsPageSize = 1000
+)
+
+// ImportMessages handles POST /api/v1/messages/imports
+// @Summary      Import messages from a file
+// @Description  Upload a CSV or NDJSON file and create a message per row in the background. CSV files need a header with a phoneNumber (or phone) column and either a content column, a templateId column or the templateId query parameter; they may add sendAt, expiresAt and ttl columns, and any other column is a template variable. NDJSON lines use the create message request fields. The format is taken from the format query parameter, the Content-Type (text/csv, application/x-ndjson) or the extension of a multipart "file" part. Rows are validated one by one; rejected rows are listed in the error report.
+// @Tags         messages
+// @Accept       text/csv,application/x-ndjson,multipart/form-data
+// @Produce      json
+// @Param        format      query     string  false  "File format, overrides the Content-Type"  Enums(csv, ndjson)
+// @Param        templateId  query     string  false  "Template for rows with neither content nor a templateId of their own"
+// @Param        file        formData  file    false  "File to import when uploading as multipart/form-data"
+// @Success      202         {object}  dto.ImportJobResponse "Import started"
+// @Failure      400         {object}  dto.ErrorResponse "Bad request - unknown format or template, empty file or missing CSV columns"
+// @Failure      401         {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      413         {object}  dto.ErrorResponse "File larger than 50 MB"
+// @Failure      429         {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500         {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/imports [post]
+func (h *MessageHandler) ImportMessages(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	cmd := usecases.ImportMessagesCommand{}
+	if templateIDStr := r.URL.Query().Get("templateId"); templateIDStr != "" {
+		templateID, err := uuid.Parse(templateIDStr)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid templateId: must be a UUID")
+			return
+		}
+		cmd.TemplateID = &templateID
+	}
+
+	body, format, err := importSource(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The import runs after the request ends, so the upload is copied to a temporary file first
+	file, err := spoolImport(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "import file is too large")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "failed to store import file")
+		return
+	}
+
+	cmd.Format = format
+	cmd.Source = file
+
+	result, err := h.messageManagement.ImportMessages(r.Context(), cmd)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusAccepted, toImportJobResponse(result))
+}
+
+// GetImportJob handles GET /api/v1/messages/imports/{id}
+// @Summary      Get the progress of an import
+// @Description  Retrieve the status and row counts of a message file import
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Import job ID (UUID)"
+// @Success      200  {object}  dto.ImportJobResponse
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid import job ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Import job not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/imports/{id} [get]
+func (h *MessageHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid import job ID")
+		return
+	}
+
+	result, err := h.messageManagement.GetImportJob(r.Context(), id)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toImportJobResponse(result))
+}
+
+// DownloadImportErrors handles GET /api/v1/messages/imports/{id}/errors
+// @Summary      Download the error report of an import
+// @Description  Download every row rejected so far by an import as a CSV file with row, error and data columns. Row numbers count data rows from 1, not including a CSV header.
+// @Tags         messages
+// @Accept       json
+// @Produce      text/csv
+// @Param        id   path      string  true  "Import job ID (UUID)"
+// @Success      200  {file}    file    "CSV error report"
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid import job ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Import job not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/imports/{id}/errors [get]
+func (h *MessageHandler) DownloadImportErrors(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid import job ID")
+		return
+	}
+
+	query := usecases.ImportErrorsQuery{JobID: id, Limit: importErrorsPageSize}
+
+	// Fetch the first page before writing anything so lookup errors still get a JSON response
+	page, err := h.messageManagement.ListImportErrors(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="import-`+id.String()+`-errors.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"row", "error", "data"})
+
+	for {
+		for _, rowErr := range page.Errors {
+			writer.Write([]string{strconv.Itoa(rowErr.Row), rowErr.Error, rowErr.Data})
+		}
+
+		if !page.HasMore || len(page.Errors) == 0 {
+			break
+		}
+
+		// The status is already sent, a failure can only cut the report short
+		query.Offset += len(page.Errors)
+		if page, err = h.messageManagement.ListImportErrors(r.Context(), query); err != nil {
+			break
+		}
+	}
+
+	writer.Flush()
+}
+
+// importSource returns the uploaded file and its format, from a raw body or a multipart "file" part
+func importSource(r *http.Request) (io.Reader, string, error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if format == "" {
+			format = importFormatFromMediaType(mediaType)
+		}
+		if format == "" {
+			return nil, "", errors.New("unknown import format: use text/csv or application/x-ndjson, or set the format query parameter")
+		}
+		return r.Body, format, nil
+	}
+
+	// Read the multipart body as a stream instead of buffering the whole form
+	reader := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, "", errors.New(`multipart upload must contain a "file" part`)
+		}
+		if err != nil {
+			return nil, "", errors.New("invalid multipart body")
+		}
+
+		if part.FormName() != "file" {
+			continue
+		}
+
+		if format == "" {
+			format = importFormatFromFileName(part.FileName())
+		}
+		if format == "" {
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			format = importFormatFromMediaType(partType)
+		}
+		if format == "" {
+			return nil, "", errors.New("unknown import format: use a .csv or .ndjson file, or set the format query parameter")
+		}
+		return part, format, nil
+	}
+}
+
+// importFormatFromMediaType maps a media type to an import format, or returns "" if it is not recognized
+func importFormatFromMediaType(mediaType string) string {
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return "csv"
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return "ndjson"
+	default:
+		return ""
+	}
+}
+
+// importFormatFromFileName maps a file extension to an import format, or returns "" if it is not recognized
+func importFormatFromFileName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	default:
+		return ""
+	}
+}
+
+// tempImportFile is an uploaded import file that is deleted once closed
+type tempImportFile struct {
+	*os.File
+}
+
+// Close closes and removes the temporary file
+func (f tempImportFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
+
+// spoolImport copies an upload to a temporary file and rewinds it for reading
+func spoolImport(body io.Reader) (io.ReadCloser, error) {
+	file, err := os.CreateTemp("", "message-import-*")
+	if err != nil {
+		return nil, err
+	}
+	spooled := tempImportFile{File: file}
+
+	if _, err := io.Copy(file, body); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+
+	return spooled, nil
+}
+
+// toImportJobResponse converts a use case import job response to its API representation
+func toImportJobResponse(job *usecases.ImportJobResponse) dto.ImportJobResponse {
+	return dto.ImportJobResponse{
+		ID:            job.ID.String(),
+		Format:        job.Format,
+		Status:        strings.ToLower(job.Status),
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		ErrorsURL:     "/api/v1/messages/imports/" + job.ID.String() + "/errors",
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		CompletedAt:   job.CompletedAt,
+	}
+}
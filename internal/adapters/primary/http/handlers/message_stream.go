@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// streamRetryMillis is how long clients wait before reconnecting a dropped stream
+const streamRetryMillis = 3000
+
+// MessageStreamHandler handles the server-sent event stream of message status changes
+type MessageStreamHandler struct {
+	stream    usecases.MessageStreamUseCase
+	heartbeat time.Duration
+}
+
+// NewMessageStreamHandler creates a new message stream handler
+func NewMessageStreamHandler(stream usecases.MessageStreamUseCase, heartbeat time.Duration) *MessageStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+
+	return &MessageStreamHandler{
+		stream:    stream,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamMessages handles GET /api/v1/messages/stream
+// @Summary      Stream message status changes
+// @Description  Server-sent event stream of messages being created (event message.created) and changing status (event message.status_changed), on any instance. Each event's data is JSON in the shape of a subscription callback body and its id is the event ID. Reconnecting with the Last-Event-ID header (or last_event_id) first sends the changes missed since that event, as far back as the stream's resume window reaches. Clients too slow to keep up are disconnected and resume the same way. A comment line is sent periodically to keep the connection open.
+// @Tags         messages
+// @Produce      text/event-stream
+// @Param        status         query     []string  false  "Only stream changes to these statuses (comma-separated or repeated)"  collectionFormat(multi)
+// @Param        phone_number   query     string    false  "Only stream changes of messages to this phone number"
+// @Param        last_event_id  query     int       false  "Resume after this event ID; the Last-Event-ID header takes precedence"
+// @Param        Last-Event-ID  header    int       false  "Resume after this event ID"
+// @Success      200            {object}  dto.MessageStreamEvent  "Stream of events"
+// @Failure      400            {object}  dto.ErrorResponse "Bad request - invalid status, phone number or event ID"
+// @Failure      401            {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      422            {object}  dto.ErrorResponse "Message stream is not running"
+// @Failure      429            {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500            {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /messages/stream [get]
+func (h *MessageStreamHandler) StreamMessages(w http.ResponseWriter, r *http.Request) {
+	query := usecases.StreamStatusEventsQuery{
+		PhoneNumber: r.URL.Query().Get("phone_number"),
+	}
+
+	for _, value := range r.URL.Query()["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				query.Statuses = append(query.Statuses, status)
+			}
+		}
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid last event ID")
+			return
+		}
+		query.LastEventID = &id
+	}
+
+	events, err := h.stream.StreamStatusEvents(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		}
+
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeStreamEvent writes a status change as a server-sent event
+func writeStreamEvent(w http.ResponseWriter, event usecases.StatusEventResponse) error {
+	data, err := json.Marshal(toMessageStreamEvent(event))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+// toMessageStreamEvent converts a use case status event to its stream DTO
+func toMessageStreamEvent(event usecases.StatusEventResponse) dto.MessageStreamEvent {
+	return dto.MessageStreamEvent{
+		ID:         strconv.FormatInt(event.ID, 10),
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data: dto.MessageStreamEventData{
+			MessageID:   event.MessageID.String(),
+			PhoneNumber: event.PhoneNumber,
+			From:        event.From,
+			To:          event.To,
+			Trigger:     event.Trigger,
+		},
+	}
+}
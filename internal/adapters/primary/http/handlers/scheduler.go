@@ -26,7 +26,7 @@ func NewSchedulerHandler(scheduler *services.ProcessingScheduler, interval time.
 
 // GetSchedulerStatus handles GET /api/v1/scheduler/status
 // @Summary      Get scheduler status
-// @Description  Retrieve the current status and statistics of the background message scheduler
+// @Description  Retrieve the current status and statistics of the background message scheduler, including the webhook circuit breaker that pauses claiming while the endpoint is down
 // @Tags         scheduler
 // @Accept       json
 // @Produce      json
@@ -52,10 +52,17 @@ func (h *SchedulerHandler) GetSchedulerStatus(w http.ResponseWriter, r *http.Req
 		TotalProcessed:        stats.TotalProcessed,
 		TotalSuccessful:       stats.TotalSuccessful,
 		TotalFailed:           stats.TotalFailed,
+		TotalExpired:          stats.TotalExpired,
+		TotalRetried:          stats.TotalRetried,
+		TotalSuppressed:       stats.TotalSuppressed,
 		LastProcessingTime:    stats.LastProcessingTime,
 		NextProcessingIn:      nextProcessingIn.Round(time.Second).String(),
 		Interval:              h.interval.String(),
 		BatchSize:             h.batchSize,
+		SkippedRuns:           stats.SkippedRuns,
+	}
+	if status := h.scheduler.CircuitStatus(); status != nil {
+		response.WebhookCircuit = toCircuitBreakerResponse(*status)
 	}
 
 	writeJSONResponse(w, http.StatusOK, response)
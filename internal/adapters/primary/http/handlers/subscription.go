@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// SubscriptionHandler handles HTTP requests for status-change subscriptions
+type SubscriptionHandler struct {
+	subscriptions usecases.SubscriptionUseCase
+}
+
+// NewSubscriptionHandler creates a new subscription handler
+func NewSubscriptionHandler(subscriptions usecases.SubscriptionUseCase) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptions: subscriptions,
+	}
+}
+
+// CreateSubscription handles POST /api/v1/subscriptions
+// @Summary      Subscribe to message status changes
+// @Description  Register an endpoint that receives a signed POST for every matching message status change. Each callback carries X-Dispatcher-Event-Id, X-Dispatcher-Timestamp (unix seconds) and X-Dispatcher-Signature: "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. The secret is only returned in this response. Callbacks not answered with 2xx are retried with exponential backoff.
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        subscription  body      dto.CreateSubscriptionRequest  true  "Endpoint and status filter"
+// @Success      201           {object}  dto.SubscriptionResponse
+// @Failure      400           {object}  dto.ErrorResponse "Bad request - invalid URL, description or status"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429           {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500           {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions [post]
+func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.subscriptions.CreateSubscription(r.Context(), usecases.CreateSubscriptionCommand{
+		URL:         req.URL,
+		Description: req.Description,
+		Statuses:    req.Statuses,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, toSubscriptionResponse(result))
+}
+
+// ListSubscriptions handles GET /api/v1/subscriptions
+// @Summary      List subscriptions
+// @Description  Retrieve status-change subscriptions, oldest first. Secrets are not included.
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "Number of subscriptions to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset  query     int  false  "Number of subscriptions to skip"  minimum(0)  default(0)
+// @Success      200     {array}   dto.SubscriptionResponse
+// @Header       200     {string}  X-Total-Count  "Total number of subscriptions"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions [get]
+func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	query := usecases.ListSubscriptionsQuery{
+		Limit:  20, // Default limit
+		Offset: 0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.subscriptions.ListSubscriptions(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.SubscriptionResponse, len(result.Subscriptions))
+	for i, subscription := range result.Subscriptions {
+		responses[i] = toSubscriptionResponse(&subscription)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
+
+// GetSubscription handles GET /api/v1/subscriptions/{id}
+// @Summary      Get a subscription
+// @Description  Retrieve a status-change subscription without its secret
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID (UUID)"
+// @Success      200  {object}  dto.SubscriptionResponse
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid subscription ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Subscription not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id} [get]
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	result, err := h.subscriptions.GetSubscription(r.Context(), id)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toSubscriptionResponse(result))
+}
+
+// UpdateSubscription handles PUT /api/v1/subscriptions/{id}
+// @Summary      Update a subscription
+// @Description  Replace the endpoint, description and status filter of a subscription, or pause it with active=false. A paused subscription queues no new status changes and holds its pending callbacks until it is resumed. The secret stays the same.
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string                         true  "Subscription ID (UUID)"
+// @Param        subscription  body      dto.UpdateSubscriptionRequest  true  "New endpoint and status filter"
+// @Success      200           {object}  dto.SubscriptionResponse
+// @Failure      400           {object}  dto.ErrorResponse "Bad request - invalid subscription ID, URL, description or status"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404           {object}  dto.ErrorResponse "Subscription not found"
+// @Failure      429           {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500           {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id} [put]
+func (h *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	var req dto.UpdateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	result, err := h.subscriptions.UpdateSubscription(r.Context(), usecases.UpdateSubscriptionCommand{
+		ID:          id,
+		URL:         req.URL,
+		Description: req.Description,
+		Statuses:    req.Statuses,
+		Active:      active,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toSubscriptionResponse(result))
+}
+
+// DeleteSubscription handles DELETE /api/v1/subscriptions/{id}
+// @Summary      Delete a subscription
+// @Description  Stop delivering status changes to an endpoint. Its pending callbacks and delivery log are removed.
+// @Tags         subscriptions
+// @Param        id   path      string  true  "Subscription ID (UUID)"
+// @Success      204  "Subscription deleted"
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid subscription ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Subscription not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id} [delete]
+func (h *SubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	if err := h.subscriptions.DeleteSubscription(r.Context(), id); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/v1/subscriptions/{id}/deliveries
+// @Summary      List the callbacks of a subscription
+// @Description  Retrieve the delivery log of a subscription, most recent first: one entry per status change queued for the endpoint, with its attempts and latest outcome
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true   "Subscription ID (UUID)"
+// @Param        status  query     string  false  "Filter by delivery state"  Enums(pending, delivered, failed)
+// @Param        limit   query     int     false  "Number of deliveries to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset  query     int     false  "Number of deliveries to skip"  minimum(0)  default(0)
+// @Success      200     {array}   dto.EventDeliveryResponse
+// @Header       200     {string}  X-Total-Count  "Total number of matching deliveries"
+// @Failure      400     {object}  dto.ErrorResponse "Bad request - invalid subscription ID or status"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404     {object}  dto.ErrorResponse "Subscription not found"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/deliveries [get]
+func (h *SubscriptionHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	query := usecases.ListEventDeliveriesQuery{
+		SubscriptionID: id,
+		Status:         r.URL.Query().Get("status"),
+		Limit:          20, // Default limit
+		Offset:         0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.subscriptions.ListDeliveries(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.EventDeliveryResponse, len(result.Deliveries))
+	for i, delivery := range result.Deliveries {
+		responses[i] = toEventDeliveryResponse(delivery)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
+
+// ReplayEvents handles POST /api/v1/subscriptions/{id}/replay
+// @Summary      Replay status changes
+// @Description  Queue every status change in [from, to) that the subscription matches for delivery again, e.g. after the endpoint was down. Replayed callbacks keep their event ID and carry "replay": true, so already processed events can be recognized. Ranges holding more events than the configured maximum are rejected.
+// @Tags         subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                   true  "Subscription ID (UUID)"
+// @Param        replay  body      dto.ReplayEventsRequest  true  "Time range to replay"
+// @Success      202     {object}  dto.ReplayEventsResponse
+// @Failure      400     {object}  dto.ErrorResponse "Bad request - invalid subscription ID or range, or too many events in range"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404     {object}  dto.ErrorResponse "Subscription not found"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /subscriptions/{id}/replay [post]
+func (h *SubscriptionHandler) ReplayEvents(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	var req dto.ReplayEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.subscriptions.ReplayEvents(r.Context(), usecases.ReplayEventsCommand{
+		SubscriptionID: id,
+		From:           req.From,
+		To:             req.To,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusAccepted, dto.ReplayEventsResponse{Queued: result.Queued})
+}
+
+// toSubscriptionResponse converts a use case subscription response to its API representation
+func toSubscriptionResponse(subscription *usecases.SubscriptionResponse) dto.SubscriptionResponse {
+	return dto.SubscriptionResponse{
+		ID:          subscription.ID.String(),
+		URL:         subscription.URL,
+		Description: subscription.Description,
+		Statuses:    subscription.Statuses,
+		Secret:      subscription.Secret,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+// toEventDeliveryResponse converts a use case delivery log entry to its API representation
+func toEventDeliveryResponse(delivery usecases.EventDeliveryResponse) dto.EventDeliveryResponse {
+	return dto.EventDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		MessageID:      delivery.MessageID.String(),
+		From:           delivery.From,
+		To:             delivery.To,
+		OccurredAt:     delivery.OccurredAt,
+		Status:         delivery.Status,
+		Replay:         delivery.Replay,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// maxSuppressionImportBytes bounds the size of a bulk suppression import body
+const maxSuppressionImportBytes = 10 << 20
+
+// SuppressionHandler handles HTTP requests for the recipient suppression list
+type SuppressionHandler struct {
+	suppressionManagement usecases.SuppressionManagementUseCase
+}
+
+// NewSuppressionHandler creates a new suppression handler
+func NewSuppressionHandler(suppressionManagement usecases.SuppressionManagementUseCase) *SuppressionHandler {
+	return &SuppressionHandler{
+		suppressionManagement: suppressionManagement,
+	}
+}
+
+// AddSuppression handles POST /api/v1/suppressions
+// @Summary      Suppress a phone number
+// @Description  Put a phone number that opted out on the suppression list. New messages to it are stored as SUPPRESSED and pending ones are withheld instead of sent. Suppressing a number twice keeps and returns the original entry.
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        suppression  body      dto.AddSuppressionRequest  true  "Phone number to suppress"
+// @Success      201          {object}  dto.SuppressionResponse
+// @Failure      400          {object}  dto.ErrorResponse "Bad request - invalid phone number or reason"
+// @Failure      401          {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429          {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500          {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /suppressions [post]
+func (h *SuppressionHandler) AddSuppression(w http.ResponseWriter, r *http.Request) {
+	var req dto.AddSuppressionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.suppressionManagement.AddSuppression(r.Context(), usecases.AddSuppressionCommand{
+		PhoneNumber: req.PhoneNumber,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, toSuppressionResponse(result))
+}
+
+// ImportSuppressions handles POST /api/v1/suppressions/imports
+// @Summary      Suppress many phone numbers
+// @Description  Put many phone numbers on the suppression list at once. Send a JSON body, or a CSV file (raw text/csv body or a multipart "file" part) with a phoneNumber column and an optional reason column. Invalid entries are reported without stopping the others.
+// @Tags         suppressions
+// @Accept       json,text/csv,multipart/form-data
+// @Produce      json
+// @Param        suppressions  body      dto.ImportSuppressionsRequest  true  "Phone numbers to suppress"
+// @Param        reason        query     string                         false  "Reason for CSV rows without a reason column"
+// @Success      200           {object}  dto.ImportSuppressionsResponse
+// @Failure      400           {object}  dto.ErrorResponse "Bad request - unreadable body or too many entries"
+// @Failure      401           {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      413           {object}  dto.ErrorResponse "Body too large"
+// @Failure      429           {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500           {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /suppressions/imports [post]
+func (h *SuppressionHandler) ImportSuppressions(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSuppressionImportBytes)
+
+	cmd, err := suppressionImportCommand(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "import body too large")
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.suppressionManagement.ImportSuppressions(r.Context(), cmd)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ImportSuppressionsResponse{
+		AddedCount:      result.AddedCount,
+		DuplicateCount:  result.DuplicateCount,
+		RejectedCount:   result.RejectedCount,
+		RejectedEntries: make([]dto.SuppressionImportErrorEntry, len(result.RejectedEntries)),
+	}
+	for i, entry := range result.RejectedEntries {
+		response.RejectedEntries[i] = dto.SuppressionImportErrorEntry{
+			Index:       entry.Index,
+			PhoneNumber: entry.PhoneNumber,
+			Error:       entry.Error,
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ListSuppressions handles GET /api/v1/suppressions
+// @Summary      List suppressed phone numbers
+// @Description  Retrieve the suppression list, most recently suppressed first
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "Number of entries to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset  query     int  false  "Number of entries to skip"  minimum(0)  default(0)
+// @Success      200     {array}   dto.SuppressionResponse
+// @Header       200     {string}  X-Total-Count  "Total number of suppressed phone numbers"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /suppressions [get]
+func (h *SuppressionHandler) ListSuppressions(w http.ResponseWriter, r *http.Request) {
+	query := usecases.ListSuppressionsQuery{
+		Limit:  20, // Default limit
+		Offset: 0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.suppressionManagement.ListSuppressions(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.SuppressionResponse, len(result.Suppressions))
+	for i, suppression := range result.Suppressions {
+		responses[i] = toSuppressionResponse(&suppression)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
+
+// CheckSuppression handles GET /api/v1/suppressions/{phoneNumber}
+// @Summary      Check whether a phone number is suppressed
+// @Description  Retrieve the suppression list entry of a phone number. Any spelling of the number is accepted; URL-encode the leading + as %2B.
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  path      string  true  "Phone number"
+// @Success      200          {object}  dto.SuppressionResponse "The number is suppressed"
+// @Failure      400          {object}  dto.ErrorResponse "Bad request - invalid phone number"
+// @Failure      401          {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404          {object}  dto.ErrorResponse "The number is not suppressed"
+// @Failure      429          {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500          {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /suppressions/{phoneNumber} [get]
+func (h *SuppressionHandler) CheckSuppression(w http.ResponseWriter, r *http.Request) {
+	phoneNumber, err := phoneNumberParam(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid phone number")
+		return
+	}
+
+	result, err := h.suppressionManagement.CheckSuppression(r.Context(), phoneNumber)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toSuppressionResponse(result))
+}
+
+// RemoveSuppression handles DELETE /api/v1/suppressions/{phoneNumber}
+// @Summary      Remove a phone number from the suppression list
+// @Description  Allow messages to a phone number again, e.g. after it opted back in. Messages already SUPPRESSED stay suppressed.
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  path  string  true  "Phone number"
+// @Success      204          "Removed"
+// @Failure      400          {object}  dto.ErrorResponse "Bad request - invalid phone number"
+// @Failure      401          {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404          {object}  dto.ErrorResponse "The number is not suppressed"
+// @Failure      429          {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500          {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /suppressions/{phoneNumber} [delete]
+func (h *SuppressionHandler) RemoveSuppression(w http.ResponseWriter, r *http.Request) {
+	phoneNumber, err := phoneNumberParam(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid phone number")
+		return
+	}
+
+	if err := h.suppressionManagement.RemoveSuppression(r.Context(), phoneNumber); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// phoneNumberParam returns the phone number path parameter, decoding an escaped leading +
+func phoneNumberParam(r *http.Request) (string, error) {
+	return url.PathUnescape(chi.URLParam(r, "phoneNumber"))
+}
+
+// suppressionImportCommand reads the entries of a bulk suppression import from a JSON or CSV body
+func suppressionImportCommand(r *http.Request) (usecases.ImportSuppressionsCommand, error) {
+	cmd := usecases.ImportSuppressionsCommand{Reason: r.URL.Query().Get("reason")}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "" || mediaType == "application/json" {
+		var req dto.ImportSuppressionsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return cmd, err
+			}
+			return cmd, errors.New("invalid request body")
+		}
+
+		if req.Reason != "" {
+			cmd.Reason = req.Reason
+		}
+		cmd.Suppressions = make([]usecases.AddSuppressionCommand, len(req.Suppressions))
+		for i, entry := range req.Suppressions {
+			cmd.Suppressions[i] = usecases.AddSuppressionCommand{PhoneNumber: entry.PhoneNumber, Reason: entry.Reason}
+		}
+		return cmd, nil
+	}
+
+	source, format, err := importSource(r)
+	if err != nil {
+		return cmd, err
+	}
+	if format != "csv" {
+		return cmd, errors.New("unsupported suppression import format: use application/json or text/csv")
+	}
+
+	cmd.Suppressions, err = readSuppressionCSV(source)
+	return cmd, err
+}
+
+// readSuppressionCSV reads suppression entries from a CSV file with a phoneNumber and an optional reason column
+func readSuppressionCSV(source io.Reader) ([]usecases.AddSuppressionCommand, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("import file is empty")
+	}
+	if err != nil {
+		return nil, readSuppressionCSVError(err)
+	}
+
+	phoneColumn, reasonColumn := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(name))) {
+		case "phone", "phonenumber":
+			phoneColumn = i
+		case "reason":
+			reasonColumn = i
+		}
+	}
+	if phoneColumn < 0 {
+		return nil, errors.New("import file must have a phoneNumber column")
+	}
+
+	var entries []usecases.AddSuppressionCommand
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, readSuppressionCSVError(err)
+		}
+
+		var entry usecases.AddSuppressionCommand
+		if phoneColumn < len(record) {
+			entry.PhoneNumber = strings.TrimSpace(record[phoneColumn])
+		}
+		if reasonColumn >= 0 && reasonColumn < len(record) {
+			entry.Reason = strings.TrimSpace(record[reasonColumn])
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// readSuppressionCSVError keeps body size errors recognizable and describes anything else as malformed CSV
+func readSuppressionCSVError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return err
+	}
+	return errors.New("invalid CSV: " + err.Error())
+}
+
+// toSuppressionResponse converts a use case suppression to its API representation
+func toSuppressionResponse(suppression *usecases.SuppressionResponse) dto.SuppressionResponse {
+	return dto.SuppressionResponse{
+		PhoneNumber: suppression.PhoneNumber,
+		Reason:      suppression.Reason,
+		Source:      suppression.Source,
+		CreatedAt:   suppression.CreatedAt,
+	}
+}
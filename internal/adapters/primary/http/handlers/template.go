@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// TemplateHandler handles HTTP requests for message template operations
+type TemplateHandler struct {
+	templateManagement usecases.TemplateManagementUseCase
+}
+
+// NewTemplateHandler creates a new template handler
+func NewTemplateHandler(templateManagement usecases.TemplateManagementUseCase) *TemplateHandler {
+	return &TemplateHandler{
+		templateManagement: templateManagement,
+	}
+}
+
+// CreateTemplate handles POST /api/v1/templates
+// @Summary      Create a message template
+// @Description  Create a named message body with {{variable}} placeholders. Messages created with its templateId get the body rendered with their variables.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        template  body      dto.CreateTemplateRequest  true  "Template data"
+// @Success      201       {object}  dto.TemplateResponse
+// @Failure      400       {object}  dto.ErrorResponse "Bad request - invalid name or body"
+// @Failure      401       {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      409       {object}  dto.ErrorResponse "A template with this name already exists"
+// @Failure      429       {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500       {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /templates [post]
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.templateManagement.CreateTemplate(r.Context(), usecases.CreateTemplateCommand{
+		Name: req.Name,
+		Body: req.Body,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, toTemplateResponse(result))
+}
+
+// ListTemplates handles GET /api/v1/templates
+// @Summary      List message templates
+// @Description  Retrieve templates ordered by name
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "Number of templates to return (1-100)"  minimum(1)  maximum(100)  default(20)
+// @Param        offset  query     int  false  "Number of templates to skip"  minimum(0)  default(0)
+// @Success      200     {array}   dto.TemplateResponse
+// @Header       200     {string}  X-Total-Count  "Total number of templates"
+// @Failure      401     {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      429     {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500     {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /templates [get]
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	query := usecases.ListTemplatesQuery{
+		Limit:  20, // Default limit
+		Offset: 0,  // Default offset
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			query.Limit = limit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	result, err := h.templateManagement.ListTemplates(r.Context(), query)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	responses := make([]dto.TemplateResponse, len(result.Templates))
+	for i, template := range result.Templates {
+		responses[i] = toTemplateResponse(&template)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	writeJSONResponse(w, http.StatusOK, responses)
+}
+
+// GetTemplate handles GET /api/v1/templates/{id}
+// @Summary      Get a message template
+// @Description  Retrieve the current version of a template
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Template ID (UUID)"
+// @Success      200  {object}  dto.TemplateResponse
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid template ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Template not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /templates/{id} [get]
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	result, err := h.templateManagement.GetTemplate(r.Context(), id)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toTemplateResponse(result))
+}
+
+// UpdateTemplate handles PUT /api/v1/templates/{id}
+// @Summary      Update a message template
+// @Description  Replace the body of a template. A changed body becomes a new version; messages already created keep the version they were rendered from. The name cannot be changed.
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                     true  "Template ID (UUID)"
+// @Param        template  body      dto.UpdateTemplateRequest  true  "New template body"
+// @Success      200       {object}  dto.TemplateResponse
+// @Failure      400       {object}  dto.ErrorResponse "Bad request - invalid template ID or body"
+// @Failure      401       {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404       {object}  dto.ErrorResponse "Template not found"
+// @Failure      409       {object}  dto.ErrorResponse "Template was updated concurrently"
+// @Failure      429       {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500       {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	var req dto.UpdateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.templateManagement.UpdateTemplate(r.Context(), usecases.UpdateTemplateCommand{
+		ID:   id,
+		Body: req.Body,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toTemplateResponse(result))
+}
+
+// DeleteTemplate handles DELETE /api/v1/templates/{id}
+// @Summary      Delete a message template
+// @Description  Delete a template. Messages already rendered from it are kept and still reference it.
+// @Tags         templates
+// @Param        id   path      string  true  "Template ID (UUID)"
+// @Success      204  "Template deleted"
+// @Failure      400  {object}  dto.ErrorResponse "Bad request - invalid template ID"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Template not found"
+// @Failure      429  {object}  dto.ErrorResponse "Rate limit exceeded"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	if err := h.templateManagement.DeleteTemplate(r.Context(), id); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toTemplateResponse converts a use case template response to its API representation
+func toTemplateResponse(template *usecases.TemplateResponse) dto.TemplateResponse {
+	return dto.TemplateResponse{
+		ID:        template.ID.String(),
+		Name:      template.Name,
+		Body:      template.Body,
+		Version:   template.Version,
+		Variables: template.Variables,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
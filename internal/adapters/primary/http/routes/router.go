@@ -15,12 +15,23 @@ import (
 // RouterConfig contains configuration for the router
 type RouterConfig struct {
 	APIKey string
+
+	// Provider callbacks are signed with their own secret and limited separately from client requests
+	CallbackSecret             string
+	CallbackSignatureTolerance time.Duration
+	CallbackRequestsPerMinute  int
 }
 
 // SetupRouter creates and configures the Chi router
 func SetupRouter(
 	config RouterConfig,
 	messageHandler *handlers.MessageHandler,
+	messageStreamHandler *handlers.MessageStreamHandler,
+	templateHandler *handlers.TemplateHandler,
+	suppressionHandler *handlers.SuppressionHandler,
+	inboundHandler *handlers.InboundHandler,
+	receiptHandler *handlers.DeliveryReceiptHandler,
+	subscriptionHandler *handlers.SubscriptionHandler,
 	healthHandler *handlers.HealthHandler,
 	schedulerHandler *handlers.SchedulerHandler,
 ) *chi.Mux {
@@ -30,23 +41,25 @@ func SetupRouter(
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 
-	// Global rate limiting (60 requests per minute per IP)
-	r.Use(httpMiddleware.RateLimitMiddleware(httpMiddleware.RateLimitConfig{
+	// Client rate limiting (60 requests per minute per IP), shared by every route but the provider callbacks
+	clientRateLimit := httpMiddleware.RateLimitMiddleware(httpMiddleware.RateLimitConfig{
 		Enabled:           true,
 		RequestsPerMinute: 60,
 		CleanupInterval:   5 * time.Minute,
-	}))
+	})
 
 	r.Use(httpMiddleware.RequestLogging()) // Structured logging with correlation IDs
 	r.Use(httpMiddleware.PanicRecovery())  // Panic recovery with structured logging
-	r.Use(middleware.Timeout(60 * time.Second))
+
+	// Message streams stay open until the client leaves
+	r.Use(timeoutExcept(60*time.Second, "/api/v1/messages/stream"))
 
 	// CORS middleware (simple)
 	r.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID")
+			w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID, Idempotency-Key")
 
 			if r.Method == "OPTIONS" {
 				return
@@ -56,41 +69,122 @@ func SetupRouter(
 		})
 	})
 
-	// Health endpoint (no auth required)
-	r.Get("/health", healthHandler.Health)
+	r.Group(func(r chi.Router) {
+		r.Use(clientRateLimit)
 
-	// Swagger documentation (development only)
-	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
-	))
+		// Health endpoint (no auth required)
+		r.Get("/health", healthHandler.Health)
 
-	// API routes with authentication
-	r.Route("/api/v1", func(r chi.Router) {
-		// Apply auth middleware
-		r.Use(httpMiddleware.ClientCredentialsAuth(httpMiddleware.AuthConfig{
-			APIKey: config.APIKey,
-		}))
-
-		// Message routes
-		r.Route("/messages", func(r chi.Router) {
-			r.Post("/", messageHandler.CreateMessage)
-			r.Get("/", messageHandler.ListMessages)
-			r.Get("/{id}", messageHandler.GetMessage)
-		})
+		// Swagger documentation (development only)
+		r.Get("/swagger/*", httpSwagger.Handler(
+			httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
+		))
+	})
 
-		// Messaging status routes
-		r.Route("/messaging", func(r chi.Router) {
-			r.Get("/status", messageHandler.GetProcessingStatus)
-			r.Post("/process", messageHandler.ProcessMessages)
+	r.Route("/api/v1", func(r chi.Router) {
+		// Provider callbacks, authenticated by their signature
+		r.Group(func(r chi.Router) {
+			r.Use(httpMiddleware.RateLimitMiddleware(httpMiddleware.RateLimitConfig{
+				Enabled:           config.CallbackRequestsPerMinute > 0,
+				RequestsPerMinute: config.CallbackRequestsPerMinute,
+				CleanupInterval:   5 * time.Minute,
+			}))
+			r.Use(httpMiddleware.ProviderSignatureAuth(httpMiddleware.ProviderAuthConfig{
+				Secret:    config.CallbackSecret,
+				Tolerance: config.CallbackSignatureTolerance,
+			}))
+
+			r.Post("/inbound", inboundHandler.ReceiveInboundMessage)
+			r.Post("/delivery-receipts", receiptHandler.ReceiveDeliveryReceipt)
 		})
 
-		// Scheduler management routes
-		r.Route("/scheduler", func(r chi.Router) {
-			r.Get("/status", schedulerHandler.GetSchedulerStatus)
-			r.Post("/start", schedulerHandler.StartScheduler)
-			r.Post("/stop", schedulerHandler.StopScheduler)
+		// Client routes, authenticated by the API key
+		r.Group(func(r chi.Router) {
+			r.Use(clientRateLimit)
+			r.Use(httpMiddleware.ClientCredentialsAuth(httpMiddleware.AuthConfig{
+				APIKey: config.APIKey,
+			}))
+
+			// Message routes
+			r.Route("/messages", func(r chi.Router) {
+				r.Post("/", messageHandler.CreateMessage)
+				r.Post("/batch", messageHandler.CreateMessagesBatch)
+				r.Post("/imports", messageHandler.ImportMessages)
+				r.Get("/imports/{id}", messageHandler.GetImportJob)
+				r.Get("/imports/{id}/errors", messageHandler.DownloadImportErrors)
+				r.Get("/", messageHandler.ListMessages)
+				r.Get("/dead-letter", messageHandler.ListDeadLetters)
+				r.Get("/stream", messageStreamHandler.StreamMessages)
+				r.Post("/requeue", messageHandler.RequeueMessages)
+				r.Get("/{id}", messageHandler.GetMessage)
+				r.Get("/{id}/attempts", messageHandler.ListMessageAttempts)
+				r.Post("/{id}/cancel", messageHandler.CancelMessage)
+				r.Post("/{id}/requeue", messageHandler.RequeueMessage)
+			})
+
+			// Template routes
+			r.Route("/templates", func(r chi.Router) {
+				r.Post("/", templateHandler.CreateTemplate)
+				r.Get("/", templateHandler.ListTemplates)
+				r.Get("/{id}", templateHandler.GetTemplate)
+				r.Put("/{id}", templateHandler.UpdateTemplate)
+				r.Delete("/{id}", templateHandler.DeleteTemplate)
+			})
+
+			// Suppression list routes
+			r.Route("/suppressions", func(r chi.Router) {
+				r.Post("/", suppressionHandler.AddSuppression)
+				r.Post("/imports", suppressionHandler.ImportSuppressions)
+				r.Get("/", suppressionHandler.ListSuppressions)
+				r.Get("/{phoneNumber}", suppressionHandler.CheckSuppression)
+				r.Delete("/{phoneNumber}", suppressionHandler.RemoveSuppression)
+			})
+
+			// Conversation routes
+			r.Get("/conversations/{phoneNumber}", inboundHandler.GetConversation)
+
+			// Status-change subscription routes
+			r.Route("/subscriptions", func(r chi.Router) {
+				r.Post("/", subscriptionHandler.CreateSubscription)
+				r.Get("/", subscriptionHandler.ListSubscriptions)
+				r.Get("/{id}", subscriptionHandler.GetSubscription)
+				r.Put("/{id}", subscriptionHandler.UpdateSubscription)
+				r.Delete("/{id}", subscriptionHandler.DeleteSubscription)
+				r.Get("/{id}/deliveries", subscriptionHandler.ListDeliveries)
+				r.Post("/{id}/replay", subscriptionHandler.ReplayEvents)
+			})
+
+			// Messaging status routes
+			r.Route("/messaging", func(r chi.Router) {
+				r.Get("/status", messageHandler.GetProcessingStatus)
+				r.Post("/process", messageHandler.ProcessMessages)
+			})
+
+			// Scheduler management routes
+			r.Route("/scheduler", func(r chi.Router) {
+				r.Get("/status", schedulerHandler.GetSchedulerStatus)
+				r.Post("/start", schedulerHandler.StartScheduler)
+				r.Post("/stop", schedulerHandler.StopScheduler)
+			})
 		})
 	})
 
 	return r
 }
+
+// timeoutExcept applies a request timeout to every path but the given long-lived ones
+func timeoutExcept(timeout time.Duration, paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withTimeout := middleware.Timeout(timeout)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range paths {
+				if r.URL.Path == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			withTimeout.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// receiptColumns lists the delivery_receipts table columns in scan order
+var receiptColumns = []string{
+	"external_id", "status", "error_code", "reported_at", "received_at", "message_id", "applied_at",
+}
+
+// DeliveryReceiptRepository implements the DeliveryReceiptRepository interface using PostgreSQL
+type DeliveryReceiptRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewDeliveryReceiptRepository creates a new PostgreSQL delivery receipt repository
+func NewDeliveryReceiptRepository(pool *pgxpool.Pool) repositories.DeliveryReceiptRepository {
+	return &DeliveryReceiptRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create stores a delivery receipt unless one with the same external ID is already stored
+func (r *DeliveryReceiptRepository) Create(ctx context.Context, receipt *message.DeliveryReceipt) (bool, error) {
+	query, args, err := r.qb.
+		Insert("delivery_receipts").
+		Columns(receiptColumns...).
+		Values(
+			receipt.ExternalID,
+			receipt.Status.String(),
+			receipt.ErrorCode,
+			receipt.ReportedAt,
+			receipt.ReceivedAt,
+			messageIDValue(receipt.MessageID),
+			receipt.AppliedAt,
+		).
+		Suffix("ON CONFLICT (external_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetByExternalID retrieves the delivery receipt of an external ID
+func (r *DeliveryReceiptRepository) GetByExternalID(ctx context.Context, externalID string) (*message.DeliveryReceipt, error) {
+	query, args, err := r.qb.
+		Select(receiptColumns...).
+		From("delivery_receipts").
+		Where(squirrel.Eq{"external_id": externalID}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	receipt, err := scanDeliveryReceipt(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "delivery receipt")
+	}
+
+	return receipt, nil
+}
+
+// MarkApplied records the message a receipt was applied to
+func (r *DeliveryReceiptRepository) MarkApplied(ctx context.Context, receipt *message.DeliveryReceipt) error {
+	query, args, err := r.qb.
+		Update("delivery_receipts").
+		Set("message_id", messageIDValue(receipt.MessageID)).
+		Set("applied_at", receipt.AppliedAt).
+		Where(squirrel.Eq{"external_id": receipt.ExternalID}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("delivery receipt not found for update")
+	}
+
+	return nil
+}
+
+// scanDeliveryReceipt scans a single row into a delivery receipt
+func scanDeliveryReceipt(row pgx.Row) (*message.DeliveryReceipt, error) {
+	var (
+		receipt   message.DeliveryReceipt
+		status    string
+		messageID *string
+	)
+
+	if err := row.Scan(&receipt.ExternalID, &status, &receipt.ErrorCode, &receipt.ReportedAt, &receipt.ReceivedAt, &messageID, &receipt.AppliedAt); err != nil {
+		return nil, err
+	}
+
+	receipt.Status = message.Status(status)
+	if messageID != nil {
+		id := message.MessageID(*messageID)
+		receipt.MessageID = &id
+	}
+	return &receipt, nil
+}
+
+// messageIDValue converts an optional message ID into a nullable column value
+func messageIDValue(id *message.MessageID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+	return &value
+}
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// eventDeliveryColumns lists the subscription_deliveries columns and the status change
+// and phone number they deliver, in scan order. Queries alias the tables d, h and m.
+var eventDeliveryColumns = []string{
+	"d.id", "d.subscription_id", "d.status", "d.replay", "d.attempts", "d.next_attempt_at",
+	"d.last_attempt_at", "d.last_status_code", "d.last_error", "d.delivered_at", "d.created_at",
+	"h.id", "h.message_id", "h.from_status", "h.to_status", "h.triggered_by", "h.occurred_at", "m.phone_number",
+}
+
+// eventDeliveryJoins joins a delivery to the status change and message it delivers
+const eventDeliveryJoins = `
+	JOIN message_status_history h ON h.id = d.event_id
+	JOIN messages m ON m.id = h.message_id`
+
+// EventDeliveryRepository implements the EventDeliveryRepository interface using PostgreSQL
+type EventDeliveryRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewEventDeliveryRepository creates a new PostgreSQL event delivery repository
+func NewEventDeliveryRepository(pool *pgxpool.Pool) repositories.EventDeliveryRepository {
+	return &EventDeliveryRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// ClaimDue atomically leases pending deliveries that are due, to active subscriptions only
+func (r *EventDeliveryRepository) ClaimDue(ctx context.Context, workerID string, limit int, leaseDuration time.Duration) ([]*message.EventDelivery, error) {
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if workerID == "" {
+		return nil, errors.NewValidationError("worker ID cannot be empty")
+	}
+
+	// Lease in a single statement, like claiming messages, so concurrent
+	// dispatchers skip each other's rows and never send the same delivery
+	query := `
+		WITH d AS (
+			UPDATE subscription_deliveries
+			SET locked_by = $1, lease_until = NOW() + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT sd.id FROM subscription_deliveries sd
+				JOIN subscriptions s ON s.id = sd.subscription_id
+				WHERE sd.status = $3 AND sd.next_attempt_at <= NOW() AND s.active
+					AND (sd.lease_until IS NULL OR sd.lease_until <= NOW())
+				ORDER BY sd.next_attempt_at ASC, sd.id ASC
+				LIMIT $4
+				FOR UPDATE OF sd SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + strings.Join(eventDeliveryColumns, ", ") + ` FROM d` + eventDeliveryJoins + `
+		ORDER BY d.next_attempt_at ASC, d.id ASC`
+
+	rows, err := r.pool.Query(ctx, query, workerID, leaseDuration.Seconds(), message.EventDeliveryPending.String(), limit)
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to claim event deliveries: %v", err)
+	}
+	defer rows.Close()
+
+	return scanEventDeliveries(rows)
+}
+
+// UpdateClaimed stores the outcome of an attempt and releases the lease, only while the worker still holds it
+func (r *EventDeliveryRepository) UpdateClaimed(ctx context.Context, delivery *message.EventDelivery, workerID string) error {
+	query, args, err := r.qb.
+		Update("subscription_deliveries").
+		Set("status", delivery.Status.String()).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_attempt_at", delivery.LastAttemptAt).
+		Set("last_status_code", delivery.LastStatusCode).
+		Set("last_error", delivery.LastError).
+		Set("delivered_at", delivery.DeliveredAt).
+		Set("locked_by", nil).
+		Set("lease_until", nil).
+		Where(squirrel.Eq{"id": delivery.ID, "locked_by": workerID}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewConflictError("event delivery %d is no longer leased to worker %s", delivery.ID, workerID)
+	}
+
+	return nil
+}
+
+// ListBySubscription retrieves the deliveries of a subscription matching the filter, most recent first
+func (r *EventDeliveryRepository) ListBySubscription(ctx context.Context, id message.SubscriptionID, filter repositories.EventDeliveryFilter, pagination repositories.Pagination) ([]*message.EventDelivery, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(eventDeliveryColumns...).
+		From("subscription_deliveries d" + eventDeliveryJoins).
+		Where(eventDeliveryConditions(id, filter)).
+		OrderBy("d.id DESC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build deliveries query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEventDeliveries(rows)
+}
+
+// CountBySubscription counts the deliveries of a subscription matching the filter
+func (r *EventDeliveryRepository) CountBySubscription(ctx context.Context, id message.SubscriptionID, filter repositories.EventDeliveryFilter) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("subscription_deliveries d").
+		Where(eventDeliveryConditions(id, filter)).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build deliveries count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountEvents counts the status changes in [from, to) the subscription matches
+func (r *EventDeliveryRepository) CountEvents(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("message_status_history h").
+		Where(replayConditions(subscription, from, to)).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build events count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Replay queues a new delivery of every status change in [from, to) the subscription matches
+func (r *EventDeliveryRepository) Replay(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error) {
+	// The outer insert numbers the placeholders of the select
+	events := squirrel.
+		Select().
+		Column(squirrel.Expr("?::uuid", subscription.ID.String())).
+		Columns("h.id", "TRUE").
+		From("message_status_history h").
+		Where(replayConditions(subscription, from, to)).
+		OrderBy("h.occurred_at ASC", "h.id ASC")
+
+	query, args, err := r.qb.
+		Insert("subscription_deliveries").
+		Columns("subscription_id", "event_id", "replay").
+		Select(events).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build replay query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// eventDeliveryConditions builds the WHERE clause for the deliveries of a subscription
+func eventDeliveryConditions(id message.SubscriptionID, filter repositories.EventDeliveryFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"d.subscription_id": id.String()}}
+	if filter.Status != nil {
+		conditions = append(conditions, squirrel.Eq{"d.status": filter.Status.String()})
+	}
+	return conditions
+}
+
+// replayConditions builds the WHERE clause for the status changes a replay delivers
+func replayConditions(subscription *message.Subscription, from, to time.Time) squirrel.And {
+	conditions := squirrel.And{
+		squirrel.GtOrEq{"h.occurred_at": from},
+		squirrel.Lt{"h.occurred_at": to},
+	}
+	if len(subscription.Statuses) > 0 {
+		conditions = append(conditions, squirrel.Eq{"h.to_status": statusNames(subscription.Statuses)})
+	}
+	return conditions
+}
+
+// scanEventDeliveries scans all rows into event deliveries
+func scanEventDeliveries(rows pgx.Rows) ([]*message.EventDelivery, error) {
+	var deliveries []*message.EventDelivery
+	for rows.Next() {
+		var (
+			delivery       message.EventDelivery
+			subscriptionID string
+			status         string
+			messageID      string
+			fromStatus     *string
+			toStatus       string
+			trigger        string
+			phoneNumber    string
+		)
+
+		if err := rows.Scan(
+			&delivery.ID, &subscriptionID, &status, &delivery.Replay, &delivery.Attempts, &delivery.NextAttemptAt,
+			&delivery.LastAttemptAt, &delivery.LastStatusCode, &delivery.LastError, &delivery.DeliveredAt, &delivery.CreatedAt,
+			&delivery.Event.ID, &messageID, &fromStatus, &toStatus, &trigger, &delivery.Event.OccurredAt, &phoneNumber,
+		); err != nil {
+			return nil, err
+		}
+
+		delivery.SubscriptionID = message.SubscriptionID(subscriptionID)
+		delivery.Status = message.EventDeliveryStatus(status)
+		delivery.Event.MessageID = message.MessageID(messageID)
+		delivery.Event.To = message.Status(toStatus)
+		delivery.Event.Trigger = message.Trigger(trigger)
+		delivery.Event.PhoneNumber = message.PhoneNumber(phoneNumber)
+		if fromStatus != nil {
+			from := message.Status(*fromStatus)
+			delivery.Event.From = &from
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// expiredKeysPurgeBatch bounds how many expired keys are removed on each reservation
+const expiredKeysPurgeBatch = 100
+
+// IdempotencyRepository implements the IdempotencyRepository interface using PostgreSQL
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewIdempotencyRepository creates a new PostgreSQL idempotency repository
+func NewIdempotencyRepository(pool *pgxpool.Pool) repositories.IdempotencyRepository {
+	return &IdempotencyRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Reserve stores a new key, returning false if an unexpired key already exists
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *repositories.IdempotencyRecord) (bool, error) {
+	// Purge a bounded batch of expired keys (including this one) so the table stays within its retention window
+	purgeQuery := `
+		DELETE FROM idempotency_keys
+		WHERE key IN (
+			SELECT key FROM idempotency_keys
+			WHERE expires_at <= NOW()
+			LIMIT $1
+		) OR (key = $2 AND expires_at <= NOW())`
+
+	if _, err := r.pool.Exec(ctx, purgeQuery, expiredKeysPurgeBatch, record.Key); err != nil {
+		return false, errors.NewRepositoryError("failed to purge expired idempotency keys: %v", err)
+	}
+
+	query, args, err := r.qb.
+		Insert("idempotency_keys").
+		Columns("key", "fingerprint", "reserved_message_id", "created_at", "expires_at").
+		Values(record.Key, record.Fingerprint, messageIDValue(record.ReservedMessageID), record.CreatedAt, record.ExpiresAt).
+		Suffix("ON CONFLICT (key) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// GetByKey retrieves an unexpired idempotency record by its key
+func (r *IdempotencyRepository) GetByKey(ctx context.Context, key string) (*repositories.IdempotencyRecord, error) {
+	query, args, err := r.qb.
+		Select("key", "fingerprint", "message_id", "reserved_message_id", "response", "created_at", "expires_at").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"key": key}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	var (
+		record            repositories.IdempotencyRecord
+		messageID         *string
+		reservedMessageID *string
+		createdAt         time.Time
+		expiresAt         time.Time
+	)
+
+	err = r.pool.QueryRow(ctx, query, args...).Scan(
+		&record.Key, &record.Fingerprint, &messageID, &reservedMessageID, &record.Response, &createdAt, &expiresAt,
+	)
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "idempotency key")
+	}
+
+	if messageID != nil {
+		id := message.MessageID(*messageID)
+		record.MessageID = &id
+	}
+	if reservedMessageID != nil {
+		id := message.MessageID(*reservedMessageID)
+		record.ReservedMessageID = &id
+	}
+	record.CreatedAt = createdAt
+	record.ExpiresAt = expiresAt
+
+	return &record, nil
+}
+
+// Complete stores the created message and its response for a reserved key
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, messageID message.MessageID, response []byte) error {
+	query, args, err := r.qb.
+		Update("idempotency_keys").
+		Set("message_id", messageID.String()).
+		Set("response", response).
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("idempotency key not found for update")
+	}
+
+	return nil
+}
+
+// Release removes a reserved key whose request did not complete
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	query, args, err := r.qb.
+		Delete("idempotency_keys").
+		Where(squirrel.Eq{"key": key}).
+		Where(squirrel.Eq{"message_id": nil}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build delete query: %v", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// inboundColumns lists the inbound_messages table columns in scan order
+var inboundColumns = []string{
+	"id", "phone_number", "content", "external_id", "keyword_action", "received_at", "created_at",
+}
+
+// InboundMessageRepository implements the InboundMessageRepository interface using PostgreSQL
+type InboundMessageRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewInboundMessageRepository creates a new PostgreSQL inbound message repository
+func NewInboundMessageRepository(pool *pgxpool.Pool) repositories.InboundMessageRepository {
+	return &InboundMessageRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create stores an inbound message unless one with the same external ID is already stored
+func (r *InboundMessageRepository) Create(ctx context.Context, msg *message.InboundMessage) (bool, error) {
+	query, args, err := r.qb.
+		Insert("inbound_messages").
+		Columns(inboundColumns...).
+		Values(
+			msg.ID.String(),
+			msg.PhoneNumber.String(),
+			msg.Content,
+			msg.ExternalID,
+			msg.Action.String(),
+			msg.ReceivedAt,
+			msg.CreatedAt,
+		).
+		Suffix("ON CONFLICT (external_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetByExternalID retrieves an inbound message by its provider message ID
+func (r *InboundMessageRepository) GetByExternalID(ctx context.Context, externalID string) (*message.InboundMessage, error) {
+	query, args, err := r.qb.
+		Select(inboundColumns...).
+		From("inbound_messages").
+		Where(squirrel.Eq{"external_id": externalID}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	msg, err := scanInboundMessage(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "inbound message")
+	}
+
+	return msg, nil
+}
+
+// ListByPhoneNumber retrieves the inbound messages of a phone number, most recently received first
+func (r *InboundMessageRepository) ListByPhoneNumber(ctx context.Context, phoneNumber message.PhoneNumber, pagination repositories.Pagination) ([]*message.InboundMessage, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(inboundColumns...).
+		From("inbound_messages").
+		Where(squirrel.Eq{"phone_number": phoneNumber.String()}).
+		OrderBy("received_at DESC", "id ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build phone number query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*message.InboundMessage
+	for rows.Next() {
+		msg, err := scanInboundMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// scanInboundMessage scans a single row into an inbound message
+func scanInboundMessage(row pgx.Row) (*message.InboundMessage, error) {
+	var (
+		msg         message.InboundMessage
+		id          string
+		phoneNumber string
+		action      string
+	)
+
+	if err := row.Scan(&id, &phoneNumber, &msg.Content, &msg.ExternalID, &action, &msg.ReceivedAt, &msg.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	msg.ID = message.InboundMessageID(id)
+	msg.PhoneNumber = message.PhoneNumber(phoneNumber)
+	msg.Action = message.KeywordAction(action)
+	return &msg, nil
+}
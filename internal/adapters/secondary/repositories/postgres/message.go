@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -13,6 +15,38 @@ import (
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
 )
 
+// dueForAttemptSQL matches messages whose send time has come, that have not
+// expired and that are not waiting out a retry backoff
+const dueForAttemptSQL = `(send_at IS NULL OR send_at <= NOW())
+			  AND (expires_at IS NULL OR expires_at > NOW())
+			  AND (next_attempt_at IS NULL OR next_attempt_at <= NOW())`
+
+// dueForAttempt is dueForAttemptSQL as a query builder condition
+var dueForAttempt = squirrel.Expr(dueForAttemptSQL)
+
+// messageColumns lists the messages table columns in scan order
+var messageColumns = []string{
+	"id", "phone_number", "content", "status",
+	"external_id", "retry_count", "send_at", "expires_at", "next_attempt_at",
+	"locked_by", "lease_until",
+	"attempt_count", "last_error", "last_error_class", "last_http_status",
+	"created_at", "updated_at", "sent_at",
+	"template_id", "template_version",
+	"carrier_error_code", "delivery_reported_at",
+	"priority", "provider",
+}
+
+// messageInsertColumns adds the columns derived from the phone number, written for querying only
+var messageInsertColumns = append(slices.Clone(messageColumns), "phone_country_code", "phone_region")
+
+// statusHistoryColumns lists the message_status_history columns written and read by the repository
+var statusHistoryColumns = []string{
+	"message_id", "from_status", "to_status", "triggered_by", "occurred_at",
+}
+
+// maxRowsPerInsert bounds multi-row inserts well below PostgreSQL's limit of 65535 bind parameters
+const maxRowsPerInsert = 1000
+
 // MessageRepository implements the MessageRepository interface using PostgreSQL
 type MessageRepository struct {
 	pool *pgxpool.Pool
@@ -31,42 +65,104 @@ func NewMessageRepository(pool *pgxpool.Pool) repositories.MessageRepository {
 func (r *MessageRepository) Create(ctx context.Context, msg *message.Message) error {
 	query, args, err := r.qb.
 		Insert("messages").
-		Columns(
-			"id", "phone_number", "content", "status",
-			"external_id", "retry_count", "created_at", "updated_at", "sent_at",
-		).
-		Values(
-			msg.ID.String(),
-			msg.PhoneNumber.String(),
-			msg.Content.String(),
-			msg.Status.String(),
-			msg.ExternalID,
-			msg.RetryCount,
-			msg.CreatedAt,
-			msg.UpdatedAt,
-			msg.SentAt,
-		).
+		Columns(messageInsertColumns...).
+		Values(messageValues(msg)...).
 		ToSql()
 
 	if err != nil {
 		return errors.NewRepositoryError("failed to build insert query: %v", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	return r.saveWithStatusChanges(ctx, msg, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, query, args...)
+		return err
+	})
+}
+
+// CreateBatch creates all messages with multi-row inserts in a single transaction
+func (r *MessageRepository) CreateBatch(ctx context.Context, msgs []*message.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		return r.insertBatch(ctx, tx, msgs)
+	})
 	if err != nil {
 		return err
 	}
 
+	for _, msg := range msgs {
+		msg.ClearStatusChanges()
+	}
 	return nil
 }
 
+// insertBatch stores messages with multi-row inserts and their pending status changes within tx.
+// The caller clears the status changes once tx is committed.
+func (r *MessageRepository) insertBatch(ctx context.Context, tx pgx.Tx, msgs []*message.Message) error {
+	var changes []message.StatusChange
+	for _, msg := range msgs {
+		changes = append(changes, msg.PendingStatusChanges()...)
+	}
+
+	for start := 0; start < len(msgs); start += maxRowsPerInsert {
+		end := min(start+maxRowsPerInsert, len(msgs))
+
+		insert := r.qb.Insert("messages").Columns(messageInsertColumns...)
+		for _, msg := range msgs[start:end] {
+			insert = insert.Values(messageValues(msg)...)
+		}
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return errors.NewRepositoryError("failed to build batch insert query: %v", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return r.insertStatusChanges(ctx, tx, changes)
+}
+
+// messageValues returns the column values of a message in messageInsertColumns order
+func messageValues(msg *message.Message) []interface{} {
+	return []interface{}{
+		msg.ID.String(),
+		msg.PhoneNumber.String(),
+		msg.Content.String(),
+		msg.Status.String(),
+		msg.ExternalID,
+		msg.RetryCount,
+		msg.SendAt,
+		msg.ExpiresAt,
+		msg.NextAttemptAt,
+		msg.LockedBy,
+		msg.LeaseUntil,
+		msg.AttemptCount,
+		msg.LastError,
+		errorClassValue(msg.LastErrorClass),
+		msg.LastHTTPStatus,
+		msg.CreatedAt,
+		msg.UpdatedAt,
+		msg.SentAt,
+		templateIDValue(msg.TemplateID),
+		msg.TemplateVersion,
+		msg.CarrierErrorCode,
+		msg.DeliveryReportedAt,
+		msg.Priority.Rank(),
+		msg.Provider,
+		msg.PhoneNumber.CountryCode(),
+		phoneRegionValue(msg.PhoneNumber),
+	}
+}
+
 // GetByID retrieves a message by its ID
 func (r *MessageRepository) GetByID(ctx context.Context, id message.MessageID) (*message.Message, error) {
 	query, args, err := r.qb.
-		Select(
-			"id", "phone_number", "content", "status",
-			"external_id", "retry_count", "created_at", "updated_at", "sent_at",
-		).
+		Select(messageColumns...).
 		From("messages").
 		Where(squirrel.Eq{"id": id.String()}).
 		ToSql()
@@ -84,26 +180,49 @@ func (r *MessageRepository) GetByID(ctx context.Context, id message.MessageID) (
 	return msg, nil
 }
 
-// GetPendingMessages retrieves pending messages with limit
+// GetByExternalID retrieves a message by the ID the provider returned when accepting it
+func (r *MessageRepository) GetByExternalID(ctx context.Context, externalID string) (*message.Message, error) {
+	query, args, err := r.qb.
+		Select(messageColumns...).
+		From("messages").
+		Where(squirrel.Eq{"external_id": externalID}).
+		OrderBy("sent_at DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	msg, err := r.scanMessage(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "message")
+	}
+
+	return msg, nil
+}
+
+// GetPendingMessages retrieves pending messages that are due for sending with limit
 func (r *MessageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*message.Message, error) {
 	if limit <= 0 {
 		return nil, errors.NewValidationError("limit must be greater than zero")
 	}
 
-	// Use raw SQL for FOR UPDATE SKIP LOCKED to prevent race conditions in distributed environment
-	// This ensures each instance gets different messages atomically
-	// Include external_id and sent_at as NULL for pending messages to match scanMessages expectations
-	query := `
-		SELECT id, phone_number, content, status, 
-		       NULL as external_id, retry_count, 
-		       created_at, updated_at, NULL as sent_at
-		FROM messages 
-		WHERE status = $1 
-		ORDER BY created_at ASC 
-		LIMIT $2
-		FOR UPDATE SKIP LOCKED`
+	// This is a plain read, use ClaimPendingMessages to take ownership of messages for sending
+	query, args, err := r.qb.
+		Select(messageColumns...).
+		From("messages").
+		Where(squirrel.Eq{"status": message.StatusPending.String()}).
+		Where(dueForAttempt).
+		OrderBy("priority ASC", "created_at ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build pending query: %v", err)
+	}
 
-	rows, err := r.pool.Query(ctx, query, message.StatusPending.String(), limit)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, errors.NewRepositoryError("failed to query pending messages: %v", err)
 	}
@@ -112,16 +231,309 @@ func (r *MessageRepository) GetPendingMessages(ctx context.Context, limit int) (
 	return r.scanMessages(rows)
 }
 
+// ClaimPendingMessages atomically leases a batch of due pending messages to a worker, limited to the given priorities if any
+func (r *MessageRepository) ClaimPendingMessages(ctx context.Context, workerID string, priorities []message.Priority, limit int, leaseDuration time.Duration) ([]*message.Message, error) {
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if workerID == "" {
+		return nil, errors.NewValidationError("worker ID cannot be empty")
+	}
+
+	// Any priority is claimed when none is given
+	ranks := []int{}
+	for _, priority := range priorities {
+		ranks = append(ranks, priority.Rank())
+	}
+
+	// Select and update in a single statement so the row locks taken by
+	// FOR UPDATE SKIP LOCKED are held until the status change commits.
+	// Concurrent claimers skip locked rows and never see the same message.
+	query := `
+		WITH claimed AS (
+			UPDATE messages
+			SET status = $1, locked_by = $2, lease_until = NOW() + make_interval(secs => $3), updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM messages
+				WHERE status = $4 AND (cardinality($7::smallint[]) = 0 OR priority = ANY($7)) AND ` + dueForAttemptSQL + `
+				ORDER BY priority ASC, created_at ASC
+				LIMIT $5
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + strings.Join(messageColumns, ", ") + `
+		), history AS (
+			INSERT INTO message_status_history (message_id, from_status, to_status, triggered_by)
+			SELECT id, $4, $1, $6 FROM claimed
+		)
+		SELECT ` + strings.Join(messageColumns, ", ") + ` FROM claimed
+		ORDER BY priority ASC, created_at ASC`
+
+	rows, err := r.pool.Query(ctx, query,
+		message.StatusProcessing.String(), workerID, leaseDuration.Seconds(),
+		message.StatusPending.String(), limit, message.TriggerFromContext(ctx).String(), ranks,
+	)
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to claim pending messages: %v", err)
+	}
+	defer rows.Close()
+
+	return r.scanMessages(rows)
+}
+
+// ReleaseExpiredLeases returns messages held by crashed or stalled workers to the pending queue
+func (r *MessageRepository) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	update := r.qb.
+		Update("messages").
+		Set("status", message.StatusPending.String()).
+		Set("locked_by", nil).
+		Set("lease_until", nil).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"status": message.StatusProcessing.String()}).
+		Where(squirrel.Expr("lease_until <= NOW()"))
+
+	query, args, err := withStatusHistory(ctx, update, message.StatusProcessing, message.StatusPending).ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build lease release query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // Update updates an existing message
 func (r *MessageRepository) Update(ctx context.Context, msg *message.Message) error {
+	query, args, err := r.buildUpdate(msg).ToSql()
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	return r.saveWithStatusChanges(ctx, msg, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return errors.NewNotFoundError("message not found for update")
+		}
+
+		return nil
+	})
+}
+
+// UpdateClaimed updates a message only if the worker still holds its processing lease
+func (r *MessageRepository) UpdateClaimed(ctx context.Context, msg *message.Message, workerID string) error {
+	query, args, err := r.buildUpdate(msg).
+		Where(squirrel.Eq{"status": message.StatusProcessing.String()}).
+		Where(squirrel.Eq{"locked_by": workerID}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	return r.saveWithStatusChanges(ctx, msg, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return errors.NewConflictError("message %s is no longer leased to worker %s", msg.ID, workerID)
+		}
+
+		return nil
+	})
+}
+
+// UpdatePending updates a message only if it is still pending.
+// A claim holding the row lock makes this wait and then match nothing, so a
+// message can never be changed here once a worker has started sending it.
+func (r *MessageRepository) UpdatePending(ctx context.Context, msg *message.Message) error {
+	query, args, err := r.buildUpdate(msg).
+		Where(squirrel.Eq{"status": message.StatusPending.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	return r.saveWithStatusChanges(ctx, msg, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return errors.NewConflictError("message %s is no longer pending", msg.ID)
+		}
+
+		return nil
+	})
+}
+
+// UpdateSent updates a message only if it is still sent, so two receipts
+// applied at the same time cannot both record a delivery outcome
+func (r *MessageRepository) UpdateSent(ctx context.Context, msg *message.Message) error {
+	query, args, err := r.buildUpdate(msg).
+		Where(squirrel.Eq{"status": message.StatusSent.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	return r.saveWithStatusChanges(ctx, msg, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return errors.NewConflictError("message %s is no longer awaiting a delivery receipt", msg.ID)
+		}
+
+		return nil
+	})
+}
+
+// saveWithStatusChanges runs write and stores the pending status changes of msg in one transaction.
+// The changes are cleared from msg once committed.
+func (r *MessageRepository) saveWithStatusChanges(ctx context.Context, msg *message.Message, write func(tx pgx.Tx) error) error {
+	changes := msg.PendingStatusChanges()
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := write(tx); err != nil {
+			return err
+		}
+
+		return r.insertStatusChanges(ctx, tx, changes)
+	})
+	if err != nil {
+		return err
+	}
+
+	msg.ClearStatusChanges()
+	return nil
+}
+
+// insertStatusChanges stores status changes attributed to the trigger carried by ctx
+func (r *MessageRepository) insertStatusChanges(ctx context.Context, tx pgx.Tx, changes []message.StatusChange) error {
+	trigger := message.TriggerFromContext(ctx)
+
+	for start := 0; start < len(changes); start += maxRowsPerInsert {
+		end := min(start+maxRowsPerInsert, len(changes))
+
+		insert := r.qb.Insert("message_status_history").Columns(statusHistoryColumns...)
+		for _, change := range changes[start:end] {
+			insert = insert.Values(change.MessageID.String(), statusValue(change.From), change.To.String(), trigger.String(), change.OccurredAt)
+		}
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return errors.NewRepositoryError("failed to build status history insert query: %v", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withStatusHistory makes a bulk status update also record a history row for every message it moves
+func withStatusHistory(ctx context.Context, update squirrel.UpdateBuilder, from, to message.Status) squirrel.UpdateBuilder {
+	return update.
+		Prefix("WITH changed AS (").
+		Suffix(`RETURNING id)
+			INSERT INTO message_status_history (message_id, from_status, to_status, triggered_by)
+			SELECT id, ?, ?, ? FROM changed`,
+			from.String(), to.String(), message.TriggerFromContext(ctx).String(),
+		)
+}
+
+// GetStatusHistory retrieves the status transitions of a message, oldest first
+func (r *MessageRepository) GetStatusHistory(ctx context.Context, id message.MessageID) ([]message.StatusChange, error) {
+	query, args, err := r.qb.
+		Select(statusHistoryColumns...).
+		From("message_status_history").
+		Where(squirrel.Eq{"message_id": id.String()}).
+		OrderBy("occurred_at ASC", "id ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build status history query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []message.StatusChange
+	for rows.Next() {
+		var (
+			change     message.StatusChange
+			messageID  string
+			fromStatus *string
+			toStatus   string
+			trigger    string
+		)
+
+		if err := rows.Scan(&messageID, &fromStatus, &toStatus, &trigger, &change.OccurredAt); err != nil {
+			return nil, err
+		}
+
+		change.MessageID = message.MessageID(messageID)
+		change.To = message.Status(toStatus)
+		change.Trigger = message.Trigger(trigger)
+		if fromStatus != nil {
+			from := message.Status(*fromStatus)
+			change.From = &from
+		}
+
+		history = append(history, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// buildUpdate builds the update statement writing every mutable message column
+func (r *MessageRepository) buildUpdate(msg *message.Message) squirrel.UpdateBuilder {
 	updateQuery := r.qb.
 		Update("messages").
 		Set("phone_number", msg.PhoneNumber.String()).
+		Set("phone_country_code", msg.PhoneNumber.CountryCode()).
+		Set("phone_region", phoneRegionValue(msg.PhoneNumber)).
 		Set("content", msg.Content.String()).
 		Set("status", msg.Status.String()).
 		Set("retry_count", msg.RetryCount).
+		Set("send_at", msg.SendAt).
+		Set("expires_at", msg.ExpiresAt).
+		Set("next_attempt_at", msg.NextAttemptAt).
+		Set("locked_by", msg.LockedBy).
+		Set("lease_until", msg.LeaseUntil).
+		Set("attempt_count", msg.AttemptCount).
+		Set("last_error", msg.LastError).
+		Set("last_error_class", errorClassValue(msg.LastErrorClass)).
+		Set("last_http_status", msg.LastHTTPStatus).
 		Set("updated_at", msg.UpdatedAt).
 		Set("sent_at", msg.SentAt).
+		Set("carrier_error_code", msg.CarrierErrorCode).
+		Set("delivery_reported_at", msg.DeliveryReportedAt).
+		Set("priority", msg.Priority.Rank()).
+		Set("provider", msg.Provider).
 		Where(squirrel.Eq{"id": msg.ID.String()})
 
 	// Set external_id if message is sent
@@ -129,21 +541,7 @@ func (r *MessageRepository) Update(ctx context.Context, msg *message.Message) er
 		updateQuery = updateQuery.Set("external_id", *msg.ExternalID)
 	}
 
-	query, args, err := updateQuery.ToSql()
-	if err != nil {
-		return errors.NewRepositoryError("failed to build update query: %v", err)
-	}
-
-	result, err := r.pool.Exec(ctx, query, args...)
-	if err != nil {
-		return err
-	}
-
-	if result.RowsAffected() == 0 {
-		return errors.NewNotFoundError("message not found for update")
-	}
-
-	return nil
+	return updateQuery
 }
 
 // GetByStatus retrieves messages by status with pagination
@@ -156,10 +554,7 @@ func (r *MessageRepository) GetByStatus(ctx context.Context, status message.Stat
 	}
 
 	query, args, err := r.qb.
-		Select(
-			"id", "phone_number", "content", "status",
-			"external_id", "retry_count", "created_at", "updated_at", "sent_at",
-		).
+		Select(messageColumns...).
 		From("messages").
 		Where(squirrel.Eq{"status": status.String()}).
 		OrderBy("created_at DESC").
@@ -201,6 +596,178 @@ func (r *MessageRepository) CountByStatus(ctx context.Context, status message.St
 	return count, nil
 }
 
+// GetScheduledMessages retrieves pending messages whose send time is still in the future
+func (r *MessageRepository) GetScheduledMessages(ctx context.Context, pagination repositories.Pagination) ([]*message.Message, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(messageColumns...).
+		From("messages").
+		Where(squirrel.Eq{"status": message.StatusPending.String()}).
+		Where(squirrel.Expr("send_at > NOW()")).
+		OrderBy("send_at ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build scheduled messages query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanMessages(rows)
+}
+
+// GetFailedMessages retrieves dead-lettered messages, most recently failed first
+func (r *MessageRepository) GetFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter, pagination repositories.Pagination) ([]*message.Message, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(messageColumns...).
+		From("messages").
+		Where(deadLetterConditions(filter)).
+		OrderBy("updated_at DESC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build dead-letter query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanMessages(rows)
+}
+
+// CountFailedMessages counts dead-lettered messages matching the filter
+func (r *MessageRepository) CountFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("messages").
+		Where(deadLetterConditions(filter)).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build dead-letter count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// RequeueFailedMessages returns matching dead-lettered messages to the pending queue
+func (r *MessageRepository) RequeueFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter) (int64, error) {
+	update := r.qb.
+		Update("messages").
+		Set("status", message.StatusPending.String()).
+		Set("retry_count", 0).
+		Set("next_attempt_at", nil).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(deadLetterConditions(filter))
+
+	query, args, err := withStatusHistory(ctx, update, message.StatusFailed, message.StatusPending).ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build requeue query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// deadLetterConditions builds the WHERE clause for failed messages matching the filter.
+// FAILED is terminal, so updated_at is the failure time.
+func deadLetterConditions(filter repositories.DeadLetterFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"status": message.StatusFailed.String()}}
+
+	if filter.PhoneNumber != nil {
+		conditions = append(conditions, squirrel.Eq{"phone_number": filter.PhoneNumber.String()})
+	}
+	if filter.ErrorClass != nil {
+		conditions = append(conditions, squirrel.Eq{"last_error_class": filter.ErrorClass.String()})
+	}
+	if filter.FailedFrom != nil {
+		conditions = append(conditions, squirrel.GtOrEq{"updated_at": *filter.FailedFrom})
+	}
+	if filter.FailedTo != nil {
+		conditions = append(conditions, squirrel.Lt{"updated_at": *filter.FailedTo})
+	}
+
+	return conditions
+}
+
+// ExpireOverdueMessages marks pending messages past their expiry time as expired
+func (r *MessageRepository) ExpireOverdueMessages(ctx context.Context) (int64, error) {
+	update := r.qb.
+		Update("messages").
+		Set("status", message.StatusExpired.String()).
+		Where(squirrel.Eq{"status": message.StatusPending.String()}).
+		Where(squirrel.Expr("expires_at <= NOW()"))
+
+	query, args, err := withStatusHistory(ctx, update, message.StatusPending, message.StatusExpired).ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build expire query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CountScheduled counts pending messages whose send time is still in the future
+func (r *MessageRepository) CountScheduled(ctx context.Context) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("messages").
+		Where(squirrel.Eq{"status": message.StatusPending.String()}).
+		Where(squirrel.Expr("send_at > NOW()")).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build scheduled count query: %v", err)
+	}
+
+	var count int64
+	row := r.pool.QueryRow(ctx, query, args...)
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // DeleteByID deletes a message by its ID
 func (r *MessageRepository) DeleteByID(ctx context.Context, id message.MessageID) error {
 	query, args, err := r.qb.
@@ -234,12 +801,9 @@ func (r *MessageRepository) GetSentMessages(ctx context.Context, pagination repo
 	}
 
 	query, args, err := r.qb.
-		Select(
-			"id", "phone_number", "content", "status",
-			"external_id", "retry_count", "created_at", "updated_at", "sent_at",
-		).
+		Select(messageColumns...).
 		From("messages").
-		Where(squirrel.Eq{"status": message.StatusSent.String()}).
+		Where(squirrel.Eq{"status": []string{message.StatusSent.String(), message.StatusDelivered.String(), message.StatusUndelivered.String()}}).
 		OrderBy("sent_at DESC").
 		Limit(uint64(pagination.Limit)).
 		Offset(uint64(pagination.Offset)).
@@ -268,10 +832,7 @@ func (r *MessageRepository) GetByPhoneNumber(ctx context.Context, phoneNumber me
 	}
 
 	query, args, err := r.qb.
-		Select(
-			"id", "phone_number", "content", "status",
-			"external_id", "retry_count", "created_at", "updated_at", "sent_at",
-		).
+		Select(messageColumns...).
 		From("messages").
 		Where(squirrel.Eq{"phone_number": phoneNumber.String()}).
 		OrderBy("created_at DESC").
@@ -292,29 +853,53 @@ func (r *MessageRepository) GetByPhoneNumber(ctx context.Context, phoneNumber me
 	return r.scanMessages(rows)
 }
 
+// messageRow holds the raw column values of a messages row
+type messageRow struct {
+	id            string
+	phone         string
+	content       string
+	status        string
+	externalID    *string
+	retryCount    int
+	sendAt        *time.Time
+	expiresAt     *time.Time
+	nextAttemptAt *time.Time
+	lockedBy      *string
+	leaseUntil    *time.Time
+	attemptCount  int
+	lastError     *string
+	lastErrClass  *string
+	lastHTTPStat  *int
+	createdAt     time.Time
+	updatedAt     time.Time
+	sentAt        *time.Time
+	templateID    *string
+	templateVer   *int
+	carrierCode   *string
+	reportedAt    *time.Time
+	priority      int
+	provider      *string
+}
+
 // scanMessage scans a single row into a message
 func (r *MessageRepository) scanMessage(row pgx.Row) (*message.Message, error) {
-	var (
-		idStr      string
-		phoneStr   string
-		contentStr string
-		statusStr  string
-		externalID *string
-		retryCount int
-		createdAt  time.Time
-		updatedAt  time.Time
-		sentAt     *time.Time
-	)
+	var mr messageRow
 
 	err := row.Scan(
-		&idStr, &phoneStr, &contentStr, &statusStr,
-		&externalID, &retryCount, &createdAt, &updatedAt, &sentAt,
+		&mr.id, &mr.phone, &mr.content, &mr.status,
+		&mr.externalID, &mr.retryCount, &mr.sendAt, &mr.expiresAt, &mr.nextAttemptAt,
+		&mr.lockedBy, &mr.leaseUntil,
+		&mr.attemptCount, &mr.lastError, &mr.lastErrClass, &mr.lastHTTPStat,
+		&mr.createdAt, &mr.updatedAt, &mr.sentAt,
+		&mr.templateID, &mr.templateVer,
+		&mr.carrierCode, &mr.reportedAt,
+		&mr.priority, &mr.provider,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.buildMessage(idStr, phoneStr, contentStr, statusStr, externalID, retryCount, createdAt, updatedAt, sentAt)
+	return r.buildMessage(mr)
 }
 
 // scanMessages scans multiple rows into messages
@@ -322,27 +907,7 @@ func (r *MessageRepository) scanMessages(rows pgx.Rows) ([]*message.Message, err
 	var messages []*message.Message
 
 	for rows.Next() {
-		var (
-			idStr      string
-			phoneStr   string
-			contentStr string
-			statusStr  string
-			externalID *string
-			retryCount int
-			createdAt  time.Time
-			updatedAt  time.Time
-			sentAt     *time.Time
-		)
-
-		err := rows.Scan(
-			&idStr, &phoneStr, &contentStr, &statusStr,
-			&externalID, &retryCount, &createdAt, &updatedAt, &sentAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		msg, err := r.buildMessage(idStr, phoneStr, contentStr, statusStr, externalID, retryCount, createdAt, updatedAt, sentAt)
+		msg, err := r.scanMessage(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -358,44 +923,104 @@ func (r *MessageRepository) scanMessages(rows pgx.Rows) ([]*message.Message, err
 }
 
 // buildMessage builds a message from scanned values
-func (r *MessageRepository) buildMessage(
-	idStr, phoneStr, contentStr, statusStr string,
-	externalID *string,
-	retryCount int,
-	createdAt, updatedAt time.Time,
-	sentAt *time.Time,
-) (*message.Message, error) {
-	// Create value objects
-	phoneNumber, err := message.NewPhoneNumber(phoneStr)
-	if err != nil {
-		return nil, errors.NewRepositoryError("invalid phone number from database: %v", err)
-	}
+func (r *MessageRepository) buildMessage(mr messageRow) (*message.Message, error) {
+	// Numbers stored under older validation rules must still load, the processor rejects those that no longer validate
+	phoneNumber := message.PhoneNumber(mr.phone)
 
-	content, err := message.NewContent(contentStr)
+	content, err := message.NewContent(mr.content)
 	if err != nil {
 		return nil, errors.NewRepositoryError("invalid content from database: %v", err)
 	}
 
-	status := message.Status(statusStr)
+	status := message.Status(mr.status)
 	if !status.IsValid() {
-		return nil, errors.NewRepositoryError("invalid status from database: %s", statusStr)
+		return nil, errors.NewRepositoryError("invalid status from database: %s", mr.status)
+	}
+
+	priority, err := message.PriorityFromRank(mr.priority)
+	if err != nil {
+		return nil, errors.NewRepositoryError("invalid priority from database: %d", mr.priority)
 	}
 
 	// Create and populate message
 	msg := &message.Message{
-		ID:          message.MessageID(idStr),
-		PhoneNumber: phoneNumber,
-		Content:     content,
-		Status:      status,
-		RetryCount:  retryCount,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-		SentAt:      sentAt,
+		ID:             message.MessageID(mr.id),
+		PhoneNumber:    phoneNumber,
+		Content:        content,
+		Status:         status,
+		Priority:       priority,
+		Provider:       mr.provider,
+		RetryCount:     mr.retryCount,
+		SendAt:         mr.sendAt,
+		ExpiresAt:      mr.expiresAt,
+		NextAttemptAt:  mr.nextAttemptAt,
+		LockedBy:       mr.lockedBy,
+		LeaseUntil:     mr.leaseUntil,
+		AttemptCount:   mr.attemptCount,
+		LastError:      mr.lastError,
+		LastHTTPStatus: mr.lastHTTPStat,
+		CreatedAt:      mr.createdAt,
+		UpdatedAt:      mr.updatedAt,
+		SentAt:         mr.sentAt,
+
+		CarrierErrorCode:   mr.carrierCode,
+		DeliveryReportedAt: mr.reportedAt,
+	}
+
+	if mr.externalID != nil {
+		msg.ExternalID = mr.externalID
+	}
+
+	if mr.lastErrClass != nil {
+		class := message.ErrorClass(*mr.lastErrClass)
+		msg.LastErrorClass = &class
 	}
 
-	if externalID != nil {
-		msg.ExternalID = externalID
+	if mr.templateID != nil {
+		templateID := message.TemplateID(*mr.templateID)
+		msg.TemplateID = &templateID
+		msg.TemplateVersion = mr.templateVer
 	}
 
 	return msg, nil
 }
+
+// statusValue converts an optional status into a nullable column value
+func statusValue(status *message.Status) *string {
+	if status == nil {
+		return nil
+	}
+
+	value := status.String()
+	return &value
+}
+
+// errorClassValue converts an optional error class into a nullable column value
+func errorClassValue(class *message.ErrorClass) *string {
+	if class == nil {
+		return nil
+	}
+
+	value := class.String()
+	return &value
+}
+
+// templateIDValue converts an optional template ID into a nullable column value
+func templateIDValue(id *message.TemplateID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+	return &value
+}
+
+// phoneRegionValue converts the region of a phone number into a nullable column value
+func phoneRegionValue(phone message.PhoneNumber) *string {
+	region := phone.Region()
+	if region == "" {
+		return nil
+	}
+
+	return &region
+}
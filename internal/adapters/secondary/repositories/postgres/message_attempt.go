@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// attemptColumns lists the message_attempts table columns in scan order
+var attemptColumns = []string{
+	"id", "message_id", "retry_count", "attempt_number",
+	"started_at", "duration_ms", "http_status", "response_body", "error", "error_class",
+	"provider",
+}
+
+// MessageAttemptRepository implements the MessageAttemptRepository interface using PostgreSQL
+type MessageAttemptRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewMessageAttemptRepository creates a new PostgreSQL delivery attempt repository
+func NewMessageAttemptRepository(pool *pgxpool.Pool) repositories.MessageAttemptRepository {
+	return &MessageAttemptRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create stores a completed delivery attempt
+func (r *MessageAttemptRepository) Create(ctx context.Context, attempt *message.Attempt) error {
+	query, args, err := r.qb.
+		Insert("message_attempts").
+		Columns(attemptColumns...).
+		Values(
+			attempt.ID.String(),
+			attempt.MessageID.String(),
+			attempt.RetryCount,
+			attempt.AttemptNumber,
+			attempt.StartedAt,
+			attempt.Duration.Milliseconds(),
+			attempt.HTTPStatus,
+			attempt.ResponseBody,
+			attempt.Error,
+			errorClassValue(attempt.ErrorClass),
+			attempt.Provider,
+		).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// GetByMessageID retrieves the delivery attempts of a message, oldest first
+func (r *MessageAttemptRepository) GetByMessageID(ctx context.Context, messageID message.MessageID, pagination repositories.Pagination) ([]*message.Attempt, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(attemptColumns...).
+		From("message_attempts").
+		Where(squirrel.Eq{"message_id": messageID.String()}).
+		OrderBy("started_at ASC", "attempt_number ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build attempts query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*message.Attempt
+	for rows.Next() {
+		attempt, err := r.scanAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// CountByMessageID counts the delivery attempts of a message
+func (r *MessageAttemptRepository) CountByMessageID(ctx context.Context, messageID message.MessageID) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("message_attempts").
+		Where(squirrel.Eq{"message_id": messageID.String()}).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build attempts count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// scanAttempt scans a single row into a delivery attempt
+func (r *MessageAttemptRepository) scanAttempt(row pgx.Row) (*message.Attempt, error) {
+	var (
+		attempt    message.Attempt
+		id         string
+		messageID  string
+		durationMs int64
+		errorClass *string
+	)
+
+	err := row.Scan(
+		&id, &messageID, &attempt.RetryCount, &attempt.AttemptNumber,
+		&attempt.StartedAt, &durationMs, &attempt.HTTPStatus, &attempt.ResponseBody, &attempt.Error, &errorClass,
+		&attempt.Provider,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt.ID = message.AttemptID(id)
+	attempt.MessageID = message.MessageID(messageID)
+	attempt.Duration = time.Duration(durationMs) * time.Millisecond
+
+	if errorClass != nil {
+		class := message.ErrorClass(*errorClass)
+		attempt.ErrorClass = &class
+	}
+
+	return &attempt, nil
+}
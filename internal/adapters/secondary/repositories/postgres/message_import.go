@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// importJobColumns lists the message_import_jobs table columns in scan order
+var importJobColumns = []string{
+	"id", "format", "status", "processed_rows", "created_count", "failed_count",
+	"error", "created_at", "updated_at", "completed_at",
+}
+
+// execer runs statements on the pool or within a transaction
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// ImportJobRepository implements the ImportJobRepository interface using PostgreSQL
+type ImportJobRepository struct {
+	pool     *pgxpool.Pool
+	qb       squirrel.StatementBuilderType
+	messages *MessageRepository // Stores the messages of a chunk in the chunk's transaction
+}
+
+// NewImportJobRepository creates a new PostgreSQL import job repository
+func NewImportJobRepository(pool *pgxpool.Pool) repositories.ImportJobRepository {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return &ImportJobRepository{
+		pool:     pool,
+		qb:       qb,
+		messages: &MessageRepository{pool: pool, qb: qb},
+	}
+}
+
+// Create stores a new import job
+func (r *ImportJobRepository) Create(ctx context.Context, job *message.ImportJob) error {
+	query, args, err := r.qb.
+		Insert("message_import_jobs").
+		Columns(importJobColumns...).
+		Values(
+			job.ID.String(),
+			job.Format.String(),
+			job.Status.String(),
+			job.ProcessedRows,
+			job.CreatedCount,
+			job.FailedCount,
+			job.Error,
+			job.CreatedAt,
+			job.UpdatedAt,
+			job.CompletedAt,
+		).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// Update stores the progress and status of an import job
+func (r *ImportJobRepository) Update(ctx context.Context, job *message.ImportJob) error {
+	return r.update(ctx, r.pool, job)
+}
+
+// update stores the progress and status of an import job through db
+func (r *ImportJobRepository) update(ctx context.Context, db execer, job *message.ImportJob) error {
+	query, args, err := r.qb.
+		Update("message_import_jobs").
+		Set("status", job.Status.String()).
+		Set("processed_rows", job.ProcessedRows).
+		Set("created_count", job.CreatedCount).
+		Set("failed_count", job.FailedCount).
+		Set("error", job.Error).
+		Set("updated_at", job.UpdatedAt).
+		Set("completed_at", job.CompletedAt).
+		Where(squirrel.Eq{"id": job.ID.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("import job not found for update")
+	}
+
+	return nil
+}
+
+// SaveChunk stores the messages and rejected rows of a processed chunk together with the job progress
+// in one transaction
+func (r *ImportJobRepository) SaveChunk(ctx context.Context, job *message.ImportJob, msgs []*message.Message, rowErrors []message.ImportRowError) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := r.messages.insertBatch(ctx, tx, msgs); err != nil {
+			return err
+		}
+
+		if err := r.insertRowErrors(ctx, tx, rowErrors); err != nil {
+			return err
+		}
+
+		return r.update(ctx, tx, job)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		msg.ClearStatusChanges()
+	}
+	return nil
+}
+
+// FailStale marks jobs still processing that made no progress since before the given time as failed.
+// Running imports store their progress after every chunk, so these were interrupted by a stopped server.
+func (r *ImportJobRepository) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int64, error) {
+	query, args, err := r.qb.
+		Update("message_import_jobs").
+		Set("status", message.ImportStatusFailed.String()).
+		Set("error", reason).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"status": message.ImportStatusProcessing.String()}).
+		Where(squirrel.Lt{"updated_at": updatedBefore}).
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build stale import update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetByID retrieves an import job by its ID
+func (r *ImportJobRepository) GetByID(ctx context.Context, id message.ImportJobID) (*message.ImportJob, error) {
+	query, args, err := r.qb.
+		Select(importJobColumns...).
+		From("message_import_jobs").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	var (
+		job    message.ImportJob
+		jobID  string
+		format string
+		status string
+	)
+
+	err = r.pool.QueryRow(ctx, query, args...).Scan(
+		&jobID, &format, &status, &job.ProcessedRows, &job.CreatedCount, &job.FailedCount,
+		&job.Error, &job.CreatedAt, &job.UpdatedAt, &job.CompletedAt,
+	)
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "import job")
+	}
+
+	job.ID = message.ImportJobID(jobID)
+	job.Format = message.ImportFormat(format)
+	job.Status = message.ImportStatus(status)
+
+	return &job, nil
+}
+
+// insertRowErrors stores rejected rows of an import job with multi-row inserts within tx
+func (r *ImportJobRepository) insertRowErrors(ctx context.Context, tx pgx.Tx, rowErrors []message.ImportRowError) error {
+	for start := 0; start < len(rowErrors); start += maxRowsPerInsert {
+		end := min(start+maxRowsPerInsert, len(rowErrors))
+
+		insert := r.qb.Insert("message_import_errors").Columns("job_id", "row_number", "error", "data")
+		for _, rowErr := range rowErrors[start:end] {
+			insert = insert.Values(rowErr.JobID.String(), rowErr.Row, rowErr.Error, rowErr.Data)
+		}
+
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return errors.NewRepositoryError("failed to build row errors insert query: %v", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetRowErrors retrieves the rejected rows of an import job in row order
+func (r *ImportJobRepository) GetRowErrors(ctx context.Context, id message.ImportJobID, pagination repositories.Pagination) ([]message.ImportRowError, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select("row_number", "error", "data").
+		From("message_import_errors").
+		Where(squirrel.Eq{"job_id": id.String()}).
+		OrderBy("row_number ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build row errors query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rowErrors []message.ImportRowError
+	for rows.Next() {
+		rowErr := message.ImportRowError{JobID: id}
+		if err := rows.Scan(&rowErr.Row, &rowErr.Error, &rowErr.Data); err != nil {
+			return nil, err
+		}
+		rowErrors = append(rowErrors, rowErr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rowErrors, nil
+}
@@ -0,0 +1,5 @@
+-- Add optional scheduled send time to messages
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS send_at TIMESTAMP WITH TIME ZONE;
+
+-- Support due-message lookups and scheduled listings
+CREATE INDEX IF NOT EXISTS idx_messages_status_send_at ON messages(status, send_at) WHERE send_at IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Expired messages were never sent, keep them as failed
+UPDATE messages SET status = 'FAILED' WHERE status = 'EXPIRED';
+
+-- Restore previous status constraint
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED'));
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_status_expires_at;
+
+-- Drop column
+ALTER TABLE messages DROP COLUMN IF EXISTS expires_at;
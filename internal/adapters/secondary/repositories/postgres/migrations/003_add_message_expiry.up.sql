@@ -0,0 +1,10 @@
+-- Add optional expiry time to messages
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Support the expiry sweeper
+CREATE INDEX IF NOT EXISTS idx_messages_status_expires_at ON messages(status, expires_at) WHERE expires_at IS NOT NULL;
+
+-- Allow the terminal EXPIRED status
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'EXPIRED'));
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_status_next_attempt_at;
+
+-- Drop column
+ALTER TABLE messages DROP COLUMN IF EXISTS next_attempt_at;
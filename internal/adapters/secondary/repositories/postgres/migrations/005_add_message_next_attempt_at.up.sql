@@ -0,0 +1,5 @@
+-- Earliest time a failed message may be re-attempted
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE;
+
+-- Support skipping messages that are waiting out their retry backoff
+CREATE INDEX IF NOT EXISTS idx_messages_status_next_attempt_at ON messages(status, next_attempt_at) WHERE next_attempt_at IS NOT NULL;
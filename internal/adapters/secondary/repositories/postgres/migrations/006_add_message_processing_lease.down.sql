@@ -0,0 +1,14 @@
+-- Claimed messages were not sent yet, return them to the queue
+UPDATE messages SET status = 'PENDING' WHERE status = 'PROCESSING';
+
+-- Restore previous status constraint
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'EXPIRED'));
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_processing_lease_until;
+
+-- Drop columns
+ALTER TABLE messages DROP COLUMN IF EXISTS lease_until;
+ALTER TABLE messages DROP COLUMN IF EXISTS locked_by;
@@ -0,0 +1,11 @@
+-- Track which worker holds a message and until when
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS locked_by VARCHAR(255);
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS lease_until TIMESTAMP WITH TIME ZONE;
+
+-- Support reclaiming messages whose lease ran out
+CREATE INDEX IF NOT EXISTS idx_messages_processing_lease_until ON messages(lease_until) WHERE status = 'PROCESSING';
+
+-- Allow the PROCESSING status for claimed messages
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED'));
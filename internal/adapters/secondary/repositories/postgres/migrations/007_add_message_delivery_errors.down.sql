@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_failed_error_class;
+DROP INDEX IF EXISTS idx_messages_failed_updated_at;
+
+-- Drop columns
+ALTER TABLE messages DROP COLUMN IF EXISTS last_http_status;
+ALTER TABLE messages DROP COLUMN IF EXISTS last_error_class;
+ALTER TABLE messages DROP COLUMN IF EXISTS last_error;
+ALTER TABLE messages DROP COLUMN IF EXISTS attempt_count;
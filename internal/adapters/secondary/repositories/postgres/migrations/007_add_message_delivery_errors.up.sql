@@ -0,0 +1,9 @@
+-- Keep the outcome of the latest delivery attempt for dead-letter inspection
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS attempt_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS last_error TEXT;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS last_error_class VARCHAR(50);
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS last_http_status INTEGER;
+
+-- Support filtering the dead-letter view
+CREATE INDEX IF NOT EXISTS idx_messages_failed_updated_at ON messages(updated_at DESC) WHERE status = 'FAILED';
+CREATE INDEX IF NOT EXISTS idx_messages_failed_error_class ON messages(last_error_class) WHERE status = 'FAILED';
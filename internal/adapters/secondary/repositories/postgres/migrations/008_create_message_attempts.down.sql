@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_message_attempts_message_id_started_at;
+
+-- Drop table
+DROP TABLE IF EXISTS message_attempts;
@@ -0,0 +1,16 @@
+-- Log every webhook request made to deliver a message
+CREATE TABLE IF NOT EXISTS message_attempts (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    attempt_number INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    http_status INTEGER,
+    response_body TEXT,
+    error TEXT,
+    error_class VARCHAR(50)
+);
+
+-- Support listing the attempts of a message in order
+CREATE INDEX IF NOT EXISTS idx_message_attempts_message_id_started_at ON message_attempts(message_id, started_at);
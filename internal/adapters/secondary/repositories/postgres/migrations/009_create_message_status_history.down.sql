@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_message_status_history_message_id_occurred_at;
+
+-- Drop table
+DROP TABLE IF EXISTS message_status_history;
@@ -0,0 +1,12 @@
+-- Record every status transition of a message
+CREATE TABLE IF NOT EXISTS message_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    triggered_by VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Support reading the timeline of a message in order
+CREATE INDEX IF NOT EXISTS idx_message_status_history_message_id_occurred_at ON message_status_history(message_id, occurred_at);
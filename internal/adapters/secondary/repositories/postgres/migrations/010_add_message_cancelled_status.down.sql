@@ -0,0 +1,7 @@
+-- Cancelled messages were never sent and must not be picked up again
+UPDATE messages SET status = 'EXPIRED' WHERE status = 'CANCELLED';
+
+-- Restore previous status constraint
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED'));
@@ -0,0 +1,4 @@
+-- Allow the terminal CANCELLED status for messages cancelled before being sent
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED', 'CANCELLED'));
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_message_import_errors_job_id_row_number;
+
+-- Drop tables
+DROP TABLE IF EXISTS message_import_errors;
+DROP TABLE IF EXISTS message_import_jobs;
@@ -0,0 +1,28 @@
+-- Track messages created from uploaded CSV / NDJSON files
+CREATE TABLE IF NOT EXISTS message_import_jobs (
+    id UUID PRIMARY KEY,
+    format VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PROCESSING',
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE message_import_jobs ADD CONSTRAINT chk_message_import_jobs_status
+    CHECK (status IN ('PROCESSING', 'COMPLETED', 'FAILED'));
+
+-- Rows rejected during an import, served as a downloadable report
+CREATE TABLE IF NOT EXISTS message_import_errors (
+    id BIGSERIAL PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES message_import_jobs(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    data TEXT NOT NULL DEFAULT ''
+);
+
+-- Support reading the error report of a job in row order
+CREATE INDEX IF NOT EXISTS idx_message_import_errors_job_id_row_number ON message_import_errors(job_id, row_number);
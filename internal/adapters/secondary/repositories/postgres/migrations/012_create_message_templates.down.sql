@@ -0,0 +1,10 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_template_id;
+DROP INDEX IF EXISTS idx_message_templates_name;
+
+-- Drop columns
+ALTER TABLE messages DROP COLUMN IF EXISTS template_version;
+ALTER TABLE messages DROP COLUMN IF EXISTS template_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS message_templates;
@@ -0,0 +1,18 @@
+-- Named message bodies with {{variable}} placeholders
+CREATE TABLE IF NOT EXISTS message_templates (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_templates_name ON message_templates(name);
+
+-- Remember which template version a message was rendered from.
+-- No foreign key: deleting a template keeps the messages it produced.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS template_id UUID;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS template_version INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_messages_template_id ON messages(template_id) WHERE template_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_phone_country_code;
+
+-- Drop columns
+ALTER TABLE messages DROP COLUMN IF EXISTS phone_region;
+ALTER TABLE messages DROP COLUMN IF EXISTS phone_country_code;
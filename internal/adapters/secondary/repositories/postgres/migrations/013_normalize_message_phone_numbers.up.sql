@@ -0,0 +1,12 @@
+-- Store phone numbers in E.164 form so a recipient written with separators or without + is one number.
+-- Numbers were validated as international numbers before, so dropping everything but digits is enough.
+UPDATE messages
+SET phone_number = '+' || regexp_replace(phone_number, '[^0-9]', '', 'g')
+WHERE phone_number !~ '^\+[0-9]+$';
+
+-- Calling code and region of the phone number, written with each message for querying.
+-- Messages created before this migration get them on their next update.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS phone_country_code SMALLINT;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS phone_region CHAR(2);
+
+CREATE INDEX IF NOT EXISTS idx_messages_phone_country_code ON messages(phone_country_code);
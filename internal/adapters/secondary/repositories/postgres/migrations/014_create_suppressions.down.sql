@@ -0,0 +1,13 @@
+-- Suppressed messages were never sent and must not be picked up again
+UPDATE messages SET status = 'CANCELLED' WHERE status = 'SUPPRESSED';
+
+-- Restore previous status constraint
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED', 'CANCELLED'));
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_suppressions_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS suppressions;
@@ -0,0 +1,14 @@
+-- Recipients that opted out; messages to them are never sent
+CREATE TABLE IF NOT EXISTS suppressions (
+    phone_number VARCHAR(20) PRIMARY KEY, -- E.164, matching messages.phone_number
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppressions_created_at ON suppressions(created_at);
+
+-- Allow the terminal SUPPRESSED status for messages withheld because their recipient opted out
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED', 'CANCELLED', 'SUPPRESSED'));
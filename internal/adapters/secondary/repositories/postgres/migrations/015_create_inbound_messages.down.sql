@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_inbound_messages_phone_number_received_at;
+DROP INDEX IF EXISTS idx_inbound_messages_external_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS inbound_messages;
@@ -0,0 +1,19 @@
+-- Replies forwarded by the provider, linked to the recipient's phone number
+CREATE TABLE IF NOT EXISTS inbound_messages (
+    id UUID PRIMARY KEY,
+    phone_number VARCHAR(20) NOT NULL, -- E.164, matching messages.phone_number
+    content TEXT NOT NULL DEFAULT '',
+    external_id VARCHAR(255),
+    keyword_action VARCHAR(20) NOT NULL DEFAULT '',
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE inbound_messages ADD CONSTRAINT chk_inbound_messages_keyword_action
+    CHECK (keyword_action IN ('', 'opt_out', 'opt_in'));
+
+-- Recognize callbacks the provider delivers more than once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inbound_messages_external_id ON inbound_messages(external_id);
+
+-- Support reading the replies of a phone number, most recent first
+CREATE INDEX IF NOT EXISTS idx_inbound_messages_phone_number_received_at ON inbound_messages(phone_number, received_at DESC);
@@ -0,0 +1,14 @@
+-- Drop tables
+DROP TABLE IF EXISTS delivery_receipts;
+
+-- Delivery outcomes fold back into SENT, which is all that was known before
+UPDATE messages SET status = 'SENT' WHERE status IN ('DELIVERED', 'UNDELIVERED');
+
+-- Restore previous status constraint
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED', 'CANCELLED', 'SUPPRESSED'));
+
+-- Drop columns
+ALTER TABLE messages DROP COLUMN IF EXISTS delivery_reported_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS carrier_error_code;
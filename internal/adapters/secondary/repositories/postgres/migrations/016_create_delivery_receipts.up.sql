@@ -0,0 +1,23 @@
+-- Final delivery outcome reported by the carrier for a sent message
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS carrier_error_code VARCHAR(50);
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS delivery_reported_at TIMESTAMP WITH TIME ZONE;
+
+-- Allow the DELIVERED and UNDELIVERED statuses that follow SENT
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS chk_messages_status;
+ALTER TABLE messages ADD CONSTRAINT chk_messages_status 
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SENT', 'FAILED', 'EXPIRED', 'CANCELLED', 'SUPPRESSED', 'DELIVERED', 'UNDELIVERED'));
+
+-- Delivery receipts as reported by the provider, one per external ID. A receipt that arrives
+-- before its message is marked SENT stays unapplied until the message catches up.
+CREATE TABLE IF NOT EXISTS delivery_receipts (
+    external_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    error_code VARCHAR(50),
+    reported_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    message_id UUID REFERENCES messages(id) ON DELETE SET NULL,
+    applied_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE delivery_receipts ADD CONSTRAINT chk_delivery_receipts_status
+    CHECK (status IN ('DELIVERED', 'UNDELIVERED'));
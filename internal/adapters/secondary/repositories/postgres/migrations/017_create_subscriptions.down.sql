@@ -0,0 +1,15 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS enqueue_subscription_deliveries ON message_status_history;
+
+-- Drop function
+DROP FUNCTION IF EXISTS enqueue_subscription_deliveries();
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_message_status_history_occurred_at;
+DROP INDEX IF EXISTS idx_subscription_deliveries_event_id;
+DROP INDEX IF EXISTS idx_subscription_deliveries_subscription_id;
+DROP INDEX IF EXISTS idx_subscription_deliveries_due;
+
+-- Drop tables
+DROP TABLE IF EXISTS subscription_deliveries;
+DROP TABLE IF EXISTS subscriptions;
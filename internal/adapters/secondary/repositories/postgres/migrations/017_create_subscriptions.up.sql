@@ -0,0 +1,61 @@
+-- Endpoints of API clients that receive a signed callback for every message status change
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    statuses TEXT[] NOT NULL DEFAULT '{}', -- Empty delivers every status
+    secret VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Delivery log: one row per status change and subscription, plus one per replay
+CREATE TABLE IF NOT EXISTS subscription_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES message_status_history(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    replay BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_by VARCHAR(255),
+    lease_until TIMESTAMP WITH TIME ZONE,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE subscription_deliveries ADD CONSTRAINT chk_subscription_deliveries_status
+    CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED'));
+
+-- Support claiming due deliveries
+CREATE INDEX IF NOT EXISTS idx_subscription_deliveries_due ON subscription_deliveries(next_attempt_at, id) WHERE status = 'PENDING';
+
+-- Support reading the delivery log of a subscription, most recent first
+CREATE INDEX IF NOT EXISTS idx_subscription_deliveries_subscription_id ON subscription_deliveries(subscription_id, id DESC);
+
+-- Support removing the deliveries of deleted messages
+CREATE INDEX IF NOT EXISTS idx_subscription_deliveries_event_id ON subscription_deliveries(event_id);
+
+-- Support replaying the status changes of a time range
+CREATE INDEX IF NOT EXISTS idx_message_status_history_occurred_at ON message_status_history(occurred_at);
+
+-- Queue a delivery to every matching active subscription in the transaction that stores a status change,
+-- so no write path can change a status without notifying subscribers
+CREATE OR REPLACE FUNCTION enqueue_subscription_deliveries()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO subscription_deliveries (subscription_id, event_id)
+    SELECT id, NEW.id FROM subscriptions
+    WHERE active AND (cardinality(statuses) = 0 OR NEW.to_status = ANY(statuses));
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER enqueue_subscription_deliveries
+    AFTER INSERT ON message_status_history
+    FOR EACH ROW
+    EXECUTE FUNCTION enqueue_subscription_deliveries();
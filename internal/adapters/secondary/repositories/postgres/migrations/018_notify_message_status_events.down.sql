@@ -0,0 +1,5 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS notify_message_status_events ON message_status_history;
+
+-- Drop function
+DROP FUNCTION IF EXISTS notify_message_status_events();
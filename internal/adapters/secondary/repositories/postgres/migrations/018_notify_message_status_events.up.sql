@@ -0,0 +1,15 @@
+-- Wake the status stream of every replica once per statement that stores status changes.
+-- The payload is left empty so a transaction sends a single notification however many rows it wrote;
+-- listeners read the new rows from message_status_history themselves.
+CREATE OR REPLACE FUNCTION notify_message_status_events()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('message_status_events', '');
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_message_status_events
+    AFTER INSERT ON message_status_history
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION notify_message_status_events();
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_messages_pending_priority_created_at;
+
+-- Drop column
+ALTER TABLE messages DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,7 @@
+-- Sending priority, stored as a rank so due messages can be read in priority order from an index:
+-- 0 = critical, 1 = high, 2 = normal, 3 = bulk. Existing messages become normal.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 2
+    CONSTRAINT chk_messages_priority CHECK (priority BETWEEN 0 AND 3);
+
+-- Support claiming pending messages by priority, then age
+CREATE INDEX IF NOT EXISTS idx_messages_pending_priority_created_at ON messages(priority, created_at) WHERE status = 'PENDING';
@@ -0,0 +1,3 @@
+-- Drop columns
+ALTER TABLE message_attempts DROP COLUMN IF EXISTS provider;
+ALTER TABLE messages DROP COLUMN IF EXISTS provider;
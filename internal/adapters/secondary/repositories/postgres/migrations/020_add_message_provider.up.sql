@@ -0,0 +1,4 @@
+-- Webhook provider that accepted a message, and the provider each delivery attempt was sent to.
+-- Rows written before providers were named stay NULL.
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS provider VARCHAR(100);
+ALTER TABLE message_attempts ADD COLUMN IF NOT EXISTS provider VARCHAR(100);
@@ -0,0 +1,2 @@
+-- Drop column
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS reserved_message_id;
@@ -0,0 +1,3 @@
+-- Message a reserved idempotency key will create, written before the message exists and therefore
+-- without a foreign key. A replay resolves the key to this message if its response was never stored.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS reserved_message_id UUID;
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+)
+
+// statusEventsChannel is the notification channel the status history trigger signals
+const statusEventsChannel = "message_status_events"
+
+// StatusEventRepository implements the StatusEventRepository interface using PostgreSQL
+type StatusEventRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewStatusEventRepository creates a new PostgreSQL status event repository
+func NewStatusEventRepository(pool *pgxpool.Pool) repositories.StatusEventRepository {
+	return &StatusEventRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// ListAfter retrieves status changes matching the filter, in event ID order
+func (r *StatusEventRepository) ListAfter(ctx context.Context, filter repositories.StatusEventFilter, limit int) ([]*message.StatusEvent, error) {
+	if limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+
+	conditions := squirrel.And{squirrel.Gt{"h.id": filter.AfterID}}
+	if filter.Since != nil {
+		conditions = append(conditions, squirrel.GtOrEq{"h.occurred_at": *filter.Since})
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, squirrel.Eq{"h.to_status": statusNames(filter.Statuses)})
+	}
+	if filter.PhoneNumber != nil {
+		conditions = append(conditions, squirrel.Eq{"m.phone_number": filter.PhoneNumber.String()})
+	}
+
+	query, args, err := r.qb.
+		Select("h.id", "h.message_id", "h.from_status", "h.to_status", "h.triggered_by", "h.occurred_at", "m.phone_number").
+		From("message_status_history h").
+		Join("messages m ON m.id = h.message_id").
+		Where(conditions).
+		OrderBy("h.id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build status events query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*message.StatusEvent
+	for rows.Next() {
+		var (
+			event       message.StatusEvent
+			messageID   string
+			fromStatus  *string
+			toStatus    string
+			trigger     string
+			phoneNumber string
+		)
+
+		if err := rows.Scan(&event.ID, &messageID, &fromStatus, &toStatus, &trigger, &event.OccurredAt, &phoneNumber); err != nil {
+			return nil, err
+		}
+
+		event.MessageID = message.MessageID(messageID)
+		event.To = message.Status(toStatus)
+		event.Trigger = message.Trigger(trigger)
+		event.PhoneNumber = message.PhoneNumber(phoneNumber)
+		if fromStatus != nil {
+			from := message.Status(*fromStatus)
+			event.From = &from
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LatestID returns the ID of the most recent status change, 0 if there is none
+func (r *StatusEventRepository) LatestID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.pool.QueryRow(ctx, "SELECT COALESCE(MAX(id), 0) FROM message_status_history").Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// StatusEventListener implements the StatusEventNotifier interface with PostgreSQL LISTEN/NOTIFY
+type StatusEventListener struct {
+	pool *pgxpool.Pool
+}
+
+// NewStatusEventListener creates a new PostgreSQL status event listener
+func NewStatusEventListener(pool *pgxpool.Pool) services.StatusEventNotifier {
+	return &StatusEventListener{
+		pool: pool,
+	}
+}
+
+// Listen calls notify for every notification of stored status changes until ctx is done or the connection fails.
+// It holds a connection of its own, taken out of the pool for as long as it listens.
+func (l *StatusEventListener) Listen(ctx context.Context, notify func()) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return errors.NewRepositoryError("failed to acquire listener connection: %v", err)
+	}
+
+	// A connection in LISTEN mode must not be handed to other queries
+	conn := pooled.Hijack()
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+statusEventsChannel); err != nil {
+		return errors.NewRepositoryError("failed to listen for status events: %v", err)
+	}
+
+	// Changes stored while no connection was listening are picked up right away
+	notify()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return errors.NewRepositoryError("status event listener failed: %v", err)
+		}
+		notify()
+	}
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// subscriptionColumns lists the subscriptions table columns in scan order
+var subscriptionColumns = []string{
+	"id", "url", "description", "statuses", "secret", "active", "created_at", "updated_at",
+}
+
+// SubscriptionRepository implements the SubscriptionRepository interface using PostgreSQL
+type SubscriptionRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewSubscriptionRepository creates a new PostgreSQL subscription repository
+func NewSubscriptionRepository(pool *pgxpool.Pool) repositories.SubscriptionRepository {
+	return &SubscriptionRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create stores a new subscription
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription *message.Subscription) error {
+	query, args, err := r.qb.
+		Insert("subscriptions").
+		Columns(subscriptionColumns...).
+		Values(
+			subscription.ID.String(),
+			subscription.URL,
+			subscription.Description,
+			statusNames(subscription.Statuses),
+			subscription.Secret,
+			subscription.Active,
+			subscription.CreatedAt,
+			subscription.UpdatedAt,
+		).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// Update stores the endpoint, description, status filter and active flag of a subscription
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription *message.Subscription) error {
+	query, args, err := r.qb.
+		Update("subscriptions").
+		Set("url", subscription.URL).
+		Set("description", subscription.Description).
+		Set("statuses", statusNames(subscription.Statuses)).
+		Set("active", subscription.Active).
+		Set("updated_at", subscription.UpdatedAt).
+		Where(squirrel.Eq{"id": subscription.ID.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("subscription not found for update")
+	}
+
+	return nil
+}
+
+// Delete removes a subscription; its deliveries are removed with it
+func (r *SubscriptionRepository) Delete(ctx context.Context, id message.SubscriptionID) error {
+	query, args, err := r.qb.
+		Delete("subscriptions").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build delete query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("subscription not found")
+	}
+
+	return nil
+}
+
+// GetByID retrieves a subscription
+func (r *SubscriptionRepository) GetByID(ctx context.Context, id message.SubscriptionID) (*message.Subscription, error) {
+	query, args, err := r.qb.
+		Select(subscriptionColumns...).
+		From("subscriptions").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	subscription, err := scanSubscription(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "subscription")
+	}
+
+	return subscription, nil
+}
+
+// List retrieves subscriptions, oldest first
+func (r *SubscriptionRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Subscription, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(subscriptionColumns...).
+		From("subscriptions").
+		OrderBy("created_at ASC", "id ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*message.Subscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// Count returns the total number of subscriptions
+func (r *SubscriptionRepository) Count(ctx context.Context) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("subscriptions").
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// scanSubscription scans a single row into a subscription
+func scanSubscription(row pgx.Row) (*message.Subscription, error) {
+	var (
+		subscription message.Subscription
+		id           string
+		statuses     []string
+	)
+
+	if err := row.Scan(&id, &subscription.URL, &subscription.Description, &statuses, &subscription.Secret, &subscription.Active, &subscription.CreatedAt, &subscription.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	subscription.ID = message.SubscriptionID(id)
+	subscription.Statuses = make([]message.Status, len(statuses))
+	for i, status := range statuses {
+		subscription.Statuses[i] = message.Status(status)
+	}
+	return &subscription, nil
+}
+
+// statusNames converts statuses into a text array column value
+func statusNames(statuses []message.Status) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = status.String()
+	}
+	return names
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// suppressionColumns lists the suppressions table columns in scan order
+var suppressionColumns = []string{"phone_number", "reason", "source", "created_at"}
+
+// SuppressionRepository implements the SuppressionRepository interface using PostgreSQL
+type SuppressionRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewSuppressionRepository creates a new PostgreSQL suppression repository
+func NewSuppressionRepository(pool *pgxpool.Pool) repositories.SuppressionRepository {
+	return &SuppressionRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Add stores a suppression unless the phone number is already suppressed
+func (r *SuppressionRepository) Add(ctx context.Context, suppression *message.Suppression) (bool, error) {
+	added, err := r.AddBatch(ctx, []*message.Suppression{suppression})
+	if err != nil {
+		return false, err
+	}
+
+	return added > 0, nil
+}
+
+// AddBatch stores suppressions with multi-row inserts in a single transaction, skipping numbers already suppressed
+func (r *SuppressionRepository) AddBatch(ctx context.Context, suppressions []*message.Suppression) (int64, error) {
+	var added int64
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		for start := 0; start < len(suppressions); start += maxRowsPerInsert {
+			end := min(start+maxRowsPerInsert, len(suppressions))
+
+			insert := r.qb.Insert("suppressions").Columns(suppressionColumns...)
+			for _, suppression := range suppressions[start:end] {
+				insert = insert.Values(
+					suppression.PhoneNumber.String(),
+					suppression.Reason,
+					suppression.Source.String(),
+					suppression.CreatedAt,
+				)
+			}
+
+			// The first opt-out of a number is the one kept on record
+			query, args, err := insert.Suffix("ON CONFLICT (phone_number) DO NOTHING").ToSql()
+			if err != nil {
+				return errors.NewRepositoryError("failed to build batch insert query: %v", err)
+			}
+
+			result, err := tx.Exec(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			added += result.RowsAffected()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// Remove deletes the suppression of a phone number
+func (r *SuppressionRepository) Remove(ctx context.Context, phoneNumber message.PhoneNumber) error {
+	query, args, err := r.qb.
+		Delete("suppressions").
+		Where(squirrel.Eq{"phone_number": phoneNumber.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build delete query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("phone number %s is not suppressed", phoneNumber)
+	}
+
+	return nil
+}
+
+// Get retrieves the suppression of a phone number
+func (r *SuppressionRepository) Get(ctx context.Context, phoneNumber message.PhoneNumber) (*message.Suppression, error) {
+	query, args, err := r.qb.
+		Select(suppressionColumns...).
+		From("suppressions").
+		Where(squirrel.Eq{"phone_number": phoneNumber.String()}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	suppression, err := scanSuppression(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "suppression")
+	}
+
+	return suppression, nil
+}
+
+// List retrieves suppressions, most recent first
+func (r *SuppressionRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Suppression, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(suppressionColumns...).
+		From("suppressions").
+		OrderBy("created_at DESC", "phone_number ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suppressions []*message.Suppression
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suppressions, nil
+}
+
+// Count returns the total number of suppressed phone numbers
+func (r *SuppressionRepository) Count(ctx context.Context) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("suppressions").
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// scanSuppression scans a single row into a suppression
+func scanSuppression(row pgx.Row) (*message.Suppression, error) {
+	var (
+		suppression message.Suppression
+		phoneNumber string
+		source      string
+	)
+
+	if err := row.Scan(&phoneNumber, &suppression.Reason, &source, &suppression.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	suppression.PhoneNumber = message.PhoneNumber(phoneNumber)
+	suppression.Source = message.SuppressionSource(source)
+	return &suppression, nil
+}
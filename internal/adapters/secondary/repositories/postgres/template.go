@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// templateColumns lists the message_templates table columns in scan order
+var templateColumns = []string{"id", "name", "body", "version", "created_at", "updated_at"}
+
+// TemplateRepository implements the TemplateRepository interface using PostgreSQL
+type TemplateRepository struct {
+	pool *pgxpool.Pool
+	qb   squirrel.StatementBuilderType
+}
+
+// NewTemplateRepository creates a new PostgreSQL template repository
+func NewTemplateRepository(pool *pgxpool.Pool) repositories.TemplateRepository {
+	return &TemplateRepository{
+		pool: pool,
+		qb:   squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create stores a new template, failing with a conflict if its name is taken
+func (r *TemplateRepository) Create(ctx context.Context, template *message.Template) error {
+	query, args, err := r.qb.
+		Insert("message_templates").
+		Columns(templateColumns...).
+		Values(
+			template.ID.String(),
+			template.Name,
+			template.Body,
+			template.Version,
+			template.CreatedAt,
+			template.UpdatedAt,
+		).
+		Suffix("ON CONFLICT (name) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build insert query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewConflictError("template named %s already exists", template.Name)
+	}
+
+	return nil
+}
+
+// Update stores a new version of a template, only if the previous version is still the current one
+func (r *TemplateRepository) Update(ctx context.Context, template *message.Template) error {
+	query, args, err := r.qb.
+		Update("message_templates").
+		Set("body", template.Body).
+		Set("version", template.Version).
+		Set("updated_at", template.UpdatedAt).
+		Where(squirrel.Eq{"id": template.ID.String()}).
+		Where(squirrel.Lt{"version": template.Version}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build update query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, template.ID); err != nil {
+			return err
+		}
+		return errors.NewConflictError("template %s was changed concurrently", template.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a template
+func (r *TemplateRepository) Delete(ctx context.Context, id message.TemplateID) error {
+	query, args, err := r.qb.
+		Delete("message_templates").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+
+	if err != nil {
+		return errors.NewRepositoryError("failed to build delete query: %v", err)
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NewNotFoundError("template not found")
+	}
+
+	return nil
+}
+
+// GetByID retrieves the current version of a template
+func (r *TemplateRepository) GetByID(ctx context.Context, id message.TemplateID) (*message.Template, error) {
+	query, args, err := r.qb.
+		Select(templateColumns...).
+		From("message_templates").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	template, err := scanTemplate(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, errors.MapNotFoundError(err, "template")
+	}
+
+	return template, nil
+}
+
+// List retrieves templates ordered by name
+func (r *TemplateRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Template, error) {
+	if pagination.Limit <= 0 {
+		return nil, errors.NewValidationError("limit must be greater than zero")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	query, args, err := r.qb.
+		Select(templateColumns...).
+		From("message_templates").
+		OrderBy("name ASC").
+		Limit(uint64(pagination.Limit)).
+		Offset(uint64(pagination.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("failed to build select query: %v", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*message.Template
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// Count returns the total number of templates
+func (r *TemplateRepository) Count(ctx context.Context) (int64, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("message_templates").
+		ToSql()
+
+	if err != nil {
+		return 0, errors.NewRepositoryError("failed to build count query: %v", err)
+	}
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// scanTemplate scans a single row into a template
+func scanTemplate(row pgx.Row) (*message.Template, error) {
+	var (
+		template message.Template
+		id       string
+	)
+
+	if err := row.Scan(&id, &template.Name, &template.Body, &template.Version, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	template.ID = message.TemplateID(id)
+	return &template, nil
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
+	"go.uber.org/zap"
+)
+
+// rateLimitKeyPrefix namespaces token buckets in Redis
+const rateLimitKeyPrefix = "rate_limit:"
+
+// takeTokenScript refills and takes from a token bucket atomically, using the Redis clock so that
+// replicas with skewed clocks share one bucket. It returns whether a token was taken and the
+// milliseconds until the next one is added.
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1])
+local updated_at = tonumber(bucket[2])
+if tokens == nil or updated_at == nil then
+  tokens = burst
+  updated_at = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - updated_at) * rate / 1000)
+
+local taken = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  taken = 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {taken, wait}
+`)
+
+// RedisRateLimiter implements the RateLimiter interface with token buckets shared in Redis
+type RedisRateLimiter struct {
+	client   *redis.Client
+	fallback *localRateLimiter
+	degraded atomic.Bool
+}
+
+// NewRedisRateLimiter creates a rate limiter whose buckets are shared by every replica using the same Redis.
+// One limiter serves any number of buckets over a single connection pool; while Redis is unavailable each
+// replica falls back to in-process buckets allowing the FallbackShare of their limits.
+func NewRedisRateLimiter(config services.CacheConfig) *RedisRateLimiter {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Password: config.Password,
+		DB:       config.DB,
+	})
+
+	return &RedisRateLimiter{
+		client:   rdb,
+		fallback: newLocalRateLimiter(),
+	}
+}
+
+// Close closes the connections to Redis
+func (r *RedisRateLimiter) Close() error {
+	return r.client.Close()
+}
+
+// Take takes a token from the shared bucket of key, or from the in-process bucket while Redis is unavailable
+func (r *RedisRateLimiter) Take(ctx context.Context, key string, limit services.RateLimit) (bool, time.Duration, error) {
+	limit = normalizeRateLimit(limit)
+
+	result, err := takeTokenScript.Run(ctx, r.client, []string{rateLimitKeyPrefix + key}, limit.Rate, limit.Burst).Int64Slice()
+	if err != nil || len(result) != 2 {
+		if ctx.Err() != nil {
+			return false, 0, ctx.Err()
+		}
+
+		if !r.degraded.Swap(true) {
+			logger.WarnCtx(ctx, "Rate limiter cannot reach Redis, limiting in process",
+				zap.String("key", key),
+				zap.Float64("fallback_share", limit.FallbackShare),
+				zap.Error(err),
+			)
+		}
+
+		return r.fallback.Take(ctx, key, services.RateLimit{
+			Rate:  limit.Rate * limit.FallbackShare,
+			Burst: int(math.Ceil(float64(limit.Burst) * limit.FallbackShare)),
+		})
+	}
+
+	if r.degraded.Swap(false) {
+		logger.InfoCtx(ctx, "Rate limiter reached Redis again", zap.String("key", key))
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// localBucket is an in-process token bucket
+type localBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// localRateLimiter implements the RateLimiter interface with token buckets held by this process only
+type localRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*localBucket
+}
+
+// NewLocalRateLimiter creates a rate limiter whose buckets are not shared with other replicas
+func NewLocalRateLimiter() services.RateLimiter {
+	return newLocalRateLimiter()
+}
+
+// newLocalRateLimiter creates an in-process rate limiter, also used as the Redis fallback
+func newLocalRateLimiter() *localRateLimiter {
+	return &localRateLimiter{
+		buckets: make(map[string]*localBucket),
+	}
+}
+
+// Take takes a token from the in-process bucket of key
+func (l *localRateLimiter) Take(ctx context.Context, key string, limit services.RateLimit) (bool, time.Duration, error) {
+	limit = normalizeRateLimit(limit)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &localBucket{tokens: float64(limit.Burst), updatedAt: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*limit.Rate)
+	bucket.updatedAt = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+
+	wait := time.Duration(math.Ceil((1 - bucket.tokens) / limit.Rate * float64(time.Second)))
+	return false, wait, nil
+}
+
+// normalizeRateLimit makes sure a bucket always holds at least one token and refills
+func normalizeRateLimit(limit services.RateLimit) services.RateLimit {
+	if limit.Rate <= 0 {
+		limit.Rate = 1
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	if limit.FallbackShare <= 0 || limit.FallbackShare > 1 {
+		limit.FallbackShare = 1
+	}
+
+	return limit
+}
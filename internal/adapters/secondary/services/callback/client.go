@@ -0,0 +1,89 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
+	"go.uber.org/zap"
+)
+
+// Headers carried by every status-change callback
+const (
+	headerEventID   = "X-Dispatcher-Event-Id"
+	headerTimestamp = "X-Dispatcher-Timestamp"
+	headerSignature = "X-Dispatcher-Signature"
+)
+
+// maxResponseExcerpt bounds how much of an error response is kept in the delivery log
+const maxResponseExcerpt = 512
+
+// httpEventCallbackService implements the EventCallbackService interface
+type httpEventCallbackService struct {
+	client *http.Client
+}
+
+// NewEventCallbackService creates a new event callback service with HTTP client
+func NewEventCallbackService(config services.EventCallbackConfig) services.EventCallbackService {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second // Default fallback
+	}
+
+	return &httpEventCallbackService{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Send posts a signed event to a subscription endpoint once; retries are scheduled by the caller
+func (s *httpEventCallbackService) Send(ctx context.Context, callback services.EventCallback) (int, error) {
+	start := time.Now()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", callback.URL, bytes.NewReader(callback.Body))
+	if err != nil {
+		return 0, domainErrors.NewValidationError("failed to create HTTP request: %v", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "Message-Dispatcher/1.0")
+	httpReq.Header.Set(headerEventID, callback.EventID)
+	httpReq.Header.Set(headerTimestamp, strconv.FormatInt(callback.Timestamp.Unix(), 10))
+	httpReq.Header.Set(headerSignature, callback.Signature)
+
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		logger.WarnCtx(ctx, "Event callback request failed",
+			zap.String("url", callback.URL),
+			zap.String("event_id", callback.EventID),
+			zap.Error(err),
+			zap.Int64("duration_ms", time.Since(start).Milliseconds()),
+		)
+		return 0, domainErrors.NewBusinessError("HTTP request failed: %v", err)
+	}
+	defer httpResp.Body.Close()
+
+	// Only an excerpt is needed for the delivery log; drain the rest so the connection can be reused
+	excerpt, _ := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseExcerpt))
+	io.Copy(io.Discard, httpResp.Body)
+
+	logger.InfoCtx(ctx, "Event callback request completed",
+		zap.String("url", callback.URL),
+		zap.String("event_id", callback.EventID),
+		zap.Int("status_code", httpResp.StatusCode),
+		zap.Int64("duration_ms", time.Since(start).Milliseconds()),
+	)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return httpResp.StatusCode, domainErrors.NewBusinessError("endpoint returned HTTP %d: %s", httpResp.StatusCode, string(excerpt))
+	}
+
+	return httpResp.StatusCode, nil
+}
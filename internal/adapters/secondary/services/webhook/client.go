@@ -7,10 +7,15 @@ import (
 	"errors"
 	"io"
 	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
 	"go.uber.org/zap"
@@ -18,17 +23,21 @@ import (
 
 // httpWebhookService implements the WebhookService interface
 type httpWebhookService struct {
-	client *http.Client
-	config services.WebhookConfig
+	client      *http.Client
+	config      services.WebhookConfig
+	attemptRepo repositories.MessageAttemptRepository
+	gate        *tokenGate // Throughput limit of the endpoint, nil if it has none
 }
 
-// NewWebhookService creates a new webhook service with HTTP client
-func NewWebhookService(config services.WebhookConfig) services.WebhookService {
+// NewWebhookService creates a new webhook service with HTTP client.
+// Every request is logged to attemptRepo when it is set.
+func NewWebhookService(config services.WebhookConfig, attemptRepo repositories.MessageAttemptRepository) services.WebhookService {
 	return &httpWebhookService{
 		client: &http.Client{
 			Timeout: config.Timeout,
 		},
-		config: config,
+		config:      config,
+		attemptRepo: attemptRepo,
 	}
 }
 
@@ -45,10 +54,11 @@ func (s *httpWebhookService) SendMessage(ctx context.Context, request services.W
 	}
 
 	// Try initial request + retries
+	attempts := 0
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Add delay for retry attempts (not for first attempt)
+		// Add delay for retry attempts (not for first attempt), at least as long as the endpoint asked for
 		if attempt > 0 {
-			delay := s.calculateBackoffDelay(attempt)
+			delay := max(s.calculateBackoffDelay(attempt), retryAfter(lastErr))
 			logger.WarnCtx(ctx, "Webhook request failed, retrying",
 				zap.Int("attempt", attempt),
 				zap.Int("max_retries", maxRetries),
@@ -64,7 +74,24 @@ func (s *httpWebhookService) SendMessage(ctx context.Context, request services.W
 			}
 		}
 
-		response, err := s.sendSingleRequest(ctx, request)
+		if s.gate != nil {
+			if err := s.gate.wait(ctx, request); err != nil {
+				if attempt == 0 {
+					return nil, err
+				}
+				// Out of capacity for the retry, the delivery fails with the error it would have retried
+				logger.WarnCtx(ctx, "Webhook retry throttled",
+					zap.Int("attempt", attempt),
+					zap.Error(err),
+				)
+				break
+			}
+		}
+
+		attempts++
+		deliveryAttempt := message.NewAttempt(message.MessageID(request.MessageID), request.RetryCount, attempt+1, time.Now())
+		response, err := s.sendSingleRequest(ctx, request, deliveryAttempt)
+		s.recordAttempt(ctx, deliveryAttempt, err)
 		if err == nil {
 			// Success!
 			if attempt > 0 {
@@ -98,11 +125,11 @@ func (s *httpWebhookService) SendMessage(ctx context.Context, request services.W
 		}
 	}
 
-	return nil, domainErrors.NewBusinessError("webhook request failed after %d attempts: %v", maxRetries+1, lastErr)
+	return nil, domainErrors.NewBusinessErrorWithCause(lastErr, "webhook request failed after %d attempts: %v", attempts, lastErr)
 }
 
-// sendSingleRequest makes a single HTTP request to the webhook endpoint
-func (s *httpWebhookService) sendSingleRequest(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+// sendSingleRequest makes a single HTTP request to the webhook endpoint, storing the response on the attempt
+func (s *httpWebhookService) sendSingleRequest(ctx context.Context, request services.WebhookRequest, attempt *message.Attempt) (*services.WebhookResponse, error) {
 	start := time.Now()
 
 	// Prepare request body
@@ -133,7 +160,7 @@ func (s *httpWebhookService) sendSingleRequest(ctx context.Context, request serv
 			zap.Error(err),
 			zap.Int64("duration_ms", duration.Milliseconds()),
 		)
-		return nil, domainErrors.NewBusinessError("HTTP request failed: %v", err)
+		return nil, services.NewWebhookError(classifyTransportError(err), 0, domainErrors.NewBusinessError("HTTP request failed: %v", err))
 	}
 	defer httpResp.Body.Close()
 
@@ -147,9 +174,11 @@ func (s *httpWebhookService) sendSingleRequest(ctx context.Context, request serv
 			zap.Int("status_code", httpResp.StatusCode),
 			zap.Error(err),
 		)
-		return nil, domainErrors.NewBusinessError("failed to read response body: %v", err)
+		return nil, services.NewWebhookError(classifyTransportError(err), httpResp.StatusCode, domainErrors.NewBusinessError("failed to read response body: %v", err))
 	}
 
+	attempt.RecordResponse(httpResp.StatusCode, string(respBody))
+
 	// Log request completion
 	logger.InfoCtx(ctx, "Webhook request completed",
 		zap.String("url", s.config.URL),
@@ -160,19 +189,72 @@ func (s *httpWebhookService) sendSingleRequest(ctx context.Context, request serv
 
 	// Check HTTP status
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		return nil, domainErrors.NewBusinessError("webhook returned HTTP %d: %s", httpResp.StatusCode, string(respBody))
+		webhookErr := services.NewWebhookError(
+			message.ErrorClassForHTTPStatus(httpResp.StatusCode),
+			httpResp.StatusCode,
+			domainErrors.NewBusinessError("webhook returned HTTP %d: %s", httpResp.StatusCode, string(respBody)),
+		)
+		webhookErr.RetryAfter = parseRetryAfter(httpResp.Header.Get("Retry-After"), time.Now())
+		return nil, webhookErr
 	}
 
 	// Parse response
 	var webhookResp services.WebhookResponse
 	if err := json.Unmarshal(respBody, &webhookResp); err != nil {
-		return nil, domainErrors.NewBusinessError("failed to parse webhook response - Raw body: '%s', Error: %v", string(respBody), err)
+		return nil, services.NewWebhookError(
+			message.ErrorClassInvalidResponse,
+			httpResp.StatusCode,
+			domainErrors.NewBusinessError("failed to parse webhook response - Raw body: '%s', Error: %v", string(respBody), err),
+		)
 	}
 
 	return &webhookResp, nil
 }
 
-// shouldRetryError determines if an error should be retried based on HTTP status code
+// recordAttempt completes the attempt with its outcome and stores it in the delivery log.
+// Logging failures never fail the delivery itself.
+func (s *httpWebhookService) recordAttempt(ctx context.Context, attempt *message.Attempt, err error) {
+	attempt.Finish(time.Since(attempt.StartedAt))
+	if s.config.Provider != "" {
+		provider := s.config.Provider
+		attempt.Provider = &provider
+	}
+	if err != nil {
+		class := message.ErrorClassUnknown
+		var webhookErr *services.WebhookError
+		if errors.As(err, &webhookErr) {
+			class = webhookErr.Class
+		}
+		attempt.RecordError(class, err.Error())
+	}
+
+	if s.attemptRepo == nil || attempt.MessageID.IsEmpty() {
+		return
+	}
+
+	// Keep the log complete even if the delivery was cancelled meanwhile
+	if err := s.attemptRepo.Create(context.WithoutCancel(ctx), attempt); err != nil {
+		logger.WarnCtx(ctx, "Failed to record webhook attempt",
+			zap.String("message_id", attempt.MessageID.String()),
+			zap.Int("attempt_number", attempt.AttemptNumber),
+			zap.Error(err),
+		)
+	}
+}
+
+// classifyTransportError distinguishes timeouts from other network failures
+func classifyTransportError(err error) message.ErrorClass {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return message.ErrorClassTimeout
+	}
+
+	return message.ErrorClassNetwork
+}
+
+// shouldRetryError determines if an error should be retried within this call.
+// Permanent failures are never retried, and failures whose Retry-After is longer than the
+// backoff limit are left to the message-level retry instead of holding the worker.
 func (s *httpWebhookService) shouldRetryError(err error) bool {
 	// Don't retry validation errors
 	var validationErr domainErrors.ValidationError
@@ -180,9 +262,50 @@ func (s *httpWebhookService) shouldRetryError(err error) bool {
 		return false
 	}
 
+	var webhookErr *services.WebhookError
+	if errors.As(err, &webhookErr) {
+		if webhookErr.Permanent {
+			return false
+		}
+		if webhookErr.RetryAfter > s.maxBackoffDelay() {
+			return false
+		}
+	}
+
 	return true
 }
 
+// retryAfter returns the delay the endpoint asked for with the failure, 0 if none
+func retryAfter(err error) time.Duration {
+	var webhookErr *services.WebhookError
+	if errors.As(err, &webhookErr) {
+		return webhookErr.RetryAfter
+	}
+
+	return 0
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
 // calculateBackoffDelay calculates the delay for retry attempts using exponential backoff
 func (s *httpWebhookService) calculateBackoffDelay(attempt int) time.Duration {
 	// Calculate exponential backoff: base * 2^(attempt-1)
@@ -195,11 +318,7 @@ func (s *httpWebhookService) calculateBackoffDelay(attempt int) time.Duration {
 	exponentialDelay := float64(baseDelay) * math.Pow(2, float64(attempt-1))
 
 	// Apply maximum delay limit
-	maxDelay := s.config.RetryBackoffMax
-	if maxDelay <= 0 {
-		maxDelay = 5 * time.Second // Default fallback
-	}
-
+	maxDelay := s.maxBackoffDelay()
 	if exponentialDelay > float64(maxDelay) {
 		exponentialDelay = float64(maxDelay)
 	}
@@ -207,6 +326,15 @@ func (s *httpWebhookService) calculateBackoffDelay(attempt int) time.Duration {
 	return time.Duration(exponentialDelay)
 }
 
+// maxBackoffDelay returns the longest delay between attempts of a single call
+func (s *httpWebhookService) maxBackoffDelay() time.Duration {
+	if s.config.RetryBackoffMax <= 0 {
+		return 5 * time.Second // Default fallback
+	}
+
+	return s.config.RetryBackoffMax
+}
+
 // IsHealthy checks if the webhook service is healthy and reachable
 func (s *httpWebhookService) IsHealthy(ctx context.Context) error {
 	if s.config.URL == "" {
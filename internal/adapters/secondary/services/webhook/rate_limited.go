@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
+	"go.uber.org/zap"
+)
+
+// tokenGate holds requests back once the endpoint's throughput limit is reached
+type tokenGate struct {
+	limiter services.RateLimiter
+	key     string
+	limit   services.RateLimit
+	maxWait time.Duration
+}
+
+// NewRateLimitedWebhookService creates a webhook service sending no faster than config.RateLimit allows for
+// config.Provider, counted by limiter across every replica. Every HTTP request takes a token, retries within a
+// delivery included. A delivery waits up to config.RateLimitMaxWait for its first token and otherwise fails
+// with a ThrottledError without reaching the endpoint; a retry that gets no token in time ends the delivery
+// with the failure it would have retried.
+func NewRateLimitedWebhookService(config services.WebhookConfig, attemptRepo repositories.MessageAttemptRepository, limiter services.RateLimiter) services.WebhookService {
+	// Providers sharing a limiter each get their own bucket, named after the URL when no provider is given
+	bucket := config.Provider
+	if bucket == "" {
+		bucket = config.URL
+	}
+
+	return &httpWebhookService{
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+		config:      config,
+		attemptRepo: attemptRepo,
+		gate: &tokenGate{
+			limiter: limiter,
+			key:     "webhook:" + bucket,
+			limit:   config.RateLimit,
+			maxWait: config.RateLimitMaxWait,
+		},
+	}
+}
+
+// wait returns once a token was taken, or with a ThrottledError if none is available within the maximum wait
+func (g *tokenGate) wait(ctx context.Context, request services.WebhookRequest) error {
+	deadline := time.Now().Add(g.maxWait)
+
+	for {
+		taken, retryAfter, err := g.limiter.Take(ctx, g.key, g.limit)
+		if err != nil {
+			// Running out of time while asking for a token is no delivery failure
+			if ctx.Err() != nil {
+				return services.NewThrottledError(0)
+			}
+			return err
+		}
+		if taken {
+			return nil
+		}
+
+		if time.Now().Add(retryAfter).After(deadline) {
+			logger.DebugCtx(ctx, "Webhook request throttled",
+				zap.String("message_id", request.MessageID),
+				zap.Duration("retry_after", retryAfter),
+			)
+			return services.NewThrottledError(retryAfter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return services.NewThrottledError(retryAfter)
+		case <-time.After(retryAfter):
+		}
+	}
+}
@@ -28,6 +28,11 @@ type RepositoryError interface {
 	IsRepositoryError() // Marker method
 }
 
+type ConflictError interface {
+	error
+	IsConflictError() // Marker method
+}
+
 // Concrete implementations
 type validationError struct {
 	message string
@@ -65,6 +70,15 @@ func (e repositoryError) Error() string      { return e.message }
 func (e repositoryError) Unwrap() error      { return e.cause }
 func (e repositoryError) IsRepositoryError() {} // Marker method implementation
 
+type conflictError struct {
+	message string
+	cause   error
+}
+
+func (e conflictError) Error() string    { return e.message }
+func (e conflictError) Unwrap() error    { return e.cause }
+func (e conflictError) IsConflictError() {} // Marker method implementation
+
 // Factory Functions - sprintf style
 func NewValidationError(format string, args ...interface{}) ValidationError {
 	return validationError{
@@ -118,6 +132,19 @@ func NewRepositoryErrorWithCause(cause error, format string, args ...interface{}
 	}
 }
 
+func NewConflictError(format string, args ...interface{}) ConflictError {
+	return conflictError{
+		message: fmt.Sprintf("conflict: "+format, args...),
+	}
+}
+
+func NewConflictErrorWithCause(cause error, format string, args ...interface{}) ConflictError {
+	return conflictError{
+		message: fmt.Sprintf("conflict: "+format, args...),
+		cause:   cause,
+	}
+}
+
 // MapNotFoundError converts pgx.ErrNoRows to business not found error
 func MapNotFoundError(err error, context string) error {
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -0,0 +1,86 @@
+package message
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxResponseExcerptLength bounds how much of a webhook response body is kept per attempt
+const MaxResponseExcerptLength = 1000
+
+// AttemptID represents a unique identifier for a delivery attempt
+type AttemptID string
+
+// NewAttemptID generates a new unique attempt ID
+func NewAttemptID() AttemptID {
+	return AttemptID(uuid.New().String())
+}
+
+// String returns the string representation of AttemptID
+func (id AttemptID) String() string {
+	return string(id)
+}
+
+// Attempt records a single webhook request made to deliver a message
+type Attempt struct {
+	ID            AttemptID
+	MessageID     MessageID
+	Provider      *string // Webhook provider the request was sent to, if known
+	RetryCount    int     // Message-level retry the attempt belongs to, 0 for the first delivery
+	AttemptNumber int     // Webhook request number within the delivery, starting at 1
+	StartedAt     time.Time
+	Duration      time.Duration
+	HTTPStatus    *int
+	ResponseBody  *string // Excerpt of the response body, if a response was received
+	Error         *string
+	ErrorClass    *ErrorClass
+}
+
+// NewAttempt starts recording a webhook request for the given message
+func NewAttempt(messageID MessageID, retryCount, attemptNumber int, startedAt time.Time) *Attempt {
+	return &Attempt{
+		ID:            NewAttemptID(),
+		MessageID:     messageID,
+		RetryCount:    retryCount,
+		AttemptNumber: attemptNumber,
+		StartedAt:     startedAt,
+	}
+}
+
+// RecordResponse stores the HTTP status and an excerpt of the body returned by the endpoint
+func (a *Attempt) RecordResponse(httpStatus int, body string) {
+	excerpt := truncate(body, MaxResponseExcerptLength)
+	a.HTTPStatus = &httpStatus
+	a.ResponseBody = &excerpt
+}
+
+// RecordError stores why the attempt failed
+func (a *Attempt) RecordError(class ErrorClass, reason string) {
+	if !class.IsValid() {
+		class = ErrorClassUnknown
+	}
+	reason = truncate(reason, MaxLastErrorLength)
+
+	a.Error = &reason
+	a.ErrorClass = &class
+}
+
+// Finish stores how long the attempt took
+func (a *Attempt) Finish(duration time.Duration) {
+	a.Duration = duration
+}
+
+// IsSuccessful checks if the attempt completed without an error
+func (a *Attempt) IsSuccessful() bool {
+	return a.Error == nil
+}
+
+// truncate cuts s to at most maxLength bytes without splitting a UTF-8 sequence
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	return strings.ToValidUTF8(s[:maxLength], "")
+}
@@ -0,0 +1,96 @@
+package message
+
+import (
+	"strings"
+	"time"
+)
+
+// Delivery receipt limits
+const (
+	maxReceiptExternalIDLength = 255
+	maxCarrierErrorCodeLength  = 50
+)
+
+// DeliveryReceipt is the carrier's final word on a sent message, reported by the provider
+// under the external ID it returned when accepting the message
+type DeliveryReceipt struct {
+	ExternalID string
+	Status     Status  // DELIVERED or UNDELIVERED
+	ErrorCode  *string // Carrier error code of an undelivered message, if given
+	ReportedAt time.Time
+	ReceivedAt time.Time
+
+	MessageID *MessageID // Set once the receipt has been applied to its message
+	AppliedAt *time.Time
+}
+
+// NewDeliveryReceipt creates a delivery receipt. A zero reportedAt means the outcome was reported just now.
+func NewDeliveryReceipt(externalID string, status Status, errorCode string, reportedAt time.Time) (*DeliveryReceipt, error) {
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return nil, NewValidationError("external ID is required")
+	}
+	if len(externalID) > maxReceiptExternalIDLength {
+		return nil, NewValidationError("external ID too long: %d characters (max: %d)", len(externalID), maxReceiptExternalIDLength)
+	}
+
+	if status != StatusDelivered && status != StatusUndelivered {
+		return nil, NewValidationError("invalid delivery status: %s (must be %s or %s)", status, StatusDelivered, StatusUndelivered)
+	}
+
+	errorCode = strings.TrimSpace(errorCode)
+	if len(errorCode) > maxCarrierErrorCodeLength {
+		return nil, NewValidationError("carrier error code too long: %d characters (max: %d)", len(errorCode), maxCarrierErrorCodeLength)
+	}
+
+	now := time.Now()
+	if reportedAt.IsZero() {
+		reportedAt = now
+	}
+
+	receipt := &DeliveryReceipt{
+		ExternalID: externalID,
+		Status:     status,
+		ReportedAt: reportedAt,
+		ReceivedAt: now,
+	}
+	if errorCode != "" {
+		receipt.ErrorCode = &errorCode
+	}
+
+	return receipt, nil
+}
+
+// IsApplied checks if the receipt has been applied to its message
+func (r *DeliveryReceipt) IsApplied() bool {
+	return r.AppliedAt != nil
+}
+
+// MarkApplied records that the receipt has been applied to the given message
+func (r *DeliveryReceipt) MarkApplied(messageID MessageID) {
+	now := time.Now()
+	r.MessageID = &messageID
+	r.AppliedAt = &now
+}
+
+// ApplyDeliveryReceipt moves a sent message to DELIVERED or UNDELIVERED.
+// It reports false without changing anything if the message already has a final delivery outcome,
+// so a receipt can safely be applied more than once.
+func (m *Message) ApplyDeliveryReceipt(receipt *DeliveryReceipt) (bool, error) {
+	if m.Status == StatusDelivered || m.Status == StatusUndelivered {
+		return false, nil
+	}
+
+	if m.Status != StatusSent {
+		return false, NewInvalidStatusTransitionError(m.Status, receipt.Status)
+	}
+
+	now := time.Now()
+	m.transitionTo(receipt.Status, now)
+	m.CarrierErrorCode = receipt.ErrorCode
+	reportedAt := receipt.ReportedAt
+	m.DeliveryReportedAt = &reportedAt
+	m.UpdatedAt = now
+
+	return true, nil
+}
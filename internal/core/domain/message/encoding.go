@@ -0,0 +1,88 @@
+package message
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Encoding represents the character set an SMS is sent with
+type Encoding string
+
+const (
+	EncodingGSM7 Encoding = "GSM-7"
+	EncodingUCS2 Encoding = "UCS-2"
+)
+
+// String returns the string representation of Encoding
+func (e Encoding) String() string {
+	return string(e)
+}
+
+// SMS segment sizes, in septets for GSM-7 and UTF-16 code units for UCS-2.
+// Parts of a concatenated message lose room to the user data header.
+const (
+	gsm7SingleSegment = 160
+	gsm7MultiSegment  = 153
+	ucs2SingleSegment = 70
+	ucs2MultiSegment  = 67
+)
+
+// GSM 03.38 character sets. Characters of the extension table are sent as an escape plus the character.
+const (
+	gsm7BasicChars     = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7ExtensionChars = "\f^{}\\[~]|€"
+)
+
+// Encoding returns GSM-7 if every character of the content is in the GSM 03.38 alphabet, otherwise UCS-2
+func (c Content) Encoding() Encoding {
+	for _, r := range string(c) {
+		if !strings.ContainsRune(gsm7BasicChars, r) && !strings.ContainsRune(gsm7ExtensionChars, r) {
+			return EncodingUCS2
+		}
+	}
+	return EncodingGSM7
+}
+
+// Segments returns the number of SMS parts needed to send the content.
+// A character never straddles two parts, so a part may be sent slightly short of its size.
+func (c Content) Segments() int {
+	encoding := c.Encoding()
+
+	single, multi := gsm7SingleSegment, gsm7MultiSegment
+	if encoding == EncodingUCS2 {
+		single, multi = ucs2SingleSegment, ucs2MultiSegment
+	}
+
+	total := 0
+	for _, r := range string(c) {
+		total += encoding.units(r)
+	}
+	if total == 0 {
+		return 0
+	}
+	if total <= single {
+		return 1
+	}
+
+	segments, used := 1, 0
+	for _, r := range string(c) {
+		units := encoding.units(r)
+		if used+units > multi {
+			segments++
+			used = 0
+		}
+		used += units
+	}
+	return segments
+}
+
+// units returns the number of septets or code units a character takes in the encoding
+func (e Encoding) units(r rune) int {
+	if e == EncodingUCS2 {
+		return utf16.RuneLen(r)
+	}
+	if strings.ContainsRune(gsm7ExtensionChars, r) {
+		return 2
+	}
+	return 1
+}
@@ -1,6 +1,7 @@
 package message
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,9 +11,15 @@ import (
 type Status string
 
 const (
-	StatusPending Status = "PENDING"
-	StatusSent    Status = "SENT"
-	StatusFailed  Status = "FAILED"
+	StatusPending     Status = "PENDING"
+	StatusProcessing  Status = "PROCESSING"
+	StatusSent        Status = "SENT"
+	StatusFailed      Status = "FAILED"
+	StatusExpired     Status = "EXPIRED"
+	StatusCancelled   Status = "CANCELLED"
+	StatusSuppressed  Status = "SUPPRESSED"
+	StatusDelivered   Status = "DELIVERED"   // The carrier confirmed delivery to the handset
+	StatusUndelivered Status = "UNDELIVERED" // The carrier reported that delivery failed
 )
 
 // String returns the string representation of Status
@@ -23,13 +30,142 @@ func (s Status) String() string {
 // IsValid checks if the status is valid
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusPending, StatusSent, StatusFailed:
+	case StatusPending, StatusProcessing, StatusSent, StatusFailed, StatusExpired, StatusCancelled, StatusSuppressed,
+		StatusDelivered, StatusUndelivered:
 		return true
 	default:
 		return false
 	}
 }
 
+// ErrorClass categorizes why a delivery attempt failed
+type ErrorClass string
+
+const (
+	ErrorClassTimeout         ErrorClass = "timeout"
+	ErrorClassNetwork         ErrorClass = "network"
+	ErrorClassClientError     ErrorClass = "http_4xx"
+	ErrorClassServerError     ErrorClass = "http_5xx"
+	ErrorClassInvalidResponse ErrorClass = "invalid_response"
+	ErrorClassUnknown         ErrorClass = "unknown"
+)
+
+// String returns the string representation of ErrorClass
+func (c ErrorClass) String() string {
+	return string(c)
+}
+
+// IsValid checks if the error class is valid
+func (c ErrorClass) IsValid() bool {
+	switch c {
+	case ErrorClassTimeout, ErrorClassNetwork, ErrorClassClientError,
+		ErrorClassServerError, ErrorClassInvalidResponse, ErrorClassUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrorClassForHTTPStatus returns the error class for a non-successful HTTP status code
+func ErrorClassForHTTPStatus(statusCode int) ErrorClass {
+	switch {
+	case statusCode >= 400 && statusCode < 500:
+		return ErrorClassClientError
+	case statusCode >= 500:
+		return ErrorClassServerError
+	default:
+		return ErrorClassInvalidResponse
+	}
+}
+
+// IsPermanentHTTPStatus reports whether repeating a request answered with this status cannot succeed.
+// That is every client error except 408 Request Timeout, 425 Too Early and 429 Too Many Requests.
+func IsPermanentHTTPStatus(statusCode int) bool {
+	switch statusCode {
+	case 408, 425, 429:
+		return false
+	default:
+		return statusCode >= 400 && statusCode < 500
+	}
+}
+
+// Priority determines the order in which due messages are sent
+type Priority string
+
+const (
+	PriorityCritical Priority = "critical" // Messages that are useless when late, e.g. one-time passwords
+	PriorityHigh     Priority = "high"
+	PriorityNormal   Priority = "normal" // Used when no priority is given
+	PriorityBulk     Priority = "bulk"   // Traffic that can wait, e.g. marketing campaigns
+)
+
+// priorities lists every priority, most urgent first
+var priorities = []Priority{PriorityCritical, PriorityHigh, PriorityNormal, PriorityBulk}
+
+// ParsePriority converts a priority name (any case) into a Priority, defaulting to normal when empty
+func ParsePriority(name string) (Priority, error) {
+	if name == "" {
+		return PriorityNormal, nil
+	}
+
+	priority := Priority(strings.ToLower(strings.TrimSpace(name)))
+	if !priority.IsValid() {
+		return "", NewValidationError("invalid priority: %s (must be critical, high, normal or bulk)", name)
+	}
+
+	return priority, nil
+}
+
+// PriorityFromRank returns the priority with the given rank
+func PriorityFromRank(rank int) (Priority, error) {
+	if rank < 0 || rank >= len(priorities) {
+		return "", NewValidationError("invalid priority rank: %d", rank)
+	}
+
+	return priorities[rank], nil
+}
+
+// UrgentPriorities returns the priorities that may use the delivery capacity reserved for urgent traffic
+func UrgentPriorities() []Priority {
+	return []Priority{PriorityCritical, PriorityHigh}
+}
+
+// RegularPriorities returns the priorities that are limited to the unreserved delivery capacity
+func RegularPriorities() []Priority {
+	return []Priority{PriorityNormal, PriorityBulk}
+}
+
+// String returns the string representation of Priority
+func (p Priority) String() string {
+	return string(p)
+}
+
+// IsValid checks if the priority is valid
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityCritical, PriorityHigh, PriorityNormal, PriorityBulk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank orders priorities for sending, 0 being the most urgent
+func (p Priority) Rank() int {
+	for rank, priority := range priorities {
+		if priority == p {
+			return rank
+		}
+	}
+
+	return PriorityNormal.Rank()
+}
+
+// IsUrgent checks if the priority may use the delivery capacity reserved for urgent traffic
+func (p Priority) IsUrgent() bool {
+	return p == PriorityCritical || p == PriorityHigh
+}
+
 // MessageID represents a unique identifier for a message
 type MessageID string
 
@@ -50,15 +186,34 @@ func (id MessageID) IsEmpty() bool {
 
 // Message represents the core business entity for messages
 type Message struct {
-	ID          MessageID
-	PhoneNumber PhoneNumber
-	Content     Content
-	Status      Status
-	ExternalID  *string
-	RetryCount  int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	SentAt      *time.Time
+	ID             MessageID
+	PhoneNumber    PhoneNumber
+	Content        Content
+	Status         Status
+	Priority       Priority
+	ExternalID     *string
+	Provider       *string // Webhook provider that accepted the message
+	RetryCount     int
+	SendAt         *time.Time
+	ExpiresAt      *time.Time
+	NextAttemptAt  *time.Time
+	LockedBy       *string
+	LeaseUntil     *time.Time
+	AttemptCount   int
+	LastError      *string
+	LastErrorClass *ErrorClass
+	LastHTTPStatus *int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SentAt         *time.Time
+
+	TemplateID      *TemplateID // Template the content was rendered from, if any
+	TemplateVersion *int        // Version of that template at render time
+
+	CarrierErrorCode   *string    // Error code of an UNDELIVERED delivery receipt, if the carrier gave one
+	DeliveryReportedAt *time.Time // When the carrier reported the final delivery outcome
+
+	statusChanges []StatusChange
 }
 
 // NewMessage creates a new message with the provided phone number and content
@@ -72,29 +227,181 @@ func NewMessage(phoneNumber PhoneNumber, content Content) (*Message, error) {
 	}
 
 	now := time.Now()
+	id := NewMessageID()
 	return &Message{
-		ID:          NewMessageID(),
+		ID:          id,
 		PhoneNumber: phoneNumber,
 		Content:     content,
 		Status:      StatusPending,
+		Priority:    PriorityNormal,
 		RetryCount:  0,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		statusChanges: []StatusChange{
+			{MessageID: id, To: StatusPending, OccurredAt: now},
+		},
 	}, nil
 }
 
+// NewTemplatedMessage creates a new message whose content is rendered from a template version
+func NewTemplatedMessage(phoneNumber PhoneNumber, template *Template, variables map[string]string) (*Message, error) {
+	content, err := template.Render(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := NewMessage(phoneNumber, content)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, version := template.ID, template.Version
+	msg.TemplateID = &templateID
+	msg.TemplateVersion = &version
+
+	return msg, nil
+}
+
 // Business methods
 
-// MarkAsSent marks the message as successfully sent
-func (m *Message) MarkAsSent(externalID string) error {
+// Schedule defers delivery of a pending message until the given time
+func (m *Message) Schedule(sendAt time.Time) error {
+	if m.Status != StatusPending {
+		return NewInvalidScheduleError(m.Status)
+	}
+
+	if sendAt.IsZero() {
+		return NewValidationError("send time cannot be empty")
+	}
+
+	m.SendAt = &sendAt
+	m.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetPriority changes the sending priority of a pending message
+func (m *Message) SetPriority(priority Priority) error {
+	if m.Status != StatusPending {
+		return NewInvalidPriorityError(m.Status)
+	}
+
+	if !priority.IsValid() {
+		return NewValidationError("invalid priority: %s", priority)
+	}
+
+	m.Priority = priority
+	m.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetExpiry sets the time after which a pending message must no longer be sent
+func (m *Message) SetExpiry(expiresAt time.Time) error {
 	if m.Status != StatusPending {
+		return NewInvalidExpiryError(m.Status)
+	}
+
+	if expiresAt.IsZero() {
+		return NewValidationError("expiry time cannot be empty")
+	}
+
+	if m.SendAt != nil && !expiresAt.After(*m.SendAt) {
+		return NewValidationError("expiry time must be after send time")
+	}
+
+	m.ExpiresAt = &expiresAt
+	m.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IsExpired checks if the message expiry time has passed
+func (m *Message) IsExpired(now time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(now)
+}
+
+// IsDue checks if the message send time has been reached
+func (m *Message) IsDue(now time.Time) bool {
+	return m.SendAt == nil || !m.SendAt.After(now)
+}
+
+// IsReadyForAttempt checks if the message is due and not waiting for a retry backoff
+func (m *Message) IsReadyForAttempt(now time.Time) bool {
+	return m.IsDue(now) && (m.NextAttemptAt == nil || !m.NextAttemptAt.After(now))
+}
+
+// IsScheduled checks if the message is pending with a send time in the future
+func (m *Message) IsScheduled(now time.Time) bool {
+	return m.Status == StatusPending && !m.IsDue(now)
+}
+
+// Claim moves a pending message to processing, leased to the given worker until leaseUntil
+func (m *Message) Claim(workerID string, leaseUntil time.Time) error {
+	if m.Status != StatusPending {
+		return NewInvalidStatusTransitionError(m.Status, StatusProcessing)
+	}
+
+	if workerID == "" {
+		return NewValidationError("worker ID cannot be empty")
+	}
+
+	now := time.Now()
+	m.transitionTo(StatusProcessing, now)
+	m.LockedBy = &workerID
+	m.LeaseUntil = &leaseUntil
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// ReleaseClaim returns a processing message to the pending queue without counting an attempt
+func (m *Message) ReleaseClaim() error {
+	if m.Status != StatusProcessing {
+		return NewInvalidStatusTransitionError(m.Status, StatusPending)
+	}
+
+	now := time.Now()
+	m.transitionTo(StatusPending, now)
+	m.releaseLease()
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// IsLeaseExpired checks if a processing message's lease has run out
+func (m *Message) IsLeaseExpired(now time.Time) bool {
+	return m.Status == StatusProcessing && m.LeaseUntil != nil && !m.LeaseUntil.After(now)
+}
+
+// isDeliverable checks if the message is still waiting for or undergoing delivery
+func (m *Message) isDeliverable() bool {
+	return m.Status == StatusPending || m.Status == StatusProcessing
+}
+
+// releaseLease clears the processing lease held on the message
+func (m *Message) releaseLease() {
+	m.LockedBy = nil
+	m.LeaseUntil = nil
+}
+
+// MarkAsSent marks the message as successfully sent by the named provider; an empty provider is not recorded
+func (m *Message) MarkAsSent(externalID, provider string) error {
+	if !m.isDeliverable() {
 		return NewInvalidStatusTransitionError(m.Status, StatusSent)
 	}
 
 	now := time.Now()
-	m.Status = StatusSent
+	m.transitionTo(StatusSent, now)
 	m.ExternalID = &externalID
+	m.Provider = nil
+	if provider != "" {
+		m.Provider = &provider
+	}
+	m.AttemptCount++
 	m.SentAt = &now
+	m.NextAttemptAt = nil
+	m.releaseLease()
 	m.UpdatedAt = now
 
 	return nil
@@ -102,19 +409,124 @@ func (m *Message) MarkAsSent(externalID string) error {
 
 // MarkAsFailed marks the message as failed
 func (m *Message) MarkAsFailed() error {
-	if m.Status == StatusSent {
+	if m.Status == StatusSent || m.Status == StatusExpired || m.Status == StatusCancelled || m.Status == StatusSuppressed ||
+		m.Status == StatusDelivered || m.Status == StatusUndelivered {
 		return NewInvalidStatusTransitionError(m.Status, StatusFailed)
 	}
 
-	m.Status = StatusFailed
+	now := time.Now()
+	m.transitionTo(StatusFailed, now)
+	m.NextAttemptAt = nil
+	m.releaseLease()
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// MarkAsExpired marks an unsent message as expired so it is never sent
+func (m *Message) MarkAsExpired() error {
+	if !m.isDeliverable() {
+		return NewInvalidStatusTransitionError(m.Status, StatusExpired)
+	}
+
+	now := time.Now()
+	m.transitionTo(StatusExpired, now)
+	m.releaseLease()
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// Cancel withdraws a message that has not been picked up for delivery yet.
+// Only pending messages, including scheduled ones, can be cancelled.
+func (m *Message) Cancel() error {
+	if m.Status != StatusPending {
+		return NewInvalidCancelError(m.Status)
+	}
+
+	now := time.Now()
+	m.transitionTo(StatusCancelled, now)
+	m.NextAttemptAt = nil
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// MarkAsSuppressed withdraws an unsent message because its recipient is on the suppression list.
+// The suppression reason is kept as the last error so it is visible with the message.
+func (m *Message) MarkAsSuppressed(suppression *Suppression) error {
+	if !m.isDeliverable() {
+		return NewInvalidStatusTransitionError(m.Status, StatusSuppressed)
+	}
+
+	reason := truncate(suppression.Describe(), MaxLastErrorLength)
+
+	now := time.Now()
+	m.transitionTo(StatusSuppressed, now)
+	m.LastError = &reason
+	m.NextAttemptAt = nil
+	m.releaseLease()
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// Reject fails an unsent message that can never be delivered, such as one whose stored phone number no longer
+// validates. The reason is kept as the last error so it is visible with the message.
+func (m *Message) Reject(reason string) error {
+	if !m.isDeliverable() {
+		return NewInvalidStatusTransitionError(m.Status, StatusFailed)
+	}
+
+	reason = truncate(reason, MaxLastErrorLength)
+
+	now := time.Now()
+	m.transitionTo(StatusFailed, now)
+	m.LastError = &reason
+	m.NextAttemptAt = nil
+	m.releaseLease()
+	m.UpdatedAt = now
+
+	return nil
+}
+
+// RecordDeliveryError stores why the latest delivery attempt failed.
+// httpStatus is 0 when no response was received.
+func (m *Message) RecordDeliveryError(class ErrorClass, reason string, httpStatus int) {
+	if !class.IsValid() {
+		class = ErrorClassUnknown
+	}
+	reason = truncate(reason, MaxLastErrorLength)
+
+	m.AttemptCount++
+	m.LastError = &reason
+	m.LastErrorClass = &class
+	m.LastHTTPStatus = nil
+	if httpStatus > 0 {
+		m.LastHTTPStatus = &httpStatus
+	}
 	m.UpdatedAt = time.Now()
+}
+
+// Requeue moves a failed message back to the pending queue with a fresh retry budget.
+// The last error is kept so the previous failure stays visible.
+func (m *Message) Requeue() error {
+	if m.Status != StatusFailed {
+		return NewInvalidStatusTransitionError(m.Status, StatusPending)
+	}
+
+	now := time.Now()
+	m.transitionTo(StatusPending, now)
+	m.RetryCount = 0
+	m.NextAttemptAt = nil
+	m.UpdatedAt = now
 
 	return nil
 }
 
 // IncrementRetry increments the retry count
 func (m *Message) IncrementRetry() error {
-	if m.Status != StatusPending && m.Status != StatusFailed {
+	if !m.isDeliverable() && m.Status != StatusFailed {
 		return NewInvalidRetryError(m.Status)
 	}
 
@@ -128,12 +540,30 @@ func (m *Message) IncrementRetry() error {
 	return nil
 }
 
+// ScheduleRetry returns the message to the pending queue for another delivery attempt at the given time
+func (m *Message) ScheduleRetry(nextAttemptAt time.Time) error {
+	if !m.isDeliverable() {
+		return NewInvalidRetryError(m.Status)
+	}
+
+	if err := m.IncrementRetry(); err != nil {
+		return err
+	}
+
+	m.transitionTo(StatusPending, m.UpdatedAt)
+	m.NextAttemptAt = &nextAttemptAt
+	m.releaseLease()
+
+	return nil
+}
+
 // CanRetry checks if the message can be retried
 func (m *Message) CanRetry() bool {
-	return (m.Status == StatusPending || m.Status == StatusFailed) && m.RetryCount < MaxRetryAttempts
+	return (m.isDeliverable() || m.Status == StatusFailed) && m.RetryCount < MaxRetryAttempts
 }
 
 // Constants for business rules
 const (
-	MaxRetryAttempts = 3
+	MaxRetryAttempts   = 3
+	MaxLastErrorLength = 1000
 )
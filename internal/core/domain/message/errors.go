@@ -1,6 +1,8 @@
 package message
 
 import (
+	"strings"
+
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
 )
 
@@ -21,6 +23,26 @@ func NewInvalidRetryError(status Status) error {
 	return errors.NewBusinessError("cannot retry message in status: %s", status)
 }
 
+// NewInvalidScheduleError creates an invalid schedule error
+func NewInvalidScheduleError(status Status) error {
+	return errors.NewBusinessError("cannot schedule message in status: %s", status)
+}
+
+// NewInvalidExpiryError creates an invalid expiry error
+func NewInvalidExpiryError(status Status) error {
+	return errors.NewBusinessError("cannot set expiry for message in status: %s", status)
+}
+
+// NewInvalidPriorityError creates an invalid priority change error
+func NewInvalidPriorityError(status Status) error {
+	return errors.NewBusinessError("cannot change priority of message in status: %s", status)
+}
+
+// NewInvalidCancelError creates an invalid cancellation error
+func NewInvalidCancelError(status Status) error {
+	return errors.NewBusinessError("cannot cancel message in status: %s", status)
+}
+
 // NewMaxRetriesExceededError creates a max retries exceeded error
 func NewMaxRetriesExceededError(currentRetries int) error {
 	return errors.NewBusinessError("maximum retry attempts exceeded: %d", currentRetries)
@@ -35,10 +57,14 @@ func NewContentValidationError(reason string) error {
 	return errors.NewValidationError("invalid message content: %s", reason)
 }
 
-func NewContentTooLongError(length, maxLength int) error {
-	return errors.NewValidationError("message content too long: %d characters (max: %d)", length, maxLength)
+func NewContentTooLongError(segments, maxSegments int, encoding Encoding) error {
+	return errors.NewValidationError("message content too long: %d %s segments (max: %d)", segments, encoding, maxSegments)
 }
 
 func NewEmptyContentError() error {
 	return errors.NewValidationError("message content cannot be empty")
 }
+
+func NewMissingTemplateVariablesError(names []string) error {
+	return errors.NewValidationError("missing template variables: %s", strings.Join(names, ", "))
+}
@@ -0,0 +1,137 @@
+package message
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportFormat identifies the file format of a message import
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+// String returns the string representation of ImportFormat
+func (f ImportFormat) String() string {
+	return string(f)
+}
+
+// IsValid checks if the import format is valid
+func (f ImportFormat) IsValid() bool {
+	switch f {
+	case ImportFormatCSV, ImportFormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// ImportStatus represents the current state of an import job
+type ImportStatus string
+
+const (
+	ImportStatusProcessing ImportStatus = "PROCESSING"
+	ImportStatusCompleted  ImportStatus = "COMPLETED"
+	ImportStatusFailed     ImportStatus = "FAILED"
+)
+
+// String returns the string representation of ImportStatus
+func (s ImportStatus) String() string {
+	return string(s)
+}
+
+// ImportJobID represents a unique identifier for an import job
+type ImportJobID string
+
+// NewImportJobID generates a new unique import job ID
+func NewImportJobID() ImportJobID {
+	return ImportJobID(uuid.New().String())
+}
+
+// String returns the string representation of ImportJobID
+func (id ImportJobID) String() string {
+	return string(id)
+}
+
+// ImportJob tracks the progress of creating messages from an uploaded file
+type ImportJob struct {
+	ID            ImportJobID
+	Format        ImportFormat
+	Status        ImportStatus
+	ProcessedRows int
+	CreatedCount  int
+	FailedCount   int
+	Error         *string // Why the whole import stopped, row errors are kept separately
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// ImportRowError records why a single row of an import was rejected
+type ImportRowError struct {
+	JobID ImportJobID
+	Row   int // 1-based data row number, not counting a CSV header
+	Error string
+	Data  string // Excerpt of the raw row
+}
+
+// NewImportJob starts tracking an import of a file in the given format
+func NewImportJob(format ImportFormat) (*ImportJob, error) {
+	if !format.IsValid() {
+		return nil, NewValidationError("unsupported import format: %s", format)
+	}
+
+	now := time.Now()
+	return &ImportJob{
+		ID:        NewImportJobID(),
+		Format:    format,
+		Status:    ImportStatusProcessing,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// NewImportRowError creates a row error for the job, keeping an excerpt of the raw row
+func NewImportRowError(jobID ImportJobID, row int, reason, data string) ImportRowError {
+	return ImportRowError{
+		JobID: jobID,
+		Row:   row,
+		Error: truncate(reason, MaxLastErrorLength),
+		Data:  truncate(data, MaxResponseExcerptLength),
+	}
+}
+
+// RecordChunk adds the outcome of a processed chunk of rows to the job progress
+func (j *ImportJob) RecordChunk(created, failed int) {
+	j.ProcessedRows += created + failed
+	j.CreatedCount += created
+	j.FailedCount += failed
+	j.UpdatedAt = time.Now()
+}
+
+// Complete marks the job as finished after every row was processed
+func (j *ImportJob) Complete() {
+	now := time.Now()
+	j.Status = ImportStatusCompleted
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// Fail marks the job as stopped before the end of the file.
+// Rows processed before the failure stay created.
+func (j *ImportJob) Fail(reason string) {
+	reason = truncate(reason, MaxLastErrorLength)
+	now := time.Now()
+	j.Status = ImportStatusFailed
+	j.Error = &reason
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// IsFinished checks if the job has stopped processing rows
+func (j *ImportJob) IsFinished() bool {
+	return j.Status != ImportStatusProcessing
+}
@@ -0,0 +1,176 @@
+package message
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Inbound message limits
+const (
+	maxInboundContentLength    = 5000
+	maxInboundExternalIDLength = 255
+)
+
+// Default keywords recognized in replies, in English, Turkish, Spanish, German and French.
+// Matching ignores case, surrounding punctuation and the Turkish dotted/dotless i distinction.
+var (
+	DefaultOptOutKeywords = []string{
+		"STOP", "STOPALL", "UNSUBSCRIBE", "CANCEL", "END", "QUIT",
+		"IPTAL", "DUR", "ALTO", "BAJA", "ABMELDEN", "ARRET", "ARRÊT",
+	}
+	DefaultOptInKeywords = []string{
+		"START", "UNSTOP", "SUBSCRIBE",
+		"BASLA", "BAŞLA", "ALTA", "ANMELDEN", "COMMENCER",
+	}
+)
+
+// KeywordAction is what a reply asks for by consisting of an opt-out or opt-in keyword
+type KeywordAction string
+
+const (
+	KeywordActionNone   KeywordAction = ""        // An ordinary reply
+	KeywordActionOptOut KeywordAction = "opt_out" // The sender no longer wants messages
+	KeywordActionOptIn  KeywordAction = "opt_in"  // The sender wants messages again
+)
+
+// String returns the string representation of KeywordAction
+func (a KeywordAction) String() string {
+	return string(a)
+}
+
+// Keywords recognizes replies that opt a recipient out of or back into messages
+type Keywords struct {
+	optOut map[string]bool
+	optIn  map[string]bool
+}
+
+// NewKeywords creates a keyword set. A keyword cannot both opt out and opt in.
+func NewKeywords(optOut, optIn []string) (*Keywords, error) {
+	k := &Keywords{
+		optOut: make(map[string]bool, len(optOut)),
+		optIn:  make(map[string]bool, len(optIn)),
+	}
+
+	for _, keyword := range optOut {
+		normalized := normalizeKeyword(keyword)
+		if normalized == "" {
+			return nil, NewValidationError("opt-out keyword cannot be empty")
+		}
+		k.optOut[normalized] = true
+	}
+
+	for _, keyword := range optIn {
+		normalized := normalizeKeyword(keyword)
+		if normalized == "" {
+			return nil, NewValidationError("opt-in keyword cannot be empty")
+		}
+		if k.optOut[normalized] {
+			return nil, NewValidationError("keyword %s cannot both opt out and opt in", keyword)
+		}
+		k.optIn[normalized] = true
+	}
+
+	return k, nil
+}
+
+// DefaultKeywords returns the keyword set built from the default keyword lists
+func DefaultKeywords() *Keywords {
+	keywords, _ := NewKeywords(DefaultOptOutKeywords, DefaultOptInKeywords)
+	return keywords
+}
+
+// Match returns the action requested by a reply. Only a reply that is exactly a keyword counts,
+// so "Please stop by the store" is an ordinary reply.
+func (k *Keywords) Match(content string) KeywordAction {
+	normalized := normalizeKeyword(content)
+	switch {
+	case k.optOut[normalized]:
+		return KeywordActionOptOut
+	case k.optIn[normalized]:
+		return KeywordActionOptIn
+	default:
+		return KeywordActionNone
+	}
+}
+
+// normalizeKeyword lower-cases a keyword and strips surrounding whitespace and punctuation.
+// Turkish İ and ı fold to i so IPTAL, İPTAL and iptal are the same keyword.
+func normalizeKeyword(s string) string {
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	s = strings.NewReplacer("İ", "i", "ı", "i").Replace(s)
+	return strings.ToLower(s)
+}
+
+// InboundMessageID represents a unique identifier for an inbound message
+type InboundMessageID string
+
+// NewInboundMessageID generates a new unique inbound message ID
+func NewInboundMessageID() InboundMessageID {
+	return InboundMessageID(uuid.New().String())
+}
+
+// String returns the string representation of InboundMessageID
+func (id InboundMessageID) String() string {
+	return string(id)
+}
+
+// InboundMessage is a reply a recipient sent back, forwarded by the provider
+type InboundMessage struct {
+	ID          InboundMessageID
+	PhoneNumber PhoneNumber // The sender, i.e. the recipient of our outbound messages
+	Content     string
+	ExternalID  *string // Provider message ID, used to recognize redelivered callbacks
+	Action      KeywordAction
+	ReceivedAt  time.Time // When the provider received the reply
+	CreatedAt   time.Time
+}
+
+// NewInboundMessage creates an inbound message and classifies its content against the keywords.
+// A zero receivedAt means the reply was received just now.
+func NewInboundMessage(phoneNumber PhoneNumber, content, externalID string, receivedAt time.Time, keywords *Keywords) (*InboundMessage, error) {
+	if err := phoneNumber.Validate(); err != nil {
+		return nil, err
+	}
+
+	if length := utf8.RuneCountInString(content); length > maxInboundContentLength {
+		return nil, NewValidationError("inbound message content too long: %d characters (max: %d)", length, maxInboundContentLength)
+	}
+
+	if len(externalID) > maxInboundExternalIDLength {
+		return nil, NewValidationError("inbound message ID too long: %d characters (max: %d)", len(externalID), maxInboundExternalIDLength)
+	}
+
+	now := time.Now()
+	if receivedAt.IsZero() {
+		receivedAt = now
+	}
+
+	msg := &InboundMessage{
+		ID:          NewInboundMessageID(),
+		PhoneNumber: phoneNumber,
+		Content:     content,
+		Action:      keywords.Match(content),
+		ReceivedAt:  receivedAt,
+		CreatedAt:   now,
+	}
+	if externalID != "" {
+		msg.ExternalID = &externalID
+	}
+
+	return msg, nil
+}
+
+// Suppression returns the suppression an opt-out reply asks for, or nil for any other reply
+func (m *InboundMessage) Suppression() (*Suppression, error) {
+	if m.Action != KeywordActionOptOut {
+		return nil, nil
+	}
+
+	return NewSuppression(m.PhoneNumber, "replied "+strings.ToUpper(normalizeKeyword(m.Content)), SuppressionSourceKeyword)
+}
@@ -0,0 +1,254 @@
+package message
+
+import (
+	"slices"
+	"strings"
+)
+
+// PhoneNumberType classifies what kind of line a phone number belongs to
+type PhoneNumberType string
+
+const (
+	PhoneTypeMobile            PhoneNumberType = "mobile"
+	PhoneTypeFixedLine         PhoneNumberType = "fixed_line"
+	PhoneTypeFixedLineOrMobile PhoneNumberType = "fixed_line_or_mobile" // The numbering plan does not tell them apart
+	PhoneTypeTollFree          PhoneNumberType = "toll_free"
+	PhoneTypeUnknown           PhoneNumberType = "unknown"
+)
+
+// String returns the string representation of PhoneNumberType
+func (t PhoneNumberType) String() string {
+	return string(t)
+}
+
+// E.164 limits on the digits of a phone number
+const (
+	maxPhoneDigits          = 15
+	minNationalNumberDigits = 4
+)
+
+// phoneSeparators are the characters allowed between digits of a phone number
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "", "/", "")
+
+// numberingPlan describes how the national numbers of a region are written and classified.
+// Regions without a plan are still recognized by their calling code, with any national number length.
+type numberingPlan struct {
+	region      string
+	callingCode string
+	trunkPrefix string   // Dialled before national numbers within the region, "" if none
+	lengths     []int    // Valid national number lengths
+	areaCodes   []string // Prefixes identifying the region among those sharing its calling code
+	mobile      []string
+	fixedLine   []string
+	tollFree    []string
+	anyLine     bool // Mobile and fixed line numbers share the same ranges
+}
+
+// numberingPlans holds the regions whose number lengths and types are known.
+// For calling codes shared by several regions, the main region comes last.
+var numberingPlans = []numberingPlan{
+	{region: "TR", callingCode: "90", trunkPrefix: "0", lengths: []int{10},
+		mobile: []string{"5"}, fixedLine: []string{"2", "3", "4"}, tollFree: []string{"800"}},
+	{region: "CA", callingCode: "1", trunkPrefix: "1", lengths: []int{10}, anyLine: true,
+		tollFree: []string{"800", "833", "844", "855", "866", "877", "888"},
+		areaCodes: []string{"204", "226", "236", "249", "250", "263", "289", "306", "343", "354", "365", "367", "368",
+			"382", "387", "403", "416", "418", "428", "431", "437", "438", "450", "460", "468", "474", "506", "514",
+			"519", "548", "579", "581", "584", "587", "604", "613", "639", "647", "672", "683", "705", "709", "742",
+			"753", "778", "780", "782", "807", "819", "825", "867", "873", "879", "902", "905", "942"}},
+	{region: "US", callingCode: "1", trunkPrefix: "1", lengths: []int{10}, anyLine: true,
+		tollFree: []string{"800", "833", "844", "855", "866", "877", "888"}},
+	{region: "GB", callingCode: "44", trunkPrefix: "0", lengths: []int{9, 10},
+		mobile: []string{"71", "72", "73", "74", "75", "77", "78", "79"}, fixedLine: []string{"1", "2"}, tollFree: []string{"800", "808"}},
+	{region: "DE", callingCode: "49", trunkPrefix: "0", lengths: []int{6, 7, 8, 9, 10, 11},
+		mobile: []string{"15", "16", "17"}, fixedLine: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, tollFree: []string{"800"}},
+	{region: "FR", callingCode: "33", trunkPrefix: "0", lengths: []int{9},
+		mobile: []string{"6", "7"}, fixedLine: []string{"1", "2", "3", "4", "5", "9"}, tollFree: []string{"80"}},
+	{region: "NL", callingCode: "31", trunkPrefix: "0", lengths: []int{9},
+		mobile: []string{"6"}, fixedLine: []string{"1", "2", "3", "4", "5", "7"}, tollFree: []string{"800"}},
+	{region: "ES", callingCode: "34", lengths: []int{9},
+		mobile: []string{"6", "7"}, fixedLine: []string{"8", "9"}, tollFree: []string{"800", "900"}},
+	{region: "IT", callingCode: "39", lengths: []int{6, 7, 8, 9, 10, 11},
+		mobile: []string{"3"}, fixedLine: []string{"0"}, tollFree: []string{"80"}},
+}
+
+// callingCodeRegions maps each calling code to its main region
+var callingCodeRegions = map[string]string{
+	"1": "US", "7": "RU", "20": "EG", "27": "ZA", "30": "GR", "31": "NL", "32": "BE", "33": "FR", "34": "ES",
+	"36": "HU", "39": "IT", "40": "RO", "41": "CH", "43": "AT", "44": "GB", "45": "DK", "46": "SE", "47": "NO",
+	"48": "PL", "49": "DE", "51": "PE", "52": "MX", "53": "CU", "54": "AR", "55": "BR", "56": "CL", "57": "CO",
+	"58": "VE", "60": "MY", "61": "AU", "62": "ID", "63": "PH", "64": "NZ", "65": "SG", "66": "TH", "81": "JP",
+	"82": "KR", "84": "VN", "86": "CN", "90": "TR", "91": "IN", "92": "PK", "93": "AF", "94": "LK", "95": "MM",
+	"98": "IR", "211": "SS", "212": "MA", "213": "DZ", "216": "TN", "218": "LY", "220": "GM", "221": "SN",
+	"222": "MR", "223": "ML", "224": "GN", "225": "CI", "226": "BF", "227": "NE", "228": "TG", "229": "BJ",
+	"230": "MU", "231": "LR", "232": "SL", "233": "GH", "234": "NG", "235": "TD", "236": "CF", "237": "CM",
+	"238": "CV", "239": "ST", "240": "GQ", "241": "GA", "242": "CG", "243": "CD", "244": "AO", "245": "GW",
+	"246": "IO", "248": "SC", "249": "SD", "250": "RW", "251": "ET", "252": "SO", "253": "DJ", "254": "KE",
+	"255": "TZ", "256": "UG", "257": "BI", "258": "MZ", "260": "ZM", "261": "MG", "262": "RE", "263": "ZW",
+	"264": "NA", "265": "MW", "266": "LS", "267": "BW", "268": "SZ", "269": "KM", "290": "SH", "291": "ER",
+	"297": "AW", "298": "FO", "299": "GL", "350": "GI", "351": "PT", "352": "LU", "353": "IE", "354": "IS",
+	"355": "AL", "356": "MT", "357": "CY", "358": "FI", "359": "BG", "370": "LT", "371": "LV", "372": "EE",
+	"373": "MD", "374": "AM", "375": "BY", "376": "AD", "377": "MC", "378": "SM", "380": "UA", "381": "RS",
+	"382": "ME", "383": "XK", "385": "HR", "386": "SI", "387": "BA", "389": "MK", "420": "CZ", "421": "SK",
+	"423": "LI", "500": "FK", "501": "BZ", "502": "GT", "503": "SV", "504": "HN", "505": "NI", "506": "CR",
+	"507": "PA", "508": "PM", "509": "HT", "590": "GP", "591": "BO", "592": "GY", "593": "EC", "594": "GF",
+	"595": "PY", "596": "MQ", "597": "SR", "598": "UY", "599": "CW", "670": "TL", "672": "NF", "673": "BN",
+	"674": "NR", "675": "PG", "676": "TO", "677": "SB", "678": "VU", "679": "FJ", "680": "PW", "681": "WF",
+	"682": "CK", "683": "NU", "685": "WS", "686": "KI", "687": "NC", "688": "TV", "689": "PF", "690": "TK",
+	"691": "FM", "692": "MH", "850": "KP", "852": "HK", "853": "MO", "855": "KH", "856": "LA", "880": "BD",
+	"886": "TW", "960": "MV", "961": "LB", "962": "JO", "963": "SY", "964": "IQ", "965": "KW", "966": "SA",
+	"967": "YE", "968": "OM", "970": "PS", "971": "AE", "972": "IL", "973": "BH", "974": "QA", "975": "BT",
+	"976": "MN", "977": "NP", "992": "TJ", "993": "TM", "994": "AZ", "995": "GE", "996": "KG", "998": "UZ",
+}
+
+// IsKnownRegion reports whether national numbers of the region can be parsed
+func IsKnownRegion(region string) bool {
+	_, ok := regionCallingCode(strings.ToUpper(region))
+	return ok
+}
+
+// normalizePhoneNumber converts a phone number to E.164 digits with a leading +.
+// Numbers starting with + or 00 are international; other numbers are national numbers of
+// defaultRegion if they fit its numbering plan, and international numbers without the + otherwise.
+func normalizePhoneNumber(phone, defaultRegion string) (string, bool) {
+	digits := phoneSeparators.Replace(strings.TrimSpace(phone))
+
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	case defaultRegion != "":
+		if national, ok := nationalToE164(digits, strings.ToUpper(defaultRegion)); ok {
+			return national, true
+		}
+	}
+
+	if !isDigits(digits) {
+		return "", false
+	}
+
+	normalized := "+" + digits
+	return normalized, isValidE164(normalized)
+}
+
+// nationalToE164 reads digits as a national number of the region, with or without its trunk prefix
+func nationalToE164(digits, region string) (string, bool) {
+	callingCode, ok := regionCallingCode(region)
+	if !ok || !isDigits(digits) {
+		return "", false
+	}
+
+	if plan := planForRegion(region); plan != nil && plan.trunkPrefix != "" {
+		trimmed := strings.TrimPrefix(digits, plan.trunkPrefix)
+		if trimmed != digits && slices.Contains(plan.lengths, len(trimmed)) {
+			digits = trimmed
+		}
+	}
+
+	normalized := "+" + callingCode + digits
+	return normalized, isValidE164(normalized)
+}
+
+// isValidE164 checks that a normalized number has a known calling code and a plausible national number
+func isValidE164(phone string) bool {
+	if !strings.HasPrefix(phone, "+") || !isDigits(phone[1:]) || len(phone)-1 > maxPhoneDigits {
+		return false
+	}
+
+	// A leading 0 is a trunk prefix that was not removed, except in Italy where it is part of the number
+	callingCode, national, ok := splitCallingCode(phone[1:])
+	if !ok || strings.HasPrefix(national, "0") && callingCode != "39" {
+		return false
+	}
+
+	if plan := planForNumber(callingCode, national); plan != nil {
+		return slices.Contains(plan.lengths, len(national))
+	}
+	return len(national) >= minNationalNumberDigits
+}
+
+// splitCallingCode splits E.164 digits into the calling code and the national number
+func splitCallingCode(digits string) (string, string, bool) {
+	for size := 1; size <= 3 && size < len(digits); size++ {
+		if _, ok := callingCodeRegions[digits[:size]]; ok {
+			return digits[:size], digits[size:], true
+		}
+	}
+	return "", "", false
+}
+
+// regionCallingCode returns the calling code of a region
+func regionCallingCode(region string) (string, bool) {
+	for code, main := range callingCodeRegions {
+		if main == region {
+			return code, true
+		}
+	}
+	if plan := planForRegion(region); plan != nil {
+		return plan.callingCode, true
+	}
+	return "", false
+}
+
+// planForRegion returns the numbering plan of a region, or nil if it is not known
+func planForRegion(region string) *numberingPlan {
+	for i := range numberingPlans {
+		if numberingPlans[i].region == region {
+			return &numberingPlans[i]
+		}
+	}
+	return nil
+}
+
+// planForNumber returns the numbering plan a national number belongs to, or nil if it is not known
+func planForNumber(callingCode, national string) *numberingPlan {
+	for i := range numberingPlans {
+		plan := &numberingPlans[i]
+		if plan.callingCode != callingCode {
+			continue
+		}
+		if len(plan.areaCodes) == 0 || hasAnyPrefix(national, plan.areaCodes) {
+			return plan
+		}
+	}
+	return nil
+}
+
+// classify returns the line type of a national number under the plan
+func (p *numberingPlan) classify(national string) PhoneNumberType {
+	switch {
+	case hasAnyPrefix(national, p.tollFree):
+		return PhoneTypeTollFree
+	case p.anyLine:
+		return PhoneTypeFixedLineOrMobile
+	case hasAnyPrefix(national, p.mobile):
+		return PhoneTypeMobile
+	case hasAnyPrefix(national, p.fixedLine):
+		return PhoneTypeFixedLine
+	default:
+		return PhoneTypeUnknown
+	}
+}
+
+// hasAnyPrefix reports whether s starts with any of the prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package message
+
+import (
+	"context"
+	"time"
+)
+
+// Trigger identifies what caused a message status transition
+type Trigger string
+
+const (
+	TriggerAPI           Trigger = "api"            // A client request, e.g. creating the message
+	TriggerScheduler     Trigger = "scheduler"      // A background processing scheduler tick
+	TriggerManualProcess Trigger = "manual_process" // A manual /messaging/process request
+	TriggerRequeue       Trigger = "requeue"        // A dead-letter requeue request
+	TriggerImport        Trigger = "import"         // A file import job
+	TriggerSystem        Trigger = "system"         // Anything not attributed to a specific caller
+)
+
+// String returns the string representation of Trigger
+func (t Trigger) String() string {
+	return string(t)
+}
+
+// IsValid checks if the trigger is valid
+func (t Trigger) IsValid() bool {
+	switch t {
+	case TriggerAPI, TriggerScheduler, TriggerManualProcess, TriggerRequeue, TriggerImport, TriggerSystem:
+		return true
+	default:
+		return false
+	}
+}
+
+// triggerKey is the context key holding the trigger of the current operation
+type triggerKey struct{}
+
+// WithTrigger returns a context attributing status transitions made with it to trigger
+func WithTrigger(ctx context.Context, trigger Trigger) context.Context {
+	return context.WithValue(ctx, triggerKey{}, trigger)
+}
+
+// TriggerFromContext returns the trigger carried by ctx, or TriggerSystem if none is set
+func TriggerFromContext(ctx context.Context) Trigger {
+	if trigger, ok := ctx.Value(triggerKey{}).(Trigger); ok && trigger.IsValid() {
+		return trigger
+	}
+	return TriggerSystem
+}
+
+// StatusChange is the domain event raised when a message moves between statuses
+type StatusChange struct {
+	MessageID  MessageID
+	From       *Status // Nil for the initial status of a new message
+	To         Status
+	Trigger    Trigger // Set when the change is persisted
+	OccurredAt time.Time
+}
+
+// transitionTo moves the message to status and records the change as a pending event
+func (m *Message) transitionTo(status Status, at time.Time) {
+	if m.Status == status {
+		return
+	}
+
+	from := m.Status
+	m.Status = status
+	m.statusChanges = append(m.statusChanges, StatusChange{
+		MessageID:  m.ID,
+		From:       &from,
+		To:         status,
+		OccurredAt: at,
+	})
+}
+
+// PendingStatusChanges returns the status changes recorded since the message was last persisted
+func (m *Message) PendingStatusChanges() []StatusChange {
+	changes := make([]StatusChange, len(m.statusChanges))
+	copy(changes, m.statusChanges)
+	return changes
+}
+
+// ClearStatusChanges discards recorded status changes once they have been persisted
+func (m *Message) ClearStatusChanges() {
+	m.statusChanges = nil
+}
@@ -0,0 +1,240 @@
+package message
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Subscription limits
+const (
+	maxSubscriptionURLLength         = 2000
+	maxSubscriptionDescriptionLength = 255
+	subscriptionSecretBytes          = 32
+)
+
+// SubscriptionID represents a unique identifier for a status-change subscription
+type SubscriptionID string
+
+// NewSubscriptionID generates a new unique subscription ID
+func NewSubscriptionID() SubscriptionID {
+	return SubscriptionID(uuid.New().String())
+}
+
+// String returns the string representation of SubscriptionID
+func (id SubscriptionID) String() string {
+	return string(id)
+}
+
+// Subscription is an endpoint of an API client that receives a signed callback for every message status change
+type Subscription struct {
+	ID          SubscriptionID
+	URL         string
+	Description string
+	Statuses    []Status // Statuses whose changes are delivered; empty means every status, including ones added later
+	Secret      string   // Shared key signing the callbacks
+	Active      bool     // Inactive subscriptions queue no new events and hold pending deliveries
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewSubscription creates an active subscription with a freshly generated signing secret
+func NewSubscription(endpoint, description string, statuses []Status) (*Subscription, error) {
+	now := time.Now()
+	subscription := &Subscription{
+		ID:        NewSubscriptionID(),
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := subscription.Update(endpoint, description, statuses, true); err != nil {
+		return nil, err
+	}
+
+	secret, err := newSubscriptionSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = secret
+
+	return subscription, nil
+}
+
+// Update replaces the endpoint, description, status filter and active flag of the subscription
+func (s *Subscription) Update(endpoint, description string, statuses []Status, active bool) error {
+	endpoint = strings.TrimSpace(endpoint)
+	if err := validateSubscriptionURL(endpoint); err != nil {
+		return err
+	}
+
+	description = strings.TrimSpace(description)
+	if length := utf8.RuneCountInString(description); length > maxSubscriptionDescriptionLength {
+		return NewValidationError("subscription description too long: %d characters (max: %d)", length, maxSubscriptionDescriptionLength)
+	}
+
+	seen := make(map[Status]bool)
+	filter := []Status{}
+	for _, status := range statuses {
+		status = Status(strings.ToUpper(strings.TrimSpace(status.String())))
+		if !status.IsValid() {
+			return NewValidationError("invalid status: %s", status)
+		}
+		if !seen[status] {
+			seen[status] = true
+			filter = append(filter, status)
+		}
+	}
+
+	s.URL = endpoint
+	s.Description = description
+	s.Statuses = filter
+	s.Active = active
+	s.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Matches checks if changes to the given status are delivered to the subscription
+func (s *Subscription) Matches(status Status) bool {
+	if len(s.Statuses) == 0 {
+		return true
+	}
+
+	for _, subscribed := range s.Statuses {
+		if subscribed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature of a callback body sent at the given time, as "sha256=" followed by
+// the hex HMAC-SHA256 of "<unix timestamp>.<body>" keyed with the subscription secret
+func (s *Subscription) Sign(timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateSubscriptionURL accepts absolute http and https URLs only
+func validateSubscriptionURL(endpoint string) error {
+	if endpoint == "" {
+		return NewValidationError("subscription URL cannot be empty")
+	}
+	if len(endpoint) > maxSubscriptionURLLength {
+		return NewValidationError("subscription URL too long: %d characters (max: %d)", len(endpoint), maxSubscriptionURLLength)
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return NewValidationError("invalid subscription URL: %s (must be an absolute http or https URL)", endpoint)
+	}
+
+	return nil
+}
+
+// newSubscriptionSecret generates a random hex-encoded signing secret
+func newSubscriptionSecret() (string, error) {
+	secret := make([]byte, subscriptionSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// StatusEvent is a stored message status change as delivered to subscriptions
+type StatusEvent struct {
+	ID          int64 // Position in the status history, unique across all messages
+	PhoneNumber PhoneNumber
+	StatusChange
+}
+
+// EventDeliveryStatus represents the state of delivering an event to a subscription
+type EventDeliveryStatus string
+
+const (
+	EventDeliveryPending   EventDeliveryStatus = "PENDING"   // Waiting for its first or next attempt
+	EventDeliveryDelivered EventDeliveryStatus = "DELIVERED" // The endpoint acknowledged it with a 2xx response
+	EventDeliveryFailed    EventDeliveryStatus = "FAILED"    // Every attempt failed; replay the event to try again
+)
+
+// String returns the string representation of EventDeliveryStatus
+func (s EventDeliveryStatus) String() string {
+	return string(s)
+}
+
+// IsValid checks if the event delivery status is valid
+func (s EventDeliveryStatus) IsValid() bool {
+	switch s {
+	case EventDeliveryPending, EventDeliveryDelivered, EventDeliveryFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// EventDelivery tracks the callbacks made for one event to one subscription
+type EventDelivery struct {
+	ID             int64
+	SubscriptionID SubscriptionID
+	Event          StatusEvent
+	Status         EventDeliveryStatus
+	Replay         bool // Queued by a replay rather than by the status change itself
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int // HTTP status of the latest attempt, nil if no response was received
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// RecordSuccess marks the delivery as acknowledged by the endpoint
+func (d *EventDelivery) RecordSuccess(statusCode int) {
+	now := time.Now()
+	d.recordAttempt(statusCode, now)
+	d.Status = EventDeliveryDelivered
+	d.LastError = nil
+	d.DeliveredAt = &now
+}
+
+// RecordFailure records a failed attempt. The delivery is retried after retryDelay
+// until maxAttempts attempts have been made, then it fails. It reports whether another attempt follows.
+func (d *EventDelivery) RecordFailure(statusCode int, cause string, maxAttempts int, retryDelay time.Duration) bool {
+	now := time.Now()
+	d.recordAttempt(statusCode, now)
+
+	reason := truncate(cause, MaxLastErrorLength)
+	d.LastError = &reason
+
+	if d.Attempts >= maxAttempts {
+		d.Status = EventDeliveryFailed
+		return false
+	}
+
+	d.NextAttemptAt = now.Add(retryDelay)
+	return true
+}
+
+// recordAttempt counts an attempt and keeps its HTTP status
+func (d *EventDelivery) recordAttempt(statusCode int, at time.Time) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.LastStatusCode = nil
+	if statusCode > 0 {
+		d.LastStatusCode = &statusCode
+	}
+}
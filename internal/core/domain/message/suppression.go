@@ -0,0 +1,73 @@
+package message
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSuppressionReasonLength bounds the free-text reason stored with a suppression
+const maxSuppressionReasonLength = 255
+
+// SuppressionSource identifies how a phone number ended up on the suppression list
+type SuppressionSource string
+
+const (
+	SuppressionSourceAPI     SuppressionSource = "api"     // Added through the suppression API
+	SuppressionSourceImport  SuppressionSource = "import"  // Added by a bulk suppression import
+	SuppressionSourceKeyword SuppressionSource = "keyword" // The recipient replied with an opt-out keyword
+)
+
+// String returns the string representation of SuppressionSource
+func (s SuppressionSource) String() string {
+	return string(s)
+}
+
+// IsValid checks if the suppression source is valid
+func (s SuppressionSource) IsValid() bool {
+	switch s {
+	case SuppressionSourceAPI, SuppressionSourceImport, SuppressionSourceKeyword:
+		return true
+	default:
+		return false
+	}
+}
+
+// Suppression is a recipient that opted out and must not be sent any message
+type Suppression struct {
+	PhoneNumber PhoneNumber
+	Reason      string // Optional free-text reason, e.g. how the opt-out was received
+	Source      SuppressionSource
+	CreatedAt   time.Time
+}
+
+// NewSuppression creates a suppression list entry for a phone number
+func NewSuppression(phoneNumber PhoneNumber, reason string, source SuppressionSource) (*Suppression, error) {
+	if err := phoneNumber.Validate(); err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if length := utf8.RuneCountInString(reason); length > maxSuppressionReasonLength {
+		return nil, NewValidationError("suppression reason too long: %d characters (max: %d)", length, maxSuppressionReasonLength)
+	}
+
+	if !source.IsValid() {
+		return nil, NewValidationError("invalid suppression source: %s", source)
+	}
+
+	return &Suppression{
+		PhoneNumber: phoneNumber,
+		Reason:      reason,
+		Source:      source,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// Describe explains why messages to the phone number are not sent
+func (s *Suppression) Describe() string {
+	if s.Reason == "" {
+		return "recipient is on the suppression list"
+	}
+	return "recipient is on the suppression list: " + s.Reason
+}
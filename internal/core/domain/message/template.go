@@ -0,0 +1,138 @@
+package message
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Template limits
+const (
+	maxTemplateNameLength = 100
+	maxTemplateBodyLength = 1000 // Rendered content is still bound by the message content limit
+)
+
+// templatePlaceholder matches a {{variable}} placeholder, allowing spaces inside the braces
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplateID represents a unique identifier for a message template
+type TemplateID string
+
+// NewTemplateID generates a new unique template ID
+func NewTemplateID() TemplateID {
+	return TemplateID(uuid.New().String())
+}
+
+// String returns the string representation of TemplateID
+func (id TemplateID) String() string {
+	return string(id)
+}
+
+// Template is a named message body with {{variable}} placeholders.
+// Every change to the body creates a new version.
+type Template struct {
+	ID        TemplateID
+	Name      string
+	Body      string
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTemplate creates the first version of a template after validating its name and body
+func NewTemplate(name, body string) (*Template, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, NewValidationError("template name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxTemplateNameLength {
+		return nil, NewValidationError("template name too long: %d characters (max: %d)", utf8.RuneCountInString(name), maxTemplateNameLength)
+	}
+
+	if err := validateTemplateBody(body); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Template{
+		ID:        NewTemplateID(),
+		Name:      name,
+		Body:      body,
+		Version:   1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Revise replaces the template body, starting a new version if it changed
+func (t *Template) Revise(body string) error {
+	if err := validateTemplateBody(body); err != nil {
+		return err
+	}
+
+	if body == t.Body {
+		return nil
+	}
+
+	t.Body = body
+	t.Version++
+	t.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Variables returns the distinct variable names used by the template, sorted by name
+func (t *Template) Variables() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(t.Body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render substitutes the variables into the template body and validates the result as message content.
+// Every placeholder must have a variable; variables the template does not use are ignored.
+func (t *Template) Render(variables map[string]string) (Content, error) {
+	var missing []string
+	for _, name := range t.Variables() {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", NewMissingTemplateVariablesError(missing)
+	}
+
+	rendered := templatePlaceholder.ReplaceAllStringFunc(t.Body, func(placeholder string) string {
+		return variables[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+	})
+
+	return NewContent(rendered)
+}
+
+// validateTemplateBody checks the body length and that every {{ opens a well-formed placeholder
+func validateTemplateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return NewValidationError("template body cannot be empty")
+	}
+
+	if length := utf8.RuneCountInString(body); length > maxTemplateBodyLength {
+		return NewValidationError("template body too long: %d characters (max: %d)", length, maxTemplateBodyLength)
+	}
+
+	stripped := templatePlaceholder.ReplaceAllString(body, "")
+	if strings.Contains(stripped, "{{") || strings.Contains(stripped, "}}") {
+		return NewValidationError("template body contains a malformed placeholder, use {{name}} with letters, digits and underscores")
+	}
+
+	return nil
+}
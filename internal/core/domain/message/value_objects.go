@@ -1,34 +1,38 @@
 package message
 
 import (
-	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 )
 
-// PhoneNumber represents a phone number value object
+// PhoneNumber represents a phone number value object in canonical E.164 form, such as +905551234567
 type PhoneNumber string
 
 // Content represents message content value object
 type Content string
 
-// Phone validation constants
-const (
-	minPhoneLength   = 10
-	maxPhoneLength   = 15
-	maxContentLength = 160 // SMS character limit
-)
-
-// Phone number regex pattern (international format)
-var phoneRegex = regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
+// MaxSegments is the most SMS parts any message may span; the configured limit can only be lower
+const MaxSegments = 10
 
-// NewPhoneNumber creates a new PhoneNumber after validation
+// NewPhoneNumber normalizes an international phone number to E.164.
+// Separators are dropped and a missing + is added, so "+90 555 123 4567" and "905551234567" are the same number.
 func NewPhoneNumber(phone string) (PhoneNumber, error) {
-	p := PhoneNumber(phone)
-	if err := p.Validate(); err != nil {
-		return "", err
+	return ParsePhoneNumber(phone, "")
+}
+
+// ParsePhoneNumber normalizes a phone number to E.164, reading numbers without an
+// international prefix as national numbers of defaultRegion (an ISO 3166-1 alpha-2 code) when it is set
+func ParsePhoneNumber(phone, defaultRegion string) (PhoneNumber, error) {
+	if strings.TrimSpace(phone) == "" {
+		return "", NewValidationError("phone number cannot be empty")
+	}
+
+	normalized, ok := normalizePhoneNumber(phone, defaultRegion)
+	if !ok {
+		return "", NewPhoneNumberValidationError(strings.TrimSpace(phone))
 	}
-	return p, nil
+	return PhoneNumber(normalized), nil
 }
 
 // String returns the string representation of PhoneNumber
@@ -36,26 +40,14 @@ func (p PhoneNumber) String() string {
 	return string(p)
 }
 
-// Validate validates the phone number format
+// Validate checks that the phone number is in canonical E.164 form with a known calling code
 func (p PhoneNumber) Validate() error {
-	phone := strings.TrimSpace(string(p))
-
-	if phone == "" {
+	if p.IsEmpty() {
 		return NewValidationError("phone number cannot be empty")
 	}
 
-	// Remove spaces and common separators for validation
-	cleanPhone := strings.ReplaceAll(phone, " ", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, "-", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, "(", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, ")", "")
-
-	if len(cleanPhone) < minPhoneLength || len(cleanPhone) > maxPhoneLength {
-		return NewPhoneNumberValidationError(phone)
-	}
-
-	if !phoneRegex.MatchString(cleanPhone) {
-		return NewPhoneNumberValidationError(phone)
+	if !isValidE164(string(p)) {
+		return NewPhoneNumberValidationError(string(p))
 	}
 
 	return nil
@@ -66,6 +58,40 @@ func (p PhoneNumber) IsEmpty() bool {
 	return strings.TrimSpace(string(p)) == ""
 }
 
+// CountryCode returns the international calling code of the number, such as 90, or 0 if it is not valid
+func (p PhoneNumber) CountryCode() int {
+	callingCode, _, ok := splitCallingCode(strings.TrimPrefix(string(p), "+"))
+	if !ok {
+		return 0
+	}
+	code, _ := strconv.Atoi(callingCode)
+	return code
+}
+
+// Region returns the ISO 3166-1 alpha-2 region of the number, or "" if it is not valid
+func (p PhoneNumber) Region() string {
+	callingCode, national, ok := splitCallingCode(strings.TrimPrefix(string(p), "+"))
+	if !ok {
+		return ""
+	}
+	if plan := planForNumber(callingCode, national); plan != nil {
+		return plan.region
+	}
+	return callingCodeRegions[callingCode]
+}
+
+// Type classifies the line the number belongs to, as far as its region's numbering plan is known
+func (p PhoneNumber) Type() PhoneNumberType {
+	callingCode, national, ok := splitCallingCode(strings.TrimPrefix(string(p), "+"))
+	if !ok {
+		return PhoneTypeUnknown
+	}
+	if plan := planForNumber(callingCode, national); plan != nil {
+		return plan.classify(national)
+	}
+	return PhoneTypeUnknown
+}
+
 // NewContent creates a new Content after validation
 func NewContent(content string) (Content, error) {
 	c := Content(content)
@@ -88,9 +114,13 @@ func (c Content) Validate() error {
 		return NewEmptyContentError()
 	}
 
-	length := utf8.RuneCountInString(content)
-	if length > maxContentLength {
-		return NewContentTooLongError(length, maxContentLength)
+	return c.ValidateSegments(MaxSegments)
+}
+
+// ValidateSegments checks that the content fits in at most maxSegments SMS parts
+func (c Content) ValidateSegments(maxSegments int) error {
+	if segments := c.Segments(); segments > maxSegments {
+		return NewContentTooLongError(segments, maxSegments, c.Encoding())
 	}
 
 	return nil
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// DeliveryReceiptRepository defines the interface for delivery receipt persistence
+type DeliveryReceiptRepository interface {
+	// Create stores a delivery receipt unless one with the same external ID is already stored,
+	// reporting whether it was stored
+	Create(ctx context.Context, receipt *message.DeliveryReceipt) (bool, error)
+
+	// GetByExternalID retrieves the delivery receipt of an external ID
+	GetByExternalID(ctx context.Context, externalID string) (*message.DeliveryReceipt, error)
+
+	// MarkApplied records that a receipt has been applied to its message
+	MarkApplied(ctx context.Context, receipt *message.DeliveryReceipt) error
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// IdempotencyRecord represents a stored idempotency key and the outcome of its first request
+type IdempotencyRecord struct {
+	Key               string
+	Fingerprint       string
+	MessageID         *message.MessageID // Set once the original request completed
+	ReservedMessageID *message.MessageID // Message the original request creates, stored with the reservation
+	Response          []byte             // Serialized original response
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+}
+
+// IsCompleted checks if the original request finished and its response is stored
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.MessageID != nil && len(r.Response) > 0
+}
+
+// IdempotencyRepository defines the interface for idempotency key storage
+type IdempotencyRepository interface {
+	// Reserve stores a new key and the message it will create, returning false if an unexpired key already exists
+	Reserve(ctx context.Context, record *IdempotencyRecord) (bool, error)
+
+	// GetByKey retrieves an unexpired idempotency record by its key
+	GetByKey(ctx context.Context, key string) (*IdempotencyRecord, error)
+
+	// Complete stores the created message and its response for a reserved key
+	Complete(ctx context.Context, key string, messageID message.MessageID, response []byte) error
+
+	// Release removes a reserved key whose request did not complete
+	Release(ctx context.Context, key string) error
+}
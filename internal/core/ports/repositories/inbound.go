@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// InboundMessageRepository defines the interface for inbound message persistence
+type InboundMessageRepository interface {
+	// Create stores an inbound message unless one with the same external ID is already stored,
+	// reporting whether it was stored
+	Create(ctx context.Context, msg *message.InboundMessage) (bool, error)
+
+	// GetByExternalID retrieves an inbound message by its provider message ID
+	GetByExternalID(ctx context.Context, externalID string) (*message.InboundMessage, error)
+
+	// ListByPhoneNumber retrieves the inbound messages of a phone number, most recently received first
+	ListByPhoneNumber(ctx context.Context, phoneNumber message.PhoneNumber, pagination Pagination) ([]*message.InboundMessage, error)
+}
@@ -2,25 +2,54 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
 )
 
 // MessageRepository defines the interface for message data access
 type MessageRepository interface {
-	// Create creates a new message in the repository
+	// Create creates a new message in the repository.
+	// Create, CreateBatch, Update, UpdateClaimed, UpdatePending and UpdateSent also store the message's pending status changes,
+	// attributed to the trigger carried by ctx.
 	Create(ctx context.Context, msg *message.Message) error
 
+	// CreateBatch creates all messages at once; either every message is stored or none is
+	CreateBatch(ctx context.Context, msgs []*message.Message) error
+
 	// GetByID retrieves a message by its ID
 	GetByID(ctx context.Context, id message.MessageID) (*message.Message, error)
 
-	// GetPendingMessages retrieves pending messages with limit
+	// GetByExternalID retrieves a message by the ID the provider returned when accepting it
+	GetByExternalID(ctx context.Context, externalID string) (*message.Message, error)
+
+	// GetPendingMessages retrieves pending messages that are due for sending with limit, by priority then age
 	GetPendingMessages(ctx context.Context, limit int) ([]*message.Message, error)
 
+	// ClaimPendingMessages atomically moves up to limit due pending messages to PROCESSING, by priority then age,
+	// leased to workerID for leaseDuration. Only the given priorities are claimed, or any when none are given.
+	// Each message is claimed by at most one worker.
+	ClaimPendingMessages(ctx context.Context, workerID string, priorities []message.Priority, limit int, leaseDuration time.Duration) ([]*message.Message, error)
+
+	// UpdateClaimed updates a message only while workerID still holds its processing lease
+	UpdateClaimed(ctx context.Context, msg *message.Message, workerID string) error
+
+	// UpdatePending updates a message only while it is still PENDING, i.e. not claimed by a worker
+	UpdatePending(ctx context.Context, msg *message.Message) error
+
+	// UpdateSent updates a message only while it is still SENT, i.e. has no delivery outcome yet
+	UpdateSent(ctx context.Context, msg *message.Message) error
+
+	// ReleaseExpiredLeases returns processing messages whose lease ran out to PENDING
+	ReleaseExpiredLeases(ctx context.Context) (int64, error)
+
+	// GetScheduledMessages retrieves pending messages whose send time is still in the future
+	GetScheduledMessages(ctx context.Context, pagination Pagination) ([]*message.Message, error)
+
 	// Update updates an existing message
 	Update(ctx context.Context, msg *message.Message) error
 
-	// GetSentMessages retrieves sent messages with pagination
+	// GetSentMessages retrieves sent messages, including those with a delivery outcome, with pagination
 	GetSentMessages(ctx context.Context, pagination Pagination) ([]*message.Message, error)
 
 	// GetByStatus retrieves messages by status with pagination
@@ -32,10 +61,36 @@ type MessageRepository interface {
 	// CountByStatus counts messages by status
 	CountByStatus(ctx context.Context, status message.Status) (int64, error)
 
+	// ExpireOverdueMessages marks pending messages past their expiry time as expired
+	ExpireOverdueMessages(ctx context.Context) (int64, error)
+
+	// GetFailedMessages retrieves FAILED messages matching the dead-letter filter with pagination
+	GetFailedMessages(ctx context.Context, filter DeadLetterFilter, pagination Pagination) ([]*message.Message, error)
+
+	// CountFailedMessages counts FAILED messages matching the dead-letter filter
+	CountFailedMessages(ctx context.Context, filter DeadLetterFilter) (int64, error)
+
+	// RequeueFailedMessages moves FAILED messages matching the filter back to PENDING with a fresh retry budget
+	RequeueFailedMessages(ctx context.Context, filter DeadLetterFilter) (int64, error)
+
+	// CountScheduled counts pending messages whose send time is still in the future
+	CountScheduled(ctx context.Context) (int64, error)
+
+	// GetStatusHistory retrieves the status transitions of a message, oldest first
+	GetStatusHistory(ctx context.Context, id message.MessageID) ([]message.StatusChange, error)
+
 	// DeleteByID deletes a message by ID (for testing purposes)
 	DeleteByID(ctx context.Context, id message.MessageID) error
 }
 
+// DeadLetterFilter narrows down failed messages; zero-valued fields match everything
+type DeadLetterFilter struct {
+	PhoneNumber *message.PhoneNumber
+	ErrorClass  *message.ErrorClass
+	FailedFrom  *time.Time // Inclusive lower bound of the failure time
+	FailedTo    *time.Time // Exclusive upper bound of the failure time
+}
+
 // Pagination represents pagination parameters
 type Pagination struct {
 	Limit  int
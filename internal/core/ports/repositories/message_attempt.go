@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// MessageAttemptRepository defines the interface for the per-attempt delivery log
+type MessageAttemptRepository interface {
+	// Create stores a completed delivery attempt
+	Create(ctx context.Context, attempt *message.Attempt) error
+
+	// GetByMessageID retrieves the delivery attempts of a message in chronological order
+	GetByMessageID(ctx context.Context, messageID message.MessageID, pagination Pagination) ([]*message.Attempt, error)
+
+	// CountByMessageID counts the delivery attempts of a message
+	CountByMessageID(ctx context.Context, messageID message.MessageID) (int64, error)
+}
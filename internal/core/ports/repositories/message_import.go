@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// ImportJobRepository defines the interface for message import jobs and their row errors
+type ImportJobRepository interface {
+	// Create stores a new import job
+	Create(ctx context.Context, job *message.ImportJob) error
+
+	// Update stores the progress and status of an import job
+	Update(ctx context.Context, job *message.ImportJob) error
+
+	// GetByID retrieves an import job by its ID
+	GetByID(ctx context.Context, id message.ImportJobID) (*message.ImportJob, error)
+
+	// SaveChunk stores the messages and rejected rows of a processed chunk together with the job progress,
+	// in one transaction so a failed chunk leaves neither messages nor progress behind
+	SaveChunk(ctx context.Context, job *message.ImportJob, msgs []*message.Message, rowErrors []message.ImportRowError) error
+
+	// FailStale marks jobs still processing that made no progress since before the given time as failed,
+	// returning how many were marked
+	FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int64, error)
+
+	// GetRowErrors retrieves the rejected rows of an import job in row order
+	GetRowErrors(ctx context.Context, id message.ImportJobID, pagination Pagination) ([]message.ImportRowError, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// StatusEventFilter selects stored status changes after a position in the status history
+type StatusEventFilter struct {
+	AfterID     int64                // Only changes stored after this event
+	Since       *time.Time           // Only changes that occurred at or after this time
+	Statuses    []message.Status     // Only changes to these statuses; empty for all
+	PhoneNumber *message.PhoneNumber // Only changes of messages to this phone number
+}
+
+// StatusEventRepository defines the interface for reading the status history as an event log.
+// Event IDs increase in the order changes are stored, though a change may become visible after later ones.
+type StatusEventRepository interface {
+	// ListAfter retrieves status changes matching the filter, in event ID order
+	ListAfter(ctx context.Context, filter StatusEventFilter, limit int) ([]*message.StatusEvent, error)
+
+	// LatestID returns the ID of the most recent status change, 0 if there is none
+	LatestID(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// SubscriptionRepository defines the interface for status-change subscription persistence
+type SubscriptionRepository interface {
+	// Create stores a new subscription
+	Create(ctx context.Context, subscription *message.Subscription) error
+
+	// Update stores the endpoint, description, status filter and active flag of a subscription
+	Update(ctx context.Context, subscription *message.Subscription) error
+
+	// Delete removes a subscription together with its deliveries
+	Delete(ctx context.Context, id message.SubscriptionID) error
+
+	// GetByID retrieves a subscription
+	GetByID(ctx context.Context, id message.SubscriptionID) (*message.Subscription, error)
+
+	// List retrieves subscriptions, oldest first
+	List(ctx context.Context, pagination Pagination) ([]*message.Subscription, error)
+
+	// Count returns the total number of subscriptions
+	Count(ctx context.Context) (int64, error)
+}
+
+// EventDeliveryFilter narrows down the deliveries of a subscription
+type EventDeliveryFilter struct {
+	Status *message.EventDeliveryStatus // Only deliveries in this state
+}
+
+// EventDeliveryRepository defines the interface for the delivery log of status-change events.
+// A delivery is queued for every active subscription matching a status change when the change is stored.
+type EventDeliveryRepository interface {
+	// ClaimDue atomically leases pending deliveries that are due, to active subscriptions only.
+	// Deliveries whose lease ran out are claimed again.
+	ClaimDue(ctx context.Context, workerID string, limit int, leaseDuration time.Duration) ([]*message.EventDelivery, error)
+
+	// UpdateClaimed stores the outcome of an attempt and releases the lease.
+	// It fails with a conflict if the delivery is no longer leased to workerID.
+	UpdateClaimed(ctx context.Context, delivery *message.EventDelivery, workerID string) error
+
+	// ListBySubscription retrieves the deliveries of a subscription matching the filter, most recent first
+	ListBySubscription(ctx context.Context, id message.SubscriptionID, filter EventDeliveryFilter, pagination Pagination) ([]*message.EventDelivery, error)
+
+	// CountBySubscription counts the deliveries of a subscription matching the filter
+	CountBySubscription(ctx context.Context, id message.SubscriptionID, filter EventDeliveryFilter) (int64, error)
+
+	// CountEvents counts the status changes in [from, to) the subscription matches
+	CountEvents(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error)
+
+	// Replay queues a new delivery of every status change in [from, to) the subscription matches
+	// and returns how many were queued
+	Replay(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// SuppressionRepository defines the interface for suppression list persistence
+type SuppressionRepository interface {
+	// Add stores a suppression unless the phone number is already suppressed,
+	// reporting whether it was added. An existing entry is kept unchanged.
+	Add(ctx context.Context, suppression *message.Suppression) (bool, error)
+
+	// AddBatch stores all suppressions whose phone numbers are not suppressed yet
+	// and returns how many were added
+	AddBatch(ctx context.Context, suppressions []*message.Suppression) (int64, error)
+
+	// Remove deletes the suppression of a phone number
+	Remove(ctx context.Context, phoneNumber message.PhoneNumber) error
+
+	// Get retrieves the suppression of a phone number, failing with not found if it is not suppressed
+	Get(ctx context.Context, phoneNumber message.PhoneNumber) (*message.Suppression, error)
+
+	// List retrieves suppressions, most recent first
+	List(ctx context.Context, pagination Pagination) ([]*message.Suppression, error)
+
+	// Count returns the total number of suppressed phone numbers
+	Count(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// TemplateRepository defines the interface for message template persistence
+type TemplateRepository interface {
+	// Create stores a new template, failing with a conflict if its name is taken
+	Create(ctx context.Context, template *message.Template) error
+
+	// Update stores a new version of a template.
+	// It fails with a conflict if another update stored that version first.
+	Update(ctx context.Context, template *message.Template) error
+
+	// Delete removes a template; messages rendered from it keep their content
+	Delete(ctx context.Context, id message.TemplateID) error
+
+	// GetByID retrieves the current version of a template
+	GetByID(ctx context.Context, id message.TemplateID) (*message.Template, error)
+
+	// List retrieves templates ordered by name
+	List(ctx context.Context, pagination Pagination) ([]*message.Template, error)
+
+	// Count returns the total number of templates
+	Count(ctx context.Context) (int64, error)
+}
@@ -58,7 +58,9 @@ type CacheConfig struct {
 type SentMessageCacheData struct {
 	MessageID   string    `json:"message_id"`
 	ExternalID  string    `json:"external_id"`
+	Provider    string    `json:"provider,omitempty"`
 	PhoneNumber string    `json:"phone_number"`
 	Content     string    `json:"content"`
+	Status      string    `json:"status,omitempty"`
 	SentAt      time.Time `json:"sent_at"`
 }
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Requests are sent
+	CircuitOpen     CircuitState = "open"      // Requests are rejected without being sent
+	CircuitHalfOpen CircuitState = "half_open" // A few trial requests decide whether to close again
+)
+
+// CircuitStatus describes a circuit breaker at a point in time
+type CircuitStatus struct {
+	State               CircuitState
+	ConsecutiveFailures int
+	OpenedAt            *time.Time // When the circuit last opened, nil while it never did
+	RetryAt             *time.Time // When an open circuit lets trial requests through, nil unless open
+}
+
+// IsOpen reports whether requests are currently rejected
+func (s CircuitStatus) IsOpen() bool {
+	return s.State == CircuitOpen
+}
+
+// CircuitBreaker stops calling a failing dependency for a while
+type CircuitBreaker interface {
+	// Status returns the current state of the circuit
+	Status() CircuitStatus
+}
+
+// CircuitOpenError reports a request that was not sent because the circuit is open
+type CircuitOpenError struct {
+	RetryAt time.Time // When trial requests are let through again
+}
+
+// NewCircuitOpenError creates a circuit open error
+func NewCircuitOpenError(retryAt time.Time) *CircuitOpenError {
+	return &CircuitOpenError{
+		RetryAt: retryAt,
+	}
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit is open until %s", e.RetryAt.Format(time.RFC3339))
+}
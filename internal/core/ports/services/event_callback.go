@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// StatusEventType is the type of the events sent to subscriptions
+const StatusEventType = "message.status_changed"
+
+// StatusEventPayload is the JSON body of a status-change callback
+type StatusEventPayload struct {
+	ID         string          `json:"id"` // Stable across retries and replays of the same change
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Replay     bool            `json:"replay"`
+	Data       StatusEventData `json:"data"`
+}
+
+// StatusEventData describes the status change of a message
+type StatusEventData struct {
+	MessageID   string  `json:"messageId"`
+	PhoneNumber string  `json:"phoneNumber"`
+	From        *string `json:"from,omitempty"` // Omitted for the initial status
+	To          string  `json:"to"`
+	Trigger     string  `json:"trigger"`
+}
+
+// EventCallback is a signed event ready to be posted to a subscription endpoint
+type EventCallback struct {
+	URL       string
+	EventID   string
+	Timestamp time.Time // Signed together with the body
+	Signature string
+	Body      []byte
+}
+
+// EventCallbackService posts status-change events to subscription endpoints
+type EventCallbackService interface {
+	// Send posts the callback once and returns the HTTP status of the response.
+	// Any status outside 2xx is returned together with an error; a status of 0 means no response was received.
+	Send(ctx context.Context, callback EventCallback) (int, error)
+}
+
+// EventCallbackConfig contains configuration for the event callback service
+type EventCallbackConfig struct {
+	Timeout time.Duration // Deadline of a single callback request
+}
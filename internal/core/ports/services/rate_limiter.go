@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RateLimit describes a token bucket: Rate tokens are added per second, up to Burst tokens
+type RateLimit struct {
+	Rate  float64
+	Burst int
+
+	// Share of the limit each replica allows on its own while the shared buckets are unavailable,
+	// so the replicas together stay under it; a share outside (0, 1] allows the whole limit
+	FallbackShare float64
+}
+
+// RateLimiter hands out tokens from named buckets shared by every replica
+type RateLimiter interface {
+	// Take takes a token from the bucket of key. When none is left it returns false and
+	// how long it takes until the next token is added.
+	Take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error)
+}
+
+// ThrottledError reports a request that was not sent because the endpoint's throughput limit was reached
+type ThrottledError struct {
+	RetryAfter time.Duration // Time until the next token is added
+}
+
+// NewThrottledError creates a throttled error
+func NewThrottledError(retryAfter time.Duration) *ThrottledError {
+	return &ThrottledError{
+		RetryAfter: retryAfter,
+	}
+}
+
+// Error implements the error interface
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("throughput limit reached, next token in %s", e.RetryAfter)
+}
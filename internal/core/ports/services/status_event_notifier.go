@@ -0,0 +1,10 @@
+package services
+
+import "context"
+
+// StatusEventNotifier signals that status changes were stored, by this or any other replica
+type StatusEventNotifier interface {
+	// Listen calls notify whenever new status changes may be readable, until ctx is done or
+	// the underlying connection fails. Notifications can be coalesced, so notify may cover many changes.
+	Listen(ctx context.Context, notify func()) error
+}
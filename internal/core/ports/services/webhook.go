@@ -3,18 +3,57 @@ package services
 import (
 	"context"
 	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
 )
 
 // WebhookRequest represents the data sent to webhook endpoint
 type WebhookRequest struct {
 	To      string `json:"to"`
 	Content string `json:"content"`
+
+	// MessageID and RetryCount identify the message in the delivery log, they are not sent
+	MessageID  string `json:"-"`
+	RetryCount int    `json:"-"`
 }
 
 // WebhookResponse represents the response from webhook endpoint
 type WebhookResponse struct {
 	MessageID string `json:"messageId"`
 	Message   string `json:"message"`
+
+	// Provider names the provider that accepted the message, it is set by the router and not received
+	Provider string `json:"-"`
+}
+
+// WebhookError describes a failed webhook delivery
+type WebhookError struct {
+	Class      message.ErrorClass
+	StatusCode int           // HTTP status returned by the endpoint, 0 if no response was received
+	Permanent  bool          // Sending the same request again cannot succeed
+	RetryAfter time.Duration // Delay the endpoint asked for with Retry-After, 0 if none
+	Err        error
+}
+
+// NewWebhookError creates a webhook error of the given class.
+// Client errors other than 408, 425 and 429 are permanent, everything else is transient.
+func NewWebhookError(class message.ErrorClass, statusCode int, err error) *WebhookError {
+	return &WebhookError{
+		Class:      class,
+		StatusCode: statusCode,
+		Permanent:  message.IsPermanentHTTPStatus(statusCode),
+		Err:        err,
+	}
+}
+
+// Error implements the error interface
+func (e *WebhookError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *WebhookError) Unwrap() error {
+	return e.Err
 }
 
 // WebhookService handles sending messages to external webhook endpoints
@@ -28,10 +67,15 @@ type WebhookService interface {
 
 // WebhookConfig contains configuration for webhook service
 type WebhookConfig struct {
+	Provider         string        `yaml:"provider"` // Name of the provider at URL, recorded on delivery attempts
 	URL              string        `yaml:"url" env:"WEBHOOK_URL"`
 	AuthToken        string        `yaml:"auth_token" env:"WEBHOOK_AUTH_TOKEN"`
 	Timeout          time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
 	MaxRetries       int           `yaml:"max_retries" env:"WEBHOOK_MAX_RETRIES"`
 	RetryBackoffBase time.Duration `yaml:"retry_backoff_base" env:"WEBHOOK_RETRY_BACKOFF_BASE"`
 	RetryBackoffMax  time.Duration `yaml:"retry_backoff_max" env:"WEBHOOK_RETRY_BACKOFF_MAX"`
+
+	// Throughput limit of the endpoint across replicas; requests wait up to RateLimitMaxWait for a token
+	RateLimit        RateLimit     `yaml:"rate_limit"`
+	RateLimitMaxWait time.Duration `yaml:"rate_limit_max_wait" env:"WEBHOOK_RATE_LIMIT_MAX_WAIT"`
 }
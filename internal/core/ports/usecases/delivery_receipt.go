@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryReceiptUseCase handles delivery reports forwarded by the provider
+type DeliveryReceiptUseCase interface {
+	// ReceiveDeliveryReceipt records the delivery outcome of a sent message.
+	// A receipt for a message that is not marked SENT yet is kept and applied once it is.
+	// Receiving the same receipt again changes nothing and returns the stored one.
+	ReceiveDeliveryReceipt(ctx context.Context, cmd ReceiveDeliveryReceiptCommand) (*DeliveryReceiptResponse, error)
+}
+
+// ReceiveDeliveryReceiptCommand represents a delivery report forwarded by the provider
+type ReceiveDeliveryReceiptCommand struct {
+	ExternalID string    `json:"external_id" validate:"required"` // Message ID the provider returned when accepting the message
+	Status     string    `json:"status" validate:"required"`      // DELIVERED or UNDELIVERED
+	ErrorCode  string    `json:"error_code,omitempty"`            // Carrier error code of an undelivered message
+	ReportedAt time.Time `json:"reported_at,omitempty"`           // Zero means the outcome was reported just now
+}
+
+// DeliveryReceiptResponse represents the output for delivery receipt operations
+type DeliveryReceiptResponse struct {
+	ExternalID string     `json:"external_id"`
+	Status     string     `json:"status"`
+	ErrorCode  *string    `json:"error_code,omitempty"`
+	ReportedAt time.Time  `json:"reported_at"`
+	ReceivedAt time.Time  `json:"received_at"`
+	MessageID  *uuid.UUID `json:"message_id,omitempty"` // Set once the receipt has been applied
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+	Duplicate  bool       `json:"duplicate"` // The receipt had been received before
+}
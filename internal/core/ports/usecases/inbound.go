@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+)
+
+// Conversation entry directions
+const (
+	DirectionOutbound = "outbound" // A message we sent to the phone number
+	DirectionInbound  = "inbound"  // A reply the phone number sent us
+)
+
+// InboundMessageUseCase handles replies forwarded by the provider
+type InboundMessageUseCase interface {
+	// ReceiveInboundMessage stores a reply and applies the opt-out or opt-in it asks for.
+	// A callback redelivered with the same external ID returns the reply stored the first time.
+	ReceiveInboundMessage(ctx context.Context, cmd ReceiveInboundMessageCommand) (*InboundMessageResponse, error)
+
+	// GetConversation retrieves the outbound messages and replies of a phone number, most recent first
+	GetConversation(ctx context.Context, query ConversationQuery) (*ConversationResponse, error)
+}
+
+// InboundConfig contains configuration for the inbound message use case
+type InboundConfig struct {
+	DefaultRegion string            // ISO 3166-1 alpha-2 region of phone numbers given without a country code
+	Keywords      *message.Keywords // Replies that opt a recipient out or back in; defaults to the built-in keywords
+}
+
+// ReceiveInboundMessageCommand represents a reply forwarded by the provider
+type ReceiveInboundMessageCommand struct {
+	From       string    `json:"from" validate:"required"`
+	Content    string    `json:"content"`
+	ExternalID string    `json:"external_id,omitempty"`
+	ReceivedAt time.Time `json:"received_at,omitempty"` // Zero means the reply was received just now
+}
+
+// ConversationQuery represents the input for retrieving the conversation with a phone number
+type ConversationQuery struct {
+	PhoneNumber string `json:"phone_number"`
+	Limit       int    `json:"limit,omitempty"`
+	Offset      int    `json:"offset,omitempty"`
+}
+
+// InboundMessageResponse represents the output for inbound message operations
+type InboundMessageResponse struct {
+	ID            uuid.UUID `json:"id"`
+	PhoneNumber   string    `json:"phone_number"`
+	Content       string    `json:"content"`
+	ExternalID    *string   `json:"external_id,omitempty"`
+	KeywordAction string    `json:"keyword_action,omitempty"` // opt_out or opt_in if the reply was a keyword
+	ReceivedAt    time.Time `json:"received_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ConversationEntry is either an outbound message or an inbound reply
+type ConversationEntry struct {
+	Direction  string                  `json:"direction"`
+	OccurredAt time.Time               `json:"occurred_at"` // Creation time of an outbound message, receipt time of a reply
+	Outbound   *MessageResponse        `json:"outbound,omitempty"`
+	Inbound    *InboundMessageResponse `json:"inbound,omitempty"`
+}
+
+// ConversationResponse represents the messages exchanged with a phone number
+type ConversationResponse struct {
+	PhoneNumber string               `json:"phone_number"`
+	Suppression *SuppressionResponse `json:"suppression,omitempty"` // Set while the phone number is opted out
+	Entries     []ConversationEntry  `json:"entries"`
+	HasMore     bool                 `json:"has_more"`
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,11 +16,41 @@ type MessageManagementUseCase interface {
 	// CreateMessage creates a new message for sending
 	CreateMessage(ctx context.Context, cmd CreateMessageCommand) (*MessageResponse, error)
 
+	// CreateMessages validates and creates a batch of messages, reporting the outcome of each item
+	CreateMessages(ctx context.Context, cmds []CreateMessageCommand) (*CreateMessagesResult, error)
+
+	// ImportMessages starts creating messages from an uploaded file and returns the import job tracking it
+	ImportMessages(ctx context.Context, cmd ImportMessagesCommand) (*ImportJobResponse, error)
+
+	// GetImportJob retrieves the progress of an import job
+	GetImportJob(ctx context.Context, id uuid.UUID) (*ImportJobResponse, error)
+
+	// ListImportErrors retrieves the rows an import job rejected
+	ListImportErrors(ctx context.Context, query ImportErrorsQuery) (*ListImportErrorsResponse, error)
+
+	// RecoverImportJobs fails import jobs left processing by a stopped server, returning how many were failed
+	RecoverImportJobs(ctx context.Context) (int64, error)
+
 	// GetMessageByID retrieves a message by its ID
 	GetMessageByID(ctx context.Context, id uuid.UUID) (*MessageResponse, error)
 
+	// CancelMessage cancels a message that has not been picked up for delivery yet
+	CancelMessage(ctx context.Context, id uuid.UUID) (*MessageResponse, error)
+
 	// ListMessages retrieves messages with optional filtering and pagination
 	ListMessages(ctx context.Context, query ListMessagesQuery) (*ListMessagesResponse, error)
+
+	// ListDeadLetters retrieves permanently failed messages with their last delivery error
+	ListDeadLetters(ctx context.Context, query DeadLetterQuery) (*ListMessagesResponse, error)
+
+	// RequeueMessage moves a single failed message back to the pending queue
+	RequeueMessage(ctx context.Context, id uuid.UUID) (*MessageResponse, error)
+
+	// RequeueFailedMessages moves all failed messages matching the filter back to the pending queue
+	RequeueFailedMessages(ctx context.Context, filter DeadLetterFilter) (*RequeueResult, error)
+
+	// ListMessageAttempts retrieves every webhook request made to deliver a message
+	ListMessageAttempts(ctx context.Context, query MessageAttemptsQuery) (*ListAttemptsResponse, error)
 }
 
 // MessageProcessingUseCase handles message processing operations
@@ -29,34 +60,161 @@ type MessageProcessingUseCase interface {
 
 	// GetProcessingStatus returns the current processing status
 	GetProcessingStatus(ctx context.Context) (*ProcessingStatus, error)
+
+	// ExpireOverdueMessages marks pending messages past their expiry time as expired
+	ExpireOverdueMessages(ctx context.Context) (int64, error)
+}
+
+// ManagementConfig contains configuration for message management use cases
+type ManagementConfig struct {
+	IdempotencyRetention time.Duration // How long Idempotency-Key replays are honored
+	BatchMaxItems        int           // Upper bound for the number of messages in a batch create request
+	ImportChunkSize      int           // Number of file rows stored per batch insert during an import
+	ImportStaleAfter     time.Duration // How long an import may go without progress before it counts as interrupted
+	MaxSegments          int           // Upper bound for the number of SMS parts a message may span
+	DefaultRegion        string        // ISO 3166-1 alpha-2 region of phone numbers given without a country code
+}
+
+// ProcessingConfig contains configuration for message processing use cases
+type ProcessingConfig struct {
+	RetryBackoffBase time.Duration // Delay before the first message-level retry
+	RetryBackoffMax  time.Duration // Upper bound for the exponential retry delay
+	WorkerID         string        // Identifies this instance when claiming messages
+	LeaseDuration    time.Duration // How long a claimed message stays reserved for this worker
+	Concurrency      int           // Number of messages delivered in parallel within a batch
+	MaxBatchSize     int           // Upper bound for the number of messages claimed per batch
+	BatchTimeout     time.Duration // Deadline for delivering a batch, capped at LeaseDuration
+
+	// Share of the workers kept for critical and high priority messages while normal and bulk ones are
+	// delivered, and how often those workers claim urgent messages that became due during a batch
+	UrgentReservedShare float64
+	UrgentPollInterval  time.Duration
 }
 
 // DTOs for use cases
 
 // CreateMessageCommand represents the input for creating a message
 type CreateMessageCommand struct {
-	PhoneNumber string `json:"phone_number" validate:"required"`
-	Content     string `json:"content" validate:"required"`
+	PhoneNumber string            `json:"phone_number" validate:"required"`
+	Content     string            `json:"content,omitempty"`     // Required unless TemplateID is set
+	TemplateID  *uuid.UUID        `json:"template_id,omitempty"` // Render the content from this template instead
+	Variables   map[string]string `json:"variables,omitempty"`   // Values for the template placeholders
+	SendAt      *time.Time        `json:"send_at,omitempty"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
+	TTL         time.Duration     `json:"ttl,omitempty"`      // Relative to send time, mutually exclusive with ExpiresAt
+	Priority    string            `json:"priority,omitempty"` // critical, high, normal (default) or bulk
+
+	// IdempotencyKey makes retries of the same request return the original result
+	IdempotencyKey string `json:"-"`
+}
+
+// CreateMessagesResult represents the outcome of a batch create, with one item per command in order
+type CreateMessagesResult struct {
+	Items        []BatchItemResult `json:"items"`
+	CreatedCount int               `json:"created_count"`
+	FailedCount  int               `json:"failed_count"`
+}
+
+// BatchItemResult represents the outcome of a single command in a batch create
+type BatchItemResult struct {
+	Index   int              `json:"index"`
+	Message *MessageResponse `json:"message,omitempty"` // Set when the message was created
+	Error   error            `json:"-"`                 // Set when the command was rejected
+}
+
+// ImportMessagesCommand represents the input for importing messages from a file
+type ImportMessagesCommand struct {
+	Format     string        `json:"format"`                // csv or ndjson
+	TemplateID *uuid.UUID    `json:"template_id,omitempty"` // Template for rows without content or a template of their own
+	Source     io.ReadCloser `json:"-"`                     // File contents, closed once the import finishes
+}
+
+// ImportJobResponse represents the progress of an import job
+type ImportJobResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Format        string     `json:"format"`
+	Status        string     `json:"status"`
+	ProcessedRows int        `json:"processed_rows"`
+	CreatedCount  int        `json:"created_count"`
+	FailedCount   int        `json:"failed_count"`
+	Error         *string    `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ImportErrorsQuery represents the input for listing the rows rejected by an import job
+type ImportErrorsQuery struct {
+	JobID  uuid.UUID `json:"job_id"`
+	Limit  int       `json:"limit,omitempty"`
+	Offset int       `json:"offset,omitempty"`
+}
+
+// ImportRowErrorResponse represents a single row rejected by an import job
+type ImportRowErrorResponse struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+	Data  string `json:"data"`
+}
+
+// ListImportErrorsResponse represents the output for listing the rows rejected by an import job
+type ListImportErrorsResponse struct {
+	Errors     []ImportRowErrorResponse `json:"errors"`
+	TotalCount int64                    `json:"total_count"`
+	HasMore    bool                     `json:"has_more"`
 }
 
 // MessageResponse represents the output for message operations
 type MessageResponse struct {
-	ID          uuid.UUID  `json:"id"`
-	PhoneNumber string     `json:"phone_number"`
-	Content     string     `json:"content"`
-	Status      string     `json:"status"`
-	ExternalID  *string    `json:"external_id,omitempty"`
-	RetryCount  int        `json:"retry_count"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	SentAt      *time.Time `json:"sent_at,omitempty"`
+	ID             uuid.UUID  `json:"id"`
+	PhoneNumber    string     `json:"phone_number"`
+	Content        string     `json:"content"`
+	Status         string     `json:"status"`
+	Priority       string     `json:"priority"`
+	ExternalID     *string    `json:"external_id,omitempty"`
+	Provider       *string    `json:"provider,omitempty"`
+	RetryCount     int        `json:"retry_count"`
+	SendAt         *time.Time `json:"send_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	AttemptCount   int        `json:"attempt_count"`
+	LastError      *string    `json:"last_error,omitempty"`
+	LastErrorClass *string    `json:"last_error_class,omitempty"`
+	LastHTTPStatus *int       `json:"last_http_status,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+
+	CarrierErrorCode   *string    `json:"carrier_error_code,omitempty"`
+	DeliveryReportedAt *time.Time `json:"delivery_reported_at,omitempty"`
+
+	TemplateID      *uuid.UUID `json:"template_id,omitempty"`
+	TemplateVersion *int       `json:"template_version,omitempty"`
+
+	CountryCode int    `json:"country_code"`     // International calling code of the phone number
+	Region      string `json:"region,omitempty"` // ISO 3166-1 alpha-2 region of the phone number
+	NumberType  string `json:"number_type"`
+
+	Encoding string `json:"encoding"` // GSM-7 or UCS-2, depending on the characters of the content
+	Segments int    `json:"segments"` // Number of SMS parts the content is sent as
+
+	StatusHistory []StatusChangeResponse `json:"status_history,omitempty"` // Only populated when a single message is fetched
+}
+
+// StatusChangeResponse represents a single status transition in a message timeline
+type StatusChangeResponse struct {
+	From       *string   `json:"from,omitempty"` // Empty for the initial status
+	To         string    `json:"to"`
+	Trigger    string    `json:"trigger"`
+	OccurredAt time.Time `json:"occurred_at"`
 }
 
 // ListMessagesQuery represents the input for listing messages
 type ListMessagesQuery struct {
-	Status *message.Status `json:"status,omitempty"`
-	Limit  int             `json:"limit,omitempty"`
-	Offset int             `json:"offset,omitempty"`
+	Status    *message.Status `json:"status,omitempty"`
+	Scheduled bool            `json:"scheduled,omitempty"` // Only pending messages whose send time is in the future
+	Limit     int             `json:"limit,omitempty"`
+	Offset    int             `json:"offset,omitempty"`
 }
 
 // ListMessagesResponse represents the output for listing messages
@@ -66,12 +224,65 @@ type ListMessagesResponse struct {
 	HasMore    bool              `json:"has_more"`
 }
 
+// DeadLetterFilter selects failed messages; empty fields match everything
+type DeadLetterFilter struct {
+	PhoneNumber string     `json:"phone_number,omitempty"`
+	ErrorClass  string     `json:"error_class,omitempty"`
+	FailedFrom  *time.Time `json:"failed_from,omitempty"`
+	FailedTo    *time.Time `json:"failed_to,omitempty"`
+}
+
+// DeadLetterQuery represents the input for listing failed messages
+type DeadLetterQuery struct {
+	Filter DeadLetterFilter `json:"filter"`
+	Limit  int              `json:"limit,omitempty"`
+	Offset int              `json:"offset,omitempty"`
+}
+
+// MessageAttemptsQuery represents the input for listing the delivery attempts of a message
+type MessageAttemptsQuery struct {
+	MessageID uuid.UUID `json:"message_id"`
+	Limit     int       `json:"limit,omitempty"`
+	Offset    int       `json:"offset,omitempty"`
+}
+
+// AttemptResponse represents a single webhook request made to deliver a message
+type AttemptResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Provider      *string   `json:"provider,omitempty"`
+	RetryCount    int       `json:"retry_count"`
+	AttemptNumber int       `json:"attempt_number"`
+	StartedAt     time.Time `json:"started_at"`
+	DurationMs    int64     `json:"duration_ms"`
+	HTTPStatus    *int      `json:"http_status,omitempty"`
+	ResponseBody  *string   `json:"response_body,omitempty"`
+	Error         *string   `json:"error,omitempty"`
+	ErrorClass    *string   `json:"error_class,omitempty"`
+}
+
+// ListAttemptsResponse represents the output for listing delivery attempts
+type ListAttemptsResponse struct {
+	Attempts   []AttemptResponse `json:"attempts"`
+	TotalCount int64             `json:"total_count"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// RequeueResult represents the outcome of a bulk requeue
+type RequeueResult struct {
+	RequeuedCount int64 `json:"requeued_count"`
+}
+
 // ProcessingResult represents the result of message processing
 type ProcessingResult struct {
-	ProcessedCount int     `json:"processed_count"`
-	SuccessCount   int     `json:"success_count"`
-	FailedCount    int     `json:"failed_count"`
-	Errors         []error `json:"errors,omitempty"`
+	ProcessedCount  int     `json:"processed_count"`
+	SuccessCount    int     `json:"success_count"`
+	FailedCount     int     `json:"failed_count"`
+	ExpiredCount    int     `json:"expired_count"`
+	RetriedCount    int     `json:"retried_count"`
+	DeferredCount   int     `json:"deferred_count"`
+	SuppressedCount int     `json:"suppressed_count"`
+	ReclaimedCount  int64   `json:"reclaimed_count"`
+	Errors          []error `json:"errors,omitempty"`
 }
 
 // ProcessingStatus represents the current state of message processing
@@ -79,6 +290,7 @@ type ProcessingStatus struct {
 	IsProcessing     bool       `json:"is_processing"`
 	LastProcessedAt  *time.Time `json:"last_processed_at,omitempty"`
 	PendingCount     int64      `json:"pending_count"`
+	ProcessingCount  int64      `json:"processing_count"`
 	ProcessedToday   int64      `json:"processed_today"`
 	FailedToday      int64      `json:"failed_today"`
 	NextProcessingAt *time.Time `json:"next_processing_at,omitempty"`
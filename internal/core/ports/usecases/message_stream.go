@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types of the events in the message stream
+const (
+	MessageCreatedEvent       = "message.created"        // The initial status of a new message
+	MessageStatusChangedEvent = "message.status_changed" // Any later status change
+)
+
+// MessageStreamUseCase streams message status changes to live clients
+type MessageStreamUseCase interface {
+	// StreamStatusEvents returns the status changes matching the query as they are stored, on any replica.
+	// With a LastEventID the stored changes after it are sent first, as far back as the resume window reaches.
+	// The channel is closed when ctx is done or the client falls too far behind; resuming from the last
+	// received event ID continues the stream.
+	StreamStatusEvents(ctx context.Context, query StreamStatusEventsQuery) (<-chan StatusEventResponse, error)
+}
+
+// StreamStatusEventsQuery represents the input for streaming status changes
+type StreamStatusEventsQuery struct {
+	Statuses    []string `json:"statuses,omitempty"` // Empty streams every status
+	PhoneNumber string   `json:"phone_number,omitempty"`
+	LastEventID *int64   `json:"last_event_id,omitempty"` // Resume after this event
+}
+
+// StatusEventResponse represents a status change in the message stream
+type StatusEventResponse struct {
+	ID          int64     `json:"id"`
+	Type        string    `json:"type"`
+	MessageID   uuid.UUID `json:"message_id"`
+	PhoneNumber string    `json:"phone_number"`
+	From        *string   `json:"from,omitempty"` // Empty for the initial status
+	To          string    `json:"to"`
+	Trigger     string    `json:"trigger"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
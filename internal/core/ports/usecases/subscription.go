@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionUseCase handles status-change subscriptions of API clients
+type SubscriptionUseCase interface {
+	// CreateSubscription registers an endpoint and returns it with its signing secret
+	CreateSubscription(ctx context.Context, cmd CreateSubscriptionCommand) (*SubscriptionResponse, error)
+
+	// GetSubscription retrieves a subscription without its secret
+	GetSubscription(ctx context.Context, id uuid.UUID) (*SubscriptionResponse, error)
+
+	// ListSubscriptions retrieves subscriptions, oldest first
+	ListSubscriptions(ctx context.Context, query ListSubscriptionsQuery) (*ListSubscriptionsResponse, error)
+
+	// UpdateSubscription replaces the endpoint, description, status filter and active flag of a subscription
+	UpdateSubscription(ctx context.Context, cmd UpdateSubscriptionCommand) (*SubscriptionResponse, error)
+
+	// DeleteSubscription removes a subscription and its delivery log
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+
+	// ListDeliveries retrieves the delivery log of a subscription, most recent first
+	ListDeliveries(ctx context.Context, query ListEventDeliveriesQuery) (*ListEventDeliveriesResponse, error)
+
+	// ReplayEvents queues the status changes of a time range for delivery again
+	ReplayEvents(ctx context.Context, cmd ReplayEventsCommand) (*ReplayEventsResponse, error)
+}
+
+// EventDeliveryUseCase sends queued status-change events to subscription endpoints
+type EventDeliveryUseCase interface {
+	// DeliverPendingEvents claims and sends a batch of due deliveries
+	DeliverPendingEvents(ctx context.Context, batchSize int) (*EventDeliveryResult, error)
+}
+
+// SubscriptionConfig contains configuration for subscription management
+type SubscriptionConfig struct {
+	ReplayMaxEvents int // Upper bound for the number of events a single replay may queue
+}
+
+// EventDeliveryConfig contains configuration for delivering events to subscriptions
+type EventDeliveryConfig struct {
+	MaxAttempts      int           // Attempts per delivery before it fails
+	RetryBackoffBase time.Duration // Delay before the first retry
+	RetryBackoffMax  time.Duration // Upper bound for the exponential retry delay
+	WorkerID         string        // Identifies this instance when claiming deliveries
+	LeaseDuration    time.Duration // How long a claimed delivery stays reserved for this worker
+	Concurrency      int           // Callbacks sent in parallel within a batch
+}
+
+// CreateSubscriptionCommand represents the input for registering a subscription
+type CreateSubscriptionCommand struct {
+	URL         string   `json:"url" validate:"required"`
+	Description string   `json:"description,omitempty"`
+	Statuses    []string `json:"statuses,omitempty"` // Empty subscribes to every status
+}
+
+// UpdateSubscriptionCommand represents the input for changing a subscription
+type UpdateSubscriptionCommand struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url" validate:"required"`
+	Description string    `json:"description,omitempty"`
+	Statuses    []string  `json:"statuses,omitempty"`
+	Active      bool      `json:"active"`
+}
+
+// ListSubscriptionsQuery represents the input for listing subscriptions
+type ListSubscriptionsQuery struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// ListEventDeliveriesQuery represents the input for listing the deliveries of a subscription
+type ListEventDeliveriesQuery struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Status         string    `json:"status,omitempty"`
+	Limit          int       `json:"limit,omitempty"`
+	Offset         int       `json:"offset,omitempty"`
+}
+
+// ReplayEventsCommand represents the input for replaying events
+type ReplayEventsCommand struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"` // Exclusive
+}
+
+// SubscriptionResponse represents the output for subscription operations
+type SubscriptionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Statuses    []string  `json:"statuses"`
+	Secret      string    `json:"secret,omitempty"` // Only returned when the subscription is created
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListSubscriptionsResponse represents the output for listing subscriptions
+type ListSubscriptionsResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+	TotalCount    int64                  `json:"total_count"`
+	HasMore       bool                   `json:"has_more"`
+}
+
+// EventDeliveryResponse represents a delivery log entry
+type EventDeliveryResponse struct {
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	MessageID      uuid.UUID  `json:"message_id"`
+	From           *string    `json:"from,omitempty"`
+	To             string     `json:"to"`
+	OccurredAt     time.Time  `json:"occurred_at"`
+	Status         string     `json:"status"`
+	Replay         bool       `json:"replay"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"` // Only while pending
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ListEventDeliveriesResponse represents the output for listing deliveries
+type ListEventDeliveriesResponse struct {
+	Deliveries []EventDeliveryResponse `json:"deliveries"`
+	TotalCount int64                   `json:"total_count"`
+	HasMore    bool                    `json:"has_more"`
+}
+
+// ReplayEventsResponse represents the output for replaying events
+type ReplayEventsResponse struct {
+	Queued int64 `json:"queued"`
+}
+
+// EventDeliveryResult represents the outcome of a delivery batch
+type EventDeliveryResult struct {
+	ClaimedCount   int     `json:"claimed_count"`
+	DeliveredCount int     `json:"delivered_count"`
+	RetryingCount  int     `json:"retrying_count"`
+	FailedCount    int     `json:"failed_count"`
+	Errors         []error `json:"errors,omitempty"`
+}
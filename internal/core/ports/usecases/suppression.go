@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"time"
+)
+
+// SuppressionManagementUseCase handles the list of recipients that opted out of messages
+type SuppressionManagementUseCase interface {
+	// AddSuppression puts a phone number on the suppression list.
+	// Adding a number that is already suppressed returns the existing entry.
+	AddSuppression(ctx context.Context, cmd AddSuppressionCommand) (*SuppressionResponse, error)
+
+	// ImportSuppressions puts many phone numbers on the suppression list, reporting entries it rejected
+	ImportSuppressions(ctx context.Context, cmd ImportSuppressionsCommand) (*ImportSuppressionsResult, error)
+
+	// CheckSuppression retrieves the suppression of a phone number, failing with not found if it is not suppressed
+	CheckSuppression(ctx context.Context, phoneNumber string) (*SuppressionResponse, error)
+
+	// ListSuppressions retrieves suppressed phone numbers, most recent first
+	ListSuppressions(ctx context.Context, query ListSuppressionsQuery) (*ListSuppressionsResponse, error)
+
+	// RemoveSuppression takes a phone number off the suppression list
+	RemoveSuppression(ctx context.Context, phoneNumber string) error
+}
+
+// SuppressionConfig contains configuration for the suppression management use case
+type SuppressionConfig struct {
+	DefaultRegion  string // ISO 3166-1 alpha-2 region of phone numbers given without a country code
+	ImportMaxItems int    // Upper bound for the number of entries in a bulk suppression import
+}
+
+// AddSuppressionCommand represents the input for suppressing a phone number
+type AddSuppressionCommand struct {
+	PhoneNumber string `json:"phone_number" validate:"required"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// ImportSuppressionsCommand represents the input for suppressing many phone numbers at once
+type ImportSuppressionsCommand struct {
+	Suppressions []AddSuppressionCommand `json:"suppressions"`
+	Reason       string                  `json:"reason,omitempty"` // Used for entries without a reason of their own
+}
+
+// ListSuppressionsQuery represents the input for listing suppressions
+type ListSuppressionsQuery struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// SuppressionResponse represents the output for suppression operations
+type SuppressionResponse struct {
+	PhoneNumber string    `json:"phone_number"`
+	Reason      string    `json:"reason,omitempty"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListSuppressionsResponse represents the output for listing suppressions
+type ListSuppressionsResponse struct {
+	Suppressions []SuppressionResponse `json:"suppressions"`
+	TotalCount   int64                 `json:"total_count"`
+	HasMore      bool                  `json:"has_more"`
+}
+
+// ImportSuppressionsResult represents the outcome of a bulk suppression import
+type ImportSuppressionsResult struct {
+	AddedCount      int64                    `json:"added_count"`
+	DuplicateCount  int                      `json:"duplicate_count"` // Entries already suppressed or repeated in the import
+	RejectedCount   int                      `json:"rejected_count"`
+	RejectedEntries []SuppressionImportError `json:"rejected_entries,omitempty"`
+}
+
+// SuppressionImportError describes an import entry that could not be suppressed
+type SuppressionImportError struct {
+	Index       int    `json:"index"` // 0-based position of the entry in the import
+	PhoneNumber string `json:"phone_number"`
+	Error       string `json:"error"`
+}
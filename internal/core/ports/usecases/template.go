@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateManagementUseCase handles message template operations
+type TemplateManagementUseCase interface {
+	// CreateTemplate validates and stores the first version of a template
+	CreateTemplate(ctx context.Context, cmd CreateTemplateCommand) (*TemplateResponse, error)
+
+	// GetTemplate retrieves the current version of a template
+	GetTemplate(ctx context.Context, id uuid.UUID) (*TemplateResponse, error)
+
+	// ListTemplates retrieves templates ordered by name
+	ListTemplates(ctx context.Context, query ListTemplatesQuery) (*ListTemplatesResponse, error)
+
+	// UpdateTemplate replaces the body of a template, creating a new version
+	UpdateTemplate(ctx context.Context, cmd UpdateTemplateCommand) (*TemplateResponse, error)
+
+	// DeleteTemplate removes a template; messages already rendered from it are kept
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+}
+
+// CreateTemplateCommand represents the input for creating a template
+type CreateTemplateCommand struct {
+	Name string `json:"name" validate:"required"`
+	Body string `json:"body" validate:"required"`
+}
+
+// UpdateTemplateCommand represents the input for revising a template
+type UpdateTemplateCommand struct {
+	ID   uuid.UUID `json:"id"`
+	Body string    `json:"body" validate:"required"`
+}
+
+// ListTemplatesQuery represents the input for listing templates
+type ListTemplatesQuery struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// TemplateResponse represents the output for template operations
+type TemplateResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	Version   int       `json:"version"`
+	Variables []string  `json:"variables"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListTemplatesResponse represents the output for listing templates
+type ListTemplatesResponse struct {
+	Templates  []TemplateResponse `json:"templates"`
+	TotalCount int64              `json:"total_count"`
+	HasMore    bool               `json:"has_more"`
+}
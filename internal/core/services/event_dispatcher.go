@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// EventDispatcher sends queued status-change callbacks to subscriptions at regular intervals
+type EventDispatcher struct {
+	eventDelivery usecases.EventDeliveryUseCase
+	interval      time.Duration
+	batchSize     int
+	isRunning     bool
+	stopChan      chan struct{}
+	doneChan      chan struct{}
+	mu            sync.RWMutex
+}
+
+// EventDispatcherConfig contains configuration for the event dispatcher
+type EventDispatcherConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewEventDispatcher creates a new event dispatcher
+func NewEventDispatcher(
+	eventDelivery usecases.EventDeliveryUseCase,
+	config EventDispatcherConfig,
+) *EventDispatcher {
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+
+	return &EventDispatcher{
+		eventDelivery: eventDelivery,
+		interval:      config.Interval,
+		batchSize:     config.BatchSize,
+		stopChan:      make(chan struct{}),
+		doneChan:      make(chan struct{}),
+	}
+}
+
+// Start begins dispatching events in the background
+func (d *EventDispatcher) Start(ctx context.Context) error {
+	d.mu.Lock()
+	if d.isRunning {
+		d.mu.Unlock()
+		return fmt.Errorf("event dispatcher is already running")
+	}
+	d.isRunning = true
+	d.mu.Unlock()
+
+	log.Printf("📡 Starting event dispatcher (interval: %v, batch size: %d)", d.interval, d.batchSize)
+
+	go d.run(ctx)
+	return nil
+}
+
+// Stop gracefully stops the event dispatcher
+func (d *EventDispatcher) Stop() error {
+	d.mu.Lock()
+	if !d.isRunning {
+		d.mu.Unlock()
+		return fmt.Errorf("event dispatcher is not running")
+	}
+	d.mu.Unlock()
+
+	log.Println("🛑 Stopping event dispatcher...")
+	close(d.stopChan)
+
+	select {
+	case <-d.doneChan:
+		log.Println("✅ Event dispatcher stopped gracefully")
+		return nil
+	case <-time.After(30 * time.Second):
+		log.Println("⚠️ Event dispatcher stop timed out")
+		return fmt.Errorf("event dispatcher stop timed out")
+	}
+}
+
+// IsRunning returns whether the dispatcher is currently running
+func (d *EventDispatcher) IsRunning() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.isRunning
+}
+
+// run is the main dispatch loop
+func (d *EventDispatcher) run(ctx context.Context) {
+	defer close(d.doneChan)
+	defer func() {
+		d.mu.Lock()
+		d.isRunning = false
+		d.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("📤 Event dispatcher stopped due to context cancellation")
+			return
+		case <-d.stopChan:
+			log.Println("📤 Event dispatcher received stop signal")
+			return
+		case <-ticker.C:
+			d.dispatchEvents(ctx)
+		}
+	}
+}
+
+// dispatchEvents sends due callbacks batch by batch until a batch comes back short,
+// so a backlog drains without waiting for the next tick
+func (d *EventDispatcher) dispatchEvents(ctx context.Context) {
+	for {
+		result, err := d.eventDelivery.DeliverPendingEvents(ctx, d.batchSize)
+		if err != nil {
+			log.Printf("❌ Error delivering events: %v", err)
+			return
+		}
+
+		if result.ClaimedCount > 0 {
+			log.Printf("📨 Delivered %d events: %d acknowledged, %d retrying, %d failed",
+				result.ClaimedCount, result.DeliveredCount, result.RetryingCount, result.FailedCount)
+
+			for i, err := range result.Errors {
+				log.Printf("   %d. %v", i+1, err)
+			}
+		}
+
+		if result.ClaimedCount < d.batchSize {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stopChan:
+			return
+		default:
+		}
+	}
+}
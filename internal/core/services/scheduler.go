@@ -7,12 +7,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 )
 
 // ProcessingScheduler handles automatic message processing at regular intervals
 type ProcessingScheduler struct {
 	messageProcessing usecases.MessageProcessingUseCase
+	circuit           servicePorts.CircuitBreaker
 	interval          time.Duration
 	batchSize         int
 	isRunning         bool
@@ -28,6 +31,10 @@ type ProcessingStats struct {
 	TotalProcessed        int64
 	TotalSuccessful       int64
 	TotalFailed           int64
+	TotalExpired          int64
+	TotalRetried          int64
+	TotalSuppressed       int64
+	SkippedRuns           int64 // Runs that claimed nothing because the webhook circuit was open
 	LastProcessingTime    time.Time
 	LastProcessingResult  *usecases.ProcessingResult
 	IsCurrentlyProcessing bool
@@ -39,13 +46,16 @@ type SchedulerConfig struct {
 	BatchSize int
 }
 
-// NewProcessingScheduler creates a new processing scheduler
+// NewProcessingScheduler creates a new processing scheduler.
+// No messages are claimed while circuit is open; circuit may be nil.
 func NewProcessingScheduler(
 	messageProcessing usecases.MessageProcessingUseCase,
+	circuit servicePorts.CircuitBreaker,
 	config SchedulerConfig,
 ) *ProcessingScheduler {
 	return &ProcessingScheduler{
 		messageProcessing: messageProcessing,
+		circuit:           circuit,
 		interval:          config.Interval,
 		batchSize:         config.BatchSize,
 		stopChan:          make(chan struct{}),
@@ -112,12 +122,26 @@ func (s *ProcessingScheduler) GetStats() ProcessingStats {
 		TotalProcessed:        s.stats.TotalProcessed,
 		TotalSuccessful:       s.stats.TotalSuccessful,
 		TotalFailed:           s.stats.TotalFailed,
+		TotalExpired:          s.stats.TotalExpired,
+		TotalRetried:          s.stats.TotalRetried,
+		TotalSuppressed:       s.stats.TotalSuppressed,
+		SkippedRuns:           s.stats.SkippedRuns,
 		LastProcessingTime:    s.stats.LastProcessingTime,
 		LastProcessingResult:  s.stats.LastProcessingResult,
 		IsCurrentlyProcessing: s.stats.IsCurrentlyProcessing,
 	}
 }
 
+// CircuitStatus returns the state of the webhook circuit, nil if there is no circuit breaker
+func (s *ProcessingScheduler) CircuitStatus() *servicePorts.CircuitStatus {
+	if s.circuit == nil {
+		return nil
+	}
+
+	status := s.circuit.Status()
+	return &status
+}
+
 // run is the main processing loop
 func (s *ProcessingScheduler) run(ctx context.Context) {
 	defer close(s.doneChan)
@@ -149,6 +173,9 @@ func (s *ProcessingScheduler) run(ctx context.Context) {
 
 // processMessages handles a single processing cycle
 func (s *ProcessingScheduler) processMessages(ctx context.Context) {
+	// Attribute status transitions made during this tick to the scheduler
+	ctx = message.WithTrigger(ctx, message.TriggerScheduler)
+
 	// Update processing status
 	s.stats.mu.Lock()
 	s.stats.IsCurrentlyProcessing = true
@@ -161,6 +188,26 @@ func (s *ProcessingScheduler) processMessages(ctx context.Context) {
 		s.stats.mu.Unlock()
 	}()
 
+	// Sweep messages that expired while waiting so they are never sent
+	expired, err := s.messageProcessing.ExpireOverdueMessages(ctx)
+	if err != nil {
+		log.Printf("❌ Error expiring overdue messages: %v", err)
+	} else if expired > 0 {
+		log.Printf("⌛ Expired %d overdue messages", expired)
+		s.stats.mu.Lock()
+		s.stats.TotalExpired += expired
+		s.stats.mu.Unlock()
+	}
+
+	// Claimed messages would only be deferred again while the webhook endpoint is down
+	if status := s.CircuitStatus(); status != nil && status.IsOpen() {
+		log.Printf("⛔ Webhook circuit is open, not claiming messages until %s", status.RetryAt.Format(time.RFC3339))
+		s.stats.mu.Lock()
+		s.stats.SkippedRuns++
+		s.stats.mu.Unlock()
+		return
+	}
+
 	log.Printf("🔄 Processing pending messages (batch size: %d)...", s.batchSize)
 
 	// Process messages
@@ -175,13 +222,16 @@ func (s *ProcessingScheduler) processMessages(ctx context.Context) {
 	s.stats.TotalProcessed += int64(result.ProcessedCount)
 	s.stats.TotalSuccessful += int64(result.SuccessCount)
 	s.stats.TotalFailed += int64(result.FailedCount)
+	s.stats.TotalExpired += int64(result.ExpiredCount)
+	s.stats.TotalRetried += int64(result.RetriedCount)
+	s.stats.TotalSuppressed += int64(result.SuppressedCount)
 	s.stats.LastProcessingResult = result
 	s.stats.mu.Unlock()
 
 	// Log results
 	if result.ProcessedCount > 0 {
-		log.Printf("✅ Processed %d messages: %d successful, %d failed",
-			result.ProcessedCount, result.SuccessCount, result.FailedCount)
+		log.Printf("✅ Processed %d messages: %d successful, %d failed, %d expired, %d retrying, %d deferred, %d suppressed",
+			result.ProcessedCount, result.SuccessCount, result.FailedCount, result.ExpiredCount, result.RetriedCount, result.DeferredCount, result.SuppressedCount)
 
 		if len(result.Errors) > 0 {
 			log.Printf("⚠️ Processing errors:")
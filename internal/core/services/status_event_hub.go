@@ -0,0 +1,470 @@
+package services
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// statusEventPageSize bounds the status changes read from the log at once
+const statusEventPageSize = 500
+
+// StatusEventHub reads newly stored status changes once per replica and fans them out to the
+// stream clients connected to it. It implements MessageStreamUseCase.
+type StatusEventHub struct {
+	events    repositories.StatusEventRepository
+	notifier  servicePorts.StatusEventNotifier
+	config    StatusEventHubConfig
+	isRunning bool
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+	mu        sync.RWMutex
+
+	cursor  statusEventCursor // Only touched by the run loop
+	settled atomic.Int64      // Copy of cursor.settled for subscribing clients
+
+	subscribersMu sync.Mutex
+	subscribers   map[*statusEventSubscriber]struct{}
+}
+
+// StatusEventHubConfig contains configuration for the status event hub
+type StatusEventHubConfig struct {
+	DefaultRegion string        // Region of phone number filters given without a country code
+	ResumeWindow  time.Duration // How far back a resuming client is sent the changes it missed
+	PollInterval  time.Duration // How often the log is read without a notification
+	BufferSize    int           // Changes queued per client before it is disconnected as too slow
+	GapTimeout    time.Duration // How long a change stored out of order is waited for
+}
+
+// NewStatusEventHub creates a new status event hub
+func NewStatusEventHub(
+	events repositories.StatusEventRepository,
+	notifier servicePorts.StatusEventNotifier,
+	config StatusEventHubConfig,
+) *StatusEventHub {
+	if config.ResumeWindow <= 0 {
+		config.ResumeWindow = 24 * time.Hour
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 256
+	}
+	if config.GapTimeout <= 0 {
+		config.GapTimeout = 30 * time.Second
+	}
+
+	return &StatusEventHub{
+		events:      events,
+		notifier:    notifier,
+		config:      config,
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		cursor:      statusEventCursor{emitted: make(map[int64]bool)},
+		subscribers: make(map[*statusEventSubscriber]struct{}),
+	}
+}
+
+// Start begins following the status history in the background, from its current end
+func (h *StatusEventHub) Start(ctx context.Context) error {
+	h.mu.Lock()
+	if h.isRunning {
+		h.mu.Unlock()
+		return fmt.Errorf("status event hub is already running")
+	}
+
+	latestID, err := h.events.LatestID(ctx)
+	if err != nil {
+		h.mu.Unlock()
+		return fmt.Errorf("failed to read the status history: %w", err)
+	}
+	h.cursor.settled = latestID
+	h.settled.Store(latestID)
+
+	h.isRunning = true
+	h.mu.Unlock()
+
+	log.Printf("📺 Starting status event hub (poll interval: %v, resume window: %v)", h.config.PollInterval, h.config.ResumeWindow)
+
+	go h.run(ctx)
+	return nil
+}
+
+// Stop gracefully stops the hub and disconnects its stream clients
+func (h *StatusEventHub) Stop() error {
+	h.mu.Lock()
+	if !h.isRunning {
+		h.mu.Unlock()
+		return fmt.Errorf("status event hub is not running")
+	}
+	h.mu.Unlock()
+
+	log.Println("🛑 Stopping status event hub...")
+	close(h.stopChan)
+
+	select {
+	case <-h.doneChan:
+		log.Println("✅ Status event hub stopped gracefully")
+		return nil
+	case <-time.After(30 * time.Second):
+		log.Println("⚠️ Status event hub stop timed out")
+		return fmt.Errorf("status event hub stop timed out")
+	}
+}
+
+// IsRunning returns whether the hub is currently running
+func (h *StatusEventHub) IsRunning() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.isRunning
+}
+
+// StreamStatusEvents returns the status changes matching the query as they are stored, preceded by the
+// stored ones after the query's LastEventID, as far back as the resume window reaches
+func (h *StatusEventHub) StreamStatusEvents(ctx context.Context, query usecases.StreamStatusEventsQuery) (<-chan usecases.StatusEventResponse, error) {
+	filter, err := h.parseFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	if !h.IsRunning() {
+		return nil, errors.NewBusinessError("message stream is not running")
+	}
+
+	// Changes above the settled position may reach the client both from the log and live,
+	// so the ones sent from the log are remembered until they come by live
+	settled := h.settled.Load()
+
+	subscriber := &statusEventSubscriber{
+		filter: filter,
+		live:   make(chan *message.StatusEvent, h.config.BufferSize),
+	}
+	h.subscribersMu.Lock()
+	h.subscribers[subscriber] = struct{}{}
+	h.subscribersMu.Unlock()
+
+	out := make(chan usecases.StatusEventResponse)
+	go func() {
+		defer close(out)
+		defer h.unsubscribe(subscriber)
+
+		sent := make(map[int64]bool)
+		if query.LastEventID != nil {
+			if err := h.resume(ctx, filter, *query.LastEventID, settled, sent, out); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("❌ Error resuming message stream after event %d: %v", *query.LastEventID, err)
+				}
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscriber.live:
+				if !ok {
+					return
+				}
+				if sent[event.ID] {
+					delete(sent, event.ID)
+					continue
+				}
+				select {
+				case out <- statusEventToResponse(event):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// resume sends the stored changes after lastEventID within the resume window
+func (h *StatusEventHub) resume(ctx context.Context, filter repositories.StatusEventFilter, lastEventID, settled int64, sent map[int64]bool, out chan<- usecases.StatusEventResponse) error {
+	since := time.Now().Add(-h.config.ResumeWindow)
+	filter.AfterID = lastEventID
+	filter.Since = &since
+
+	for {
+		events, err := h.events.ListAfter(ctx, filter, statusEventPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			select {
+			case out <- statusEventToResponse(event):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if event.ID > settled {
+				sent[event.ID] = true
+			}
+			filter.AfterID = event.ID
+		}
+
+		if len(events) < statusEventPageSize {
+			return nil
+		}
+	}
+}
+
+// parseFilter validates the statuses and phone number a client streams
+func (h *StatusEventHub) parseFilter(query usecases.StreamStatusEventsQuery) (repositories.StatusEventFilter, error) {
+	var filter repositories.StatusEventFilter
+
+	for _, name := range query.Statuses {
+		status := message.Status(strings.ToUpper(strings.TrimSpace(name)))
+		if !status.IsValid() {
+			return filter, errors.NewValidationError("invalid status: %s", name)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if query.PhoneNumber != "" {
+		phoneNumber, err := message.ParsePhoneNumber(query.PhoneNumber, h.config.DefaultRegion)
+		if err != nil {
+			return filter, err
+		}
+		filter.PhoneNumber = &phoneNumber
+	}
+
+	if query.LastEventID != nil && *query.LastEventID < 0 {
+		return filter, errors.NewValidationError("invalid last event ID: %d", *query.LastEventID)
+	}
+
+	return filter, nil
+}
+
+// unsubscribe removes a client; its live channel is closed by whoever removes it first
+func (h *StatusEventHub) unsubscribe(subscriber *statusEventSubscriber) {
+	h.subscribersMu.Lock()
+	defer h.subscribersMu.Unlock()
+
+	if _, ok := h.subscribers[subscriber]; ok {
+		delete(h.subscribers, subscriber)
+		close(subscriber.live)
+	}
+}
+
+// run is the main loop, reading the log whenever a replica signals new changes and at every poll interval
+func (h *StatusEventHub) run(ctx context.Context) {
+	defer close(h.doneChan)
+	defer func() {
+		h.mu.Lock()
+		h.isRunning = false
+		h.mu.Unlock()
+	}()
+	defer h.disconnectAll()
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wake := make(chan struct{}, 1)
+	go h.listen(listenCtx, func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+
+	ticker := time.NewTicker(h.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("📤 Status event hub stopped due to context cancellation")
+			return
+		case <-h.stopChan:
+			log.Println("📤 Status event hub received stop signal")
+			return
+		case <-wake:
+		case <-ticker.C:
+		}
+
+		h.readLog(ctx)
+	}
+}
+
+// listen keeps a notification listener running, reconnecting after failures; polling covers the gaps
+func (h *StatusEventHub) listen(ctx context.Context, notify func()) {
+	for {
+		err := h.notifier.Listen(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !stdErrors.Is(err, context.Canceled) {
+			log.Printf("⚠️ Status event notifications interrupted, reconnecting: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.config.PollInterval):
+		}
+	}
+}
+
+// readLog broadcasts the changes stored since the settled position that were not broadcast yet
+func (h *StatusEventHub) readLog(ctx context.Context) {
+	filter := repositories.StatusEventFilter{AfterID: h.cursor.settled}
+	for {
+		events, err := h.events.ListAfter(ctx, filter, statusEventPageSize)
+		if err != nil {
+			log.Printf("❌ Error reading status events: %v", err)
+			return
+		}
+
+		for _, event := range events {
+			if h.cursor.emit(event.ID) {
+				h.broadcast(event)
+			}
+			filter.AfterID = event.ID
+		}
+
+		if len(events) < statusEventPageSize {
+			break
+		}
+	}
+
+	h.cursor.settle(time.Now(), h.config.GapTimeout)
+	h.settled.Store(h.cursor.settled)
+}
+
+// broadcast queues a change for every client whose filter it matches. Clients too slow to keep up
+// are disconnected rather than holding up the others; they resume from their last event.
+func (h *StatusEventHub) broadcast(event *message.StatusEvent) {
+	h.subscribersMu.Lock()
+	defer h.subscribersMu.Unlock()
+
+	for subscriber := range h.subscribers {
+		if !subscriber.matches(event) {
+			continue
+		}
+		select {
+		case subscriber.live <- event:
+		default:
+			delete(h.subscribers, subscriber)
+			close(subscriber.live)
+		}
+	}
+}
+
+// disconnectAll ends every client stream
+func (h *StatusEventHub) disconnectAll() {
+	h.subscribersMu.Lock()
+	defer h.subscribersMu.Unlock()
+
+	for subscriber := range h.subscribers {
+		delete(h.subscribers, subscriber)
+		close(subscriber.live)
+	}
+}
+
+// statusEventSubscriber is a connected stream client
+type statusEventSubscriber struct {
+	filter repositories.StatusEventFilter
+	live   chan *message.StatusEvent
+}
+
+// matches checks if the client streams the given change
+func (s *statusEventSubscriber) matches(event *message.StatusEvent) bool {
+	if s.filter.PhoneNumber != nil && *s.filter.PhoneNumber != event.PhoneNumber {
+		return false
+	}
+	if len(s.filter.Statuses) == 0 {
+		return true
+	}
+	for _, status := range s.filter.Statuses {
+		if status == event.To {
+			return true
+		}
+	}
+	return false
+}
+
+// statusEventCursor tracks which changes were broadcast. Event IDs are assigned when a change is
+// written but become visible when its transaction commits, so a change can show up after later ones.
+type statusEventCursor struct {
+	settled  int64          // Every change up to here was broadcast or given up on
+	emitted  map[int64]bool // Changes above settled that were broadcast
+	gapSince time.Time      // When a missing change below broadcast ones was first noticed
+}
+
+// emit records a change and reports whether it still has to be broadcast
+func (c *statusEventCursor) emit(id int64) bool {
+	if id <= c.settled || c.emitted[id] {
+		return false
+	}
+	c.emitted[id] = true
+	return true
+}
+
+// settle advances past the broadcast changes. A missing change is waited for up to gapTimeout,
+// after which it is assumed rolled back and skipped; if it commits later it is only in the log.
+func (c *statusEventCursor) settle(now time.Time, gapTimeout time.Duration) {
+	for len(c.emitted) > 0 {
+		if c.emitted[c.settled+1] {
+			delete(c.emitted, c.settled+1)
+			c.settled++
+			c.gapSince = time.Time{}
+			continue
+		}
+
+		if c.gapSince.IsZero() {
+			c.gapSince = now
+		}
+		if now.Sub(c.gapSince) < gapTimeout {
+			return
+		}
+
+		next := int64(-1)
+		for id := range c.emitted {
+			if next < 0 || id < next {
+				next = id
+			}
+		}
+		c.settled = next - 1
+		c.gapSince = time.Time{}
+	}
+}
+
+// statusEventToResponse converts a status change to its stream representation
+func statusEventToResponse(event *message.StatusEvent) usecases.StatusEventResponse {
+	messageID, _ := uuid.Parse(event.MessageID.String())
+
+	response := usecases.StatusEventResponse{
+		ID:          event.ID,
+		Type:        usecases.MessageStatusChangedEvent,
+		MessageID:   messageID,
+		PhoneNumber: event.PhoneNumber.String(),
+		To:          event.To.String(),
+		Trigger:     event.Trigger.String(),
+		OccurredAt:  event.OccurredAt,
+	}
+
+	if event.From == nil {
+		response.Type = usecases.MessageCreatedEvent
+	} else {
+		from := event.From.String()
+		response.From = &from
+	}
+
+	return response
+}
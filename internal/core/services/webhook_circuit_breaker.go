@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+)
+
+// WebhookCircuitBreaker wraps a WebhookService and stops calling the endpoint for a while once it keeps
+// failing, so deliveries do not spend their retries on a provider that is down
+type WebhookCircuitBreaker struct {
+	next   servicePorts.WebhookService
+	config CircuitBreakerConfig
+
+	mu                  sync.Mutex
+	state               servicePorts.CircuitState
+	consecutiveFailures int
+	openedAt            *time.Time
+	trials              int // Trial requests in flight while half-open
+	trialSuccesses      int
+}
+
+// CircuitBreakerConfig contains configuration for the webhook circuit breaker
+type CircuitBreakerConfig struct {
+	Name             string        // Provider the circuit belongs to, used in logs
+	FailureThreshold int           // Consecutive transient failures that open the circuit
+	OpenDuration     time.Duration // How long the circuit stays open before trial requests are sent
+	HalfOpenRequests int           // Trial requests that must all succeed to close the circuit again
+}
+
+// NewWebhookCircuitBreaker creates a closed circuit breaker around the webhook service
+func NewWebhookCircuitBreaker(next servicePorts.WebhookService, config CircuitBreakerConfig) *WebhookCircuitBreaker {
+	if config.Name == "" {
+		config.Name = "default"
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenDuration <= 0 {
+		config.OpenDuration = 30 * time.Second
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	return &WebhookCircuitBreaker{
+		next:   next,
+		config: config,
+		state:  servicePorts.CircuitClosed,
+	}
+}
+
+// SendMessage sends the message unless the circuit is open, in which case it fails with a CircuitOpenError
+func (b *WebhookCircuitBreaker) SendMessage(ctx context.Context, request servicePorts.WebhookRequest) (*servicePorts.WebhookResponse, error) {
+	trial, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.next.SendMessage(ctx, request)
+	b.record(ctx, err, trial)
+
+	return response, err
+}
+
+// IsHealthy checks if the underlying webhook service is healthy
+func (b *WebhookCircuitBreaker) IsHealthy(ctx context.Context) error {
+	return b.next.IsHealthy(ctx)
+}
+
+// Status returns the current state of the circuit
+func (b *WebhookCircuitBreaker) Status() servicePorts.CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(time.Now())
+
+	status := servicePorts.CircuitStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+	}
+	if b.openedAt != nil {
+		openedAt := *b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	if b.state == servicePorts.CircuitOpen {
+		retryAt := b.openedAt.Add(b.config.OpenDuration)
+		status.RetryAt = &retryAt
+	}
+
+	return status
+}
+
+// acquire lets a request through, reporting whether it is a trial of a half-open circuit
+func (b *WebhookCircuitBreaker) acquire() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refresh(now)
+
+	switch b.state {
+	case servicePorts.CircuitOpen:
+		return false, servicePorts.NewCircuitOpenError(b.openedAt.Add(b.config.OpenDuration))
+	case servicePorts.CircuitHalfOpen:
+		// Other requests wait until the trials decided the state
+		if b.trials+b.trialSuccesses >= b.config.HalfOpenRequests {
+			return false, servicePorts.NewCircuitOpenError(now)
+		}
+		b.trials++
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// record updates the circuit with the outcome of a request
+func (b *WebhookCircuitBreaker) record(ctx context.Context, err error, trial bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if trial {
+		b.trials--
+	}
+
+	switch {
+	case isCircuitSuccess(err):
+		b.consecutiveFailures = 0
+		if trial && b.state == servicePorts.CircuitHalfOpen {
+			b.trialSuccesses++
+			if b.trialSuccesses >= b.config.HalfOpenRequests {
+				log.Printf("✅ Webhook circuit of provider %s closed after %d successful trial requests", b.config.Name, b.trialSuccesses)
+				b.state = servicePorts.CircuitClosed
+				b.trialSuccesses = 0
+			}
+		}
+	case isCircuitFailure(ctx, err):
+		b.consecutiveFailures++
+		switch {
+		case b.state == servicePorts.CircuitHalfOpen:
+			log.Printf("⛔ Webhook circuit of provider %s reopened, trial request failed: %v", b.config.Name, err)
+			b.open(time.Now())
+		case b.state == servicePorts.CircuitClosed && b.consecutiveFailures >= b.config.FailureThreshold:
+			log.Printf("⛔ Webhook circuit of provider %s opened after %d consecutive failures, pausing deliveries for %v: %v",
+				b.config.Name, b.consecutiveFailures, b.config.OpenDuration, err)
+			b.open(time.Now())
+		}
+	}
+}
+
+// open rejects requests until the open duration has passed
+func (b *WebhookCircuitBreaker) open(now time.Time) {
+	b.state = servicePorts.CircuitOpen
+	b.openedAt = &now
+	b.trials = 0
+	b.trialSuccesses = 0
+}
+
+// refresh moves an open circuit to half-open once its open duration has passed
+func (b *WebhookCircuitBreaker) refresh(now time.Time) {
+	if b.state != servicePorts.CircuitOpen || now.Before(b.openedAt.Add(b.config.OpenDuration)) {
+		return
+	}
+
+	log.Printf("🔌 Webhook circuit of provider %s half-open, sending up to %d trial requests", b.config.Name, b.config.HalfOpenRequests)
+	b.state = servicePorts.CircuitHalfOpen
+	b.trials = 0
+	b.trialSuccesses = 0
+}
+
+// isCircuitSuccess reports whether the endpoint answered; a permanent rejection still shows it is up
+func isCircuitSuccess(err error) bool {
+	var webhookErr *servicePorts.WebhookError
+	return err == nil || (errors.As(err, &webhookErr) && webhookErr.Permanent)
+}
+
+// isCircuitFailure reports whether the error shows the endpoint failing. Requests cut short by the
+// caller, held back by the rate limiter or rejected before being sent say nothing about the endpoint.
+func isCircuitFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var (
+		throttledErr   *servicePorts.ThrottledError
+		circuitOpenErr *servicePorts.CircuitOpenError
+		validationErr  domainErrors.ValidationError
+	)
+	return !errors.As(err, &throttledErr) && !errors.As(err, &circuitOpenErr) && !errors.As(err, &validationErr)
+}
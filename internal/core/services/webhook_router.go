@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+)
+
+// WebhookProvider is a named webhook endpoint the router can send through
+type WebhookProvider struct {
+	Name    string
+	Service servicePorts.WebhookService
+	Circuit servicePorts.CircuitBreaker // Circuit breaker in front of Service, nil if it has none
+}
+
+// WebhookRouter sends each message through the first provider that accepts it. It fails over to the
+// next provider when one fails transiently, is over its throughput limit or its circuit is open.
+type WebhookRouter struct {
+	providers []WebhookProvider
+}
+
+// NewWebhookRouter creates a router trying the providers in the given order
+func NewWebhookRouter(providers []WebhookProvider) *WebhookRouter {
+	return &WebhookRouter{
+		providers: providers,
+	}
+}
+
+// SendMessage sends the message through the providers in order and names the one that accepted it in the
+// response. Permanent rejections are returned at once, the next provider would not change them. If no provider
+// was tried because each is throttled or open, the ThrottledError with the shortest wait is returned, or the
+// CircuitOpenError of the circuit reopening first if none is throttled.
+func (r *WebhookRouter) SendMessage(ctx context.Context, request servicePorts.WebhookRequest) (*servicePorts.WebhookResponse, error) {
+	if len(r.providers) == 0 {
+		return nil, domainErrors.NewValidationError("no webhook provider is configured")
+	}
+
+	var (
+		lastErr      error
+		throttledErr *servicePorts.ThrottledError
+		earliestErr  *servicePorts.CircuitOpenError
+	)
+	for i, provider := range r.providers {
+		response, err := provider.Service.SendMessage(ctx, request)
+		if err == nil {
+			if i > 0 {
+				log.Printf("🔀 Message %s sent through fallback webhook provider %s", request.MessageID, provider.Name)
+			}
+			response.Provider = provider.Name
+			return response, nil
+		}
+
+		var (
+			providerThrottledErr *servicePorts.ThrottledError
+			circuitOpenErr       *servicePorts.CircuitOpenError
+		)
+		switch {
+		case errors.As(err, &providerThrottledErr) && ctx.Err() == nil:
+			// Every provider has its own bucket, the next one may have capacity
+			if throttledErr == nil || providerThrottledErr.RetryAfter < throttledErr.RetryAfter {
+				throttledErr = providerThrottledErr
+			}
+		case errors.As(err, &circuitOpenErr):
+			if earliestErr == nil || circuitOpenErr.RetryAt.Before(earliestErr.RetryAt) {
+				earliestErr = circuitOpenErr
+			}
+		case isFailoverError(ctx, err):
+			lastErr = err
+			if i < len(r.providers)-1 {
+				log.Printf("🔀 Webhook provider %s failed for message %s, failing over to %s: %v",
+					provider.Name, request.MessageID, r.providers[i+1].Name, err)
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	// A provider that was tried and failed counts as a delivery attempt, one held back by its limit or circuit does not
+	switch {
+	case lastErr != nil:
+		return nil, lastErr
+	case throttledErr != nil:
+		return nil, throttledErr
+	default:
+		return nil, earliestErr
+	}
+}
+
+// IsHealthy reports the router healthy while any of its providers is
+func (r *WebhookRouter) IsHealthy(ctx context.Context) error {
+	var lastErr error
+	for _, provider := range r.providers {
+		if lastErr = provider.Service.IsHealthy(ctx); lastErr == nil {
+			return nil
+		}
+	}
+
+	if lastErr == nil {
+		return domainErrors.NewValidationError("no webhook provider is configured")
+	}
+	return lastErr
+}
+
+// Status returns the circuit of the first provider that is not open, so the router only reports an open
+// circuit once no provider can take messages. In that case the provider reopening first is reported.
+func (r *WebhookRouter) Status() servicePorts.CircuitStatus {
+	var earliest *servicePorts.CircuitStatus
+	for _, provider := range r.providers {
+		if provider.Circuit == nil {
+			return servicePorts.CircuitStatus{State: servicePorts.CircuitClosed}
+		}
+
+		status := provider.Circuit.Status()
+		if !status.IsOpen() {
+			return status
+		}
+		if earliest == nil || status.RetryAt.Before(*earliest.RetryAt) {
+			earliest = &status
+		}
+	}
+
+	if earliest == nil {
+		return servicePorts.CircuitStatus{State: servicePorts.CircuitClosed}
+	}
+	return *earliest
+}
+
+// isFailoverError reports whether another provider may succeed where this one failed: the endpoint failed
+// transiently or could not be reached. Cancelled requests and rejected messages end the delivery.
+func isFailoverError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var webhookErr *servicePorts.WebhookError
+	return errors.As(err, &webhookErr) && !webhookErr.Permanent
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// deliveryReceiptService implements DeliveryReceiptUseCase
+type deliveryReceiptService struct {
+	messageRepo  repositories.MessageRepository
+	receiptRepo  repositories.DeliveryReceiptRepository
+	cacheService services.CacheService
+}
+
+// NewDeliveryReceiptService creates a new delivery receipt use case
+func NewDeliveryReceiptService(
+	messageRepo repositories.MessageRepository,
+	receiptRepo repositories.DeliveryReceiptRepository,
+	cacheService services.CacheService,
+) usecases.DeliveryReceiptUseCase {
+	return &deliveryReceiptService{
+		messageRepo:  messageRepo,
+		receiptRepo:  receiptRepo,
+		cacheService: cacheService,
+	}
+}
+
+// ReceiveDeliveryReceipt stores a delivery receipt and applies it to its message if the message is already marked SENT.
+// The receipt is stored first so that one arriving before the SENT update commits is picked up by the sender right after.
+func (s *deliveryReceiptService) ReceiveDeliveryReceipt(ctx context.Context, cmd usecases.ReceiveDeliveryReceiptCommand) (*usecases.DeliveryReceiptResponse, error) {
+	status := message.Status(strings.ToUpper(strings.TrimSpace(cmd.Status)))
+
+	receipt, err := message.NewDeliveryReceipt(cmd.ExternalID, status, cmd.ErrorCode, cmd.ReportedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.receiptRepo.Create(ctx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first receipt for an external ID is the one on record; a repeated one only retries applying it
+	if !created {
+		if receipt, err = s.receiptRepo.GetByExternalID(ctx, receipt.ExternalID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !receipt.IsApplied() {
+		msg, err := s.messageRepo.GetByExternalID(ctx, receipt.ExternalID)
+
+		var notFoundErr errors.NotFoundError
+		switch {
+		case stdErrors.As(err, &notFoundErr):
+			// Not marked SENT yet; the sender applies the receipt once it is
+		case err != nil:
+			return nil, err
+		default:
+			if err := applyDeliveryReceipt(ctx, s.messageRepo, s.receiptRepo, s.cacheService, msg, receipt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	response := deliveryReceiptToResponse(receipt)
+	response.Duplicate = !created
+	return response, nil
+}
+
+// applyDeliveryReceipt records the delivery outcome on a sent message and marks the receipt applied.
+// It is safe to run concurrently for the same receipt: only one run changes the message.
+func applyDeliveryReceipt(
+	ctx context.Context,
+	messageRepo repositories.MessageRepository,
+	receiptRepo repositories.DeliveryReceiptRepository,
+	cacheService services.CacheService,
+	msg *message.Message,
+	receipt *message.DeliveryReceipt,
+) error {
+	changed, err := msg.ApplyDeliveryReceipt(receipt)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		err := messageRepo.UpdateSent(ctx, msg)
+
+		// Another run applied a receipt to the message first
+		var conflictErr errors.ConflictError
+		if err != nil && !stdErrors.As(err, &conflictErr) {
+			return err
+		}
+		if err == nil {
+			evictSentMessageCache(ctx, cacheService, msg)
+		}
+	}
+
+	receipt.MarkApplied(msg.ID)
+	return receiptRepo.MarkApplied(ctx, receipt)
+}
+
+// evictSentMessageCache removes a message with a delivery outcome from the recently sent messages cache,
+// which only lists messages still SENT
+func evictSentMessageCache(ctx context.Context, cacheService services.CacheService, msg *message.Message) {
+	if msg.ExternalID != nil {
+		// Cache failures shouldn't break the flow, the listing skips entries that are no longer SENT
+		_ = cacheService.ZRem(ctx, sentMessagesCacheKey, sentMessageCacheMember(msg, *msg.ExternalID))
+	}
+	_ = cacheService.Delete(ctx, sentMessageDetailCachePrefix+msg.ID.String())
+}
+
+// deliveryReceiptToResponse converts a domain delivery receipt to response DTO
+func deliveryReceiptToResponse(receipt *message.DeliveryReceipt) *usecases.DeliveryReceiptResponse {
+	response := &usecases.DeliveryReceiptResponse{
+		ExternalID: receipt.ExternalID,
+		Status:     receipt.Status.String(),
+		ErrorCode:  receipt.ErrorCode,
+		ReportedAt: receipt.ReportedAt,
+		ReceivedAt: receipt.ReceivedAt,
+		AppliedAt:  receipt.AppliedAt,
+	}
+
+	if receipt.MessageID != nil {
+		id, _ := uuid.Parse(receipt.MessageID.String())
+		response.MessageID = &id
+	}
+
+	return response
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Default event delivery retry policy, claim lease and batch sizing
+const (
+	defaultEventMaxAttempts      = 8
+	defaultEventRetryBackoffBase = 30 * time.Second
+	defaultEventRetryBackoffMax  = time.Hour
+	defaultEventLeaseDuration    = 2 * time.Minute
+	defaultEventConcurrency      = 4
+	defaultEventBatchSize        = 50
+)
+
+// eventDeliveryService implements EventDeliveryUseCase
+type eventDeliveryService struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	deliveryRepo     repositories.EventDeliveryRepository
+	callbackService  services.EventCallbackService
+	config           usecases.EventDeliveryConfig
+}
+
+// NewEventDeliveryService creates a new event delivery use case
+func NewEventDeliveryService(
+	subscriptionRepo repositories.SubscriptionRepository,
+	deliveryRepo repositories.EventDeliveryRepository,
+	callbackService services.EventCallbackService,
+	config usecases.EventDeliveryConfig,
+) usecases.EventDeliveryUseCase {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultEventMaxAttempts
+	}
+	if config.RetryBackoffBase <= 0 {
+		config.RetryBackoffBase = defaultEventRetryBackoffBase
+	}
+	if config.RetryBackoffMax < config.RetryBackoffBase {
+		config.RetryBackoffMax = defaultEventRetryBackoffMax
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaultEventLeaseDuration
+	}
+	if config.WorkerID == "" {
+		config.WorkerID = defaultWorkerID()
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultEventConcurrency
+	}
+
+	return &eventDeliveryService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		callbackService:  callbackService,
+		config:           config,
+	}
+}
+
+// DeliverPendingEvents claims a batch of due deliveries and sends each to its subscription endpoint
+func (s *eventDeliveryService) DeliverPendingEvents(ctx context.Context, batchSize int) (*usecases.EventDeliveryResult, error) {
+	if batchSize <= 0 {
+		batchSize = defaultEventBatchSize
+	}
+
+	deliveries, err := s.deliveryRepo.ClaimDue(ctx, s.config.WorkerID, batchSize, s.config.LeaseDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &usecases.EventDeliveryResult{
+		ClaimedCount: len(deliveries),
+		Errors:       []error{},
+	}
+	if len(deliveries) == 0 {
+		return result, nil
+	}
+
+	// Finish the batch before other replicas may claim its deliveries again
+	batchCtx, cancel := context.WithTimeout(ctx, s.config.LeaseDuration)
+	defer cancel()
+
+	subscriptions := &subscriptionCache{repo: s.subscriptionRepo, loaded: make(map[message.SubscriptionID]*message.Subscription)}
+	jobs := make(chan *message.EventDelivery)
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	workers := min(s.config.Concurrency, len(deliveries))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				err := s.deliver(batchCtx, subscriptions, delivery)
+
+				mu.Lock()
+				switch {
+				case delivery.Status == message.EventDeliveryDelivered:
+					result.DeliveredCount++
+				case delivery.Status == message.EventDeliveryFailed:
+					result.FailedCount++
+				default:
+					result.RetryingCount++
+				}
+				if err != nil {
+					result.Errors = append(result.Errors, errors.NewBusinessErrorWithCause(err, "failed to deliver event %d to subscription %s", delivery.Event.ID, delivery.SubscriptionID))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, delivery := range deliveries {
+		jobs <- delivery
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return result, nil
+}
+
+// deliver sends a single event and stores the outcome, scheduling a retry if the endpoint did not acknowledge it
+func (s *eventDeliveryService) deliver(ctx context.Context, subscriptions *subscriptionCache, delivery *message.EventDelivery) error {
+	subscription, err := subscriptions.get(ctx, delivery.SubscriptionID)
+	if err != nil {
+		// Left leased; the delivery is claimed again once the lease runs out
+		return err
+	}
+
+	callback, err := buildEventCallback(subscription, delivery)
+	if err != nil {
+		return err
+	}
+
+	statusCode, sendErr := s.callbackService.Send(ctx, callback)
+	if sendErr == nil {
+		delivery.RecordSuccess(statusCode)
+	} else {
+		delivery.RecordFailure(statusCode, sendErr.Error(), s.config.MaxAttempts, s.retryDelay(delivery.Attempts))
+	}
+
+	// Keep the log complete even if the batch ran out of time meanwhile
+	if err := s.deliveryRepo.UpdateClaimed(context.WithoutCancel(ctx), delivery, s.config.WorkerID); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+// retryDelay returns an exponential backoff with equal jitter for the given number of attempts made
+func (s *eventDeliveryService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryBackoffBase
+	for i := 1; i < attempts && delay < s.config.RetryBackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > s.config.RetryBackoffMax {
+		delay = s.config.RetryBackoffMax
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// buildEventCallback renders the event as JSON and signs it with the subscription secret
+func buildEventCallback(subscription *message.Subscription, delivery *message.EventDelivery) (services.EventCallback, error) {
+	event := delivery.Event
+	eventID := strconv.FormatInt(event.ID, 10)
+
+	payload := services.StatusEventPayload{
+		ID:         eventID,
+		Type:       services.StatusEventType,
+		OccurredAt: event.OccurredAt,
+		Replay:     delivery.Replay,
+		Data: services.StatusEventData{
+			MessageID:   event.MessageID.String(),
+			PhoneNumber: event.PhoneNumber.String(),
+			To:          event.To.String(),
+			Trigger:     event.Trigger.String(),
+		},
+	}
+	if event.From != nil {
+		from := event.From.String()
+		payload.Data.From = &from
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return services.EventCallback{}, errors.NewBusinessError("failed to marshal event %s: %v", eventID, err)
+	}
+
+	timestamp := time.Now()
+	return services.EventCallback{
+		URL:       subscription.URL,
+		EventID:   eventID,
+		Timestamp: timestamp,
+		Signature: subscription.Sign(timestamp, body),
+		Body:      body,
+	}, nil
+}
+
+// subscriptionCache loads each subscription of a batch once
+type subscriptionCache struct {
+	repo   repositories.SubscriptionRepository
+	mu     sync.Mutex
+	loaded map[message.SubscriptionID]*message.Subscription
+}
+
+// get returns the subscription with the given ID, loading it on first use
+func (c *subscriptionCache) get(ctx context.Context, id message.SubscriptionID) (*message.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if subscription, ok := c.loaded[id]; ok {
+		return subscription, nil
+	}
+
+	subscription, err := c.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.loaded[id] = subscription
+	return subscription, nil
+}
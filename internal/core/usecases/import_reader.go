@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// maxImportLineLength bounds a single NDJSON line
+const maxImportLineLength = 1 << 20
+
+// importRow is a single data row read from an import file
+type importRow struct {
+	number int    // 1-based data row number
+	raw    string // Row as it appeared in the file, for the error report
+	cmd    usecases.CreateMessageCommand
+	err    error // Set when the row could not be parsed into a command
+}
+
+// importRowReader reads the data rows of an import file one at a time
+type importRowReader interface {
+	// Next returns the next row, or io.EOF at the end of the file.
+	// Any other error means the rest of the file cannot be read.
+	Next() (importRow, error)
+}
+
+// newImportRowReader creates a row reader for the given format.
+// With a default template, rows may leave out content and carry only template variables.
+func newImportRowReader(format message.ImportFormat, source io.Reader, defaultTemplate bool) (importRowReader, error) {
+	switch format {
+	case message.ImportFormatCSV:
+		return newCSVRowReader(source, defaultTemplate)
+	case message.ImportFormatNDJSON:
+		return newNDJSONRowReader(source), nil
+	default:
+		return nil, domainErrors.NewValidationError("unsupported import format: %s", format)
+	}
+}
+
+// csvColumns maps normalized CSV header names to the fields they fill.
+// Any other column is passed to the template as a variable named after its header.
+var csvColumns = map[string]string{
+	"phone":       "phoneNumber",
+	"phonenumber": "phoneNumber",
+	"content":     "content",
+	"templateid":  "templateId",
+	"sendat":      "sendAt",
+	"expiresat":   "expiresAt",
+	"ttl":         "ttl",
+	"priority":    "priority",
+}
+
+// csvRowReader reads rows of a CSV file with a header line
+type csvRowReader struct {
+	reader    *csv.Reader
+	columns   map[string]int // Field name to column index
+	variables map[string]int // Template variable name to column index
+	row       int
+}
+
+// newCSVRowReader reads the header of a CSV file and prepares to read its rows
+func newCSVRowReader(source io.Reader, defaultTemplate bool) (*csvRowReader, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domainErrors.NewValidationError("import file is empty")
+	}
+	if err != nil {
+		return nil, domainErrors.NewValidationError("invalid CSV header: %v", err)
+	}
+
+	columns := make(map[string]int)
+	variables := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // Byte order mark written by spreadsheet exports
+		normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(name))
+		if field, ok := csvColumns[normalized]; ok {
+			columns[field] = i
+		} else if name != "" {
+			variables[name] = i
+		}
+	}
+
+	if _, ok := columns["phoneNumber"]; !ok {
+		return nil, domainErrors.NewValidationError("CSV header is missing the phoneNumber column")
+	}
+
+	// Without content, every row needs a template to render from
+	_, hasContent := columns["content"]
+	_, hasTemplate := columns["templateId"]
+	if !hasContent && !hasTemplate && !defaultTemplate {
+		return nil, domainErrors.NewValidationError("CSV header is missing the content column (or a templateId column or import template)")
+	}
+
+	return &csvRowReader{reader: reader, columns: columns, variables: variables}, nil
+}
+
+// Next returns the next CSV row
+func (r *csvRowReader) Next() (importRow, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+
+	r.row++
+	row := importRow{number: r.row, raw: strings.Join(record, ",")}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		row.err = domainErrors.NewValidationError("malformed CSV row: %v", parseErr.Err)
+		return row, nil
+	}
+	if err != nil {
+		return importRow{}, err
+	}
+
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row.cmd = usecases.CreateMessageCommand{
+		PhoneNumber: field("phoneNumber"),
+		Content:     field("content"),
+		Priority:    field("priority"),
+	}
+
+	if templateID := field("templateId"); templateID != "" {
+		id, err := uuid.Parse(templateID)
+		if err != nil {
+			row.err = domainErrors.NewValidationError("invalid templateId: %s", templateID)
+			return row, nil
+		}
+		row.cmd.TemplateID = &id
+	}
+
+	// Variables only apply to rows rendered from a template
+	if row.cmd.Content == "" && len(r.variables) > 0 {
+		row.cmd.Variables = make(map[string]string, len(r.variables))
+		for name, i := range r.variables {
+			if i < len(record) {
+				row.cmd.Variables[name] = strings.TrimSpace(record[i])
+			}
+		}
+	}
+
+	row.err = parseScheduling(&row.cmd, field("sendAt"), field("expiresAt"), field("ttl"))
+
+	return row, nil
+}
+
+// ndjsonRow is a single line of an NDJSON import, shaped like a create message request
+type ndjsonRow struct {
+	PhoneNumber string            `json:"phoneNumber"`
+	Content     string            `json:"content"`
+	TemplateID  *uuid.UUID        `json:"templateId"`
+	Variables   map[string]string `json:"variables"`
+	SendAt      string            `json:"sendAt"`
+	ExpiresAt   string            `json:"expiresAt"`
+	TTL         string            `json:"ttl"`
+	Priority    string            `json:"priority"`
+}
+
+// ndjsonRowReader reads rows of a newline-delimited JSON file, skipping blank lines
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+// newNDJSONRowReader prepares to read the rows of an NDJSON file
+func newNDJSONRowReader(source io.Reader) *ndjsonRowReader {
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineLength)
+	return &ndjsonRowReader{scanner: scanner}
+}
+
+// Next returns the next non-blank NDJSON line
+func (r *ndjsonRowReader) Next() (importRow, error) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		r.row++
+		row := importRow{number: r.row, raw: line}
+
+		var data ndjsonRow
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			row.err = domainErrors.NewValidationError("malformed JSON line: %v", err)
+			return row, nil
+		}
+
+		row.cmd = usecases.CreateMessageCommand{
+			PhoneNumber: data.PhoneNumber,
+			Content:     data.Content,
+			TemplateID:  data.TemplateID,
+			Variables:   data.Variables,
+			Priority:    data.Priority,
+		}
+		row.err = parseScheduling(&row.cmd, data.SendAt, data.ExpiresAt, data.TTL)
+
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return importRow{}, fmt.Errorf("failed to read import file: %w", err)
+	}
+	return importRow{}, io.EOF
+}
+
+// parseScheduling fills the optional send time, expiry and TTL of a command from their text form
+func parseScheduling(cmd *usecases.CreateMessageCommand, sendAt, expiresAt, ttl string) error {
+	if sendAt != "" {
+		t, err := time.Parse(time.RFC3339, sendAt)
+		if err != nil {
+			return domainErrors.NewValidationError("invalid sendAt: must be an RFC 3339 time")
+		}
+		cmd.SendAt = &t
+	}
+
+	if expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return domainErrors.NewValidationError("invalid expiresAt: must be an RFC 3339 time")
+		}
+		cmd.ExpiresAt = &t
+	}
+
+	if ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return domainErrors.NewValidationError("invalid ttl: must be a duration such as 15m")
+		}
+		cmd.TTL = d
+	}
+
+	return nil
+}
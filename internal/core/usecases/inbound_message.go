@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"context"
+	stdErrors "errors"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// inboundMessageService implements InboundMessageUseCase
+type inboundMessageService struct {
+	messageRepo     repositories.MessageRepository
+	inboundRepo     repositories.InboundMessageRepository
+	suppressionRepo repositories.SuppressionRepository
+	config          usecases.InboundConfig
+}
+
+// NewInboundMessageService creates a new inbound message use case
+func NewInboundMessageService(
+	messageRepo repositories.MessageRepository,
+	inboundRepo repositories.InboundMessageRepository,
+	suppressionRepo repositories.SuppressionRepository,
+	config usecases.InboundConfig,
+) usecases.InboundMessageUseCase {
+	if config.Keywords == nil {
+		config.Keywords = message.DefaultKeywords()
+	}
+
+	return &inboundMessageService{
+		messageRepo:     messageRepo,
+		inboundRepo:     inboundRepo,
+		suppressionRepo: suppressionRepo,
+		config:          config,
+	}
+}
+
+// ReceiveInboundMessage stores a reply and updates the suppression list if the reply is a keyword
+func (s *inboundMessageService) ReceiveInboundMessage(ctx context.Context, cmd usecases.ReceiveInboundMessageCommand) (*usecases.InboundMessageResponse, error) {
+	phone, err := s.parsePhoneNumber(cmd.From)
+	if err != nil {
+		return nil, err
+	}
+
+	// A redelivered callback is answered with the stored reply and does not touch the
+	// suppression list again, so a late STOP cannot undo a START sent after it
+	if cmd.ExternalID != "" {
+		existing, err := s.findByExternalID(ctx, cmd.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return inboundMessageToResponse(existing), nil
+		}
+	}
+
+	msg, err := message.NewInboundMessage(phone, cmd.Content, cmd.ExternalID, cmd.ReceivedAt, s.config.Keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	// The suppression list is updated before the reply is stored: if storing fails the provider
+	// redelivers the callback and the update is applied again, which is harmless
+	if err := s.applyKeywordAction(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	created, err := s.inboundRepo.Create(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// A concurrent delivery of the same callback got there first
+	if !created {
+		if msg, err = s.inboundRepo.GetByExternalID(ctx, cmd.ExternalID); err != nil {
+			return nil, err
+		}
+	}
+
+	return inboundMessageToResponse(msg), nil
+}
+
+// GetConversation merges the outbound messages and replies of a phone number into one timeline.
+// Both sources are read up to the end of the requested page and interleaved by time.
+func (s *inboundMessageService) GetConversation(ctx context.Context, query usecases.ConversationQuery) (*usecases.ConversationResponse, error) {
+	phone, err := s.parsePhoneNumber(query.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	// One entry past the page tells whether there is more
+	window := repositories.Pagination{Limit: offset + limit + 1}
+
+	outbound, err := s.messageRepo.GetByPhoneNumber(ctx, phone, window)
+	if err != nil {
+		return nil, err
+	}
+
+	inbound, err := s.inboundRepo.ListByPhoneNumber(ctx, phone, window)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]usecases.ConversationEntry, 0, len(outbound)+len(inbound))
+	for _, msg := range outbound {
+		entries = append(entries, usecases.ConversationEntry{
+			Direction:  usecases.DirectionOutbound,
+			OccurredAt: msg.CreatedAt,
+			Outbound:   messageToResponse(msg),
+		})
+	}
+	for _, msg := range inbound {
+		entries = append(entries, usecases.ConversationEntry{
+			Direction:  usecases.DirectionInbound,
+			OccurredAt: msg.ReceivedAt,
+			Inbound:    inboundMessageToResponse(msg),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.After(entries[j].OccurredAt)
+	})
+
+	hasMore := len(entries) > offset+limit
+	entries = entries[min(offset, len(entries)):min(offset+limit, len(entries))]
+
+	suppression, err := findSuppression(ctx, s.suppressionRepo, phone)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &usecases.ConversationResponse{
+		PhoneNumber: phone.String(),
+		Entries:     entries,
+		HasMore:     hasMore,
+	}
+	if suppression != nil {
+		response.Suppression = suppressionToResponse(suppression)
+	}
+
+	return response, nil
+}
+
+// applyKeywordAction puts the sender on the suppression list for an opt-out reply and takes them off for an opt-in reply.
+// An opt-in only lifts an opt-out that was itself made by keyword; numbers suppressed through the API or an import stay suppressed.
+func (s *inboundMessageService) applyKeywordAction(ctx context.Context, msg *message.InboundMessage) error {
+	switch msg.Action {
+	case message.KeywordActionOptOut:
+		suppression, err := msg.Suppression()
+		if err != nil {
+			return err
+		}
+		_, err = s.suppressionRepo.Add(ctx, suppression)
+		return err
+
+	case message.KeywordActionOptIn:
+		suppression, err := findSuppression(ctx, s.suppressionRepo, msg.PhoneNumber)
+		if err != nil || suppression == nil || suppression.Source != message.SuppressionSourceKeyword {
+			return err
+		}
+
+		err = s.suppressionRepo.Remove(ctx, msg.PhoneNumber)
+		var notFoundErr errors.NotFoundError
+		if stdErrors.As(err, &notFoundErr) {
+			return nil
+		}
+		return err
+
+	default:
+		return nil
+	}
+}
+
+// findByExternalID returns the inbound message with a provider message ID, or nil if none was stored
+func (s *inboundMessageService) findByExternalID(ctx context.Context, externalID string) (*message.InboundMessage, error) {
+	msg, err := s.inboundRepo.GetByExternalID(ctx, externalID)
+
+	var notFoundErr errors.NotFoundError
+	if stdErrors.As(err, &notFoundErr) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// parsePhoneNumber normalizes a phone number to E.164 the same way messages do,
+// so replies line up with the outbound messages to the same number
+func (s *inboundMessageService) parsePhoneNumber(phoneNumber string) (message.PhoneNumber, error) {
+	if phoneNumber == "" {
+		return "", errors.NewValidationError("phone number is required")
+	}
+
+	phone, err := message.ParsePhoneNumber(phoneNumber, s.config.DefaultRegion)
+	if err != nil {
+		return "", errors.NewValidationError("invalid phone number format: %s", phoneNumber)
+	}
+
+	return phone, nil
+}
+
+// inboundMessageToResponse converts a domain inbound message to response DTO
+func inboundMessageToResponse(msg *message.InboundMessage) *usecases.InboundMessageResponse {
+	id, _ := uuid.Parse(msg.ID.String())
+
+	return &usecases.InboundMessageResponse{
+		ID:            id,
+		PhoneNumber:   msg.PhoneNumber.String(),
+		Content:       msg.Content,
+		ExternalID:    msg.ExternalID,
+		KeywordAction: msg.Action.String(),
+		ReceivedAt:    msg.ReceivedAt,
+		CreatedAt:     msg.CreatedAt,
+	}
+}
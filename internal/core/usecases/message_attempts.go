@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// ListMessageAttempts retrieves every webhook request made to deliver a message
+func (s *messageManagementService) ListMessageAttempts(ctx context.Context, query usecases.MessageAttemptsQuery) (*usecases.ListAttemptsResponse, error) {
+	if query.MessageID == uuid.Nil {
+		return nil, errors.NewValidationError("message ID cannot be empty")
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	// Distinguish unknown messages from messages that were never attempted
+	messageID := message.MessageID(query.MessageID.String())
+	if _, err := s.messageRepo.GetByID(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.attemptRepo.GetByMessageID(ctx, messageID, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.AttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		responses[i] = attemptToResponse(attempt)
+	}
+
+	totalCount, err := s.attemptRepo.CountByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.ListAttemptsResponse{
+		Attempts:   responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(attempts)) < totalCount,
+	}, nil
+}
+
+// attemptToResponse converts a domain delivery attempt to response DTO
+func attemptToResponse(attempt *message.Attempt) usecases.AttemptResponse {
+	id, _ := uuid.Parse(attempt.ID.String())
+
+	var errorClass *string
+	if attempt.ErrorClass != nil {
+		class := attempt.ErrorClass.String()
+		errorClass = &class
+	}
+
+	return usecases.AttemptResponse{
+		ID:            id,
+		Provider:      attempt.Provider,
+		RetryCount:    attempt.RetryCount,
+		AttemptNumber: attempt.AttemptNumber,
+		StartedAt:     attempt.StartedAt,
+		DurationMs:    attempt.Duration.Milliseconds(),
+		HTTPStatus:    attempt.HTTPStatus,
+		ResponseBody:  attempt.ResponseBody,
+		Error:         attempt.Error,
+		ErrorClass:    errorClass,
+	}
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// CreateMessages validates every command on its own and stores the valid ones in a single batch.
+// Invalid commands are reported per item and do not prevent the others from being created.
+func (s *messageManagementService) CreateMessages(ctx context.Context, cmds []usecases.CreateMessageCommand) (*usecases.CreateMessagesResult, error) {
+	if len(cmds) == 0 {
+		return nil, errors.NewValidationError("at least one message is required")
+	}
+	if len(cmds) > s.config.BatchMaxItems {
+		return nil, errors.NewValidationError("too many messages: %d (max: %d)", len(cmds), s.config.BatchMaxItems)
+	}
+
+	result := &usecases.CreateMessagesResult{
+		Items: make([]usecases.BatchItemResult, len(cmds)),
+	}
+
+	templates := s.newTemplateSet()
+	msgs := make([]*message.Message, 0, len(cmds))
+	indexes := make([]int, 0, len(cmds))
+	for i, cmd := range cmds {
+		result.Items[i].Index = i
+
+		msg, err := s.newMessage(ctx, cmd, templates)
+		if err != nil {
+			result.Items[i].Error = err
+			result.FailedCount++
+			continue
+		}
+
+		msgs = append(msgs, msg)
+		indexes = append(indexes, i)
+	}
+
+	if err := s.messageRepo.CreateBatch(message.WithTrigger(ctx, message.TriggerAPI), msgs); err != nil {
+		return nil, err
+	}
+
+	for i, msg := range msgs {
+		result.Items[indexes[i]].Message = messageToResponse(msg)
+	}
+	result.CreatedCount = len(msgs)
+
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// ListDeadLetters retrieves permanently failed messages with their last delivery error
+func (s *messageManagementService) ListDeadLetters(ctx context.Context, query usecases.DeadLetterQuery) (*usecases.ListMessagesResponse, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	filter, err := s.toDeadLetterFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageRepo.GetFailedMessages(ctx, filter, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.MessageResponse, len(messages))
+	for i, msg := range messages {
+		responses[i] = *messageToResponse(msg)
+	}
+
+	totalCount, err := s.messageRepo.CountFailedMessages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.ListMessagesResponse{
+		Messages:   responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(messages)) < totalCount,
+	}, nil
+}
+
+// RequeueMessage moves a single failed message back to the pending queue
+func (s *messageManagementService) RequeueMessage(ctx context.Context, id uuid.UUID) (*usecases.MessageResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("message ID cannot be empty")
+	}
+
+	msg, err := s.messageRepo.GetByID(ctx, message.MessageID(id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := msg.Requeue(); err != nil {
+		return nil, err
+	}
+
+	if err := s.messageRepo.Update(message.WithTrigger(ctx, message.TriggerRequeue), msg); err != nil {
+		return nil, err
+	}
+
+	return messageToResponse(msg), nil
+}
+
+// RequeueFailedMessages moves all failed messages matching the filter back to the pending queue
+func (s *messageManagementService) RequeueFailedMessages(ctx context.Context, filter usecases.DeadLetterFilter) (*usecases.RequeueResult, error) {
+	repoFilter, err := s.toDeadLetterFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.messageRepo.RequeueFailedMessages(message.WithTrigger(ctx, message.TriggerRequeue), repoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.RequeueResult{RequeuedCount: requeued}, nil
+}
+
+// toDeadLetterFilter validates a dead-letter filter and converts it for the repository
+func (s *messageManagementService) toDeadLetterFilter(filter usecases.DeadLetterFilter) (repositories.DeadLetterFilter, error) {
+	result := repositories.DeadLetterFilter{
+		FailedFrom: filter.FailedFrom,
+		FailedTo:   filter.FailedTo,
+	}
+
+	if filter.PhoneNumber != "" {
+		phoneNumber, err := message.ParsePhoneNumber(filter.PhoneNumber, s.config.DefaultRegion)
+		if err != nil {
+			return result, err
+		}
+		result.PhoneNumber = &phoneNumber
+	}
+
+	if filter.ErrorClass != "" {
+		class := message.ErrorClass(filter.ErrorClass)
+		if !class.IsValid() {
+			return result, errors.NewValidationError("invalid error class: %s", filter.ErrorClass)
+		}
+		result.ErrorClass = &class
+	}
+
+	if filter.FailedFrom != nil && filter.FailedTo != nil && !filter.FailedTo.After(*filter.FailedFrom) {
+		return result, errors.NewValidationError("failed_to must be after failed_from")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stdErrors "errors"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// maxIdempotencyKeyLength matches the idempotency_keys.key column size
+const maxIdempotencyKeyLength = 255
+
+// idempotencyCacheKeyPrefix namespaces completed idempotency records in cache
+const idempotencyCacheKeyPrefix = "idempotency:"
+
+// createIdempotentMessage creates a message at most once per idempotency key
+func (s *messageManagementService) createIdempotentMessage(ctx context.Context, cmd usecases.CreateMessageCommand) (*usecases.MessageResponse, error) {
+	if len(cmd.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, errors.NewValidationError("idempotency key too long: %d characters (max: %d)", len(cmd.IdempotencyKey), maxIdempotencyKeyLength)
+	}
+
+	fingerprint, err := fingerprintCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Completed keys are served from cache without touching the database
+	var cached repositories.IdempotencyRecord
+	if err := s.cacheService.GetJSON(ctx, idempotencyCacheKeyPrefix+cmd.IdempotencyKey, &cached); err == nil && cached.IsCompleted() {
+		return s.replayIdempotentResponse(ctx, &cached, fingerprint)
+	}
+
+	msg, err := s.newMessage(ctx, cmd, s.newTemplateSet())
+	if err != nil {
+		return nil, err
+	}
+
+	// The key names the message it creates from the start, so a replay finds it even if completing the key fails
+	now := time.Now()
+	messageID := msg.ID
+	record := &repositories.IdempotencyRecord{
+		Key:               cmd.IdempotencyKey,
+		Fingerprint:       fingerprint,
+		ReservedMessageID: &messageID,
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(s.config.IdempotencyRetention),
+	}
+
+	reserved, err := s.idempotencyRepo.Reserve(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reserved {
+		existing, err := s.idempotencyRepo.GetByKey(ctx, cmd.IdempotencyKey)
+		if err != nil {
+			var notFoundErr errors.NotFoundError
+			if stdErrors.As(err, &notFoundErr) {
+				// The key expired between reservation and lookup
+				return nil, errors.NewConflictError("idempotency key %s was just released, retry the request", cmd.IdempotencyKey)
+			}
+			return nil, err
+		}
+		return s.replayIdempotentResponse(ctx, existing, fingerprint)
+	}
+
+	if err := s.messageRepo.Create(message.WithTrigger(ctx, message.TriggerAPI), msg); err != nil {
+		// The insert may have committed before the error was reported, then the key is completed with the message
+		if _, getErr := s.messageRepo.GetByID(context.WithoutCancel(ctx), messageID); getErr != nil {
+			// Free the key so the client can retry
+			_ = s.idempotencyRepo.Release(context.WithoutCancel(ctx), cmd.IdempotencyKey)
+			return nil, err
+		}
+	}
+	response := messageToResponse(msg)
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, errors.NewBusinessError("failed to serialize idempotent response: %v", err)
+	}
+
+	// The message exists whatever the caller does next, so the response is stored even after a cancellation
+	err = storeWithRetry(ctx, func(ctx context.Context) error {
+		return s.idempotencyRepo.Complete(ctx, cmd.IdempotencyKey, messageID, body)
+	})
+	if err != nil {
+		// The message exists already, replays find it through the message ID reserved with the key
+		return response, nil
+	}
+
+	record.MessageID = &messageID
+	record.Response = body
+	if err := s.cacheService.SetJSON(ctx, idempotencyCacheKeyPrefix+cmd.IdempotencyKey, record, time.Until(record.ExpiresAt)); err != nil {
+		// Cache failure shouldn't break the flow
+		_ = err
+	}
+
+	return response, nil
+}
+
+// replayIdempotentResponse returns the stored response for a previously used idempotency key
+func (s *messageManagementService) replayIdempotentResponse(ctx context.Context, record *repositories.IdempotencyRecord, fingerprint string) (*usecases.MessageResponse, error) {
+	if record.Fingerprint != fingerprint {
+		return nil, errors.NewBusinessError("idempotency key %s was already used with a different request", record.Key)
+	}
+
+	if !record.IsCompleted() {
+		return s.resolveInProgressKey(ctx, record)
+	}
+
+	var response usecases.MessageResponse
+	if err := json.Unmarshal(record.Response, &response); err != nil {
+		return nil, errors.NewBusinessError("failed to read idempotent response: %v", err)
+	}
+
+	return &response, nil
+}
+
+// resolveInProgressKey returns the message of a key whose response was never stored, or a conflict while
+// the original request has not created it yet
+func (s *messageManagementService) resolveInProgressKey(ctx context.Context, record *repositories.IdempotencyRecord) (*usecases.MessageResponse, error) {
+	inProgressErr := errors.NewConflictError("request with idempotency key %s is still in progress", record.Key)
+	if record.ReservedMessageID == nil {
+		return nil, inProgressErr
+	}
+
+	msg, err := s.messageRepo.GetByID(ctx, *record.ReservedMessageID)
+	if err != nil {
+		var notFoundErr errors.NotFoundError
+		if stdErrors.As(err, &notFoundErr) {
+			return nil, inProgressErr
+		}
+		return nil, err
+	}
+
+	response := messageToResponse(msg)
+	if body, err := json.Marshal(response); err == nil {
+		// Complete the key for later replays; failing again only means the next replay looks the message up too
+		_ = s.idempotencyRepo.Complete(ctx, record.Key, msg.ID, body)
+	}
+
+	return response, nil
+}
+
+// fingerprintCommand computes a stable hash of the request payload
+func fingerprintCommand(cmd usecases.CreateMessageCommand) (string, error) {
+	// IdempotencyKey is excluded from JSON, so only the payload is hashed
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return "", errors.NewBusinessError("failed to fingerprint request: %v", err)
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
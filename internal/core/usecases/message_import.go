@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+const (
+	// defaultImportErrorsLimit is used when listing import errors without a limit
+	defaultImportErrorsLimit = 100
+
+	// maxImportErrorsLimit bounds a single page of import errors
+	maxImportErrorsLimit = 1000
+)
+
+// ImportMessages checks the file header, starts an import job and creates the messages in the background.
+// The returned job is still processing; its progress is available through GetImportJob.
+func (s *messageManagementService) ImportMessages(ctx context.Context, cmd usecases.ImportMessagesCommand) (*usecases.ImportJobResponse, error) {
+	if cmd.Source == nil {
+		return nil, domainErrors.NewValidationError("import file is required")
+	}
+
+	job, err := message.NewImportJob(message.ImportFormat(cmd.Format))
+	if err != nil {
+		cmd.Source.Close()
+		return nil, err
+	}
+
+	// Check the import-wide template up front, rows are rendered from the version loaded here
+	templates := s.newTemplateSet()
+	if cmd.TemplateID != nil {
+		if _, err := templates.get(ctx, *cmd.TemplateID); err != nil {
+			cmd.Source.Close()
+			return nil, err
+		}
+	}
+
+	reader, err := newImportRowReader(job.Format, cmd.Source, cmd.TemplateID != nil)
+	if err != nil {
+		cmd.Source.Close()
+		return nil, err
+	}
+
+	if err := s.importRepo.Create(ctx, job); err != nil {
+		cmd.Source.Close()
+		return nil, err
+	}
+
+	response := importJobToResponse(job)
+
+	// The import outlives the upload request, so it must not be cancelled with it
+	importCtx := message.WithTrigger(context.WithoutCancel(ctx), message.TriggerImport)
+	go s.runImport(importCtx, job, importRows{reader: reader, templates: templates, templateID: cmd.TemplateID}, cmd.Source)
+
+	return response, nil
+}
+
+// importRows is the row source of an import and the templates its rows are rendered from
+type importRows struct {
+	reader     importRowReader
+	templates  *templateSet
+	templateID *uuid.UUID // Used by rows with neither content nor a template of their own
+}
+
+// runImport creates messages from the rows of an import file in chunks, recording progress after each chunk
+func (s *messageManagementService) runImport(ctx context.Context, job *message.ImportJob, source importRows, file io.Closer) {
+	defer file.Close()
+
+	for {
+		rows, readErr := readImportChunk(source.reader, s.config.ImportChunkSize)
+
+		if len(rows) > 0 {
+			if err := s.importChunk(ctx, job, source, rows); err != nil {
+				job.Fail(err.Error())
+				s.finishImport(ctx, job)
+				return
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			job.Fail(readErr.Error())
+			s.finishImport(ctx, job)
+			return
+		}
+	}
+
+	job.Complete()
+	s.finishImport(ctx, job)
+}
+
+// finishImport stores the final status of an import job. A job whose status cannot be stored stays
+// processing without progress until RecoverImportJobs fails it.
+func (s *messageManagementService) finishImport(ctx context.Context, job *message.ImportJob) {
+	_ = storeWithRetry(ctx, func(ctx context.Context) error {
+		return s.importRepo.Update(ctx, job)
+	})
+}
+
+// RecoverImportJobs fails import jobs left processing by a stopped server. Running imports store their
+// progress after every chunk, so a job without progress for ImportStaleAfter is no longer running anywhere.
+func (s *messageManagementService) RecoverImportJobs(ctx context.Context) (int64, error) {
+	return s.importRepo.FailStale(ctx, time.Now().Add(-s.config.ImportStaleAfter), "import was interrupted before it finished")
+}
+
+// readImportChunk reads up to size rows, returning io.EOF once the file is exhausted
+func readImportChunk(reader importRowReader, size int) ([]importRow, error) {
+	rows := make([]importRow, 0, size)
+	for len(rows) < size {
+		row, err := reader.Next()
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// importChunk validates a chunk of rows and stores the created messages, the rejected rows and the chunk
+// outcome on the job together
+func (s *messageManagementService) importChunk(ctx context.Context, job *message.ImportJob, source importRows, rows []importRow) error {
+	msgs := make([]*message.Message, 0, len(rows))
+	var rowErrors []message.ImportRowError
+
+	for _, row := range rows {
+		if row.cmd.Content == "" && row.cmd.TemplateID == nil {
+			row.cmd.TemplateID = source.templateID
+		}
+
+		err := row.err
+		if err == nil {
+			var msg *message.Message
+			if msg, err = s.newMessage(ctx, row.cmd, source.templates); err == nil {
+				msgs = append(msgs, msg)
+				continue
+			}
+		}
+		rowErrors = append(rowErrors, message.NewImportRowError(job.ID, row.number, err.Error(), row.raw))
+	}
+
+	// The job only advances once the chunk is stored, a failed chunk leaves no trace
+	progress := *job
+	progress.RecordChunk(len(msgs), len(rowErrors))
+	if err := s.importRepo.SaveChunk(ctx, &progress, msgs, rowErrors); err != nil {
+		return err
+	}
+
+	*job = progress
+	return nil
+}
+
+// GetImportJob retrieves the progress of an import job
+func (s *messageManagementService) GetImportJob(ctx context.Context, id uuid.UUID) (*usecases.ImportJobResponse, error) {
+	if id == uuid.Nil {
+		return nil, domainErrors.NewValidationError("import job ID cannot be empty")
+	}
+
+	job, err := s.importRepo.GetByID(ctx, message.ImportJobID(id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return importJobToResponse(job), nil
+}
+
+// ListImportErrors retrieves the rows an import job rejected, in row order
+func (s *messageManagementService) ListImportErrors(ctx context.Context, query usecases.ImportErrorsQuery) (*usecases.ListImportErrorsResponse, error) {
+	if query.JobID == uuid.Nil {
+		return nil, domainErrors.NewValidationError("import job ID cannot be empty")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultImportErrorsLimit
+	}
+	if limit > maxImportErrorsLimit {
+		limit = maxImportErrorsLimit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, domainErrors.NewValidationError("offset cannot be negative")
+	}
+
+	job, err := s.importRepo.GetByID(ctx, message.ImportJobID(query.JobID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	rowErrors, err := s.importRepo.GetRowErrors(ctx, job.ID, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.ImportRowErrorResponse, len(rowErrors))
+	for i, rowErr := range rowErrors {
+		responses[i] = usecases.ImportRowErrorResponse{
+			Row:   rowErr.Row,
+			Error: rowErr.Error,
+			Data:  rowErr.Data,
+		}
+	}
+
+	// Every failed row has exactly one error entry
+	totalCount := int64(job.FailedCount)
+
+	return &usecases.ListImportErrorsResponse{
+		Errors:     responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(rowErrors)) < totalCount,
+	}, nil
+}
+
+// importJobToResponse converts an import job to its response representation
+func importJobToResponse(job *message.ImportJob) *usecases.ImportJobResponse {
+	id, _ := uuid.Parse(job.ID.String())
+
+	return &usecases.ImportJobResponse{
+		ID:            id,
+		Format:        job.Format.String(),
+		Status:        job.Status.String(),
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		CompletedAt:   job.CompletedAt,
+	}
+}
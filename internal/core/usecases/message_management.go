@@ -13,39 +13,176 @@ import (
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 )
 
+const (
+	// defaultIdempotencyRetention is used when no retention window is configured
+	defaultIdempotencyRetention = 24 * time.Hour
+
+	// defaultBatchMaxItems is used when no batch create limit is configured
+	defaultBatchMaxItems = 1000
+
+	// defaultImportChunkSize is used when no import chunk size is configured
+	defaultImportChunkSize = 500
+
+	// defaultImportStaleAfter is used when no import staleness threshold is configured
+	defaultImportStaleAfter = 15 * time.Minute
+
+	// defaultMaxSegments is used when no SMS segment limit is configured
+	defaultMaxSegments = 3
+
+	// storeAttempts and storeRetryDelay bound retries of writes that must not be lost
+	storeAttempts   = 3
+	storeRetryDelay = 50 * time.Millisecond
+)
+
 // messageManagementService implements MessageManagementUseCase
 type messageManagementService struct {
-	messageRepo  repositories.MessageRepository
-	cacheService services.CacheService
+	messageRepo     repositories.MessageRepository
+	idempotencyRepo repositories.IdempotencyRepository
+	attemptRepo     repositories.MessageAttemptRepository
+	importRepo      repositories.ImportJobRepository
+	templateRepo    repositories.TemplateRepository
+	suppressionRepo repositories.SuppressionRepository
+	cacheService    services.CacheService
+	config          usecases.ManagementConfig
 }
 
 // NewMessageManagementService creates a new message management use case
 func NewMessageManagementService(
 	messageRepo repositories.MessageRepository,
+	idempotencyRepo repositories.IdempotencyRepository,
+	attemptRepo repositories.MessageAttemptRepository,
+	importRepo repositories.ImportJobRepository,
+	templateRepo repositories.TemplateRepository,
+	suppressionRepo repositories.SuppressionRepository,
 	cacheService services.CacheService,
+	config usecases.ManagementConfig,
 ) usecases.MessageManagementUseCase {
+	if config.IdempotencyRetention <= 0 {
+		config.IdempotencyRetention = defaultIdempotencyRetention
+	}
+	if config.BatchMaxItems <= 0 {
+		config.BatchMaxItems = defaultBatchMaxItems
+	}
+	if config.ImportChunkSize <= 0 {
+		config.ImportChunkSize = defaultImportChunkSize
+	}
+	if config.ImportStaleAfter <= 0 {
+		config.ImportStaleAfter = defaultImportStaleAfter
+	}
+	if config.MaxSegments <= 0 {
+		config.MaxSegments = defaultMaxSegments
+	}
+	config.MaxSegments = min(config.MaxSegments, message.MaxSegments)
+
 	return &messageManagementService{
-		messageRepo:  messageRepo,
-		cacheService: cacheService,
+		messageRepo:     messageRepo,
+		idempotencyRepo: idempotencyRepo,
+		attemptRepo:     attemptRepo,
+		importRepo:      importRepo,
+		templateRepo:    templateRepo,
+		suppressionRepo: suppressionRepo,
+		cacheService:    cacheService,
+		config:          config,
 	}
 }
 
 // CreateMessage creates a new message for sending
 func (s *messageManagementService) CreateMessage(ctx context.Context, cmd usecases.CreateMessageCommand) (*usecases.MessageResponse, error) {
+	if cmd.IdempotencyKey != "" {
+		return s.createIdempotentMessage(ctx, cmd)
+	}
+
+	return s.createMessage(ctx, cmd)
+}
+
+// createMessage validates the command and persists a new message
+func (s *messageManagementService) createMessage(ctx context.Context, cmd usecases.CreateMessageCommand) (*usecases.MessageResponse, error) {
+	msg, err := s.newMessage(ctx, cmd, s.newTemplateSet())
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	if err := s.messageRepo.Create(message.WithTrigger(ctx, message.TriggerAPI), msg); err != nil {
+		return nil, err
+	}
+
+	return messageToResponse(msg), nil
+}
+
+// newMessage validates the command and builds the message it describes,
+// rendering its content from a template of the given set if the command names one
+func (s *messageManagementService) newMessage(ctx context.Context, cmd usecases.CreateMessageCommand, templates *templateSet) (*message.Message, error) {
 	// Validate input
 	if cmd.PhoneNumber == "" {
 		return nil, errors.NewValidationError("phone number is required")
 	}
-	if cmd.Content == "" {
-		return nil, errors.NewValidationError("message content is required")
-	}
 
-	// Create domain value objects
-	phoneNumber, err := message.NewPhoneNumber(cmd.PhoneNumber)
+	// Create domain value objects, normalizing the phone number to E.164
+	phoneNumber, err := message.ParsePhoneNumber(cmd.PhoneNumber, s.config.DefaultRegion)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid phone number format: %s", cmd.PhoneNumber)
 	}
 
+	var msg *message.Message
+	if cmd.TemplateID != nil {
+		msg, err = s.newTemplatedMessage(ctx, phoneNumber, cmd, templates)
+	} else {
+		msg, err = s.newContentMessage(phoneNumber, cmd)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Each part of a concatenated SMS is billed, so the number of parts is capped
+	if err := msg.Content.ValidateSegments(s.config.MaxSegments); err != nil {
+		return nil, err
+	}
+
+	// Defer delivery if a send time was requested
+	if cmd.SendAt != nil {
+		if err := msg.Schedule(*cmd.SendAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Urgent messages are sent ahead of the rest of the queue
+	priority, err := message.ParsePriority(cmd.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.SetPriority(priority); err != nil {
+		return nil, err
+	}
+
+	// Apply expiry, either absolute or relative to the send time
+	expiresAt, err := s.resolveExpiry(msg, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt != nil {
+		if err := msg.SetExpiry(*expiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Recipients that opted out are refused: the message is recorded as suppressed instead of queued
+	if err := s.suppressIfOptedOut(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// newContentMessage creates a message from the raw content of the command
+func (s *messageManagementService) newContentMessage(phoneNumber message.PhoneNumber, cmd usecases.CreateMessageCommand) (*message.Message, error) {
+	if cmd.Content == "" {
+		return nil, errors.NewValidationError("message content is required")
+	}
+	if len(cmd.Variables) > 0 {
+		return nil, errors.NewValidationError("variables can only be used with a templateId")
+	}
+
 	content, err := message.NewContent(cmd.Content)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid message content: %v", err)
@@ -57,12 +194,50 @@ func (s *messageManagementService) CreateMessage(ctx context.Context, cmd usecas
 		return nil, errors.NewBusinessError("failed to create message: %v", err)
 	}
 
-	// Save to repository
-	if err := s.messageRepo.Create(ctx, msg); err != nil {
+	return msg, nil
+}
+
+// newTemplatedMessage creates a message by rendering the template named by the command
+func (s *messageManagementService) newTemplatedMessage(ctx context.Context, phoneNumber message.PhoneNumber, cmd usecases.CreateMessageCommand, templates *templateSet) (*message.Message, error) {
+	if cmd.Content != "" {
+		return nil, errors.NewValidationError("content and templateId cannot be used together")
+	}
+
+	template, err := templates.get(ctx, *cmd.TemplateID)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.messageToResponse(msg), nil
+	// Missing variables and content limits of the rendered text are reported as validation errors
+	return message.NewTemplatedMessage(phoneNumber, template, cmd.Variables)
+}
+
+// resolveExpiry determines the expiry time requested by the command, if any
+func (s *messageManagementService) resolveExpiry(msg *message.Message, cmd usecases.CreateMessageCommand) (*time.Time, error) {
+	if cmd.ExpiresAt != nil && cmd.TTL != 0 {
+		return nil, errors.NewValidationError("expiresAt and ttl cannot be used together")
+	}
+
+	if cmd.TTL < 0 {
+		return nil, errors.NewValidationError("ttl cannot be negative")
+	}
+
+	expiresAt := cmd.ExpiresAt
+	if cmd.TTL > 0 {
+		// TTL starts counting when the message becomes due
+		base := msg.CreatedAt
+		if msg.SendAt != nil && msg.SendAt.After(base) {
+			base = *msg.SendAt
+		}
+		t := base.Add(cmd.TTL)
+		expiresAt = &t
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, errors.NewValidationError("expiry time must be in the future")
+	}
+
+	return expiresAt, nil
 }
 
 // GetMessageByID retrieves a message by its ID
@@ -76,7 +251,41 @@ func (s *messageManagementService) GetMessageByID(ctx context.Context, id uuid.U
 		return nil, err
 	}
 
-	return s.messageToResponse(msg), nil
+	history, err := s.messageRepo.GetStatusHistory(ctx, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := messageToResponse(msg)
+	response.StatusHistory = make([]usecases.StatusChangeResponse, 0, len(history))
+	for _, change := range history {
+		response.StatusHistory = append(response.StatusHistory, statusChangeToResponse(change))
+	}
+
+	return response, nil
+}
+
+// CancelMessage cancels a message that has not been picked up for delivery yet
+func (s *messageManagementService) CancelMessage(ctx context.Context, id uuid.UUID) (*usecases.MessageResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("message ID cannot be empty")
+	}
+
+	msg, err := s.messageRepo.GetByID(ctx, message.MessageID(id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := msg.Cancel(); err != nil {
+		return nil, err
+	}
+
+	// Only write while the message is still pending, a worker may have claimed it since it was read
+	if err := s.messageRepo.UpdatePending(message.WithTrigger(ctx, message.TriggerAPI), msg); err != nil {
+		return nil, err
+	}
+
+	return messageToResponse(msg), nil
 }
 
 // ListMessages retrieves messages with optional filtering and pagination
@@ -92,6 +301,14 @@ func (s *messageManagementService) ListMessages(ctx context.Context, query useca
 		return nil, errors.NewValidationError("offset cannot be negative")
 	}
 
+	// Scheduled messages are always pending, so other status filters cannot match
+	if query.Scheduled {
+		if query.Status != nil && *query.Status != message.StatusPending {
+			return nil, errors.NewValidationError("scheduled filter cannot be combined with status: %s", *query.Status)
+		}
+		return s.listScheduledMessages(ctx, limit, offset)
+	}
+
 	// For sent messages with small limit (≤10), try cache first for better performance
 	if query.Status != nil && *query.Status == message.StatusSent && limit <= 10 && offset == 0 {
 		if cachedMessages, err := s.getRecentSentMessagesFromCache(ctx, limit); err == nil && len(cachedMessages) > 0 {
@@ -131,7 +348,7 @@ func (s *messageManagementService) ListMessages(ctx context.Context, query useca
 	// Convert to response DTOs
 	responses := make([]usecases.MessageResponse, len(messages))
 	for i, msg := range messages {
-		responses[i] = *s.messageToResponse(msg)
+		responses[i] = *messageToResponse(msg)
 	}
 
 	// Get total count
@@ -155,10 +372,34 @@ func (s *messageManagementService) ListMessages(ctx context.Context, query useca
 	}, nil
 }
 
+// listScheduledMessages retrieves pending messages that are not yet due for sending
+func (s *messageManagementService) listScheduledMessages(ctx context.Context, limit, offset int) (*usecases.ListMessagesResponse, error) {
+	messages, err := s.messageRepo.GetScheduledMessages(ctx, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.MessageResponse, len(messages))
+	for i, msg := range messages {
+		responses[i] = *messageToResponse(msg)
+	}
+
+	totalCount, err := s.messageRepo.CountScheduled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.ListMessagesResponse{
+		Messages:   responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(messages)) < totalCount,
+	}, nil
+}
+
 // getRecentSentMessagesFromCache retrieves recent sent messages from Redis sorted set
 func (s *messageManagementService) getRecentSentMessagesFromCache(ctx context.Context, limit int) ([]usecases.MessageResponse, error) {
 	// Get recent message IDs from sorted set (latest first)
-	members, err := s.cacheService.ZRevRange(ctx, "sent_messages", 0, int64(limit-1))
+	members, err := s.cacheService.ZRevRange(ctx, sentMessagesCacheKey, 0, int64(limit-1))
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +424,7 @@ func (s *messageManagementService) getRecentSentMessagesFromCache(ctx context.Co
 
 		// Get detailed message data from cache
 		var cacheData services.SentMessageCacheData
-		cacheKey := "message_detail:" + messageID
+		cacheKey := sentMessageDetailCachePrefix + messageID
 		if err := s.cacheService.GetJSON(ctx, cacheKey, &cacheData); err != nil {
 			// If detail not found in cache, skip this message
 			continue
@@ -195,6 +436,11 @@ func (s *messageManagementService) getRecentSentMessagesFromCache(ctx context.Co
 			continue // Skip invalid UUIDs
 		}
 
+		// Receipts evict the messages they apply to; skip any entry that outlived its eviction
+		if cacheData.Status != "" && cacheData.Status != message.StatusSent.String() {
+			continue
+		}
+
 		response := usecases.MessageResponse{
 			ID:          id,
 			PhoneNumber: phoneNumber,
@@ -206,6 +452,9 @@ func (s *messageManagementService) getRecentSentMessagesFromCache(ctx context.Co
 			UpdatedAt:   cacheData.SentAt,
 			SentAt:      &cacheData.SentAt,
 		}
+		if cacheData.Provider != "" {
+			response.Provider = &cacheData.Provider
+		}
 
 		responses = append(responses, response)
 	}
@@ -214,17 +463,81 @@ func (s *messageManagementService) getRecentSentMessagesFromCache(ctx context.Co
 }
 
 // messageToResponse converts a domain message to response DTO
-func (s *messageManagementService) messageToResponse(msg *message.Message) *usecases.MessageResponse {
+func messageToResponse(msg *message.Message) *usecases.MessageResponse {
 	id, _ := uuid.Parse(msg.ID.String())
+
+	var lastErrorClass *string
+	if msg.LastErrorClass != nil {
+		class := msg.LastErrorClass.String()
+		lastErrorClass = &class
+	}
+
+	var templateID *uuid.UUID
+	if msg.TemplateID != nil {
+		id := uuid.MustParse(msg.TemplateID.String())
+		templateID = &id
+	}
+
 	return &usecases.MessageResponse{
-		ID:          id,
-		PhoneNumber: msg.PhoneNumber.String(),
-		Content:     msg.Content.String(),
-		Status:      string(msg.Status),
-		ExternalID:  msg.ExternalID,
-		RetryCount:  msg.RetryCount,
-		CreatedAt:   msg.CreatedAt,
-		UpdatedAt:   msg.UpdatedAt,
-		SentAt:      msg.SentAt,
+		ID:                 id,
+		PhoneNumber:        msg.PhoneNumber.String(),
+		Content:            msg.Content.String(),
+		Status:             string(msg.Status),
+		Priority:           msg.Priority.String(),
+		ExternalID:         msg.ExternalID,
+		Provider:           msg.Provider,
+		RetryCount:         msg.RetryCount,
+		SendAt:             msg.SendAt,
+		ExpiresAt:          msg.ExpiresAt,
+		NextAttemptAt:      msg.NextAttemptAt,
+		AttemptCount:       msg.AttemptCount,
+		LastError:          msg.LastError,
+		LastErrorClass:     lastErrorClass,
+		LastHTTPStatus:     msg.LastHTTPStatus,
+		CreatedAt:          msg.CreatedAt,
+		UpdatedAt:          msg.UpdatedAt,
+		SentAt:             msg.SentAt,
+		CarrierErrorCode:   msg.CarrierErrorCode,
+		DeliveryReportedAt: msg.DeliveryReportedAt,
+		TemplateID:         templateID,
+		TemplateVersion:    msg.TemplateVersion,
+		Encoding:           msg.Content.Encoding().String(),
+		Segments:           msg.Content.Segments(),
+		CountryCode:        msg.PhoneNumber.CountryCode(),
+		Region:             msg.PhoneNumber.Region(),
+		NumberType:         msg.PhoneNumber.Type().String(),
+	}
+}
+
+// statusChangeToResponse converts a status change event to response DTO
+func statusChangeToResponse(change message.StatusChange) usecases.StatusChangeResponse {
+	var from *string
+	if change.From != nil {
+		status := change.From.String()
+		from = &status
+	}
+
+	return usecases.StatusChangeResponse{
+		From:       from,
+		To:         change.To.String(),
+		Trigger:    change.Trigger.String(),
+		OccurredAt: change.OccurredAt,
 	}
 }
+
+// storeWithRetry tries a write that records work already done a few times, even after ctx was cancelled
+func storeWithRetry(ctx context.Context, store func(ctx context.Context) error) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	for attempt := 1; attempt <= storeAttempts; attempt++ {
+		if err = store(ctx); err == nil {
+			return nil
+		}
+		if attempt < storeAttempts {
+			time.Sleep(time.Duration(attempt) * storeRetryDelay)
+		}
+	}
+
+	return err
+}
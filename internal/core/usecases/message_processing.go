@@ -2,7 +2,12 @@ package usecases
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
@@ -12,23 +17,97 @@ import (
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 )
 
+// deliveryOutcome describes how processing a single message ended
+type deliveryOutcome int
+
+const (
+	outcomeSent deliveryOutcome = iota
+	outcomeFailed
+	outcomeExpired
+	outcomeRetrying
+	outcomeDeferred
+	outcomeSuppressed
+)
+
+// Default message-level retry backoff bounds, claim lease and worker pool sizing
+const (
+	defaultRetryBackoffBase = 30 * time.Second
+	defaultRetryBackoffMax  = 30 * time.Minute
+	defaultLeaseDuration    = 5 * time.Minute
+	defaultBatchSize        = 2
+	defaultMaxBatchSize     = 10
+	defaultConcurrency      = 1
+	defaultUrgentShare      = 0.25
+	defaultUrgentPoll       = time.Second
+)
+
+// Cache entries of recently sent messages, listed without querying the database
+const (
+	sentMessagesCacheKey         = "sent_messages"
+	sentMessageDetailCachePrefix = "message_detail:"
+	sentMessageCacheTTL          = 30 * 24 * time.Hour
+)
+
+// sentMessageCacheMember returns the sorted set member of a sent message: messageId:externalId:phoneNumber
+func sentMessageCacheMember(msg *message.Message, externalID string) string {
+	return fmt.Sprintf("%s:%s:%s", msg.ID.String(), externalID, msg.PhoneNumber.String())
+}
+
 // messageProcessingService implements MessageProcessingUseCase
 type messageProcessingService struct {
-	messageRepo    repositories.MessageRepository
-	webhookService services.WebhookService
-	cacheService   services.CacheService
+	messageRepo     repositories.MessageRepository
+	suppressionRepo repositories.SuppressionRepository
+	receiptRepo     repositories.DeliveryReceiptRepository
+	webhookService  services.WebhookService
+	cacheService    services.CacheService
+	config          usecases.ProcessingConfig
 }
 
 // NewMessageProcessingService creates a new message processing use case
 func NewMessageProcessingService(
 	messageRepo repositories.MessageRepository,
+	suppressionRepo repositories.SuppressionRepository,
+	receiptRepo repositories.DeliveryReceiptRepository,
 	webhookService services.WebhookService,
 	cacheService services.CacheService,
+	config usecases.ProcessingConfig,
 ) usecases.MessageProcessingUseCase {
+	if config.RetryBackoffBase <= 0 {
+		config.RetryBackoffBase = defaultRetryBackoffBase
+	}
+	if config.RetryBackoffMax < config.RetryBackoffBase {
+		config.RetryBackoffMax = defaultRetryBackoffMax
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaultLeaseDuration
+	}
+	if config.WorkerID == "" {
+		config.WorkerID = defaultWorkerID()
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = defaultMaxBatchSize
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+	if config.UrgentReservedShare <= 0 || config.UrgentReservedShare >= 1 {
+		config.UrgentReservedShare = defaultUrgentShare
+	}
+	if config.UrgentPollInterval <= 0 {
+		config.UrgentPollInterval = defaultUrgentPoll
+	}
+	// A batch must finish before its claims can be taken over by another replica
+	if config.BatchTimeout <= 0 || config.BatchTimeout > config.LeaseDuration {
+		config.BatchTimeout = config.LeaseDuration
+	}
+
 	return &messageProcessingService{
-		messageRepo:    messageRepo,
-		webhookService: webhookService,
-		cacheService:   cacheService,
+		messageRepo:     messageRepo,
+		suppressionRepo: suppressionRepo,
+		receiptRepo:     receiptRepo,
+		webhookService:  webhookService,
+		cacheService:    cacheService,
+		config:          config,
 	}
 }
 
@@ -36,14 +115,20 @@ func NewMessageProcessingService(
 func (s *messageProcessingService) ProcessPendingMessages(ctx context.Context, batchSize int) (*usecases.ProcessingResult, error) {
 	// Validate and set default batch size
 	if batchSize <= 0 {
-		batchSize = 2 // Default batch size
+		batchSize = defaultBatchSize
+	}
+	if batchSize > s.config.MaxBatchSize {
+		batchSize = s.config.MaxBatchSize
 	}
-	if batchSize > 10 {
-		batchSize = 10 // Maximum batch size for safety
+
+	// Return messages abandoned by crashed workers to the queue before claiming
+	reclaimed, err := s.messageRepo.ReleaseExpiredLeases(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get pending messages
-	pendingMessages, err := s.messageRepo.GetPendingMessages(ctx, batchSize)
+	// Claim pending messages so no other instance sends them, most urgent first
+	pendingMessages, err := s.messageRepo.ClaimPendingMessages(ctx, s.config.WorkerID, nil, batchSize, s.config.LeaseDuration)
 	if err != nil {
 		return nil, err
 	}
@@ -52,63 +137,299 @@ func (s *messageProcessingService) ProcessPendingMessages(ctx context.Context, b
 		ProcessedCount: len(pendingMessages),
 		SuccessCount:   0,
 		FailedCount:    0,
+		ExpiredCount:   0,
+		ReclaimedCount: reclaimed,
 		Errors:         []error{},
 	}
 
-	// Process each message
+	// Bound the whole batch so it finishes within the claim lease
+	batchCtx, cancel := context.WithTimeout(ctx, s.config.BatchTimeout)
+	defer cancel()
+
+	s.processBatch(batchCtx, pendingMessages, result)
+
+	return result, nil
+}
+
+// processBatch fans claimed messages out to a pool of workers and aggregates their outcomes.
+// Critical and high priority messages are handed out first. A share of the workers only ever delivers
+// them, and while normal and bulk messages are still being delivered those workers claim urgent messages
+// that became due in the meantime, so a slow bulk batch never holds up urgent traffic.
+func (s *messageProcessingService) processBatch(ctx context.Context, pendingMessages []*message.Message, result *usecases.ProcessingResult) {
+	var urgent, regular []*message.Message
 	for _, msg := range pendingMessages {
-		if err := s.processMessage(ctx, msg); err != nil {
-			result.FailedCount++
-			result.Errors = append(result.Errors, errors.NewBusinessErrorWithCause(err, "failed to process message %s", msg.ID))
+		if msg.Priority.IsUrgent() {
+			urgent = append(urgent, msg)
 		} else {
-			result.SuccessCount++
+			regular = append(regular, msg)
 		}
 	}
 
-	return result, nil
+	// Reserved workers are only needed while regular messages take up the others
+	reserved := 0
+	if len(regular) > 0 {
+		reserved = reservedWorkers(s.config.Concurrency, s.config.UrgentReservedShare)
+	}
+	workers := min(s.config.Concurrency-reserved, len(pendingMessages))
+
+	urgentJobs := make(chan *message.Message)
+	regularJobs := make(chan *message.Message)
+	regularDone := make(chan struct{})
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		regularWg sync.WaitGroup
+	)
+
+	deliver := func(msg *message.Message) {
+		outcome, err := s.processClaimedMessage(ctx, msg)
+
+		mu.Lock()
+		recordOutcome(result, msg, outcome, err)
+		mu.Unlock()
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			urgentJobs, regularJobs := urgentJobs, regularJobs
+			for urgentJobs != nil || regularJobs != nil {
+				// Prefer urgent messages whenever one is waiting
+				select {
+				case msg, ok := <-urgentJobs:
+					if !ok {
+						urgentJobs = nil
+						continue
+					}
+					deliver(msg)
+					continue
+				default:
+				}
+
+				select {
+				case msg, ok := <-urgentJobs:
+					if !ok {
+						urgentJobs = nil
+						continue
+					}
+					deliver(msg)
+				case msg, ok := <-regularJobs:
+					if !ok {
+						regularJobs = nil
+						continue
+					}
+					deliver(msg)
+					regularWg.Done()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < reserved; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range urgentJobs {
+				deliver(msg)
+			}
+		}()
+	}
+
+	regularWg.Add(len(regular))
+	go func() {
+		regularWg.Wait()
+		close(regularDone)
+	}()
+
+	go func() {
+		for _, msg := range regular {
+			regularJobs <- msg
+		}
+		close(regularJobs)
+	}()
+
+	for _, msg := range urgent {
+		urgentJobs <- msg
+	}
+	if reserved > 0 {
+		s.claimUrgentMessages(ctx, urgentJobs, regularDone, reserved, func(claimed int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			result.ProcessedCount += claimed
+			if err != nil {
+				result.Errors = append(result.Errors, err)
+			}
+		})
+	}
+	close(urgentJobs)
+
+	wg.Wait()
+}
+
+// claimUrgentMessages keeps the reserved workers busy with critical and high priority messages that become
+// due until the regular messages of the batch are delivered or the batch deadline passes
+func (s *messageProcessingService) claimUrgentMessages(ctx context.Context, jobs chan<- *message.Message, regularDone <-chan struct{}, limit int, record func(claimed int, err error)) {
+	ticker := time.NewTicker(s.config.UrgentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-regularDone:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		claimed, err := s.messageRepo.ClaimPendingMessages(ctx, s.config.WorkerID, message.UrgentPriorities(), limit, s.config.LeaseDuration)
+		record(len(claimed), err)
+
+		for _, msg := range claimed {
+			jobs <- msg
+		}
+	}
+}
+
+// reservedWorkers returns how many of the workers only deliver urgent messages, always leaving one for the rest
+func reservedWorkers(concurrency int, share float64) int {
+	return min(int(math.Ceil(float64(concurrency)*share)), concurrency-1)
+}
+
+// processClaimedMessage delivers a claimed message, or returns it to the queue if the batch deadline has passed
+func (s *messageProcessingService) processClaimedMessage(ctx context.Context, msg *message.Message) (deliveryOutcome, error) {
+	if ctx.Err() == nil {
+		return s.processMessage(ctx, msg)
+	}
+
+	return s.deferMessage(ctx, msg, nil)
+}
+
+// deferMessage returns a claimed message to the queue without counting an attempt, reporting cause if set
+func (s *messageProcessingService) deferMessage(ctx context.Context, msg *message.Message, cause error) (deliveryOutcome, error) {
+	if err := msg.ReleaseClaim(); err != nil {
+		return outcomeFailed, errors.NewBusinessError("failed to release claim: %v", err)
+	}
+
+	// The batch context may already be done, persist the release regardless
+	if err := s.messageRepo.UpdateClaimed(context.WithoutCancel(ctx), msg, s.config.WorkerID); err != nil {
+		return outcomeFailed, err
+	}
+
+	return outcomeDeferred, cause
+}
+
+// recordOutcome adds the outcome of a single message to the batch result
+func recordOutcome(result *usecases.ProcessingResult, msg *message.Message, outcome deliveryOutcome, err error) {
+	if err != nil {
+		result.Errors = append(result.Errors, errors.NewBusinessErrorWithCause(err, "failed to process message %s", msg.ID))
+	}
+
+	switch outcome {
+	case outcomeSent:
+		result.SuccessCount++
+	case outcomeExpired:
+		result.ExpiredCount++
+	case outcomeRetrying:
+		result.RetriedCount++
+	case outcomeDeferred:
+		result.DeferredCount++
+	case outcomeSuppressed:
+		result.SuppressedCount++
+	default:
+		result.FailedCount++
+	}
 }
 
 // processMessage processes a single message using webhook service
-func (s *messageProcessingService) processMessage(ctx context.Context, msg *message.Message) error {
+func (s *messageProcessingService) processMessage(ctx context.Context, msg *message.Message) (deliveryOutcome, error) {
+	// Never send a message that outlived its time-to-live
+	if msg.IsExpired(time.Now()) {
+		if err := msg.MarkAsExpired(); err != nil {
+			return outcomeFailed, errors.NewBusinessError("failed to mark message as expired: %v", err)
+		}
+
+		if err := s.messageRepo.UpdateClaimed(ctx, msg, s.config.WorkerID); err != nil {
+			return outcomeFailed, err
+		}
+
+		return outcomeExpired, nil
+	}
+
+	// Messages stored before the numbering plans were checked may hold numbers no provider accepts
+	if err := msg.PhoneNumber.Validate(); err != nil {
+		if err := msg.Reject(err.Error()); err != nil {
+			return outcomeFailed, errors.NewBusinessError("failed to reject message: %v", err)
+		}
+
+		if err := s.messageRepo.UpdateClaimed(ctx, msg, s.config.WorkerID); err != nil {
+			return outcomeFailed, err
+		}
+
+		return outcomeFailed, nil
+	}
+
+	// The recipient may have opted out after the message was created
+	suppression, err := findSuppression(ctx, s.suppressionRepo, msg.PhoneNumber)
+	if err != nil {
+		return s.deferMessage(ctx, msg, err)
+	}
+	if suppression != nil {
+		if err := msg.MarkAsSuppressed(suppression); err != nil {
+			return outcomeFailed, errors.NewBusinessError("failed to mark message as suppressed: %v", err)
+		}
+
+		if err := s.messageRepo.UpdateClaimed(ctx, msg, s.config.WorkerID); err != nil {
+			return outcomeFailed, err
+		}
+
+		return outcomeSuppressed, nil
+	}
+
 	// Prepare webhook request
 	webhookReq := services.WebhookRequest{
-		To:      msg.PhoneNumber.String(),
-		Content: msg.Content.String(),
+		To:         msg.PhoneNumber.String(),
+		Content:    msg.Content.String(),
+		MessageID:  msg.ID.String(),
+		RetryCount: msg.RetryCount,
 	}
 
 	// Send message via webhook
 	webhookResp, err := s.webhookService.SendMessage(ctx, webhookReq)
-	if err != nil {
-		// Mark message as failed
-		msg.MarkAsFailed()
 
-		// Update message in repository
-		if updateErr := s.messageRepo.Update(ctx, msg); updateErr != nil {
-			return updateErr
-		}
+	// Over the endpoint's throughput limit, or the endpoint is down: send it on a later run without using up a retry
+	var (
+		throttledErr   *services.ThrottledError
+		circuitOpenErr *services.CircuitOpenError
+	)
+	if stdErrors.As(err, &throttledErr) || stdErrors.As(err, &circuitOpenErr) {
+		return s.deferMessage(ctx, msg, nil)
+	}
 
-		return errors.NewBusinessErrorWithCause(err, "webhook call failed for message %s", msg.ID)
+	// Record the delivery outcome even if the batch deadline passed meanwhile
+	ctx = context.WithoutCancel(ctx)
+
+	if err != nil {
+		return s.handleDeliveryFailure(ctx, msg, err)
 	}
 
 	// Webhook success - mark message as sent
-	if err := msg.MarkAsSent(webhookResp.MessageID); err != nil {
-		return errors.NewBusinessError("failed to mark message as sent: %v", err)
+	if err := msg.MarkAsSent(webhookResp.MessageID, webhookResp.Provider); err != nil {
+		return outcomeFailed, errors.NewBusinessError("failed to mark message as sent: %v", err)
 	}
 
 	// Update message in repository
-	if err := s.messageRepo.Update(ctx, msg); err != nil {
-		return err
+	if err := s.messageRepo.UpdateClaimed(ctx, msg, s.config.WorkerID); err != nil {
+		return outcomeFailed, err
 	}
 
 	// Cache the sent message using sorted set for efficient listing
 	// Use timestamp as score for chronological ordering (latest first)
 	timestamp := float64(msg.SentAt.Unix())
 
-	// Create member data: messageId:externalId:phoneNumber for easy parsing
-	member := fmt.Sprintf("%s:%s:%s", msg.ID.String(), webhookResp.MessageID, msg.PhoneNumber.String())
-
 	// Add to sorted set with 30 days TTL
-	if err := s.cacheService.ZAdd(ctx, "sent_messages", timestamp, member, 30*24*time.Hour); err != nil {
+	member := sentMessageCacheMember(msg, webhookResp.MessageID)
+	if err := s.cacheService.ZAdd(ctx, sentMessagesCacheKey, timestamp, member, sentMessageCacheTTL); err != nil {
 		// Cache failure shouldn't break the flow, just log it
 		_ = err // Ignore cache errors for now
 	}
@@ -117,25 +438,129 @@ func (s *messageProcessingService) processMessage(ctx context.Context, msg *mess
 	cacheData := services.SentMessageCacheData{
 		MessageID:   msg.ID.String(),
 		ExternalID:  webhookResp.MessageID,
+		Provider:    webhookResp.Provider,
 		PhoneNumber: msg.PhoneNumber.String(),
 		Content:     msg.Content.String(),
+		Status:      msg.Status.String(),
 		SentAt:      *msg.SentAt,
 	}
 
-	cacheKey := "message_detail:" + msg.ID.String()
-	if err := s.cacheService.SetJSON(ctx, cacheKey, cacheData, 30*24*time.Hour); err != nil {
+	cacheKey := sentMessageDetailCachePrefix + msg.ID.String()
+	if err := s.cacheService.SetJSON(ctx, cacheKey, cacheData, sentMessageCacheTTL); err != nil {
 		// Cache failure shouldn't break the flow, just log it
 		_ = err // Ignore cache errors for now
 	}
 
 	// Keep only the latest 10 messages in the sorted set to save memory
 	// Remove messages beyond rank 10 (0-based indexing, so remove from rank 10 onwards)
-	if err := s.cacheService.ZRemRangeByRank(ctx, "sent_messages", 10, -1); err != nil {
+	if err := s.cacheService.ZRemRangeByRank(ctx, sentMessagesCacheKey, 10, -1); err != nil {
 		// Cache cleanup failure shouldn't break the flow
 		_ = err // Ignore cache cleanup errors
 	}
 
-	return nil
+	// The message is sent either way; a receipt left unapplied is applied when the provider repeats it
+	return outcomeSent, s.applyEarlyDeliveryReceipt(ctx, msg)
+}
+
+// applyEarlyDeliveryReceipt applies a delivery receipt that arrived before the message was marked SENT
+func (s *messageProcessingService) applyEarlyDeliveryReceipt(ctx context.Context, msg *message.Message) error {
+	receipt, err := s.receiptRepo.GetByExternalID(ctx, *msg.ExternalID)
+
+	var notFoundErr errors.NotFoundError
+	if stdErrors.As(err, &notFoundErr) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if receipt.IsApplied() {
+		return nil
+	}
+
+	return applyDeliveryReceipt(ctx, s.messageRepo, s.receiptRepo, s.cacheService, msg, receipt)
+}
+
+// handleDeliveryFailure requeues a message with backoff, or fails it once its retries are exhausted
+// or the failure is permanent
+func (s *messageProcessingService) handleDeliveryFailure(ctx context.Context, msg *message.Message, cause error) (deliveryOutcome, error) {
+	failure := classifyDeliveryError(cause)
+	msg.RecordDeliveryError(failure.class, cause.Error(), failure.httpStatus)
+
+	outcome := outcomeFailed
+	if !failure.permanent && msg.CanRetry() {
+		// Never retry sooner than the endpoint asked for
+		delay := max(s.retryDelay(msg.RetryCount), failure.retryAfter)
+		if err := msg.ScheduleRetry(time.Now().Add(delay)); err != nil {
+			return outcomeFailed, errors.NewBusinessError("failed to schedule retry: %v", err)
+		}
+		outcome = outcomeRetrying
+	} else if err := msg.MarkAsFailed(); err != nil {
+		return outcomeFailed, errors.NewBusinessError("failed to mark message as failed: %v", err)
+	}
+
+	// Update message in repository
+	if err := s.messageRepo.UpdateClaimed(ctx, msg, s.config.WorkerID); err != nil {
+		return outcomeFailed, err
+	}
+
+	return outcome, errors.NewBusinessErrorWithCause(cause, "webhook call failed for message %s", msg.ID)
+}
+
+// deliveryFailure describes why a webhook call failed and whether it is worth retrying
+type deliveryFailure struct {
+	class      message.ErrorClass
+	httpStatus int
+	permanent  bool
+	retryAfter time.Duration
+}
+
+// classifyDeliveryError extracts the error class, HTTP status and retry hints from a webhook failure
+func classifyDeliveryError(err error) deliveryFailure {
+	var webhookErr *services.WebhookError
+	if stdErrors.As(err, &webhookErr) {
+		return deliveryFailure{
+			class:      webhookErr.Class,
+			httpStatus: webhookErr.StatusCode,
+			permanent:  webhookErr.Permanent,
+			retryAfter: webhookErr.RetryAfter,
+		}
+	}
+
+	if stdErrors.Is(err, context.DeadlineExceeded) {
+		return deliveryFailure{class: message.ErrorClassTimeout}
+	}
+
+	return deliveryFailure{class: message.ErrorClassUnknown}
+}
+
+// retryDelay returns an exponential backoff with equal jitter for the given number of prior retries
+func (s *messageProcessingService) retryDelay(retryCount int) time.Duration {
+	delay := s.config.RetryBackoffBase
+	for i := 0; i < retryCount && delay < s.config.RetryBackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > s.config.RetryBackoffMax {
+		delay = s.config.RetryBackoffMax
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// defaultWorkerID identifies this process by host name and PID
+func defaultWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "worker"
+	}
+
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// ExpireOverdueMessages marks pending messages past their expiry time as expired
+func (s *messageProcessingService) ExpireOverdueMessages(ctx context.Context) (int64, error) {
+	return s.messageRepo.ExpireOverdueMessages(ctx)
 }
 
 // GetProcessingStatus returns the current processing status
@@ -158,13 +583,20 @@ func (s *messageProcessingService) GetProcessingStatus(ctx context.Context) (*us
 		return nil, err
 	}
 
+	// Get count of messages currently claimed by workers
+	processingCount, err := s.messageRepo.CountByStatus(ctx, message.StatusProcessing)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate next processing time (every 2 minutes)
 	nextProcessing := time.Now().Add(2 * time.Minute)
 
 	return &usecases.ProcessingStatus{
-		IsProcessing:     false, // TODO: Track actual processing state
-		LastProcessedAt:  nil,   // TODO: Track last processing time
+		IsProcessing:     processingCount > 0,
+		LastProcessedAt:  nil, // TODO: Track last processing time
 		PendingCount:     pendingCount,
+		ProcessingCount:  processingCount,
 		ProcessedToday:   sentCount,   // Simplified for now
 		FailedToday:      failedCount, // Simplified for now
 		NextProcessingAt: &nextProcessing,
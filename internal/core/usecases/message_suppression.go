@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// findSuppression returns the suppression of a phone number, or nil if the number may be messaged
+func findSuppression(ctx context.Context, repo repositories.SuppressionRepository, phoneNumber message.PhoneNumber) (*message.Suppression, error) {
+	suppression, err := repo.Get(ctx, phoneNumber)
+
+	var notFoundErr errors.NotFoundError
+	if stdErrors.As(err, &notFoundErr) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return suppression, nil
+}
+
+// suppressIfOptedOut marks a new message as suppressed if its recipient is on the suppression list.
+// The message is still stored so the refusal is on record, but it is never sent.
+func (s *messageManagementService) suppressIfOptedOut(ctx context.Context, msg *message.Message) error {
+	suppression, err := findSuppression(ctx, s.suppressionRepo, msg.PhoneNumber)
+	if err != nil || suppression == nil {
+		return err
+	}
+
+	return msg.MarkAsSuppressed(suppression)
+}
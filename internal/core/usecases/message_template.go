@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+)
+
+// templateSet loads the templates referenced while creating messages, each at most once.
+// Sharing a set across a batch renders every message of the batch from the same template version.
+type templateSet struct {
+	repo      repositories.TemplateRepository
+	templates map[uuid.UUID]*message.Template // Nil entries remember templates that do not exist
+}
+
+// newTemplateSet creates an empty template set backed by the template repository
+func (s *messageManagementService) newTemplateSet() *templateSet {
+	return &templateSet{
+		repo:      s.templateRepo,
+		templates: make(map[uuid.UUID]*message.Template),
+	}
+}
+
+// get returns the template with the given ID, loading it on first use
+func (t *templateSet) get(ctx context.Context, id uuid.UUID) (*message.Template, error) {
+	template, loaded := t.templates[id]
+	if !loaded {
+		var err error
+		template, err = t.repo.GetByID(ctx, message.TemplateID(id.String()))
+
+		var notFoundErr errors.NotFoundError
+		if err != nil && !stdErrors.As(err, &notFoundErr) {
+			return nil, err
+		}
+		t.templates[id] = template
+	}
+
+	// A missing template is a problem with the request, not a missing resource
+	if template == nil {
+		return nil, errors.NewValidationError("template %s not found", id)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,276 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// defaultReplayMaxEvents bounds a single replay when no limit is configured
+const defaultReplayMaxEvents = 10000
+
+// subscriptionManagementService implements SubscriptionUseCase
+type subscriptionManagementService struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	deliveryRepo     repositories.EventDeliveryRepository
+	config           usecases.SubscriptionConfig
+}
+
+// NewSubscriptionManagementService creates a new subscription management use case
+func NewSubscriptionManagementService(
+	subscriptionRepo repositories.SubscriptionRepository,
+	deliveryRepo repositories.EventDeliveryRepository,
+	config usecases.SubscriptionConfig,
+) usecases.SubscriptionUseCase {
+	if config.ReplayMaxEvents <= 0 {
+		config.ReplayMaxEvents = defaultReplayMaxEvents
+	}
+
+	return &subscriptionManagementService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		config:           config,
+	}
+}
+
+// CreateSubscription registers an endpoint and returns it with its signing secret
+func (s *subscriptionManagementService) CreateSubscription(ctx context.Context, cmd usecases.CreateSubscriptionCommand) (*usecases.SubscriptionResponse, error) {
+	subscription, err := message.NewSubscription(cmd.URL, cmd.Description, toStatuses(cmd.Statuses))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	// The secret is shown once; later reads leave it out
+	response := subscriptionToResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+// GetSubscription retrieves a subscription without its secret
+func (s *subscriptionManagementService) GetSubscription(ctx context.Context, id uuid.UUID) (*usecases.SubscriptionResponse, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptionToResponse(subscription), nil
+}
+
+// ListSubscriptions retrieves subscriptions, oldest first
+func (s *subscriptionManagementService) ListSubscriptions(ctx context.Context, query usecases.ListSubscriptionsQuery) (*usecases.ListSubscriptionsResponse, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	subscriptions, err := s.subscriptionRepo.List(ctx, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.subscriptionRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.SubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = *subscriptionToResponse(subscription)
+	}
+
+	return &usecases.ListSubscriptionsResponse{
+		Subscriptions: responses,
+		TotalCount:    totalCount,
+		HasMore:       int64(offset+len(subscriptions)) < totalCount,
+	}, nil
+}
+
+// UpdateSubscription replaces the endpoint, description, status filter and active flag of a subscription
+func (s *subscriptionManagementService) UpdateSubscription(ctx context.Context, cmd usecases.UpdateSubscriptionCommand) (*usecases.SubscriptionResponse, error) {
+	subscription, err := s.getSubscription(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subscription.Update(cmd.URL, cmd.Description, toStatuses(cmd.Statuses), cmd.Active); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscriptionToResponse(subscription), nil
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+func (s *subscriptionManagementService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.NewValidationError("subscription ID cannot be empty")
+	}
+
+	return s.subscriptionRepo.Delete(ctx, message.SubscriptionID(id.String()))
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, most recent first
+func (s *subscriptionManagementService) ListDeliveries(ctx context.Context, query usecases.ListEventDeliveriesQuery) (*usecases.ListEventDeliveriesResponse, error) {
+	subscription, err := s.getSubscription(ctx, query.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter repositories.EventDeliveryFilter
+	if query.Status != "" {
+		status := message.EventDeliveryStatus(strings.ToUpper(query.Status))
+		if !status.IsValid() {
+			return nil, errors.NewValidationError("invalid delivery status: %s", query.Status)
+		}
+		filter.Status = &status
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	deliveries, err := s.deliveryRepo.ListBySubscription(ctx, subscription.ID, filter, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.deliveryRepo.CountBySubscription(ctx, subscription.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.EventDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = eventDeliveryToResponse(delivery)
+	}
+
+	return &usecases.ListEventDeliveriesResponse{
+		Deliveries: responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(deliveries)) < totalCount,
+	}, nil
+}
+
+// ReplayEvents queues the status changes of a time range the subscription matches for delivery again.
+// Replayed events keep their IDs, so endpoints can recognize ones they already processed.
+func (s *subscriptionManagementService) ReplayEvents(ctx context.Context, cmd usecases.ReplayEventsCommand) (*usecases.ReplayEventsResponse, error) {
+	if cmd.From.IsZero() || cmd.To.IsZero() {
+		return nil, errors.NewValidationError("replay range requires both from and to")
+	}
+	if !cmd.To.After(cmd.From) {
+		return nil, errors.NewValidationError("replay range end must be after its start")
+	}
+
+	subscription, err := s.getSubscription(ctx, cmd.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.deliveryRepo.CountEvents(ctx, subscription, cmd.From, cmd.To)
+	if err != nil {
+		return nil, err
+	}
+	if count > int64(s.config.ReplayMaxEvents) {
+		return nil, errors.NewValidationError("replay range holds %d events (max: %d), narrow it down", count, s.config.ReplayMaxEvents)
+	}
+
+	queued, err := s.deliveryRepo.Replay(ctx, subscription, cmd.From, cmd.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecases.ReplayEventsResponse{Queued: queued}, nil
+}
+
+// getSubscription validates the ID and loads the subscription
+func (s *subscriptionManagementService) getSubscription(ctx context.Context, id uuid.UUID) (*message.Subscription, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("subscription ID cannot be empty")
+	}
+
+	return s.subscriptionRepo.GetByID(ctx, message.SubscriptionID(id.String()))
+}
+
+// toStatuses converts status names to domain statuses; they are validated by the domain
+func toStatuses(names []string) []message.Status {
+	statuses := make([]message.Status, len(names))
+	for i, name := range names {
+		statuses[i] = message.Status(name)
+	}
+	return statuses
+}
+
+// subscriptionToResponse converts a domain subscription to response DTO, leaving out the secret
+func subscriptionToResponse(subscription *message.Subscription) *usecases.SubscriptionResponse {
+	id, _ := uuid.Parse(subscription.ID.String())
+
+	statuses := make([]string, len(subscription.Statuses))
+	for i, status := range subscription.Statuses {
+		statuses[i] = status.String()
+	}
+
+	return &usecases.SubscriptionResponse{
+		ID:          id,
+		URL:         subscription.URL,
+		Description: subscription.Description,
+		Statuses:    statuses,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+// eventDeliveryToResponse converts a domain event delivery to response DTO
+func eventDeliveryToResponse(delivery *message.EventDelivery) usecases.EventDeliveryResponse {
+	messageID, _ := uuid.Parse(delivery.Event.MessageID.String())
+
+	response := usecases.EventDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.Event.ID,
+		MessageID:      messageID,
+		To:             delivery.Event.To.String(),
+		OccurredAt:     delivery.Event.OccurredAt,
+		Status:         delivery.Status.String(),
+		Replay:         delivery.Replay,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.Event.From != nil {
+		from := delivery.Event.From.String()
+		response.From = &from
+	}
+	if delivery.Status == message.EventDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
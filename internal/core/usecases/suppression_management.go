@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// defaultSuppressionImportMaxItems is used when no bulk suppression import limit is configured
+const defaultSuppressionImportMaxItems = 10000
+
+// suppressionManagementService implements SuppressionManagementUseCase
+type suppressionManagementService struct {
+	suppressionRepo repositories.SuppressionRepository
+	config          usecases.SuppressionConfig
+}
+
+// NewSuppressionManagementService creates a new suppression management use case
+func NewSuppressionManagementService(
+	suppressionRepo repositories.SuppressionRepository,
+	config usecases.SuppressionConfig,
+) usecases.SuppressionManagementUseCase {
+	if config.ImportMaxItems <= 0 {
+		config.ImportMaxItems = defaultSuppressionImportMaxItems
+	}
+
+	return &suppressionManagementService{
+		suppressionRepo: suppressionRepo,
+		config:          config,
+	}
+}
+
+// AddSuppression puts a phone number on the suppression list, returning the existing entry if it is already there
+func (s *suppressionManagementService) AddSuppression(ctx context.Context, cmd usecases.AddSuppressionCommand) (*usecases.SuppressionResponse, error) {
+	suppression, err := s.newSuppression(cmd, message.SuppressionSourceAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := s.suppressionRepo.Add(ctx, suppression)
+	if err != nil {
+		return nil, err
+	}
+
+	if !added {
+		if suppression, err = s.suppressionRepo.Get(ctx, suppression.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	return suppressionToResponse(suppression), nil
+}
+
+// ImportSuppressions validates every entry on its own and stores the valid ones in a single batch
+func (s *suppressionManagementService) ImportSuppressions(ctx context.Context, cmd usecases.ImportSuppressionsCommand) (*usecases.ImportSuppressionsResult, error) {
+	if len(cmd.Suppressions) == 0 {
+		return nil, errors.NewValidationError("at least one suppression is required")
+	}
+	if len(cmd.Suppressions) > s.config.ImportMaxItems {
+		return nil, errors.NewValidationError("too many suppressions: %d (max: %d)", len(cmd.Suppressions), s.config.ImportMaxItems)
+	}
+
+	result := &usecases.ImportSuppressionsResult{
+		RejectedEntries: []usecases.SuppressionImportError{},
+	}
+
+	seen := make(map[message.PhoneNumber]bool, len(cmd.Suppressions))
+	suppressions := make([]*message.Suppression, 0, len(cmd.Suppressions))
+	for i, entry := range cmd.Suppressions {
+		if entry.Reason == "" {
+			entry.Reason = cmd.Reason
+		}
+
+		suppression, err := s.newSuppression(entry, message.SuppressionSourceImport)
+		if err != nil {
+			result.RejectedEntries = append(result.RejectedEntries, usecases.SuppressionImportError{
+				Index:       i,
+				PhoneNumber: entry.PhoneNumber,
+				Error:       err.Error(),
+			})
+			continue
+		}
+
+		// Different spellings of a number normalize to the same entry
+		if seen[suppression.PhoneNumber] {
+			result.DuplicateCount++
+			continue
+		}
+		seen[suppression.PhoneNumber] = true
+
+		suppressions = append(suppressions, suppression)
+	}
+
+	if len(suppressions) > 0 {
+		added, err := s.suppressionRepo.AddBatch(ctx, suppressions)
+		if err != nil {
+			return nil, err
+		}
+		result.AddedCount = added
+		result.DuplicateCount += len(suppressions) - int(added)
+	}
+	result.RejectedCount = len(result.RejectedEntries)
+
+	return result, nil
+}
+
+// CheckSuppression retrieves the suppression of a phone number
+func (s *suppressionManagementService) CheckSuppression(ctx context.Context, phoneNumber string) (*usecases.SuppressionResponse, error) {
+	phone, err := s.parsePhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	suppression, err := s.suppressionRepo.Get(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+
+	return suppressionToResponse(suppression), nil
+}
+
+// ListSuppressions retrieves suppressed phone numbers, most recent first
+func (s *suppressionManagementService) ListSuppressions(ctx context.Context, query usecases.ListSuppressionsQuery) (*usecases.ListSuppressionsResponse, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	suppressions, err := s.suppressionRepo.List(ctx, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.suppressionRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.SuppressionResponse, len(suppressions))
+	for i, suppression := range suppressions {
+		responses[i] = *suppressionToResponse(suppression)
+	}
+
+	return &usecases.ListSuppressionsResponse{
+		Suppressions: responses,
+		TotalCount:   totalCount,
+		HasMore:      int64(offset+len(suppressions)) < totalCount,
+	}, nil
+}
+
+// RemoveSuppression takes a phone number off the suppression list
+func (s *suppressionManagementService) RemoveSuppression(ctx context.Context, phoneNumber string) error {
+	phone, err := s.parsePhoneNumber(phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	return s.suppressionRepo.Remove(ctx, phone)
+}
+
+// newSuppression validates the command and builds the suppression it describes
+func (s *suppressionManagementService) newSuppression(cmd usecases.AddSuppressionCommand, source message.SuppressionSource) (*message.Suppression, error) {
+	phone, err := s.parsePhoneNumber(cmd.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return message.NewSuppression(phone, cmd.Reason, source)
+}
+
+// parsePhoneNumber normalizes a phone number to E.164 the same way messages do,
+// so a suppression matches every spelling of the number
+func (s *suppressionManagementService) parsePhoneNumber(phoneNumber string) (message.PhoneNumber, error) {
+	if phoneNumber == "" {
+		return "", errors.NewValidationError("phone number is required")
+	}
+
+	phone, err := message.ParsePhoneNumber(phoneNumber, s.config.DefaultRegion)
+	if err != nil {
+		return "", errors.NewValidationError("invalid phone number format: %s", phoneNumber)
+	}
+
+	return phone, nil
+}
+
+// suppressionToResponse converts a domain suppression to response DTO
+func suppressionToResponse(suppression *message.Suppression) *usecases.SuppressionResponse {
+	return &usecases.SuppressionResponse{
+		PhoneNumber: suppression.PhoneNumber.String(),
+		Reason:      suppression.Reason,
+		Source:      suppression.Source.String(),
+		CreatedAt:   suppression.CreatedAt,
+	}
+}
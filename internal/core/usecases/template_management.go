@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// templateManagementService implements TemplateManagementUseCase
+type templateManagementService struct {
+	templateRepo repositories.TemplateRepository
+}
+
+// NewTemplateManagementService creates a new template management use case
+func NewTemplateManagementService(templateRepo repositories.TemplateRepository) usecases.TemplateManagementUseCase {
+	return &templateManagementService{
+		templateRepo: templateRepo,
+	}
+}
+
+// CreateTemplate validates and stores the first version of a template
+func (s *templateManagementService) CreateTemplate(ctx context.Context, cmd usecases.CreateTemplateCommand) (*usecases.TemplateResponse, error) {
+	template, err := message.NewTemplate(cmd.Name, cmd.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return templateToResponse(template), nil
+}
+
+// GetTemplate retrieves the current version of a template
+func (s *templateManagementService) GetTemplate(ctx context.Context, id uuid.UUID) (*usecases.TemplateResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("template ID cannot be empty")
+	}
+
+	template, err := s.templateRepo.GetByID(ctx, message.TemplateID(id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return templateToResponse(template), nil
+}
+
+// ListTemplates retrieves templates ordered by name
+func (s *templateManagementService) ListTemplates(ctx context.Context, query usecases.ListTemplatesQuery) (*usecases.ListTemplatesResponse, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	offset := query.Offset
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset cannot be negative")
+	}
+
+	templates, err := s.templateRepo.List(ctx, repositories.NewPagination(limit, offset))
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.templateRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]usecases.TemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = *templateToResponse(template)
+	}
+
+	return &usecases.ListTemplatesResponse{
+		Templates:  responses,
+		TotalCount: totalCount,
+		HasMore:    int64(offset+len(templates)) < totalCount,
+	}, nil
+}
+
+// UpdateTemplate replaces the body of a template, creating a new version if the body changed
+func (s *templateManagementService) UpdateTemplate(ctx context.Context, cmd usecases.UpdateTemplateCommand) (*usecases.TemplateResponse, error) {
+	if cmd.ID == uuid.Nil {
+		return nil, errors.NewValidationError("template ID cannot be empty")
+	}
+
+	template, err := s.templateRepo.GetByID(ctx, message.TemplateID(cmd.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	version := template.Version
+	if err := template.Revise(cmd.Body); err != nil {
+		return nil, err
+	}
+
+	// An unchanged body keeps the current version
+	if template.Version == version {
+		return templateToResponse(template), nil
+	}
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return templateToResponse(template), nil
+}
+
+// DeleteTemplate removes a template; messages already rendered from it are kept
+func (s *templateManagementService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.NewValidationError("template ID cannot be empty")
+	}
+
+	return s.templateRepo.Delete(ctx, message.TemplateID(id.String()))
+}
+
+// templateToResponse converts a domain template to response DTO
+func templateToResponse(template *message.Template) *usecases.TemplateResponse {
+	id, _ := uuid.Parse(template.ID.String())
+
+	variables := template.Variables()
+	if variables == nil {
+		variables = []string{}
+	}
+
+	return &usecases.TemplateResponse{
+		ID:        id,
+		Name:      template.Name,
+		Body:      template.Body,
+		Version:   template.Version,
+		Variables: variables,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
@@ -15,6 +15,10 @@ type Config struct {
 	Redis     RedisConfig     `mapstructure:"redis"`
 	Webhook   WebhookConfig   `mapstructure:"webhook"`
 	Scheduler SchedulerConfig `mapstructure:"scheduler"`
+	Messages  MessagesConfig  `mapstructure:"messages"`
+
+	Subscriptions SubscriptionsConfig `mapstructure:"subscriptions"`
+	Stream        StreamConfig        `mapstructure:"stream"`
 }
 
 // AppConfig contains general application configuration
@@ -66,6 +70,70 @@ type WebhookConfig struct {
 	MaxRetries       int           `mapstructure:"max_retries"`
 	RetryBackoffBase time.Duration `mapstructure:"retry_backoff_base"`
 	RetryBackoffMax  time.Duration `mapstructure:"retry_backoff_max"`
+
+	// Providers tried in order, each falling back to the next; when empty, url and auth_token are the only one
+	Providers []WebhookProviderConfig `mapstructure:"providers"`
+
+	RateLimit      WebhookRateLimitConfig      `mapstructure:"rate_limit"`
+	CircuitBreaker WebhookCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Replies and delivery receipts the provider posts back
+	Callbacks WebhookCallbacksConfig `mapstructure:"callbacks"`
+}
+
+// DefaultWebhookProvider names the provider configured through webhook.url
+const DefaultWebhookProvider = "default"
+
+// WebhookProviderConfig contains a named webhook endpoint
+type WebhookProviderConfig struct {
+	Name      string `mapstructure:"name"`
+	URL       string `mapstructure:"url"`
+	AuthToken string `mapstructure:"auth_token"`
+
+	// Throughput limit of this provider's contract; settings left out are taken from webhook.rate_limit
+	RateLimit *WebhookProviderRateLimitConfig `mapstructure:"rate_limit"`
+}
+
+// WebhookProviderRateLimitConfig overrides the global webhook rate limit for one provider
+type WebhookProviderRateLimitConfig struct {
+	Enabled       *bool         `mapstructure:"enabled"`
+	Rate          float64       `mapstructure:"rate"`
+	Burst         int           `mapstructure:"burst"`
+	MaxWait       time.Duration `mapstructure:"max_wait"`
+	FallbackShare float64       `mapstructure:"fallback_share"`
+}
+
+// ProviderList returns the configured providers in failover order
+func (c WebhookConfig) ProviderList() []WebhookProviderConfig {
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+
+	return []WebhookProviderConfig{{Name: DefaultWebhookProvider, URL: c.URL, AuthToken: c.AuthToken}}
+}
+
+// WebhookRateLimitConfig contains the outbound throughput limit of the webhook endpoint, shared across replicas
+type WebhookRateLimitConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Rate          float64       `mapstructure:"rate"` // Requests per second
+	Burst         int           `mapstructure:"burst"`
+	MaxWait       time.Duration `mapstructure:"max_wait"`
+	FallbackShare float64       `mapstructure:"fallback_share"` // Share of the limit each replica allows while Redis is down
+}
+
+// WebhookCircuitBreakerConfig contains the circuit breaker that pauses deliveries while the webhook endpoint is down
+type WebhookCircuitBreakerConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	OpenDuration     time.Duration `mapstructure:"open_duration"`
+	HalfOpenRequests int           `mapstructure:"half_open_requests"`
+}
+
+// WebhookCallbacksConfig contains the authentication and rate limit of the callbacks the provider sends
+type WebhookCallbacksConfig struct {
+	Secret             string        `mapstructure:"secret"` // Shared secret the provider signs callbacks with
+	SignatureTolerance time.Duration `mapstructure:"signature_tolerance"`
+	RequestsPerMinute  int           `mapstructure:"requests_per_minute"` // Per-IP limit, 0 for none
 }
 
 // SchedulerConfig contains background processing configuration
@@ -73,6 +141,65 @@ type SchedulerConfig struct {
 	Enabled   bool          `mapstructure:"enabled"`
 	Interval  time.Duration `mapstructure:"interval"`
 	BatchSize int           `mapstructure:"batch_size"`
+
+	// Message-level retry backoff after a failed delivery
+	RetryBackoffBase time.Duration `mapstructure:"retry_backoff_base"`
+	RetryBackoffMax  time.Duration `mapstructure:"retry_backoff_max"`
+
+	// Claiming of messages across replicas
+	WorkerID      string        `mapstructure:"worker_id"`
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+
+	// Worker pool used to deliver each batch
+	Concurrency  int           `mapstructure:"concurrency"`
+	MaxBatchSize int           `mapstructure:"max_batch_size"`
+	BatchTimeout time.Duration `mapstructure:"batch_timeout"`
+
+	// Capacity kept for critical and high priority messages
+	UrgentReservedShare float64       `mapstructure:"urgent_reserved_share"`
+	UrgentPollInterval  time.Duration `mapstructure:"urgent_poll_interval"`
+}
+
+// MessagesConfig contains message API behavior configuration
+type MessagesConfig struct {
+	IdempotencyRetention      time.Duration `mapstructure:"idempotency_retention"`
+	BatchMaxItems             int           `mapstructure:"batch_max_items"`
+	ImportChunkSize           int           `mapstructure:"import_chunk_size"`
+	ImportStaleAfter          time.Duration `mapstructure:"import_stale_after"`
+	MaxSegments               int           `mapstructure:"max_segments"`
+	DefaultRegion             string        `mapstructure:"default_region"`
+	SuppressionImportMaxItems int           `mapstructure:"suppression_import_max_items"`
+
+	// Replies that opt a recipient out of or back into messages; empty lists use the built-in keywords
+	OptOutKeywords []string `mapstructure:"opt_out_keywords"`
+	OptInKeywords  []string `mapstructure:"opt_in_keywords"`
+}
+
+// SubscriptionsConfig contains status-change callback delivery configuration
+type SubscriptionsConfig struct {
+	Interval    time.Duration `mapstructure:"interval"`
+	BatchSize   int           `mapstructure:"batch_size"`
+	Concurrency int           `mapstructure:"concurrency"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+
+	// Per-event retry backoff after a failed callback
+	MaxAttempts      int           `mapstructure:"max_attempts"`
+	RetryBackoffBase time.Duration `mapstructure:"retry_backoff_base"`
+	RetryBackoffMax  time.Duration `mapstructure:"retry_backoff_max"`
+
+	// Claiming of deliveries across replicas; the worker ID is shared with the scheduler
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+
+	ReplayMaxEvents int `mapstructure:"replay_max_events"`
+}
+
+// StreamConfig contains configuration for the server-sent message status stream
+type StreamConfig struct {
+	ResumeWindow      time.Duration `mapstructure:"resume_window"`
+	PollInterval      time.Duration `mapstructure:"poll_interval"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	BufferSize        int           `mapstructure:"buffer_size"`
+	GapTimeout        time.Duration `mapstructure:"gap_timeout"`
 }
 
 // Load loads configuration from config.yaml file only
@@ -127,8 +254,12 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database name is required")
 	}
 
-	if c.Webhook.URL == "" {
-		return fmt.Errorf("webhook URL is required")
+	if err := c.Webhook.validateProviders(); err != nil {
+		return err
+	}
+
+	if c.Webhook.Callbacks.Secret == "" {
+		return fmt.Errorf("webhook callback secret is required")
 	}
 
 	if c.Scheduler.BatchSize <= 0 {
@@ -142,6 +273,56 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ProviderRateLimit returns the throughput limit of a provider, its own settings taking precedence over webhook.rate_limit
+func (c WebhookConfig) ProviderRateLimit(provider WebhookProviderConfig) WebhookRateLimitConfig {
+	limit := c.RateLimit
+	override := provider.RateLimit
+	if override == nil {
+		return limit
+	}
+
+	if override.Enabled != nil {
+		limit.Enabled = *override.Enabled
+	}
+	if override.Rate > 0 {
+		limit.Rate = override.Rate
+	}
+	if override.Burst > 0 {
+		limit.Burst = override.Burst
+	}
+	if override.MaxWait > 0 {
+		limit.MaxWait = override.MaxWait
+	}
+	if override.FallbackShare > 0 {
+		limit.FallbackShare = override.FallbackShare
+	}
+
+	return limit
+}
+
+// validateProviders checks that every webhook provider has a URL, a unique name and a usable rate limit
+func (c WebhookConfig) validateProviders() error {
+	names := make(map[string]bool)
+	for _, provider := range c.ProviderList() {
+		if provider.Name == "" {
+			return fmt.Errorf("webhook provider name is required")
+		}
+		if provider.URL == "" {
+			return fmt.Errorf("webhook URL is required for provider %s", provider.Name)
+		}
+		if names[provider.Name] {
+			return fmt.Errorf("duplicate webhook provider: %s", provider.Name)
+		}
+		names[provider.Name] = true
+
+		if limit := c.ProviderRateLimit(provider); limit.Enabled && limit.Rate <= 0 {
+			return fmt.Errorf("webhook rate limit must be positive for provider %s", provider.Name)
+		}
+	}
+
+	return nil
+}
+
 // IsProduction returns true if the environment is production
 func (c *Config) IsProduction() bool {
 	return c.App.Environment == "production"
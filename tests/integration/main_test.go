@@ -0,0 +1,18 @@
+package integration
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/shared/logger"
+)
+
+// TestMain initializes the global logger the adapters under test log through
+func TestMain(m *testing.M) {
+	if err := logger.Initialize(logger.LoggerConfig{Level: "error"}); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	os.Exit(m.Run())
+}
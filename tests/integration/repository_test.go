@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -131,7 +132,7 @@ func TestMessageRepository_Update(t *testing.T) {
 
 	// Update the message
 	externalID := "webhook-response-123"
-	err = msg.MarkAsSent(externalID)
+	err = msg.MarkAsSent(externalID, "primary")
 	if err != nil {
 		t.Fatalf("Failed to mark message as sent: %v", err)
 	}
@@ -156,6 +157,10 @@ func TestMessageRepository_Update(t *testing.T) {
 		t.Errorf("Expected external ID %s, got %v", externalID, updatedMsg.ExternalID)
 	}
 
+	if updatedMsg.Provider == nil || *updatedMsg.Provider != "primary" {
+		t.Errorf("Expected provider primary, got %v", updatedMsg.Provider)
+	}
+
 	if updatedMsg.SentAt == nil {
 		t.Error("Expected sent_at to be set")
 	}
@@ -214,7 +219,7 @@ func TestMessageRepository_GetByStatus(t *testing.T) {
 		t.Fatalf("Failed to create message: %v", err)
 	}
 
-	err = msg.MarkAsSent("external-123")
+	err = msg.MarkAsSent("external-123", "primary")
 	if err != nil {
 		t.Fatalf("Failed to mark message as sent: %v", err)
 	}
@@ -279,3 +284,82 @@ func TestMessageRepository_CountByStatus(t *testing.T) {
 	// Cleanup
 	repo.DeleteByID(ctx, msg.ID)
 }
+
+func TestMessageRepository_ClaimPendingMessages(t *testing.T) {
+	repo := setupTestDB(t)
+	ctx := context.Background()
+
+	// Create multiple test messages
+	var testMessages []*message.Message
+	for i := 0; i < 6; i++ {
+		msg := createTestMessage(t)
+		if err := repo.Create(ctx, msg); err != nil {
+			t.Fatalf("Failed to create test message %d: %v", i, err)
+		}
+		testMessages = append(testMessages, msg)
+	}
+
+	// Claim concurrently from several workers
+	workers := []string{"worker-1", "worker-2", "worker-3"}
+	claims := make([][]*message.Message, len(workers))
+	errs := make([]error, len(workers))
+
+	var wg sync.WaitGroup
+	for i, workerID := range workers {
+		wg.Add(1)
+		go func(i int, workerID string) {
+			defer wg.Done()
+			claims[i], errs[i] = repo.ClaimPendingMessages(ctx, workerID, nil, 100, time.Minute)
+		}(i, workerID)
+	}
+	wg.Wait()
+
+	// Every message must be claimed by at most one worker
+	seen := make(map[message.MessageID]string)
+	for i, claimed := range claims {
+		if errs[i] != nil {
+			t.Fatalf("Failed to claim messages for %s: %v", workers[i], errs[i])
+		}
+
+		for _, msg := range claimed {
+			if owner, ok := seen[msg.ID]; ok {
+				t.Errorf("Message %s claimed by both %s and %s", msg.ID, owner, workers[i])
+			}
+			seen[msg.ID] = workers[i]
+
+			if msg.Status != message.StatusProcessing {
+				t.Errorf("Expected claimed status %s, got %s", message.StatusProcessing, msg.Status)
+			}
+			if msg.LockedBy == nil || *msg.LockedBy != workers[i] {
+				t.Errorf("Expected message locked by %s, got %v", workers[i], msg.LockedBy)
+			}
+		}
+	}
+
+	for _, msg := range testMessages {
+		if _, ok := seen[msg.ID]; !ok {
+			t.Errorf("Expected test message %s to be claimed", msg.ID)
+		}
+	}
+
+	// Only the lease holder may write the claimed message back
+	claimed := testMessages[0]
+	stored, err := repo.GetByID(ctx, claimed.ID)
+	if err != nil {
+		t.Fatalf("Failed to get claimed message: %v", err)
+	}
+	stored.MarkAsSent("external-123", "primary")
+
+	if err := repo.UpdateClaimed(ctx, stored, "not-the-owner"); err == nil {
+		t.Error("Expected update by a non-owner to fail")
+	}
+
+	if err := repo.UpdateClaimed(ctx, stored, seen[claimed.ID]); err != nil {
+		t.Errorf("Failed to update claimed message as owner: %v", err)
+	}
+
+	// Cleanup
+	for _, msg := range testMessages {
+		repo.DeleteByID(ctx, msg.ID)
+	}
+}
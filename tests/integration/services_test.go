@@ -2,11 +2,14 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/services/cache"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/secondary/services/webhook"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
 )
@@ -30,7 +33,7 @@ func TestWebhookService_Integration(t *testing.T) {
 			RetryBackoffBase: 100 * time.Millisecond,
 		}
 
-		webhookService := webhook.NewWebhookService(config)
+		webhookService := webhook.NewWebhookService(config, nil)
 
 		// Test webhook call
 		request := services.WebhookRequest{
@@ -58,3 +61,171 @@ func TestWebhookService_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestWebhookService_ErrorClassification(t *testing.T) {
+	tests := []struct {
+		name              string
+		statusCode        int
+		retryAfter        string
+		expectedRequests  int32
+		expectedPermanent bool
+		expectedDelay     time.Duration
+	}{
+		{"client error is permanent", http.StatusBadRequest, "", 1, true, 0},
+		{"server error is retried", http.StatusServiceUnavailable, "", 3, false, 0},
+		{"too many requests is retried", http.StatusTooManyRequests, "", 3, false, 0},
+		{"long Retry-After is left to the message retry", http.StatusTooManyRequests, "120", 1, false, 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			config := services.WebhookConfig{
+				URL:              server.URL,
+				Timeout:          5 * time.Second,
+				MaxRetries:       2,
+				RetryBackoffBase: time.Millisecond,
+				RetryBackoffMax:  10 * time.Millisecond,
+			}
+			webhookService := webhook.NewWebhookService(config, nil)
+
+			_, err := webhookService.SendMessage(context.Background(), services.WebhookRequest{To: "+905551234567", Content: "Test message"})
+
+			var webhookErr *services.WebhookError
+			if !errors.As(err, &webhookErr) {
+				t.Fatalf("Expected a webhook error, got %v", err)
+			}
+			if webhookErr.StatusCode != tt.statusCode || webhookErr.Permanent != tt.expectedPermanent || webhookErr.RetryAfter != tt.expectedDelay {
+				t.Errorf("Unexpected webhook error %+v", webhookErr)
+			}
+			if received.Load() != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, received.Load())
+			}
+		})
+	}
+}
+
+func TestRateLimitedWebhookService_Integration(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"messageId": "test-123", "message": "Message sent successfully"}`))
+	}))
+	defer server.Close()
+
+	newService := func(maxWait time.Duration) services.WebhookService {
+		config := services.WebhookConfig{
+			URL:              server.URL,
+			Timeout:          5 * time.Second,
+			RateLimit:        services.RateLimit{Rate: 10, Burst: 2},
+			RateLimitMaxWait: maxWait,
+		}
+		return webhook.NewRateLimitedWebhookService(config, nil, cache.NewLocalRateLimiter())
+	}
+	request := services.WebhookRequest{To: "+905551234567", Content: "Test message"}
+
+	t.Run("requests over the limit are throttled without reaching the endpoint", func(t *testing.T) {
+		received.Store(0)
+		webhookService := newService(0)
+
+		for i := 0; i < 2; i++ {
+			if _, err := webhookService.SendMessage(context.Background(), request); err != nil {
+				t.Fatalf("Unexpected error within burst: %v", err)
+			}
+		}
+
+		_, err := webhookService.SendMessage(context.Background(), request)
+		var throttledErr *services.ThrottledError
+		if !errors.As(err, &throttledErr) || throttledErr.RetryAfter <= 0 {
+			t.Fatalf("Expected a throttled error with a retry delay, got %v", err)
+		}
+		if received.Load() != 2 {
+			t.Errorf("Expected 2 requests at the endpoint, got %d", received.Load())
+		}
+	})
+
+	t.Run("every retry takes a token", func(t *testing.T) {
+		var failing atomic.Int32
+		failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failing.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer failingServer.Close()
+
+		config := services.WebhookConfig{
+			URL:              failingServer.URL,
+			Timeout:          5 * time.Second,
+			MaxRetries:       3,
+			RetryBackoffBase: time.Millisecond,
+			RetryBackoffMax:  time.Millisecond,
+			RateLimit:        services.RateLimit{Rate: 1, Burst: 2},
+		}
+		webhookService := webhook.NewRateLimitedWebhookService(config, nil, cache.NewLocalRateLimiter())
+
+		_, err := webhookService.SendMessage(context.Background(), request)
+		var webhookErr *services.WebhookError
+		if !errors.As(err, &webhookErr) || webhookErr.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("Expected the 429 failure once out of tokens, got %v", err)
+		}
+		if failing.Load() != 2 {
+			t.Errorf("Expected 2 requests at the endpoint, got %d", failing.Load())
+		}
+	})
+
+	t.Run("providers sharing a limiter each have their own bucket", func(t *testing.T) {
+		limiter := cache.NewLocalRateLimiter()
+		newProviderService := func(provider string) services.WebhookService {
+			config := services.WebhookConfig{
+				Provider:  provider,
+				URL:       server.URL,
+				Timeout:   5 * time.Second,
+				RateLimit: services.RateLimit{Rate: 1, Burst: 1},
+			}
+			return webhook.NewRateLimitedWebhookService(config, nil, limiter)
+		}
+		primary, secondary := newProviderService("primary"), newProviderService("secondary")
+
+		if _, err := primary.SendMessage(context.Background(), request); err != nil {
+			t.Fatalf("Unexpected error from primary: %v", err)
+		}
+		if _, err := secondary.SendMessage(context.Background(), request); err != nil {
+			t.Fatalf("Expected secondary to have its own token, got %v", err)
+		}
+
+		_, err := primary.SendMessage(context.Background(), request)
+		var throttledErr *services.ThrottledError
+		if !errors.As(err, &throttledErr) {
+			t.Errorf("Expected primary to be throttled, got %v", err)
+		}
+	})
+
+	t.Run("requests wait for a token within the maximum wait", func(t *testing.T) {
+		received.Store(0)
+		webhookService := newService(time.Second)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if _, err := webhookService.SendMessage(context.Background(), request); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("Expected the third request to wait for a token, took %v", elapsed)
+		}
+		if received.Load() != 3 {
+			t.Errorf("Expected 3 requests at the endpoint, got %d", received.Load())
+		}
+	})
+}
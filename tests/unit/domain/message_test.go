@@ -1,8 +1,15 @@
 package domain
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
@@ -36,7 +43,7 @@ func TestNewMessage(t *testing.T) {
 		{
 			name:        "content too long",
 			phoneNumber: "+905551234567",
-			content:     "This is a very long message that definitely exceeds the maximum length limit of one hundred sixty characters which should cause a validation error to be returned and this message continues to get longer and longer to definitely exceed the limit set by the SMS standard",
+			content:     strings.Repeat("This message is far too long for ten concatenated SMS parts. ", 30),
 			expectError: true,
 		},
 	}
@@ -93,7 +100,7 @@ func TestMessage_MarkAsSent(t *testing.T) {
 	msg, _ := message.NewMessage(phoneNumber, content)
 
 	externalID := "webhook-123"
-	err := msg.MarkAsSent(externalID)
+	err := msg.MarkAsSent(externalID, "primary")
 	if err != nil {
 		t.Errorf("unexpected error marking message as sent: %v", err)
 	}
@@ -106,11 +113,50 @@ func TestMessage_MarkAsSent(t *testing.T) {
 		t.Errorf("expected external ID to be %s, got %v", externalID, msg.ExternalID)
 	}
 
+	if msg.Provider == nil || *msg.Provider != "primary" {
+		t.Errorf("expected provider to be primary, got %v", msg.Provider)
+	}
+
 	if msg.SentAt == nil {
 		t.Error("expected sent_at to be set")
 	}
 }
 
+func TestMessage_MarkAsSentWithoutProvider(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	if err := msg.MarkAsSent("webhook-123", ""); err != nil {
+		t.Fatalf("unexpected error marking message as sent: %v", err)
+	}
+
+	if msg.Provider != nil {
+		t.Errorf("expected no provider to be recorded, got %s", *msg.Provider)
+	}
+}
+
+func TestMessage_Reject(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	if err := msg.Reject("invalid phone number format: +9055512345"); err != nil {
+		t.Fatalf("unexpected error rejecting message: %v", err)
+	}
+
+	if msg.Status != message.StatusFailed {
+		t.Errorf("expected status to be FAILED, got %s", msg.Status)
+	}
+	if msg.LastError == nil || *msg.LastError != "invalid phone number format: +9055512345" {
+		t.Errorf("expected the reason to be kept, got %v", msg.LastError)
+	}
+
+	if err := msg.Reject("again"); err == nil {
+		t.Error("expected a failed message not to be rejected again")
+	}
+}
+
 func TestMessage_MarkAsFailed(t *testing.T) {
 	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
 	content, _ := message.NewContent("Test message")
@@ -175,7 +221,7 @@ func TestMessage_CanRetry(t *testing.T) {
 
 	// Sent message cannot retry
 	msg2, _ := message.NewMessage(phoneNumber, content)
-	msg2.MarkAsSent("external-123")
+	msg2.MarkAsSent("external-123", "primary")
 	if msg2.CanRetry() {
 		t.Error("expected sent message to not be retryable")
 	}
@@ -190,6 +236,658 @@ func TestMessage_CanRetry(t *testing.T) {
 	}
 }
 
+func TestMessage_Claim(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	now := time.Now()
+	if err := msg.Claim("worker-1", now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error claiming message: %v", err)
+	}
+
+	if msg.Status != message.StatusProcessing || msg.LockedBy == nil || *msg.LockedBy != "worker-1" {
+		t.Errorf("expected processing message locked by worker-1, got %s", msg.Status)
+	}
+
+	// A claimed message cannot be claimed again
+	if err := msg.Claim("worker-2", now.Add(time.Minute)); err == nil {
+		t.Error("expected error claiming a processing message")
+	}
+
+	if msg.IsLeaseExpired(now) {
+		t.Error("expected lease to be active")
+	}
+
+	if !msg.IsLeaseExpired(now.Add(2 * time.Minute)) {
+		t.Error("expected lease to be expired after lease_until")
+	}
+
+	// Retrying returns the message to the queue and drops the lease
+	if err := msg.ScheduleRetry(now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error scheduling retry: %v", err)
+	}
+
+	if msg.Status != message.StatusPending || msg.LockedBy != nil || msg.LeaseUntil != nil {
+		t.Errorf("expected pending message without lease, got %s", msg.Status)
+	}
+
+	// Releasing a claim does not count as an attempt
+	released, _ := message.NewMessage(phoneNumber, content)
+	released.Claim("worker-1", now.Add(time.Minute))
+	if err := released.ReleaseClaim(); err != nil {
+		t.Fatalf("unexpected error releasing claim: %v", err)
+	}
+
+	if released.Status != message.StatusPending || released.RetryCount != 0 || released.LockedBy != nil {
+		t.Errorf("expected unleased pending message without retries, got %s with %d retries", released.Status, released.RetryCount)
+	}
+
+	if err := released.ReleaseClaim(); err == nil {
+		t.Error("expected error releasing an unclaimed message")
+	}
+
+	// Sending releases the lease
+	msg2, _ := message.NewMessage(phoneNumber, content)
+	msg2.Claim("worker-1", now.Add(time.Minute))
+	if err := msg2.MarkAsSent("external-123", "primary"); err != nil {
+		t.Fatalf("unexpected error marking claimed message as sent: %v", err)
+	}
+
+	if msg2.LockedBy != nil || msg2.LeaseUntil != nil {
+		t.Error("expected lease to be released after sending")
+	}
+}
+
+func TestMessage_ScheduleRetry(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	now := time.Now()
+	next := now.Add(time.Minute)
+
+	if err := msg.ScheduleRetry(next); err != nil {
+		t.Fatalf("unexpected error scheduling retry: %v", err)
+	}
+
+	if msg.Status != message.StatusPending || msg.RetryCount != 1 {
+		t.Errorf("expected pending with retry count 1, got %s with %d", msg.Status, msg.RetryCount)
+	}
+
+	if msg.IsReadyForAttempt(now) {
+		t.Error("expected message to wait for its next attempt")
+	}
+
+	if !msg.IsReadyForAttempt(next) {
+		t.Error("expected message to be ready at its next attempt time")
+	}
+
+	// Retries are exhausted after MaxRetryAttempts
+	for i := 1; i < message.MaxRetryAttempts; i++ {
+		if err := msg.ScheduleRetry(next); err != nil {
+			t.Fatalf("unexpected error on retry %d: %v", i+1, err)
+		}
+	}
+
+	if err := msg.ScheduleRetry(next); err == nil {
+		t.Error("expected error when exceeding max retries")
+	}
+
+	// Sent messages cannot be retried
+	sent, _ := message.NewMessage(phoneNumber, content)
+	sent.MarkAsSent("external-123", "primary")
+	if err := sent.ScheduleRetry(next); err == nil {
+		t.Error("expected error scheduling retry for sent message")
+	}
+}
+
+func TestMessage_DeliveryErrorAndRequeue(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	msg.RecordDeliveryError(message.ErrorClassClientError, "webhook returned HTTP 400", 400)
+	if msg.AttemptCount != 1 || msg.LastHTTPStatus == nil || *msg.LastHTTPStatus != 400 {
+		t.Errorf("expected one attempt with HTTP 400, got %d attempts", msg.AttemptCount)
+	}
+
+	// A failure without a response clears the previous HTTP status
+	msg.RecordDeliveryError(message.ErrorClassTimeout, "deadline exceeded", 0)
+	if msg.LastHTTPStatus != nil || *msg.LastErrorClass != message.ErrorClassTimeout {
+		t.Errorf("expected timeout without HTTP status, got %v", msg.LastHTTPStatus)
+	}
+
+	// Pending messages cannot be requeued
+	if err := msg.Requeue(); err == nil {
+		t.Error("expected error requeueing a pending message")
+	}
+
+	msg.RetryCount = message.MaxRetryAttempts
+	msg.MarkAsFailed()
+	if err := msg.Requeue(); err != nil {
+		t.Fatalf("unexpected error requeueing failed message: %v", err)
+	}
+
+	if msg.Status != message.StatusPending || msg.RetryCount != 0 || !msg.CanRetry() {
+		t.Errorf("expected retryable pending message, got %s with %d retries", msg.Status, msg.RetryCount)
+	}
+
+	if msg.AttemptCount != 2 {
+		t.Errorf("expected attempt count to survive requeue, got %d", msg.AttemptCount)
+	}
+}
+
+func TestIsPermanentHTTPStatus(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   bool
+	}{
+		{400, true},
+		{401, true},
+		{404, true},
+		{422, true},
+		{408, false},
+		{425, false},
+		{429, false},
+		{500, false},
+		{503, false},
+		{200, false},
+		{0, false},
+	}
+
+	for _, tt := range tests {
+		if got := message.IsPermanentHTTPStatus(tt.statusCode); got != tt.expected {
+			t.Errorf("expected HTTP %d permanent to be %v, got %v", tt.statusCode, tt.expected, got)
+		}
+	}
+}
+
+func TestMessage_Cancel(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+
+	// Pending and scheduled messages can be cancelled
+	pending, _ := message.NewMessage(phoneNumber, content)
+	scheduled, _ := message.NewMessage(phoneNumber, content)
+	scheduled.Schedule(time.Now().Add(time.Hour))
+
+	for _, msg := range []*message.Message{pending, scheduled} {
+		if err := msg.Cancel(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Status != message.StatusCancelled {
+			t.Errorf("expected status CANCELLED, got %s", msg.Status)
+		}
+	}
+
+	// Cancelled messages are terminal
+	if err := pending.Claim("worker-1", time.Now().Add(time.Minute)); err == nil {
+		t.Error("expected error when claiming a cancelled message")
+	}
+	if err := pending.MarkAsFailed(); err == nil {
+		t.Error("expected error when failing a cancelled message")
+	}
+
+	// Messages already picked up for delivery cannot be cancelled
+	claimed, _ := message.NewMessage(phoneNumber, content)
+	claimed.Claim("worker-1", time.Now().Add(time.Minute))
+
+	err := claimed.Cancel()
+	var businessErr domainErrors.BusinessError
+	if !errors.As(err, &businessErr) {
+		t.Errorf("expected business error, got %v", err)
+	}
+	if claimed.Status != message.StatusProcessing {
+		t.Errorf("expected status to remain PROCESSING, got %s", claimed.Status)
+	}
+}
+
+func TestMessage_MarkAsSuppressed(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+
+	suppression, err := message.NewSuppression(phoneNumber, "  replied STOP ", message.SuppressionSourceAPI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suppression.Reason != "replied STOP" {
+		t.Errorf("expected trimmed reason, got %q", suppression.Reason)
+	}
+
+	// Pending and claimed messages can be suppressed
+	pending, _ := message.NewMessage(phoneNumber, content)
+	claimed, _ := message.NewMessage(phoneNumber, content)
+	claimed.Claim("worker-1", time.Now().Add(time.Minute))
+
+	for _, msg := range []*message.Message{pending, claimed} {
+		if err := msg.MarkAsSuppressed(suppression); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Status != message.StatusSuppressed {
+			t.Errorf("expected status SUPPRESSED, got %s", msg.Status)
+		}
+		if msg.LastError == nil || *msg.LastError != "recipient is on the suppression list: replied STOP" {
+			t.Errorf("expected the suppression reason as last error, got %v", msg.LastError)
+		}
+		if msg.LockedBy != nil || msg.LeaseUntil != nil {
+			t.Error("expected the processing lease to be released")
+		}
+	}
+
+	// Suppressed messages are terminal
+	if err := pending.Claim("worker-1", time.Now().Add(time.Minute)); err == nil {
+		t.Error("expected error when claiming a suppressed message")
+	}
+	if err := pending.MarkAsFailed(); err == nil {
+		t.Error("expected error when failing a suppressed message")
+	}
+	if pending.CanRetry() {
+		t.Error("expected a suppressed message not to be retryable")
+	}
+
+	// Messages already sent are not suppressed after the fact
+	sent, _ := message.NewMessage(phoneNumber, content)
+	sent.MarkAsSent("ext-1", "primary")
+	if err := sent.MarkAsSuppressed(suppression); err == nil {
+		t.Error("expected error when suppressing a sent message")
+	}
+}
+
+func TestNewSuppression(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+
+	tests := []struct {
+		name        string
+		phoneNumber message.PhoneNumber
+		reason      string
+		source      message.SuppressionSource
+		expectError bool
+	}{
+		{"without reason", phoneNumber, "", message.SuppressionSourceImport, false},
+		{"reason at the limit", phoneNumber, strings.Repeat("a", 255), message.SuppressionSourceAPI, false},
+		{"reason too long", phoneNumber, strings.Repeat("a", 256), message.SuppressionSourceAPI, true},
+		{"unknown source", phoneNumber, "", message.SuppressionSource("sms"), true},
+		{"invalid phone number", message.PhoneNumber("05551234567"), "", message.SuppressionSourceAPI, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := message.NewSuppression(tt.phoneNumber, tt.reason, tt.source)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestMessage_StatusChanges(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	// A new message records its initial status
+	changes := msg.PendingStatusChanges()
+	if len(changes) != 1 || changes[0].From != nil || changes[0].To != message.StatusPending {
+		t.Fatalf("expected initial PENDING change, got %+v", changes)
+	}
+
+	msg.ClearStatusChanges()
+	msg.Claim("worker-1", time.Now().Add(time.Minute))
+	msg.ScheduleRetry(time.Now().Add(time.Minute))
+	msg.Claim("worker-1", time.Now().Add(time.Minute))
+	msg.MarkAsSent("ext-123", "primary")
+
+	expected := []struct{ from, to message.Status }{
+		{message.StatusPending, message.StatusProcessing},
+		{message.StatusProcessing, message.StatusPending},
+		{message.StatusPending, message.StatusProcessing},
+		{message.StatusProcessing, message.StatusSent},
+	}
+
+	changes = msg.PendingStatusChanges()
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d", len(expected), len(changes))
+	}
+	for i, want := range expected {
+		if changes[i].MessageID != msg.ID || *changes[i].From != want.from || changes[i].To != want.to {
+			t.Errorf("change %d: expected %s -> %s, got %+v", i, want.from, want.to, changes[i])
+		}
+	}
+
+	// Rejected transitions are not recorded
+	if err := msg.MarkAsFailed(); err == nil {
+		t.Error("expected error when failing a sent message")
+	}
+	if len(msg.PendingStatusChanges()) != len(expected) {
+		t.Error("expected rejected transition not to be recorded")
+	}
+}
+
+func TestTrigger_FromContext(t *testing.T) {
+	if trigger := message.TriggerFromContext(context.Background()); trigger != message.TriggerSystem {
+		t.Errorf("expected system trigger by default, got %s", trigger)
+	}
+
+	ctx := message.WithTrigger(context.Background(), message.TriggerRequeue)
+	if trigger := message.TriggerFromContext(ctx); trigger != message.TriggerRequeue {
+		t.Errorf("expected requeue trigger, got %s", trigger)
+	}
+}
+
+func TestAttempt_Record(t *testing.T) {
+	messageID := message.NewMessageID()
+	attempt := message.NewAttempt(messageID, 1, 2, time.Now())
+
+	if attempt.MessageID != messageID || attempt.RetryCount != 1 || attempt.AttemptNumber != 2 {
+		t.Errorf("unexpected attempt identity: %+v", attempt)
+	}
+
+	if !attempt.IsSuccessful() {
+		t.Error("expected attempt without error to be successful")
+	}
+
+	// Long response bodies are cut down to an excerpt
+	attempt.RecordResponse(500, strings.Repeat("x", message.MaxResponseExcerptLength+100))
+	if attempt.HTTPStatus == nil || *attempt.HTTPStatus != 500 {
+		t.Errorf("expected HTTP status 500, got %v", attempt.HTTPStatus)
+	}
+	if len(*attempt.ResponseBody) != message.MaxResponseExcerptLength {
+		t.Errorf("expected response excerpt of %d bytes, got %d", message.MaxResponseExcerptLength, len(*attempt.ResponseBody))
+	}
+
+	// Unknown error classes are normalized
+	attempt.RecordError(message.ErrorClass("bogus"), "webhook returned HTTP 500")
+	if attempt.IsSuccessful() || *attempt.ErrorClass != message.ErrorClassUnknown {
+		t.Errorf("expected failed attempt with unknown class, got %v", attempt.ErrorClass)
+	}
+}
+
+func TestImportJob(t *testing.T) {
+	if _, err := message.NewImportJob(message.ImportFormat("xlsx")); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	job, err := message.NewImportJob(message.ImportFormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != message.ImportStatusProcessing || job.IsFinished() {
+		t.Errorf("expected processing job, got %s", job.Status)
+	}
+
+	job.RecordChunk(3, 1)
+	job.RecordChunk(2, 0)
+	if job.ProcessedRows != 6 || job.CreatedCount != 5 || job.FailedCount != 1 {
+		t.Errorf("unexpected progress: %+v", job)
+	}
+
+	job.Fail("failed to read import file")
+	if !job.IsFinished() || job.Status != message.ImportStatusFailed || job.Error == nil || job.CompletedAt == nil {
+		t.Errorf("expected failed job with reason, got %+v", job)
+	}
+
+	// Row data is kept as an excerpt
+	rowErr := message.NewImportRowError(job.ID, 7, "invalid phone number", strings.Repeat("x", message.MaxResponseExcerptLength+100))
+	if rowErr.Row != 7 || len(rowErr.Data) != message.MaxResponseExcerptLength {
+		t.Errorf("unexpected row error: row %d, %d bytes of data", rowErr.Row, len(rowErr.Data))
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	t.Run("body validation", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			body        string
+			expectError bool
+		}{
+			{"plain text", "Hello there", false},
+			{"placeholders with spaces", "Hi {{ name }}, code {{code_2}}", false},
+			{"empty body", "  ", true},
+			{"unclosed placeholder", "Hi {{name", true},
+			{"invalid variable name", "Hi {{first name}}", true},
+			{"stray closing braces", "Hi }}", true},
+			{"too long", strings.Repeat("a", 1001), true},
+		}
+
+		for _, tt := range tests {
+			_, err := message.NewTemplate("test", tt.body)
+			if (err != nil) != tt.expectError {
+				t.Errorf("%s: expected error: %v, got: %v", tt.name, tt.expectError, err)
+			}
+		}
+	})
+
+	t.Run("render substitutes every placeholder", func(t *testing.T) {
+		template, err := message.NewTemplate("otp", "{{code}} is your code, {{ name }}. Again: {{code}}")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if vars := template.Variables(); len(vars) != 2 || vars[0] != "code" || vars[1] != "name" {
+			t.Errorf("expected variables [code name], got %v", vars)
+		}
+
+		content, err := template.Render(map[string]string{"code": "123", "name": "Ada", "extra": "ignored"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if content.String() != "123 is your code, Ada. Again: 123" {
+			t.Errorf("unexpected rendered content %q", content.String())
+		}
+	})
+
+	t.Run("render reports every missing variable", func(t *testing.T) {
+		template, _ := message.NewTemplate("otp", "{{code}} for {{name}} at {{time}}")
+
+		_, err := template.Render(map[string]string{"name": "Ada"})
+		var validationErr domainErrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if !strings.Contains(err.Error(), "code, time") {
+			t.Errorf("expected both missing variables in %q", err.Error())
+		}
+	})
+
+	t.Run("rendered content must be valid message content", func(t *testing.T) {
+		template, _ := message.NewTemplate("long", "Note: {{text}}")
+
+		if _, err := template.Render(map[string]string{"text": strings.Repeat("x", 1600)}); err == nil {
+			t.Error("expected error for rendered content over the message limit")
+		}
+		if _, err := template.Render(map[string]string{"text": ""}); err != nil {
+			t.Errorf("expected content with an empty variable to render, got %v", err)
+		}
+	})
+
+	t.Run("revise bumps the version only on change", func(t *testing.T) {
+		template, _ := message.NewTemplate("welcome", "Welcome {{name}}")
+
+		if err := template.Revise("Welcome {{name}}"); err != nil || template.Version != 1 {
+			t.Errorf("expected unchanged body to keep version 1, got %d (%v)", template.Version, err)
+		}
+		if err := template.Revise("Welcome aboard {{name}}"); err != nil || template.Version != 2 {
+			t.Errorf("expected changed body to be version 2, got %d (%v)", template.Version, err)
+		}
+		if err := template.Revise("{{broken"); err == nil || template.Version != 2 || template.Body != "Welcome aboard {{name}}" {
+			t.Errorf("expected invalid body to be rejected without changes, got v%d %q", template.Version, template.Body)
+		}
+	})
+
+	t.Run("templated message remembers its version", func(t *testing.T) {
+		template, _ := message.NewTemplate("welcome", "Welcome {{name}}")
+		template.Revise("Hello {{name}}")
+
+		msg, err := message.NewTemplatedMessage("+905551234567", template, map[string]string{"name": "Ada"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Content.String() != "Hello Ada" {
+			t.Errorf("unexpected content %q", msg.Content.String())
+		}
+		if msg.TemplateID == nil || *msg.TemplateID != template.ID || msg.TemplateVersion == nil || *msg.TemplateVersion != 2 {
+			t.Errorf("expected template %s version 2, got %v %v", template.ID, msg.TemplateID, msg.TemplateVersion)
+		}
+	})
+}
+
+func TestMessage_Schedule(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	now := time.Now()
+
+	// Unscheduled message is due immediately
+	if !msg.IsDue(now) {
+		t.Error("expected unscheduled message to be due")
+	}
+
+	// Future send time defers the message
+	if err := msg.Schedule(now.Add(time.Hour)); err != nil {
+		t.Errorf("unexpected error scheduling message: %v", err)
+	}
+
+	if msg.IsDue(now) {
+		t.Error("expected scheduled message not to be due yet")
+	}
+
+	if !msg.IsScheduled(now) {
+		t.Error("expected message to be scheduled")
+	}
+
+	if !msg.IsDue(now.Add(2 * time.Hour)) {
+		t.Error("expected scheduled message to be due after its send time")
+	}
+
+	// Sent message cannot be rescheduled
+	msg.MarkAsSent("external-123", "primary")
+	err := msg.Schedule(now.Add(time.Hour))
+	if err == nil {
+		t.Error("expected error when scheduling a sent message")
+	}
+
+	var businessErr domainErrors.BusinessError
+	if !errors.As(err, &businessErr) {
+		t.Errorf("expected BusinessError, got %T", err)
+	}
+}
+
+func TestMessage_Expiry(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	now := time.Now()
+
+	// Expiry must come after the send time
+	msg.Schedule(now.Add(time.Hour))
+	if err := msg.SetExpiry(now.Add(30 * time.Minute)); err == nil {
+		t.Error("expected error when expiry is before send time")
+	}
+
+	if err := msg.SetExpiry(now.Add(2 * time.Hour)); err != nil {
+		t.Errorf("unexpected error setting expiry: %v", err)
+	}
+
+	if msg.IsExpired(now) {
+		t.Error("expected message not to be expired yet")
+	}
+
+	if !msg.IsExpired(now.Add(3 * time.Hour)) {
+		t.Error("expected message to be expired after its expiry time")
+	}
+
+	// Pending message can expire
+	if err := msg.MarkAsExpired(); err != nil {
+		t.Errorf("unexpected error marking message as expired: %v", err)
+	}
+
+	if msg.Status != message.StatusExpired {
+		t.Errorf("expected status to be EXPIRED, got %s", msg.Status)
+	}
+
+	// Expired is terminal
+	if err := msg.MarkAsFailed(); err == nil {
+		t.Error("expected error when failing an expired message")
+	}
+
+	// Sent message cannot expire
+	msg2, _ := message.NewMessage(phoneNumber, content)
+	msg2.MarkAsSent("external-123", "primary")
+	if err := msg2.MarkAsExpired(); err == nil {
+		t.Error("expected error when expiring a sent message")
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected message.Priority
+		wantErr  bool
+	}{
+		{"empty defaults to normal", "", message.PriorityNormal, false},
+		{"critical", "critical", message.PriorityCritical, false},
+		{"any case", " BULK ", message.PriorityBulk, false},
+		{"unknown", "urgent", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priority, err := message.ParsePriority(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriority() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if priority != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, priority)
+			}
+		})
+	}
+
+	// Ranks order priorities from most to least urgent and map back to them
+	for rank, priority := range []message.Priority{message.PriorityCritical, message.PriorityHigh, message.PriorityNormal, message.PriorityBulk} {
+		if priority.Rank() != rank {
+			t.Errorf("expected %s to have rank %d, got %d", priority, rank, priority.Rank())
+		}
+		if fromRank, err := message.PriorityFromRank(rank); err != nil || fromRank != priority {
+			t.Errorf("expected rank %d to be %s, got %s (%v)", rank, priority, fromRank, err)
+		}
+	}
+	if _, err := message.PriorityFromRank(4); err == nil {
+		t.Error("expected error for an unknown rank")
+	}
+
+	if !message.PriorityHigh.IsUrgent() || message.PriorityNormal.IsUrgent() {
+		t.Error("expected only critical and high to be urgent")
+	}
+}
+
+func TestMessage_SetPriority(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	msg, _ := message.NewMessage(phoneNumber, content)
+
+	if msg.Priority != message.PriorityNormal {
+		t.Errorf("expected new messages to be normal priority, got %s", msg.Priority)
+	}
+
+	if err := msg.SetPriority(message.PriorityCritical); err != nil || msg.Priority != message.PriorityCritical {
+		t.Errorf("expected critical priority, got %s (%v)", msg.Priority, err)
+	}
+
+	if err := msg.SetPriority("urgent"); err == nil {
+		t.Error("expected error for an invalid priority")
+	}
+
+	// Priority is fixed once the message left the queue
+	msg.MarkAsSent("external-123", "primary")
+	if err := msg.SetPriority(message.PriorityBulk); err == nil {
+		t.Error("expected error changing the priority of a sent message")
+	}
+}
+
 func TestPhoneNumber_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -225,6 +923,98 @@ func TestPhoneNumber_Validate(t *testing.T) {
 	}
 }
 
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		defaultRegion string
+		expected      string
+		expectError   bool
+	}{
+		{"E.164", "+905551234567", "", "+905551234567", false},
+		{"separators", "+90 (555) 123-45.67", "", "+905551234567", false},
+		{"international without plus", "905551234567", "", "+905551234567", false},
+		{"international prefix 00", "0090 555 123 4567", "", "+905551234567", false},
+		{"national with trunk prefix", "0555 123 45 67", "TR", "+905551234567", false},
+		{"national without trunk prefix", "5551234567", "TR", "+905551234567", false},
+		{"international input with a default region", "905551234567", "TR", "+905551234567", false},
+		{"other country with a default region", "+44 7911 123456", "TR", "+447911123456", false},
+		{"lower case region", "07911 123456", "gb", "+447911123456", false},
+		{"NANP national number", "(415) 555-2671", "US", "+14155552671", false},
+		{"Italian number keeps its leading zero", "06 6982 1234", "IT", "+390669821234", false},
+		{"region without a numbering plan", "912 345 678", "PT", "+351912345678", false},
+		{"national number without a default region", "0555 123 45 67", "", "", true},
+		{"trunk prefix in an international number", "+90 0555 123 45 67", "", "", true},
+		{"wrong length for the region", "+90555123456", "", "", true},
+		{"unknown calling code", "+8001234567", "", "", true},
+		{"unknown default region", "0555 123 45 67", "XX", "", true},
+		{"letters", "+90 555 CALL ME", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := message.ParsePhoneNumber(tt.input, tt.defaultRegion)
+
+			if tt.expectError {
+				var validationErr domainErrors.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected ValidationError, got %v (%s)", err, phone)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if phone.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, phone)
+			}
+			if err := phone.Validate(); err != nil {
+				t.Errorf("expected normalized number to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPhoneNumber_Classification(t *testing.T) {
+	tests := []struct {
+		phone       string
+		countryCode int
+		region      string
+		numberType  message.PhoneNumberType
+	}{
+		{"+905551234567", 90, "TR", message.PhoneTypeMobile},
+		{"+902121234567", 90, "TR", message.PhoneTypeFixedLine},
+		{"+908001234567", 90, "TR", message.PhoneTypeTollFree},
+		{"+14155552671", 1, "US", message.PhoneTypeFixedLineOrMobile},
+		{"+14165552671", 1, "CA", message.PhoneTypeFixedLineOrMobile},
+		{"+18005552671", 1, "US", message.PhoneTypeTollFree},
+		{"+447911123456", 44, "GB", message.PhoneTypeMobile},
+		{"+4915112345678", 49, "DE", message.PhoneTypeMobile},
+		{"+351912345678", 351, "PT", message.PhoneTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			phone := message.PhoneNumber(tt.phone)
+
+			if phone.CountryCode() != tt.countryCode {
+				t.Errorf("expected country code %d, got %d", tt.countryCode, phone.CountryCode())
+			}
+			if phone.Region() != tt.region {
+				t.Errorf("expected region %s, got %s", tt.region, phone.Region())
+			}
+			if phone.Type() != tt.numberType {
+				t.Errorf("expected type %s, got %s", tt.numberType, phone.Type())
+			}
+		})
+	}
+
+	if message.PhoneNumber("905551234567").Validate() == nil {
+		t.Error("expected a number that is not in E.164 form to be invalid")
+	}
+}
+
 func TestContent_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -234,7 +1024,10 @@ func TestContent_Validate(t *testing.T) {
 		{"valid short message", "Hello", false},
 		{"valid max length", "This message is exactly one hundred and sixty characters long which is the maximum allowed length for SMS messages according to standards", false},
 		{"empty content", "", true},
-		{"too long", "This message is definitely longer than one hundred and sixty characters which exceeds the maximum allowed length for SMS messages and should definitely cause a validation error to be thrown because it is way too long for any normal SMS message", true},
+		{"concatenated message", "This message is definitely longer than one hundred and sixty characters which is fine now that content may be sent as several concatenated SMS parts to the phone", false},
+		{"max segments", strings.Repeat("a", 10*153), false},
+		{"too many segments", strings.Repeat("a", 10*153+1), true},
+		{"too many UCS-2 segments", strings.Repeat("ş", 10*67+1), true},
 	}
 
 	for _, tt := range tests {
@@ -255,3 +1048,319 @@ func TestContent_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_Segments(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		encoding message.Encoding
+		segments int
+	}{
+		{"short GSM-7", "Hello", message.EncodingGSM7, 1},
+		{"GSM-7 accented letters", "Café à Zürich? Ñandu costs 5€ ¡hoy!", message.EncodingGSM7, 1},
+		{"accent outside the alphabet", "Crème brûlée", message.EncodingUCS2, 1},
+		{"GSM-7 single segment limit", strings.Repeat("a", 160), message.EncodingGSM7, 1},
+		{"GSM-7 two segments", strings.Repeat("a", 161), message.EncodingGSM7, 2},
+		{"GSM-7 three segments", strings.Repeat("a", 307), message.EncodingGSM7, 3},
+		{"extension characters count twice", strings.Repeat("€", 80), message.EncodingGSM7, 1},
+		{"extension character fills a part", strings.Repeat("a", 151) + "{" + strings.Repeat("a", 152), message.EncodingGSM7, 2},
+		{"extension character is not split across parts", strings.Repeat("a", 152) + "{" + strings.Repeat("a", 152), message.EncodingGSM7, 3},
+		{"Turkish text", "Gönderiniz yola çıktı, teşekkürler!", message.EncodingUCS2, 1},
+		{"UCS-2 single segment limit", strings.Repeat("ş", 70), message.EncodingUCS2, 1},
+		{"UCS-2 two segments", strings.Repeat("ş", 71), message.EncodingUCS2, 2},
+		{"emoji take two code units", strings.Repeat("🙂", 35), message.EncodingUCS2, 1},
+		{"emoji are not split across parts", "aa" + strings.Repeat("🙂", 66), message.EncodingUCS2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := message.Content(tt.content)
+
+			if encoding := content.Encoding(); encoding != tt.encoding {
+				t.Errorf("expected encoding %s, got %s", tt.encoding, encoding)
+			}
+			if segments := content.Segments(); segments != tt.segments {
+				t.Errorf("expected %d segments, got %d", tt.segments, segments)
+			}
+		})
+	}
+}
+
+func TestKeywords_Match(t *testing.T) {
+	keywords := message.DefaultKeywords()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected message.KeywordAction
+	}{
+		{"STOP", "STOP", message.KeywordActionOptOut},
+		{"lower case with punctuation", "  stop! ", message.KeywordActionOptOut},
+		{"unsubscribe", "Unsubscribe.", message.KeywordActionOptOut},
+		{"Turkish dotted capital I", "İPTAL", message.KeywordActionOptOut},
+		{"Turkish dotless lower case i", "ıptal", message.KeywordActionOptOut},
+		{"Spanish", "baja", message.KeywordActionOptOut},
+		{"French accent", "Arrêt", message.KeywordActionOptOut},
+		{"START", "start", message.KeywordActionOptIn},
+		{"Turkish opt-in", "Başla", message.KeywordActionOptIn},
+		{"keyword inside a sentence", "Please stop by the store", message.KeywordActionNone},
+		{"ordinary reply", "Thanks!", message.KeywordActionNone},
+		{"empty reply", "", message.KeywordActionNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keywords.Match(tt.content); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewKeywords(t *testing.T) {
+	t.Run("custom keywords replace the defaults", func(t *testing.T) {
+		keywords, err := message.NewKeywords([]string{"HALT"}, []string{"RESUME"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if keywords.Match("halt") != message.KeywordActionOptOut || keywords.Match("resume") != message.KeywordActionOptIn {
+			t.Error("expected custom keywords to match")
+		}
+		if keywords.Match("STOP") != message.KeywordActionNone {
+			t.Error("expected default keywords not to match")
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		optOut []string
+		optIn  []string
+	}{
+		{"empty keyword", []string{"STOP", " "}, nil},
+		{"keyword in both lists", []string{"STOP"}, []string{"stop"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr domainErrors.ValidationError
+			if _, err := message.NewKeywords(tt.optOut, tt.optIn); !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewInboundMessage(t *testing.T) {
+	t.Run("opt-out reply asks for a keyword suppression", func(t *testing.T) {
+		msg, err := message.NewInboundMessage("+905551234567", "stop!", "inbound-1", time.Time{}, message.DefaultKeywords())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Action != message.KeywordActionOptOut || msg.ReceivedAt.IsZero() || msg.ExternalID == nil {
+			t.Errorf("unexpected inbound message %+v", msg)
+		}
+
+		suppression, err := msg.Suppression()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if suppression.Source != message.SuppressionSourceKeyword || suppression.Reason != "replied STOP" {
+			t.Errorf("unexpected suppression %+v", suppression)
+		}
+	})
+
+	t.Run("ordinary reply asks for no suppression", func(t *testing.T) {
+		msg, err := message.NewInboundMessage("+905551234567", "See you tomorrow", "", time.Time{}, message.DefaultKeywords())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if suppression, err := msg.Suppression(); suppression != nil || err != nil {
+			t.Errorf("expected no suppression, got %+v, %v", suppression, err)
+		}
+		if msg.ExternalID != nil {
+			t.Errorf("expected no external ID, got %q", *msg.ExternalID)
+		}
+	})
+
+	t.Run("content too long is rejected", func(t *testing.T) {
+		var validationErr domainErrors.ValidationError
+		_, err := message.NewInboundMessage("+905551234567", strings.Repeat("a", 5001), "", time.Time{}, message.DefaultKeywords())
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestNewDeliveryReceipt(t *testing.T) {
+	tests := []struct {
+		name        string
+		externalID  string
+		status      message.Status
+		errorCode   string
+		expectError bool
+	}{
+		{"delivered", "ext-1", message.StatusDelivered, "", false},
+		{"undelivered with error code", "ext-1", message.StatusUndelivered, "30005", false},
+		{"missing external ID", "  ", message.StatusDelivered, "", true},
+		{"external ID too long", strings.Repeat("a", 256), message.StatusDelivered, "", true},
+		{"not a delivery status", "ext-1", message.StatusSent, "", true},
+		{"error code too long", "ext-1", message.StatusUndelivered, strings.Repeat("1", 51), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt, err := message.NewDeliveryReceipt(tt.externalID, tt.status, tt.errorCode, time.Time{})
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if err == nil && (receipt.ReportedAt.IsZero() || receipt.IsApplied()) {
+				t.Errorf("unexpected receipt %+v", receipt)
+			}
+		})
+	}
+}
+
+func TestMessage_ApplyDeliveryReceipt(t *testing.T) {
+	phoneNumber, _ := message.NewPhoneNumber("+905551234567")
+	content, _ := message.NewContent("Test message")
+	reportedAt := time.Now().Add(-time.Minute)
+
+	undelivered, _ := message.NewDeliveryReceipt("ext-1", message.StatusUndelivered, "30005", reportedAt)
+	delivered, _ := message.NewDeliveryReceipt("ext-1", message.StatusDelivered, "", time.Time{})
+
+	sent, _ := message.NewMessage(phoneNumber, content)
+	sent.MarkAsSent("ext-1", "primary")
+
+	changed, err := sent.ApplyDeliveryReceipt(undelivered)
+	if err != nil || !changed {
+		t.Fatalf("expected the receipt to be applied, got %v, %v", changed, err)
+	}
+	if sent.Status != message.StatusUndelivered {
+		t.Errorf("expected status UNDELIVERED, got %s", sent.Status)
+	}
+	if sent.CarrierErrorCode == nil || *sent.CarrierErrorCode != "30005" || !sent.DeliveryReportedAt.Equal(reportedAt) {
+		t.Errorf("expected the carrier outcome, got %v at %v", sent.CarrierErrorCode, sent.DeliveryReportedAt)
+	}
+
+	// The first outcome stays
+	if changed, err := sent.ApplyDeliveryReceipt(delivered); changed || err != nil {
+		t.Errorf("expected the second receipt to change nothing, got %v, %v", changed, err)
+	}
+	if sent.Status != message.StatusUndelivered {
+		t.Errorf("expected status UNDELIVERED to stay, got %s", sent.Status)
+	}
+
+	// Only sent messages take a delivery outcome
+	pending, _ := message.NewMessage(phoneNumber, content)
+	if _, err := pending.ApplyDeliveryReceipt(delivered); err == nil {
+		t.Error("expected error when applying a receipt to a pending message")
+	}
+
+	// Delivery outcomes are terminal
+	if err := sent.MarkAsFailed(); err == nil {
+		t.Error("expected error when failing an undelivered message")
+	}
+	if sent.CanRetry() {
+		t.Error("expected an undelivered message not to be retryable")
+	}
+}
+
+func TestNewSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoint    string
+		description string
+		statuses    []message.Status
+		expectError bool
+		expected    []message.Status
+	}{
+		{"every status", "https://crm.example.com/hooks", "", nil, false, []message.Status{}},
+		{"statuses are normalized and deduplicated", " http://crm.example.com/hooks ", "CRM", []message.Status{"sent", "DELIVERED", " Sent "}, false, []message.Status{message.StatusSent, message.StatusDelivered}},
+		{"empty URL", "", "", nil, true, nil},
+		{"relative URL", "/hooks", "", nil, true, nil},
+		{"unsupported scheme", "ftp://crm.example.com/hooks", "", nil, true, nil},
+		{"unknown status", "https://crm.example.com/hooks", "", []message.Status{"READ"}, true, nil},
+		{"description too long", "https://crm.example.com/hooks", strings.Repeat("a", 256), nil, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := message.NewSubscription(tt.endpoint, tt.description, tt.statuses)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !subscription.Active || subscription.ID == "" || len(subscription.Secret) != 64 {
+				t.Errorf("expected an active subscription with a secret, got %+v", subscription)
+			}
+			if subscription.URL != strings.TrimSpace(tt.endpoint) {
+				t.Errorf("expected URL %q, got %q", strings.TrimSpace(tt.endpoint), subscription.URL)
+			}
+			if !reflect.DeepEqual(subscription.Statuses, tt.expected) {
+				t.Errorf("expected statuses %v, got %v", tt.expected, subscription.Statuses)
+			}
+		})
+	}
+}
+
+func TestSubscription_MatchesAndSign(t *testing.T) {
+	all, _ := message.NewSubscription("https://crm.example.com/hooks", "", nil)
+	filtered, _ := message.NewSubscription("https://crm.example.com/hooks", "", []message.Status{message.StatusDelivered})
+
+	if !all.Matches(message.StatusPending) || !all.Matches(message.StatusUndelivered) {
+		t.Error("expected a subscription without statuses to match every status")
+	}
+	if !filtered.Matches(message.StatusDelivered) || filtered.Matches(message.StatusSent) {
+		t.Error("expected a filtered subscription to match its statuses only")
+	}
+
+	timestamp := time.Unix(1705314725, 0)
+	body := []byte(`{"id":"1"}`)
+
+	mac := hmac.New(sha256.New, []byte(all.Secret))
+	mac.Write([]byte("1705314725.{\"id\":\"1\"}"))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if signature := all.Sign(timestamp, body); signature != expected {
+		t.Errorf("expected signature %s, got %s", expected, signature)
+	}
+	if all.Sign(timestamp, body) == filtered.Sign(timestamp, body) {
+		t.Error("expected subscriptions to sign with their own secrets")
+	}
+}
+
+func TestEventDelivery_RecordAttempts(t *testing.T) {
+	delivery := &message.EventDelivery{Status: message.EventDeliveryPending, NextAttemptAt: time.Now()}
+
+	if retry := delivery.RecordFailure(503, "endpoint returned HTTP 503", 2, time.Minute); !retry {
+		t.Fatal("expected a retry after the first failure")
+	}
+	if delivery.Status != message.EventDeliveryPending || delivery.Attempts != 1 {
+		t.Errorf("expected a pending delivery after one attempt, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+	if delivery.LastStatusCode == nil || *delivery.LastStatusCode != 503 || delivery.LastError == nil {
+		t.Errorf("expected the failed response to be recorded, got %v, %v", delivery.LastStatusCode, delivery.LastError)
+	}
+	if delivery.NextAttemptAt.Before(time.Now().Add(50 * time.Second)) {
+		t.Errorf("expected the next attempt to be delayed, got %v", delivery.NextAttemptAt)
+	}
+
+	if retry := delivery.RecordFailure(0, "connection refused", 2, time.Minute); retry {
+		t.Error("expected no retry once max attempts are reached")
+	}
+	if delivery.Status != message.EventDeliveryFailed || delivery.LastStatusCode != nil {
+		t.Errorf("expected a failed delivery without a status code, got %s, %v", delivery.Status, delivery.LastStatusCode)
+	}
+
+	delivered := &message.EventDelivery{Status: message.EventDeliveryPending}
+	delivered.RecordFailure(500, "endpoint returned HTTP 500", 8, time.Minute)
+	delivered.RecordSuccess(204)
+	if delivered.Status != message.EventDeliveryDelivered || delivered.DeliveredAt == nil || delivered.LastError != nil || delivered.Attempts != 2 {
+		t.Errorf("expected a delivered delivery after two attempts, got %+v", delivered)
+	}
+}
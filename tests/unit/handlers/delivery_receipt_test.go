@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock delivery receipt use case for testing
+type mockDeliveryReceipts struct {
+	failWith error
+	pending  bool
+	lastCmd  usecases.ReceiveDeliveryReceiptCommand
+}
+
+func (m *mockDeliveryReceipts) ReceiveDeliveryReceipt(ctx context.Context, cmd usecases.ReceiveDeliveryReceiptCommand) (*usecases.DeliveryReceiptResponse, error) {
+	m.lastCmd = cmd
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+
+	response := &usecases.DeliveryReceiptResponse{
+		ExternalID: cmd.ExternalID,
+		Status:     cmd.Status,
+		ReportedAt: time.Now(),
+		ReceivedAt: time.Now(),
+	}
+	if !m.pending {
+		messageID := uuid.New()
+		appliedAt := time.Now()
+		response.MessageID = &messageID
+		response.AppliedAt = &appliedAt
+	}
+	return response, nil
+}
+
+func newDeliveryReceiptRouter(mock *mockDeliveryReceipts) http.Handler {
+	handler := handlers.NewDeliveryReceiptHandler(mock)
+
+	r := chi.NewRouter()
+	r.Post("/delivery-receipts", handler.ReceiveDeliveryReceipt)
+	return r
+}
+
+func TestDeliveryReceiptHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		pending        bool
+		failWith       error
+		expectedStatus int
+	}{
+		{"applied", `{"externalId":"ext-1","status":"DELIVERED"}`, false, nil, http.StatusOK},
+		{"waiting for the message", `{"externalId":"ext-1","status":"DELIVERED"}`, true, nil, http.StatusAccepted},
+		{"invalid body", `{invalid json}`, false, nil, http.StatusBadRequest},
+		{"invalid status", `{"externalId":"ext-1","status":"SENT"}`, false, domainErrors.NewValidationError("invalid delivery status: SENT"), http.StatusBadRequest},
+		{"message cannot take an outcome", `{"externalId":"ext-1","status":"DELIVERED"}`, false, domainErrors.NewBusinessError("invalid status transition from FAILED to DELIVERED"), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newDeliveryReceiptRouter(&mockDeliveryReceipts{failWith: tt.failWith, pending: tt.pending})
+
+			req := httptest.NewRequest("POST", "/delivery-receipts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeliveryReceiptHandler_Request(t *testing.T) {
+	mock := &mockDeliveryReceipts{}
+	router := newDeliveryReceiptRouter(mock)
+
+	body := `{"externalId":"ext-1","status":"undelivered","errorCode":"30005","reportedAt":"2024-01-15T10:32:05Z"}`
+	req := httptest.NewRequest("POST", "/delivery-receipts", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	cmd := mock.lastCmd
+	if cmd.ExternalID != "ext-1" || cmd.Status != "undelivered" || cmd.ErrorCode != "30005" || !cmd.ReportedAt.Equal(time.Date(2024, 1, 15, 10, 32, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected command %+v", cmd)
+	}
+
+	var response dto.DeliveryReceiptResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.MessageID == nil || response.AppliedAt == nil {
+		t.Errorf("Expected the applied message in the response, got %+v", response)
+	}
+}
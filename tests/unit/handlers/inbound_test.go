@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock inbound message use case for testing
+type mockInboundMessages struct {
+	failWith     error
+	lastReceived usecases.ReceiveInboundMessageCommand
+	lastQuery    usecases.ConversationQuery
+}
+
+func (m *mockInboundMessages) ReceiveInboundMessage(ctx context.Context, cmd usecases.ReceiveInboundMessageCommand) (*usecases.InboundMessageResponse, error) {
+	m.lastReceived = cmd
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.InboundMessageResponse{
+		ID:            uuid.New(),
+		PhoneNumber:   cmd.From,
+		Content:       cmd.Content,
+		KeywordAction: "opt_out",
+		ReceivedAt:    time.Now(),
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+func (m *mockInboundMessages) GetConversation(ctx context.Context, query usecases.ConversationQuery) (*usecases.ConversationResponse, error) {
+	m.lastQuery = query
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ConversationResponse{
+		PhoneNumber: query.PhoneNumber,
+		Suppression: &usecases.SuppressionResponse{PhoneNumber: query.PhoneNumber, Source: "keyword", CreatedAt: time.Now()},
+		Entries: []usecases.ConversationEntry{
+			{Direction: usecases.DirectionInbound, OccurredAt: time.Now(), Inbound: &usecases.InboundMessageResponse{ID: uuid.New(), Content: "STOP"}},
+			{Direction: usecases.DirectionOutbound, OccurredAt: time.Now().Add(-time.Minute), Outbound: &usecases.MessageResponse{ID: uuid.New(), Content: "Your code is 1234", Status: "SENT"}},
+		},
+	}, nil
+}
+
+func newInboundRouter(mock *mockInboundMessages) http.Handler {
+	handler := handlers.NewInboundHandler(mock)
+
+	r := chi.NewRouter()
+	r.Post("/inbound", handler.ReceiveInboundMessage)
+	r.Get("/conversations/{phoneNumber}", handler.GetConversation)
+	return r
+}
+
+func TestInboundHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		failWith       error
+		expectedStatus int
+	}{
+		{"receive", "POST", "/inbound", `{"from":"+905551234567","content":"STOP","messageId":"inbound-1"}`, nil, http.StatusOK},
+		{"receive with invalid body", "POST", "/inbound", `{invalid json}`, nil, http.StatusBadRequest},
+		{"receive from invalid number", "POST", "/inbound", `{"from":"12ab","content":"STOP"}`, domainErrors.NewValidationError("invalid phone number format: 12ab"), http.StatusBadRequest},
+		{"conversation", "GET", "/conversations/%2B905551234567?limit=10", "", nil, http.StatusOK},
+		{"conversation of invalid number", "GET", "/conversations/12ab", "", domainErrors.NewValidationError("invalid phone number format: 12ab"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newInboundRouter(&mockInboundMessages{failWith: tt.failWith})
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestInboundHandler_Requests(t *testing.T) {
+	t.Run("callback fields reach the use case", func(t *testing.T) {
+		mock := &mockInboundMessages{}
+		router := newInboundRouter(mock)
+
+		body := `{"from":"+905551234567","content":"STOP","messageId":"inbound-1","receivedAt":"2024-01-15T10:30:00Z"}`
+		req := httptest.NewRequest("POST", "/inbound", bytes.NewBufferString(body))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		received := mock.lastReceived
+		if received.From != "+905551234567" || received.ExternalID != "inbound-1" || !received.ReceivedAt.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected command %+v", received)
+		}
+	})
+
+	t.Run("conversation decodes the phone number and pagination", func(t *testing.T) {
+		mock := &mockInboundMessages{}
+		router := newInboundRouter(mock)
+
+		req := httptest.NewRequest("GET", "/conversations/%2B905551234567?limit=5&offset=10", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if mock.lastQuery.PhoneNumber != "+905551234567" || mock.lastQuery.Limit != 5 || mock.lastQuery.Offset != 10 {
+			t.Errorf("Unexpected query %+v", mock.lastQuery)
+		}
+
+		var response dto.ConversationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response.Entries) != 2 || response.Entries[0].Inbound == nil || response.Entries[1].Outbound == nil || response.Suppression == nil {
+			t.Errorf("Unexpected conversation %+v", response)
+		}
+	})
+}
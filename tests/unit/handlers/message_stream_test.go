@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock message stream use case for testing; it sends its events and ends the stream
+type mockMessageStream struct {
+	failWith  error
+	events    []usecases.StatusEventResponse
+	lastQuery usecases.StreamStatusEventsQuery
+}
+
+func (m *mockMessageStream) StreamStatusEvents(ctx context.Context, query usecases.StreamStatusEventsQuery) (<-chan usecases.StatusEventResponse, error) {
+	m.lastQuery = query
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+
+	events := make(chan usecases.StatusEventResponse, len(m.events))
+	for _, event := range m.events {
+		events <- event
+	}
+	close(events)
+	return events, nil
+}
+
+func newMessageStreamRouter(mock *mockMessageStream) http.Handler {
+	handler := handlers.NewMessageStreamHandler(mock, time.Minute)
+
+	r := chi.NewRouter()
+	r.Get("/messages/stream", handler.StreamMessages)
+	return r
+}
+
+func TestMessageStreamHandler(t *testing.T) {
+	t.Run("events are written as server-sent events", func(t *testing.T) {
+		from := "PENDING"
+		mock := &mockMessageStream{events: []usecases.StatusEventResponse{{
+			ID:          42,
+			Type:        usecases.MessageStatusChangedEvent,
+			MessageID:   uuid.New(),
+			PhoneNumber: "+905551234567",
+			From:        &from,
+			To:          "SENT",
+			Trigger:     "scheduler",
+			OccurredAt:  time.Now(),
+		}}}
+		router := newMessageStreamRouter(mock)
+
+		req := httptest.NewRequest("GET", "/messages/stream?status=SENT,failed&status=DELIVERED&phone_number=%2B905551234567", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("Expected an event stream, got %d %q", w.Code, w.Header().Get("Content-Type"))
+		}
+
+		body := w.Body.String()
+		for _, expected := range []string{"retry: 3000\n", "id: 42\nevent: message.status_changed\ndata: {", `"id":"42"`, `"from":"PENDING"`, `"to":"SENT"`} {
+			if !strings.Contains(body, expected) {
+				t.Errorf("Expected %q in stream, got %q", expected, body)
+			}
+		}
+
+		query := mock.lastQuery
+		if strings.Join(query.Statuses, ",") != "SENT,failed,DELIVERED" || query.PhoneNumber != "+905551234567" || query.LastEventID != nil {
+			t.Errorf("Unexpected query %+v", query)
+		}
+	})
+
+	t.Run("the Last-Event-ID header takes precedence", func(t *testing.T) {
+		mock := &mockMessageStream{}
+		router := newMessageStreamRouter(mock)
+
+		req := httptest.NewRequest("GET", "/messages/stream?last_event_id=5", nil)
+		req.Header.Set("Last-Event-ID", "7")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		if mock.lastQuery.LastEventID == nil || *mock.lastQuery.LastEventID != 7 {
+			t.Errorf("Expected to resume after event 7, got %+v", mock.lastQuery)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		failWith       error
+		expectedStatus int
+	}{
+		{"invalid last event ID", "/messages/stream?last_event_id=abc", nil, http.StatusBadRequest},
+		{"invalid status", "/messages/stream?status=ARCHIVED", domainErrors.NewValidationError("invalid status: ARCHIVED"), http.StatusBadRequest},
+		{"stream not running", "/messages/stream", domainErrors.NewBusinessError("message stream is not running"), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newMessageStreamRouter(&mockMessageStream{failWith: tt.failWith})
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
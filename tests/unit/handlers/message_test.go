@@ -3,13 +3,20 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 )
 
@@ -32,6 +39,34 @@ func (m *mockMessageManagement) CreateMessage(ctx context.Context, cmd usecases.
 	}, nil
 }
 
+func (m *mockMessageManagement) CreateMessages(ctx context.Context, cmds []usecases.CreateMessageCommand) (*usecases.CreateMessagesResult, error) {
+	if m.shouldFail {
+		return nil, &testError{message: "batch create failed"}
+	}
+	if len(cmds) == 0 {
+		return nil, domainErrors.NewValidationError("at least one message is required")
+	}
+
+	result := &usecases.CreateMessagesResult{Items: make([]usecases.BatchItemResult, len(cmds))}
+	for i, cmd := range cmds {
+		result.Items[i].Index = i
+		if cmd.PhoneNumber == "" {
+			result.Items[i].Error = domainErrors.NewValidationError("phone number is required")
+			result.FailedCount++
+			continue
+		}
+
+		result.Items[i].Message = &usecases.MessageResponse{
+			ID:          uuid.New(),
+			PhoneNumber: cmd.PhoneNumber,
+			Content:     cmd.Content,
+			Status:      "PENDING",
+		}
+		result.CreatedCount++
+	}
+	return result, nil
+}
+
 func (m *mockMessageManagement) GetMessageByID(ctx context.Context, id uuid.UUID) (*usecases.MessageResponse, error) {
 	if m.shouldFail {
 		return nil, &testError{message: "not found"}
@@ -43,6 +78,9 @@ func (m *mockMessageManagement) GetMessageByID(ctx context.Context, id uuid.UUID
 		Content:     "Test message",
 		Status:      "sent",
 		RetryCount:  0,
+		StatusHistory: []usecases.StatusChangeResponse{
+			{To: "PENDING", Trigger: "api", OccurredAt: time.Now()},
+		},
 	}, nil
 }
 
@@ -58,6 +96,128 @@ func (m *mockMessageManagement) ListMessages(ctx context.Context, query usecases
 	}, nil
 }
 
+func (m *mockMessageManagement) ListDeadLetters(ctx context.Context, query usecases.DeadLetterQuery) (*usecases.ListMessagesResponse, error) {
+	if m.shouldFail {
+		return nil, &testError{message: "list dead letters failed"}
+	}
+
+	return &usecases.ListMessagesResponse{
+		Messages:   []usecases.MessageResponse{},
+		TotalCount: 0,
+		HasMore:    false,
+	}, nil
+}
+
+func (m *mockMessageManagement) RequeueMessage(ctx context.Context, id uuid.UUID) (*usecases.MessageResponse, error) {
+	if m.shouldFail {
+		return nil, &testError{message: "requeue failed"}
+	}
+
+	return &usecases.MessageResponse{
+		ID:          id,
+		PhoneNumber: "+905551234567",
+		Content:     "Test message",
+		Status:      "PENDING",
+		RetryCount:  0,
+	}, nil
+}
+
+func (m *mockMessageManagement) ImportMessages(ctx context.Context, cmd usecases.ImportMessagesCommand) (*usecases.ImportJobResponse, error) {
+	defer cmd.Source.Close()
+	if m.shouldFail {
+		return nil, domainErrors.NewValidationError("CSV header is missing the content column")
+	}
+
+	// The upload must be readable after the handler has stored it
+	if data, err := io.ReadAll(cmd.Source); err != nil || len(data) == 0 {
+		return nil, &testError{message: "import file not readable"}
+	}
+
+	return &usecases.ImportJobResponse{
+		ID:        uuid.New(),
+		Format:    cmd.Format,
+		Status:    "PROCESSING",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *mockMessageManagement) GetImportJob(ctx context.Context, id uuid.UUID) (*usecases.ImportJobResponse, error) {
+	if m.shouldFail {
+		return nil, domainErrors.NewNotFoundError("import job not found")
+	}
+
+	return &usecases.ImportJobResponse{
+		ID:            id,
+		Format:        "csv",
+		Status:        "COMPLETED",
+		ProcessedRows: 3,
+		CreatedCount:  1,
+		FailedCount:   2,
+	}, nil
+}
+
+func (m *mockMessageManagement) RecoverImportJobs(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockMessageManagement) ListImportErrors(ctx context.Context, query usecases.ImportErrorsQuery) (*usecases.ListImportErrorsResponse, error) {
+	if m.shouldFail {
+		return nil, domainErrors.NewNotFoundError("import job not found")
+	}
+
+	// Serve one error per page to exercise pagination
+	rowErrors := []usecases.ImportRowErrorResponse{
+		{Row: 1, Error: "invalid phone number format: 12345", Data: "12345,Hello"},
+		{Row: 3, Error: "content cannot be empty", Data: "+905551234567,"},
+	}
+	if query.Offset >= len(rowErrors) {
+		return &usecases.ListImportErrorsResponse{TotalCount: 2}, nil
+	}
+
+	return &usecases.ListImportErrorsResponse{
+		Errors:     rowErrors[query.Offset : query.Offset+1],
+		TotalCount: 2,
+		HasMore:    query.Offset+1 < len(rowErrors),
+	}, nil
+}
+
+func (m *mockMessageManagement) CancelMessage(ctx context.Context, id uuid.UUID) (*usecases.MessageResponse, error) {
+	if m.shouldFail {
+		return nil, domainErrors.NewConflictError("message %s is no longer pending", id)
+	}
+
+	return &usecases.MessageResponse{
+		ID:          id,
+		PhoneNumber: "+905551234567",
+		Content:     "Test message",
+		Status:      "CANCELLED",
+		RetryCount:  0,
+	}, nil
+}
+
+func (m *mockMessageManagement) RequeueFailedMessages(ctx context.Context, filter usecases.DeadLetterFilter) (*usecases.RequeueResult, error) {
+	if m.shouldFail {
+		return nil, &testError{message: "bulk requeue failed"}
+	}
+
+	return &usecases.RequeueResult{RequeuedCount: 3}, nil
+}
+
+func (m *mockMessageManagement) ListMessageAttempts(ctx context.Context, query usecases.MessageAttemptsQuery) (*usecases.ListAttemptsResponse, error) {
+	if m.shouldFail {
+		return nil, &testError{message: "list attempts failed"}
+	}
+
+	status := 503
+	return &usecases.ListAttemptsResponse{
+		Attempts: []usecases.AttemptResponse{
+			{ID: uuid.New(), AttemptNumber: 1, HTTPStatus: &status},
+		},
+		TotalCount: 1,
+	}, nil
+}
+
 type mockMessageProcessing struct {
 	shouldFail bool
 }
@@ -66,6 +226,10 @@ func (m *mockMessageProcessing) ProcessPendingMessages(ctx context.Context, batc
 	return nil, nil
 }
 
+func (m *mockMessageProcessing) ExpireOverdueMessages(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockMessageProcessing) GetProcessingStatus(ctx context.Context) (*usecases.ProcessingStatus, error) {
 	if m.shouldFail {
 		return nil, &testError{message: "status failed"}
@@ -107,6 +271,18 @@ func TestMessageHandler_CreateMessage(t *testing.T) {
 			shouldFail:     false,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "templated message",
+			requestBody:    `{"phoneNumber":"+905551234567","templateId":"` + uuid.New().String() + `","variables":{"code":"1234"}}`,
+			shouldFail:     false,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid template ID",
+			requestBody:    `{"phoneNumber":"+905551234567","templateId":"welcome"}`,
+			shouldFail:     false,
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "use case error",
 			requestBody:    `{"phoneNumber":"+905551234567","content":"Test message"}`,
@@ -140,6 +316,83 @@ func TestMessageHandler_CreateMessage(t *testing.T) {
 	}
 }
 
+func TestMessageHandler_CreateMessagesBatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		shouldFail      bool
+		expectedStatus  int
+		expectedCreated int
+		expectedFailed  int
+	}{
+		{
+			name:            "all messages created",
+			body:            `{"messages":[{"phoneNumber":"+905551234567","content":"One"},{"phoneNumber":"+905551234568","content":"Two"}]}`,
+			expectedStatus:  http.StatusCreated,
+			expectedCreated: 2,
+		},
+		{
+			name:            "partial success",
+			body:            `{"messages":[{"phoneNumber":"+905551234567","content":"One"},{"content":"No phone"},{"phoneNumber":"+905551234568","content":"Bad ttl","ttl":"soon"}]}`,
+			expectedStatus:  http.StatusMultiStatus,
+			expectedCreated: 1,
+			expectedFailed:  2,
+		},
+		{
+			name:           "empty batch",
+			body:           `{"messages":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{invalid json}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "use case error",
+			body:           `{"messages":[{"phoneNumber":"+905551234567","content":"One"}]}`,
+			shouldFail:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMgmt := &mockMessageManagement{shouldFail: tt.shouldFail}
+			handler := handlers.NewMessageHandler(mockMgmt, &mockMessageProcessing{})
+
+			req := httptest.NewRequest("POST", "/messages/batch", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateMessagesBatch(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if w.Code != http.StatusCreated && w.Code != http.StatusMultiStatus {
+				return
+			}
+
+			var response dto.CreateMessagesBatchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.CreatedCount != tt.expectedCreated || response.FailedCount != tt.expectedFailed {
+				t.Errorf("Expected %d created and %d failed, got %d and %d", tt.expectedCreated, tt.expectedFailed, response.CreatedCount, response.FailedCount)
+			}
+			for i, item := range response.Items {
+				if item.Index != i {
+					t.Errorf("Expected item %d to have index %d, got %d", i, i, item.Index)
+				}
+				if (item.Status == "created") != (item.Message != nil) || (item.Status == "failed") != (item.Error != "") {
+					t.Errorf("Inconsistent item result: %+v", item)
+				}
+			}
+		})
+	}
+}
+
 func TestMessageHandler_GetMessage(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -186,6 +439,16 @@ func TestMessageHandler_GetMessage(t *testing.T) {
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
 			}
+
+			if w.Code == http.StatusOK {
+				var response dto.MessageResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if len(response.StatusHistory) != 1 || response.StatusHistory[0].Trigger != "api" {
+					t.Errorf("Expected status history in response, got %+v", response.StatusHistory)
+				}
+			}
 		})
 	}
 }
@@ -242,3 +505,349 @@ func TestMessageHandler_ListMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageHandler_DeadLetters(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		shouldFail     bool
+		expectedStatus int
+	}{
+		{
+			name:           "list dead letters",
+			method:         "GET",
+			path:           "/messages/dead-letter?error_class=http_5xx&failed_from=2024-01-15T00:00:00Z",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "list dead letters with invalid time",
+			method:         "GET",
+			path:           "/messages/dead-letter?failed_to=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "requeue single message",
+			method:         "POST",
+			path:           "/messages/" + uuid.New().String() + "/requeue",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "requeue with invalid message ID",
+			method:         "POST",
+			path:           "/messages/invalid-uuid/requeue",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "bulk requeue with filter",
+			method:         "POST",
+			path:           "/messages/requeue",
+			body:           `{"errorClass":"http_5xx"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "bulk requeue without body",
+			method:         "POST",
+			path:           "/messages/requeue",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "bulk requeue with invalid body",
+			method:         "POST",
+			path:           "/messages/requeue",
+			body:           `{invalid json}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "bulk requeue use case error",
+			method:         "POST",
+			path:           "/messages/requeue",
+			shouldFail:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMgmt := &mockMessageManagement{shouldFail: tt.shouldFail}
+			handler := handlers.NewMessageHandler(mockMgmt, &mockMessageProcessing{})
+
+			r := chi.NewRouter()
+			r.Get("/messages/dead-letter", handler.ListDeadLetters)
+			r.Post("/messages/requeue", handler.RequeueMessages)
+			r.Post("/messages/{id}/requeue", handler.RequeueMessage)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMessageHandler_CancelMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		messageID      string
+		shouldFail     bool
+		expectedStatus int
+	}{
+		{
+			name:           "successful cancellation",
+			messageID:      uuid.New().String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid message ID",
+			messageID:      "invalid-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "claimed concurrently",
+			messageID:      uuid.New().String(),
+			shouldFail:     true,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMgmt := &mockMessageManagement{shouldFail: tt.shouldFail}
+			handler := handlers.NewMessageHandler(mockMgmt, &mockMessageProcessing{})
+
+			r := chi.NewRouter()
+			r.Post("/messages/{id}/cancel", handler.CancelMessage)
+
+			req := httptest.NewRequest("POST", "/messages/"+tt.messageID+"/cancel", nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMessageHandler_ListMessageAttempts(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		shouldFail     bool
+		expectedStatus int
+	}{
+		{
+			name:           "list attempts",
+			path:           "/messages/" + uuid.New().String() + "/attempts?limit=10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid message ID",
+			path:           "/messages/invalid-uuid/attempts",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "use case error",
+			path:           "/messages/" + uuid.New().String() + "/attempts",
+			shouldFail:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMgmt := &mockMessageManagement{shouldFail: tt.shouldFail}
+			handler := handlers.NewMessageHandler(mockMgmt, &mockMessageProcessing{})
+
+			r := chi.NewRouter()
+			r.Get("/messages/{id}/attempts", handler.ListMessageAttempts)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusOK && w.Header().Get("X-Total-Count") != "1" {
+				t.Errorf("Expected X-Total-Count 1, got %q", w.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+}
+
+func TestMessageHandler_ImportMessages(t *testing.T) {
+	multipartBody := func(fileName, content string) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", fileName)
+		part.Write([]byte(content))
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	csvFile := "phone,content\n+905551234567,Hello\n"
+
+	tests := []struct {
+		name           string
+		path           string
+		contentType    string
+		body           func() (io.Reader, string)
+		shouldFail     bool
+		expectedStatus int
+		expectedFormat string
+	}{
+		{
+			name:           "raw CSV body",
+			path:           "/messages/imports",
+			body:           func() (io.Reader, string) { return strings.NewReader(csvFile), "text/csv" },
+			expectedStatus: http.StatusAccepted,
+			expectedFormat: "csv",
+		},
+		{
+			name: "raw NDJSON body",
+			path: "/messages/imports",
+			body: func() (io.Reader, string) {
+				return strings.NewReader(`{"phoneNumber":"+905551234567","content":"Hello"}`), "application/x-ndjson"
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedFormat: "ndjson",
+		},
+		{
+			name:           "format query parameter",
+			path:           "/messages/imports?format=csv",
+			body:           func() (io.Reader, string) { return strings.NewReader(csvFile), "application/octet-stream" },
+			expectedStatus: http.StatusAccepted,
+			expectedFormat: "csv",
+		},
+		{
+			name:           "multipart upload",
+			path:           "/messages/imports",
+			body:           func() (io.Reader, string) { return multipartBody("recipients.csv", csvFile) },
+			expectedStatus: http.StatusAccepted,
+			expectedFormat: "csv",
+		},
+		{
+			name:           "unknown format",
+			path:           "/messages/imports",
+			body:           func() (io.Reader, string) { return strings.NewReader(csvFile), "text/plain" },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "multipart file with unknown extension",
+			path:           "/messages/imports",
+			body:           func() (io.Reader, string) { return multipartBody("recipients.xlsx", csvFile) },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid file",
+			path:           "/messages/imports",
+			body:           func() (io.Reader, string) { return strings.NewReader(csvFile), "text/csv" },
+			shouldFail:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMgmt := &mockMessageManagement{shouldFail: tt.shouldFail}
+			handler := handlers.NewMessageHandler(mockMgmt, &mockMessageProcessing{})
+
+			body, contentType := tt.body()
+			req := httptest.NewRequest("POST", tt.path, body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.ImportMessages(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusAccepted {
+				var response dto.ImportJobResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Format != tt.expectedFormat || response.Status != "processing" {
+					t.Errorf("Expected processing %s job, got %s %s", tt.expectedFormat, response.Status, response.Format)
+				}
+				if response.ErrorsURL != "/api/v1/messages/imports/"+response.ID+"/errors" {
+					t.Errorf("Unexpected errors URL %q", response.ErrorsURL)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageHandler_ImportJobs(t *testing.T) {
+	newRouter := func(shouldFail bool) *chi.Mux {
+		handler := handlers.NewMessageHandler(&mockMessageManagement{shouldFail: shouldFail}, &mockMessageProcessing{})
+		r := chi.NewRouter()
+		r.Get("/messages/imports/{id}", handler.GetImportJob)
+		r.Get("/messages/imports/{id}/errors", handler.DownloadImportErrors)
+		return r
+	}
+
+	t.Run("job progress", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", "/messages/imports/"+uuid.New().String(), nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var response dto.ImportJobResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Status != "completed" || response.CreatedCount != 1 || response.FailedCount != 2 {
+			t.Errorf("Unexpected job progress: %+v", response)
+		}
+	})
+
+	t.Run("error report spans every page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", "/messages/imports/"+uuid.New().String()+"/errors", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("Content-Type") != "text/csv" {
+			t.Errorf("Expected text/csv, got %q", w.Header().Get("Content-Type"))
+		}
+
+		expected := "row,error,data\n" +
+			"1,invalid phone number format: 12345,\"12345,Hello\"\n" +
+			"3,content cannot be empty,\"+905551234567,\"\n"
+		if w.Body.String() != expected {
+			t.Errorf("Unexpected report:\n%s", w.Body.String())
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		for _, path := range []string{"/messages/imports/" + uuid.New().String(), "/messages/imports/" + uuid.New().String() + "/errors"} {
+			w := httptest.NewRecorder()
+			newRouter(true).ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+			if w.Code != http.StatusNotFound {
+				t.Errorf("%s: expected status 404, got %d", path, w.Code)
+			}
+		}
+	})
+
+	t.Run("invalid job ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(w, httptest.NewRequest("GET", "/messages/imports/invalid-uuid/errors", nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	httpMiddleware "github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/middleware"
+)
+
+const testCallbackSecret = "test-callback-secret"
+
+func newSignedCallbackRequest(secret string, signedAt time.Time, body string) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/delivery-receipts", bytes.NewBufferString(body))
+	req.Header.Set(httpMiddleware.HeaderProviderTimestamp, timestamp)
+	req.Header.Set(httpMiddleware.HeaderProviderSignature, httpMiddleware.SignProviderCallback(secret, timestamp, []byte(body)))
+	return req
+}
+
+func serveProviderCallback(config httpMiddleware.ProviderAuthConfig, req *http.Request) (*httptest.ResponseRecorder, string) {
+	var received string
+	handler := httpMiddleware.ProviderSignatureAuth(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, received
+}
+
+func TestProviderSignatureAuth(t *testing.T) {
+	config := httpMiddleware.ProviderAuthConfig{Secret: testCallbackSecret, Tolerance: time.Minute}
+	body := `{"externalId":"ext-1","status":"DELIVERED"}`
+
+	t.Run("signed callback reaches the handler with its body", func(t *testing.T) {
+		w, received := serveProviderCallback(config, newSignedCallbackRequest(testCallbackSecret, time.Now(), body))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if received != body {
+			t.Errorf("Expected the handler to read the signed body, got %q", received)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		modify func(req *http.Request)
+	}{
+		{"tampered body", func(req *http.Request) {
+			req.Body = io.NopCloser(bytes.NewBufferString(`{"externalId":"ext-2","status":"DELIVERED"}`))
+		}},
+		{"missing signature", func(req *http.Request) {
+			req.Header.Del(httpMiddleware.HeaderProviderSignature)
+		}},
+		{"client API key instead of signature", func(req *http.Request) {
+			req.Header.Del(httpMiddleware.HeaderProviderTimestamp)
+			req.Header.Del(httpMiddleware.HeaderProviderSignature)
+			req.Header.Set("Authorization", "Bearer test-api-key")
+		}},
+		{"invalid timestamp", func(req *http.Request) {
+			req.Header.Set(httpMiddleware.HeaderProviderTimestamp, "yesterday")
+		}},
+		{"signed with another secret", func(req *http.Request) {
+			*req = *newSignedCallbackRequest("other-secret", time.Now(), body)
+		}},
+		{"stale signature", func(req *http.Request) {
+			*req = *newSignedCallbackRequest(testCallbackSecret, time.Now().Add(-2*time.Minute), body)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newSignedCallbackRequest(testCallbackSecret, time.Now(), body)
+			tt.modify(req)
+
+			w, received := serveProviderCallback(config, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("Expected status 401, got %d", w.Code)
+			}
+			if received != "" {
+				t.Error("Expected the handler not to be called")
+			}
+		})
+	}
+
+	t.Run("no secret configured", func(t *testing.T) {
+		w, _ := serveProviderCallback(httpMiddleware.ProviderAuthConfig{}, newSignedCallbackRequest("", time.Now(), body))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+	})
+}
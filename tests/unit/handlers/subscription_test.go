@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock subscription use case for testing
+type mockSubscriptions struct {
+	failWith  error
+	lastCmd   interface{}
+	lastQuery usecases.ListEventDeliveriesQuery
+}
+
+func (m *mockSubscriptions) subscription(id uuid.UUID) *usecases.SubscriptionResponse {
+	return &usecases.SubscriptionResponse{
+		ID:        id,
+		URL:       "https://crm.example.com/hooks",
+		Statuses:  []string{},
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (m *mockSubscriptions) CreateSubscription(ctx context.Context, cmd usecases.CreateSubscriptionCommand) (*usecases.SubscriptionResponse, error) {
+	m.lastCmd = cmd
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	response := m.subscription(uuid.New())
+	response.Secret = "secret"
+	return response, nil
+}
+
+func (m *mockSubscriptions) GetSubscription(ctx context.Context, id uuid.UUID) (*usecases.SubscriptionResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return m.subscription(id), nil
+}
+
+func (m *mockSubscriptions) ListSubscriptions(ctx context.Context, query usecases.ListSubscriptionsQuery) (*usecases.ListSubscriptionsResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ListSubscriptionsResponse{
+		Subscriptions: []usecases.SubscriptionResponse{*m.subscription(uuid.New())},
+		TotalCount:    1,
+	}, nil
+}
+
+func (m *mockSubscriptions) UpdateSubscription(ctx context.Context, cmd usecases.UpdateSubscriptionCommand) (*usecases.SubscriptionResponse, error) {
+	m.lastCmd = cmd
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	response := m.subscription(cmd.ID)
+	response.Active = cmd.Active
+	return response, nil
+}
+
+func (m *mockSubscriptions) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return m.failWith
+}
+
+func (m *mockSubscriptions) ListDeliveries(ctx context.Context, query usecases.ListEventDeliveriesQuery) (*usecases.ListEventDeliveriesResponse, error) {
+	m.lastQuery = query
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ListEventDeliveriesResponse{
+		Deliveries: []usecases.EventDeliveryResponse{{ID: 1, EventID: 7, MessageID: uuid.New(), To: "SENT", Status: "DELIVERED", Attempts: 1}},
+		TotalCount: 1,
+	}, nil
+}
+
+func (m *mockSubscriptions) ReplayEvents(ctx context.Context, cmd usecases.ReplayEventsCommand) (*usecases.ReplayEventsResponse, error) {
+	m.lastCmd = cmd
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ReplayEventsResponse{Queued: 3}, nil
+}
+
+func newSubscriptionRouter(mock *mockSubscriptions) http.Handler {
+	handler := handlers.NewSubscriptionHandler(mock)
+
+	r := chi.NewRouter()
+	r.Post("/subscriptions", handler.CreateSubscription)
+	r.Get("/subscriptions", handler.ListSubscriptions)
+	r.Get("/subscriptions/{id}", handler.GetSubscription)
+	r.Put("/subscriptions/{id}", handler.UpdateSubscription)
+	r.Delete("/subscriptions/{id}", handler.DeleteSubscription)
+	r.Get("/subscriptions/{id}/deliveries", handler.ListDeliveries)
+	r.Post("/subscriptions/{id}/replay", handler.ReplayEvents)
+	return r
+}
+
+func TestSubscriptionHandler(t *testing.T) {
+	id := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		failWith       error
+		expectedStatus int
+	}{
+		{"create", "POST", "/subscriptions", `{"url":"https://crm.example.com/hooks","statuses":["SENT"]}`, nil, http.StatusCreated},
+		{"create with invalid body", "POST", "/subscriptions", `{invalid json}`, nil, http.StatusBadRequest},
+		{"create with invalid URL", "POST", "/subscriptions", `{"url":"crm"}`, domainErrors.NewValidationError("invalid subscription URL: crm"), http.StatusBadRequest},
+		{"list", "GET", "/subscriptions", "", nil, http.StatusOK},
+		{"get", "GET", "/subscriptions/" + id, "", nil, http.StatusOK},
+		{"get with invalid ID", "GET", "/subscriptions/not-a-uuid", "", nil, http.StatusBadRequest},
+		{"get unknown", "GET", "/subscriptions/" + id, "", domainErrors.NewNotFoundError("subscription not found"), http.StatusNotFound},
+		{"update", "PUT", "/subscriptions/" + id, `{"url":"https://crm.example.com/hooks","active":false}`, nil, http.StatusOK},
+		{"delete", "DELETE", "/subscriptions/" + id, "", nil, http.StatusNoContent},
+		{"deliveries", "GET", "/subscriptions/" + id + "/deliveries?status=failed", "", nil, http.StatusOK},
+		{"replay", "POST", "/subscriptions/" + id + "/replay", `{"from":"2024-01-15T00:00:00Z","to":"2024-01-16T00:00:00Z"}`, nil, http.StatusAccepted},
+		{"replay of too many events", "POST", "/subscriptions/" + id + "/replay", `{"from":"2024-01-01T00:00:00Z","to":"2024-02-01T00:00:00Z"}`, domainErrors.NewValidationError("replay range holds 20000 events (max: 10000), narrow it down"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newSubscriptionRouter(&mockSubscriptions{failWith: tt.failWith})
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSubscriptionHandler_Requests(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("create returns the secret", func(t *testing.T) {
+		router := newSubscriptionRouter(&mockSubscriptions{})
+
+		req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBufferString(`{"url":"https://crm.example.com/hooks"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response dto.SubscriptionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Secret == "" {
+			t.Error("Expected the secret in the create response")
+		}
+	})
+
+	t.Run("update keeps the subscription active unless paused", func(t *testing.T) {
+		mock := &mockSubscriptions{}
+		router := newSubscriptionRouter(mock)
+
+		req := httptest.NewRequest("PUT", "/subscriptions/"+id.String(), bytes.NewBufferString(`{"url":"https://crm.example.com/hooks"}`))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		if cmd := mock.lastCmd.(usecases.UpdateSubscriptionCommand); cmd.ID != id || !cmd.Active {
+			t.Errorf("Unexpected command %+v", cmd)
+		}
+	})
+
+	t.Run("deliveries are filtered and counted", func(t *testing.T) {
+		mock := &mockSubscriptions{}
+		router := newSubscriptionRouter(mock)
+
+		req := httptest.NewRequest("GET", "/subscriptions/"+id.String()+"/deliveries?status=failed&limit=5&offset=10", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if query := mock.lastQuery; query.SubscriptionID != id || query.Status != "failed" || query.Limit != 5 || query.Offset != 10 {
+			t.Errorf("Unexpected query %+v", query)
+		}
+		if total := w.Header().Get("X-Total-Count"); total != "1" {
+			t.Errorf("Expected X-Total-Count 1, got %q", total)
+		}
+	})
+
+	t.Run("replay passes the range", func(t *testing.T) {
+		mock := &mockSubscriptions{}
+		router := newSubscriptionRouter(mock)
+
+		req := httptest.NewRequest("POST", "/subscriptions/"+id.String()+"/replay", bytes.NewBufferString(`{"from":"2024-01-15T00:00:00Z","to":"2024-01-16T00:00:00Z"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		cmd := mock.lastCmd.(usecases.ReplayEventsCommand)
+		if cmd.SubscriptionID != id || !cmd.From.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) || !cmd.To.Equal(time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected command %+v", cmd)
+		}
+
+		var response dto.ReplayEventsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Queued != 3 {
+			t.Errorf("Expected 3 queued events, got %+v (%v)", response, err)
+		}
+	})
+}
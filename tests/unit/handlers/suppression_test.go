@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock suppression use case for testing
+type mockSuppressionManagement struct {
+	failWith     error
+	lastPhone    string
+	lastImport   usecases.ImportSuppressionsCommand
+	importResult *usecases.ImportSuppressionsResult
+}
+
+func (m *mockSuppressionManagement) suppression(phoneNumber, reason string) *usecases.SuppressionResponse {
+	return &usecases.SuppressionResponse{
+		PhoneNumber: phoneNumber,
+		Reason:      reason,
+		Source:      "api",
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (m *mockSuppressionManagement) AddSuppression(ctx context.Context, cmd usecases.AddSuppressionCommand) (*usecases.SuppressionResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return m.suppression(cmd.PhoneNumber, cmd.Reason), nil
+}
+
+func (m *mockSuppressionManagement) ImportSuppressions(ctx context.Context, cmd usecases.ImportSuppressionsCommand) (*usecases.ImportSuppressionsResult, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	m.lastImport = cmd
+	if m.importResult != nil {
+		return m.importResult, nil
+	}
+	return &usecases.ImportSuppressionsResult{AddedCount: int64(len(cmd.Suppressions))}, nil
+}
+
+func (m *mockSuppressionManagement) CheckSuppression(ctx context.Context, phoneNumber string) (*usecases.SuppressionResponse, error) {
+	m.lastPhone = phoneNumber
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return m.suppression(phoneNumber, "replied STOP"), nil
+}
+
+func (m *mockSuppressionManagement) ListSuppressions(ctx context.Context, query usecases.ListSuppressionsQuery) (*usecases.ListSuppressionsResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ListSuppressionsResponse{
+		Suppressions: []usecases.SuppressionResponse{*m.suppression("+905551234567", "replied STOP")},
+		TotalCount:   4,
+		HasMore:      true,
+	}, nil
+}
+
+func (m *mockSuppressionManagement) RemoveSuppression(ctx context.Context, phoneNumber string) error {
+	m.lastPhone = phoneNumber
+	return m.failWith
+}
+
+func newSuppressionRouter(mock *mockSuppressionManagement) http.Handler {
+	handler := handlers.NewSuppressionHandler(mock)
+
+	r := chi.NewRouter()
+	r.Post("/suppressions", handler.AddSuppression)
+	r.Post("/suppressions/imports", handler.ImportSuppressions)
+	r.Get("/suppressions", handler.ListSuppressions)
+	r.Get("/suppressions/{phoneNumber}", handler.CheckSuppression)
+	r.Delete("/suppressions/{phoneNumber}", handler.RemoveSuppression)
+	return r
+}
+
+func TestSuppressionHandler(t *testing.T) {
+	notSuppressed := domainErrors.NewNotFoundError("suppression not found")
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		failWith       error
+		expectedStatus int
+	}{
+		{"add", "POST", "/suppressions", "application/json", `{"phoneNumber":"+905551234567","reason":"replied STOP"}`, nil, http.StatusCreated},
+		{"add with invalid body", "POST", "/suppressions", "application/json", `{invalid json}`, nil, http.StatusBadRequest},
+		{"add invalid phone number", "POST", "/suppressions", "application/json", `{"phoneNumber":"12ab"}`, domainErrors.NewValidationError("invalid phone number format: 12ab"), http.StatusBadRequest},
+		{"import JSON", "POST", "/suppressions/imports", "application/json", `{"suppressions":[{"phoneNumber":"+905551234567"}]}`, nil, http.StatusOK},
+		{"import CSV", "POST", "/suppressions/imports", "text/csv", "phone_number,reason\n+905551234567,STOP\n", nil, http.StatusOK},
+		{"import CSV without phone column", "POST", "/suppressions/imports", "text/csv", "number\n+905551234567\n", nil, http.StatusBadRequest},
+		{"import unsupported format", "POST", "/suppressions/imports", "application/x-ndjson", `{"phoneNumber":"+905551234567"}`, nil, http.StatusBadRequest},
+		{"import without entries", "POST", "/suppressions/imports", "application/json", `{"suppressions":[]}`, domainErrors.NewValidationError("at least one suppression is required"), http.StatusBadRequest},
+		{"list", "GET", "/suppressions?limit=1", "", "", nil, http.StatusOK},
+		{"check suppressed", "GET", "/suppressions/%2B905551234567", "", "", nil, http.StatusOK},
+		{"check not suppressed", "GET", "/suppressions/%2B905551234567", "", "", notSuppressed, http.StatusNotFound},
+		{"remove", "DELETE", "/suppressions/+905551234567", "", "", nil, http.StatusNoContent},
+		{"remove not suppressed", "DELETE", "/suppressions/+905551234567", "", "", notSuppressed, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newSuppressionRouter(&mockSuppressionManagement{failWith: tt.failWith})
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSuppressionHandler_Requests(t *testing.T) {
+	t.Run("escaped plus sign in the path is decoded", func(t *testing.T) {
+		mock := &mockSuppressionManagement{}
+		router := newSuppressionRouter(mock)
+
+		req := httptest.NewRequest("GET", "/suppressions/%2B905551234567", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		if mock.lastPhone != "+905551234567" {
+			t.Errorf("Expected +905551234567, got %q", mock.lastPhone)
+		}
+	})
+
+	t.Run("CSV rows and the reason query parameter reach the use case", func(t *testing.T) {
+		mock := &mockSuppressionManagement{}
+		router := newSuppressionRouter(mock)
+
+		body := "Phone Number,Reason\n+905551234567,replied STOP\n+905559876543\n"
+		req := httptest.NewRequest("POST", "/suppressions/imports?reason=carrier+list", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		entries := mock.lastImport.Suppressions
+		if len(entries) != 2 || entries[0].Reason != "replied STOP" || entries[1].PhoneNumber != "+905559876543" {
+			t.Errorf("Unexpected import entries %+v", entries)
+		}
+		if mock.lastImport.Reason != "carrier list" {
+			t.Errorf("Expected default reason from the query, got %q", mock.lastImport.Reason)
+		}
+	})
+
+	t.Run("import response lists rejected entries", func(t *testing.T) {
+		mock := &mockSuppressionManagement{importResult: &usecases.ImportSuppressionsResult{
+			AddedCount:    1,
+			RejectedCount: 1,
+			RejectedEntries: []usecases.SuppressionImportError{
+				{Index: 1, PhoneNumber: "12ab", Error: "invalid phone number format: 12ab"},
+			},
+		}}
+		router := newSuppressionRouter(mock)
+
+		req := httptest.NewRequest("POST", "/suppressions/imports", bytes.NewBufferString(`{"suppressions":[{"phoneNumber":"+905551234567"},{"phoneNumber":"12ab"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response dto.ImportSuppressionsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.AddedCount != 1 || len(response.RejectedEntries) != 1 || response.RejectedEntries[0].Index != 1 {
+			t.Errorf("Unexpected import response %+v", response)
+		}
+	})
+
+	t.Run("list sets the total count header", func(t *testing.T) {
+		router := newSuppressionRouter(&mockSuppressionManagement{})
+
+		req := httptest.NewRequest("GET", "/suppressions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-Total-Count"); got != "4" {
+			t.Errorf("Expected X-Total-Count 4, got %q", got)
+		}
+	})
+}
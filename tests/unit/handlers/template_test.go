@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/dto"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/adapters/primary/http/handlers"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+)
+
+// Mock template use case for testing
+type mockTemplateManagement struct {
+	failWith error
+}
+
+func (m *mockTemplateManagement) template(id uuid.UUID, name, body string) *usecases.TemplateResponse {
+	return &usecases.TemplateResponse{
+		ID:        id,
+		Name:      name,
+		Body:      body,
+		Version:   1,
+		Variables: []string{"name"},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (m *mockTemplateManagement) CreateTemplate(ctx context.Context, cmd usecases.CreateTemplateCommand) (*usecases.TemplateResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return m.template(uuid.New(), cmd.Name, cmd.Body), nil
+}
+
+func (m *mockTemplateManagement) GetTemplate(ctx context.Context, id uuid.UUID) (*usecases.TemplateResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return m.template(id, "welcome", "Hi {{name}}"), nil
+}
+
+func (m *mockTemplateManagement) ListTemplates(ctx context.Context, query usecases.ListTemplatesQuery) (*usecases.ListTemplatesResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return &usecases.ListTemplatesResponse{
+		Templates:  []usecases.TemplateResponse{*m.template(uuid.New(), "welcome", "Hi {{name}}")},
+		TotalCount: 3,
+		HasMore:    true,
+	}, nil
+}
+
+func (m *mockTemplateManagement) UpdateTemplate(ctx context.Context, cmd usecases.UpdateTemplateCommand) (*usecases.TemplateResponse, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	response := m.template(cmd.ID, "welcome", cmd.Body)
+	response.Version = 2
+	return response, nil
+}
+
+func (m *mockTemplateManagement) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	return m.failWith
+}
+
+func newTemplateRouter(mock *mockTemplateManagement) http.Handler {
+	handler := handlers.NewTemplateHandler(mock)
+
+	r := chi.NewRouter()
+	r.Post("/templates", handler.CreateTemplate)
+	r.Get("/templates", handler.ListTemplates)
+	r.Get("/templates/{id}", handler.GetTemplate)
+	r.Put("/templates/{id}", handler.UpdateTemplate)
+	r.Delete("/templates/{id}", handler.DeleteTemplate)
+	return r
+}
+
+func TestTemplateHandler(t *testing.T) {
+	id := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		failWith       error
+		expectedStatus int
+	}{
+		{"create", "POST", "/templates", `{"name":"welcome","body":"Hi {{name}}"}`, nil, http.StatusCreated},
+		{"create with invalid body", "POST", "/templates", `{invalid json}`, nil, http.StatusBadRequest},
+		{"create duplicate name", "POST", "/templates", `{"name":"welcome","body":"Hi"}`, domainErrors.NewConflictError("template named welcome already exists"), http.StatusConflict},
+		{"list", "GET", "/templates?limit=1", "", nil, http.StatusOK},
+		{"get", "GET", "/templates/" + id, "", nil, http.StatusOK},
+		{"get with invalid ID", "GET", "/templates/welcome", "", nil, http.StatusBadRequest},
+		{"get unknown template", "GET", "/templates/" + id, "", domainErrors.NewNotFoundError("template not found"), http.StatusNotFound},
+		{"update", "PUT", "/templates/" + id, `{"body":"Hello {{name}}"}`, nil, http.StatusOK},
+		{"update concurrently", "PUT", "/templates/" + id, `{"body":"Hello {{name}}"}`, domainErrors.NewConflictError("template was changed concurrently"), http.StatusConflict},
+		{"update with malformed body", "PUT", "/templates/" + id, `{"body":"Hello {{name"}`, domainErrors.NewValidationError("template body contains a malformed placeholder"), http.StatusBadRequest},
+		{"delete", "DELETE", "/templates/" + id, "", nil, http.StatusNoContent},
+		{"delete with invalid ID", "DELETE", "/templates/welcome", "", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTemplateRouter(&mockTemplateManagement{failWith: tt.failWith})
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d. Response body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTemplateHandler_Responses(t *testing.T) {
+	router := newTemplateRouter(&mockTemplateManagement{})
+
+	t.Run("update returns the new version", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/templates/"+uuid.New().String(), bytes.NewBufferString(`{"body":"Hello {{name}}"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response dto.TemplateResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Version != 2 || response.Body != "Hello {{name}}" || len(response.Variables) != 1 {
+			t.Errorf("Unexpected template response %+v", response)
+		}
+	})
+
+	t.Run("list sets the total count header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/templates", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-Total-Count"); got != "3" {
+			t.Errorf("Expected X-Total-Count 3, got %q", got)
+		}
+
+		var response []dto.TemplateResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response[0].Name != "welcome" {
+			t.Errorf("Unexpected list response %+v", response)
+		}
+	})
+}
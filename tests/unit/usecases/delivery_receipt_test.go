@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+// Mock delivery receipt repository for testing
+type mockDeliveryReceiptRepository struct {
+	mu       sync.Mutex
+	receipts map[string]*message.DeliveryReceipt
+}
+
+func newMockDeliveryReceiptRepository() *mockDeliveryReceiptRepository {
+	return &mockDeliveryReceiptRepository{
+		receipts: make(map[string]*message.DeliveryReceipt),
+	}
+}
+
+func (m *mockDeliveryReceiptRepository) Create(ctx context.Context, receipt *message.DeliveryReceipt) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.receipts[receipt.ExternalID]; exists {
+		return false, nil
+	}
+	copied := *receipt
+	m.receipts[receipt.ExternalID] = &copied
+	return true, nil
+}
+
+func (m *mockDeliveryReceiptRepository) GetByExternalID(ctx context.Context, externalID string) (*message.DeliveryReceipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	receipt, exists := m.receipts[externalID]
+	if !exists {
+		return nil, domainErrors.NewNotFoundError("delivery receipt not found")
+	}
+	copied := *receipt
+	return &copied, nil
+}
+
+func (m *mockDeliveryReceiptRepository) MarkApplied(ctx context.Context, receipt *message.DeliveryReceipt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.receipts[receipt.ExternalID]
+	if !exists {
+		return domainErrors.NewNotFoundError("delivery receipt not found for update")
+	}
+	stored.MessageID = receipt.MessageID
+	stored.AppliedAt = receipt.AppliedAt
+	return nil
+}
+
+// createSentTestMessage stores a message the provider accepted under the given external ID
+func createSentTestMessage(t *testing.T, repo *mockMessageRepository, externalID string) *message.Message {
+	t.Helper()
+
+	msg := createTestMessage(t)
+	if err := msg.MarkAsSent(externalID, "primary"); err != nil {
+		t.Fatalf("Failed to mark message as sent: %v", err)
+	}
+	msg.ClearStatusChanges()
+	repo.messages[msg.ID] = msg
+	return msg
+}
+
+func TestDeliveryReceiptService_ReceiveDeliveryReceipt(t *testing.T) {
+	t.Run("receipt for a sent message is applied", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		receiptRepo := newMockDeliveryReceiptRepository()
+		msg := createSentTestMessage(t, messageRepo, "provider-1")
+		service := usecaseImpl.NewDeliveryReceiptService(messageRepo, receiptRepo, newMockCacheServiceForManagement())
+
+		reportedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+		result, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{
+			ExternalID: "provider-1",
+			Status:     "undelivered",
+			ErrorCode:  "30005",
+			ReportedAt: reportedAt,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.AppliedAt == nil || result.MessageID == nil || result.MessageID.String() != msg.ID.String() || result.Duplicate {
+			t.Errorf("Expected an applied receipt, got %+v", result)
+		}
+
+		stored := messageRepo.messages[msg.ID]
+		if stored.Status != message.StatusUndelivered {
+			t.Errorf("Expected UNDELIVERED, got %s", stored.Status)
+		}
+		if stored.CarrierErrorCode == nil || *stored.CarrierErrorCode != "30005" {
+			t.Errorf("Expected the carrier error code, got %v", stored.CarrierErrorCode)
+		}
+		if stored.DeliveryReportedAt == nil || !stored.DeliveryReportedAt.Equal(reportedAt) {
+			t.Errorf("Expected the reported time, got %v", stored.DeliveryReportedAt)
+		}
+		history := messageRepo.history[msg.ID]
+		if len(history) != 1 || history[0].To != message.StatusUndelivered {
+			t.Errorf("Expected the transition to be recorded, got %+v", history)
+		}
+	})
+
+	t.Run("receipt evicts a recently sent message from the cache", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		cache := newMockCacheServiceForManagement()
+		msg := createSentTestMessage(t, messageRepo, "provider-1")
+		cacheKey := "message_detail:" + msg.ID.String()
+		member := msg.ID.String() + ":provider-1:" + msg.PhoneNumber.String()
+		cache.ZAdd(context.Background(), "sent_messages", float64(time.Now().Unix()), member, time.Hour)
+		cache.data[cacheKey] = services.SentMessageCacheData{MessageID: msg.ID.String(), ExternalID: "provider-1", Status: message.StatusSent.String()}
+		service := usecaseImpl.NewDeliveryReceiptService(messageRepo, newMockDeliveryReceiptRepository(), cache)
+
+		if _, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{ExternalID: "provider-1", Status: "delivered"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, ok := cache.data[cacheKey]; ok {
+			t.Error("Expected the cached message detail to be deleted")
+		}
+		if _, ok := cache.sortedSets["sent_messages"][member]; ok {
+			t.Error("Expected the message to be removed from the sent messages")
+		}
+	})
+
+	t.Run("receipt arriving before the send completes is applied by the sender", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		receiptRepo := newMockDeliveryReceiptRepository()
+		msg := createTestMessage(t)
+		messageRepo.messages[msg.ID] = msg
+		service := usecaseImpl.NewDeliveryReceiptService(messageRepo, receiptRepo, newMockCacheServiceForManagement())
+
+		// The mock webhook accepts every message under this ID
+		result, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{
+			ExternalID: "webhook-123-message-id",
+			Status:     "DELIVERED",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.AppliedAt != nil {
+			t.Errorf("Expected the receipt to wait for the message, got %+v", result)
+		}
+
+		processing := usecaseImpl.NewMessageProcessingService(messageRepo, newMockSuppressionRepository(), receiptRepo, &mockWebhookService{}, newMockCacheServiceForProcessing(), usecases.ProcessingConfig{})
+		if _, err := processing.ProcessPendingMessages(context.Background(), 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if stored := messageRepo.messages[msg.ID]; stored.Status != message.StatusDelivered {
+			t.Errorf("Expected DELIVERED, got %s", stored.Status)
+		}
+		if receipt := receiptRepo.receipts["webhook-123-message-id"]; !receipt.IsApplied() {
+			t.Error("Expected the receipt to be marked applied")
+		}
+	})
+
+	t.Run("repeated receipt keeps the first outcome", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		receiptRepo := newMockDeliveryReceiptRepository()
+		msg := createSentTestMessage(t, messageRepo, "provider-1")
+		service := usecaseImpl.NewDeliveryReceiptService(messageRepo, receiptRepo, newMockCacheServiceForManagement())
+
+		if _, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{ExternalID: "provider-1", Status: "DELIVERED"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		again, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{ExternalID: "provider-1", Status: "UNDELIVERED"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !again.Duplicate || again.Status != "DELIVERED" {
+			t.Errorf("Expected the stored receipt back, got %+v", again)
+		}
+		if stored := messageRepo.messages[msg.ID]; stored.Status != message.StatusDelivered {
+			t.Errorf("Expected DELIVERED to stay, got %s", stored.Status)
+		}
+		if got := messageRepo.callCount["UpdateSent"]; got != 1 {
+			t.Errorf("Expected one message update, got %d", got)
+		}
+	})
+
+	t.Run("message without a send is rejected", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		msg := createSentTestMessage(t, messageRepo, "provider-1")
+		msg.Status = message.StatusFailed
+		service := usecaseImpl.NewDeliveryReceiptService(messageRepo, newMockDeliveryReceiptRepository(), newMockCacheServiceForManagement())
+
+		var businessErr domainErrors.BusinessError
+		_, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{ExternalID: "provider-1", Status: "DELIVERED"})
+		if !errors.As(err, &businessErr) {
+			t.Errorf("Expected business error, got %v", err)
+		}
+	})
+
+	t.Run("invalid status is rejected", func(t *testing.T) {
+		receiptRepo := newMockDeliveryReceiptRepository()
+		service := usecaseImpl.NewDeliveryReceiptService(newMockMessageRepository(), receiptRepo, newMockCacheServiceForManagement())
+
+		var validationErr domainErrors.ValidationError
+		_, err := service.ReceiveDeliveryReceipt(context.Background(), usecases.ReceiveDeliveryReceiptCommand{ExternalID: "provider-1", Status: "SENT"})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+		if len(receiptRepo.receipts) != 0 {
+			t.Errorf("Expected nothing to be stored, got %d", len(receiptRepo.receipts))
+		}
+	})
+}
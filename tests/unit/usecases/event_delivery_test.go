@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+// Mock event callback service for testing
+type mockEventCallbackService struct {
+	mu         sync.Mutex
+	statusCode int // Response status; non-2xx fails the callback
+	callbacks  []services.EventCallback
+}
+
+func (m *mockEventCallbackService) Send(ctx context.Context, callback services.EventCallback) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callbacks = append(m.callbacks, callback)
+	if m.statusCode >= 300 {
+		return m.statusCode, domainErrors.NewBusinessError("endpoint returned HTTP %d", m.statusCode)
+	}
+	return m.statusCode, nil
+}
+
+func TestEventDeliveryService_DeliverPendingEvents(t *testing.T) {
+	setup := func(t *testing.T, statusCode int) (*mockEventDeliveryRepository, *mockEventCallbackService, *message.Subscription, usecases.EventDeliveryUseCase) {
+		t.Helper()
+
+		subscriptionRepo := newMockSubscriptionRepository()
+		subscription, err := message.NewSubscription("https://crm.example.com/hooks", "", nil)
+		if err != nil {
+			t.Fatalf("Failed to create subscription: %v", err)
+		}
+		subscriptionRepo.Create(context.Background(), subscription)
+
+		deliveryRepo := newMockEventDeliveryRepository()
+		callbackService := &mockEventCallbackService{statusCode: statusCode}
+		service := usecaseImpl.NewEventDeliveryService(subscriptionRepo, deliveryRepo, callbackService, usecases.EventDeliveryConfig{
+			MaxAttempts:      2,
+			RetryBackoffBase: time.Minute,
+			RetryBackoffMax:  time.Hour,
+			WorkerID:         "worker-1",
+		})
+		return deliveryRepo, callbackService, subscription, service
+	}
+
+	t.Run("acknowledged callbacks are delivered and signed", func(t *testing.T) {
+		deliveryRepo, callbackService, subscription, service := setup(t, 200)
+		deliveryRepo.queue(subscription, createTestStatusEvent(7, message.StatusSent, time.Now()))
+		deliveryRepo.queue(subscription, createTestStatusEvent(8, message.StatusDelivered, time.Now()))
+
+		result, err := service.DeliverPendingEvents(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.ClaimedCount != 2 || result.DeliveredCount != 2 || len(result.Errors) != 0 {
+			t.Errorf("Expected 2 delivered events, got %+v", result)
+		}
+		for _, delivery := range deliveryRepo.deliveries {
+			if delivery.Status != message.EventDeliveryDelivered || delivery.Attempts != 1 || *delivery.LastStatusCode != 200 {
+				t.Errorf("Expected a delivered event after one attempt, got %+v", delivery)
+			}
+		}
+
+		for _, callback := range callbackService.callbacks {
+			if callback.URL != subscription.URL || callback.Signature != subscription.Sign(callback.Timestamp, callback.Body) {
+				t.Errorf("Expected a callback signed with the subscription secret, got %+v", callback)
+			}
+
+			var payload services.StatusEventPayload
+			if err := json.Unmarshal(callback.Body, &payload); err != nil {
+				t.Fatalf("Failed to decode payload: %v", err)
+			}
+			if payload.ID != callback.EventID || payload.Type != services.StatusEventType || payload.Data.PhoneNumber != "+905551234567" || payload.Data.From == nil {
+				t.Errorf("Unexpected payload %+v", payload)
+			}
+		}
+	})
+
+	t.Run("failed callbacks are retried with backoff until they fail", func(t *testing.T) {
+		deliveryRepo, callbackService, subscription, service := setup(t, 503)
+		delivery := deliveryRepo.queue(subscription, createTestStatusEvent(7, message.StatusSent, time.Now()))
+
+		result, err := service.DeliverPendingEvents(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.RetryingCount != 1 || len(result.Errors) != 1 {
+			t.Errorf("Expected 1 retrying event, got %+v", result)
+		}
+
+		stored := deliveryRepo.deliveries[0]
+		if stored.Status != message.EventDeliveryPending || stored.LastError == nil || !stored.NextAttemptAt.After(time.Now().Add(29*time.Second)) {
+			t.Errorf("Expected a pending delivery scheduled with backoff, got %+v", stored)
+		}
+
+		// Nothing is due until the backoff elapses
+		result, _ = service.DeliverPendingEvents(context.Background(), 10)
+		if result.ClaimedCount != 0 {
+			t.Errorf("Expected no due deliveries, got %d", result.ClaimedCount)
+		}
+
+		stored.NextAttemptAt = time.Now()
+		result, _ = service.DeliverPendingEvents(context.Background(), 10)
+		if result.FailedCount != 1 || deliveryRepo.deliveries[0].Status != message.EventDeliveryFailed {
+			t.Errorf("Expected the delivery to fail after max attempts, got %+v", result)
+		}
+		if len(callbackService.callbacks) != 2 || callbackService.callbacks[1].EventID != "7" || deliveryRepo.deliveries[0].ID != delivery.ID {
+			t.Errorf("Expected the same event to be sent twice, got %d callbacks", len(callbackService.callbacks))
+		}
+	})
+
+	t.Run("nothing to deliver", func(t *testing.T) {
+		_, callbackService, _, service := setup(t, 200)
+
+		result, err := service.DeliverPendingEvents(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.ClaimedCount != 0 || len(callbackService.callbacks) != 0 {
+			t.Errorf("Expected no callbacks, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,251 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+// Mock inbound message repository for testing
+type mockInboundMessageRepository struct {
+	mu       sync.Mutex
+	messages []*message.InboundMessage
+}
+
+func (m *mockInboundMessageRepository) Create(ctx context.Context, msg *message.InboundMessage) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if msg.ExternalID != nil {
+		for _, existing := range m.messages {
+			if existing.ExternalID != nil && *existing.ExternalID == *msg.ExternalID {
+				return false, nil
+			}
+		}
+	}
+	m.messages = append(m.messages, msg)
+	return true, nil
+}
+
+func (m *mockInboundMessageRepository) GetByExternalID(ctx context.Context, externalID string) (*message.InboundMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, msg := range m.messages {
+		if msg.ExternalID != nil && *msg.ExternalID == externalID {
+			return msg, nil
+		}
+	}
+	return nil, domainErrors.NewNotFoundError("inbound message not found")
+}
+
+func (m *mockInboundMessageRepository) ListByPhoneNumber(ctx context.Context, phoneNumber message.PhoneNumber, pagination repositories.Pagination) ([]*message.InboundMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*message.InboundMessage
+	for _, msg := range m.messages {
+		if msg.PhoneNumber == phoneNumber {
+			result = append(result, msg)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ReceivedAt.After(result[j].ReceivedAt) })
+
+	start := min(pagination.Offset, len(result))
+	end := min(start+pagination.Limit, len(result))
+	return result[start:end], nil
+}
+
+func TestInboundMessageService_ReceiveInboundMessage(t *testing.T) {
+	t.Run("opt-out keyword suppresses the sender", func(t *testing.T) {
+		inboundRepo := &mockInboundMessageRepository{}
+		suppressionRepo := newMockSuppressionRepository()
+		service := usecaseImpl.NewInboundMessageService(newMockMessageRepository(), inboundRepo, suppressionRepo, usecases.InboundConfig{DefaultRegion: "TR"})
+
+		result, err := service.ReceiveInboundMessage(context.Background(), usecases.ReceiveInboundMessageCommand{
+			From:       "0555 123 45 67",
+			Content:    "Stop",
+			ExternalID: "inbound-1",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.PhoneNumber != "+905551234567" || result.KeywordAction != "opt_out" {
+			t.Errorf("Expected a normalized opt-out reply, got %+v", result)
+		}
+		suppression, exists := suppressionRepo.suppressions["+905551234567"]
+		if !exists || suppression.Source != message.SuppressionSourceKeyword || suppression.Reason != "replied STOP" {
+			t.Errorf("Expected a keyword suppression, got %+v", suppression)
+		}
+		if len(inboundRepo.messages) != 1 {
+			t.Errorf("Expected the reply to be stored, got %d", len(inboundRepo.messages))
+		}
+	})
+
+	t.Run("opt-in keyword lifts a keyword opt-out only", func(t *testing.T) {
+		suppressionRepo := newMockSuppressionRepository("+905559876543") // Suppressed through the API
+		suppressionRepo.suppressions["+905551234567"] = message.Suppression{
+			PhoneNumber: "+905551234567",
+			Source:      message.SuppressionSourceKeyword,
+		}
+		service := usecaseImpl.NewInboundMessageService(newMockMessageRepository(), &mockInboundMessageRepository{}, suppressionRepo, usecases.InboundConfig{})
+
+		for _, from := range []string{"+905551234567", "+905559876543"} {
+			if _, err := service.ReceiveInboundMessage(context.Background(), usecases.ReceiveInboundMessageCommand{From: from, Content: "START"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		if _, exists := suppressionRepo.suppressions["+905551234567"]; exists {
+			t.Error("Expected the keyword opt-out to be lifted")
+		}
+		if _, exists := suppressionRepo.suppressions["+905559876543"]; !exists {
+			t.Error("Expected the API suppression to stay")
+		}
+	})
+
+	t.Run("redelivered callback does not apply its keyword again", func(t *testing.T) {
+		inboundRepo := &mockInboundMessageRepository{}
+		suppressionRepo := newMockSuppressionRepository()
+		service := usecaseImpl.NewInboundMessageService(newMockMessageRepository(), inboundRepo, suppressionRepo, usecases.InboundConfig{})
+
+		stop := usecases.ReceiveInboundMessageCommand{From: "+905551234567", Content: "STOP", ExternalID: "inbound-1"}
+		first, err := service.ReceiveInboundMessage(context.Background(), stop)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := service.ReceiveInboundMessage(context.Background(), usecases.ReceiveInboundMessageCommand{From: "+905551234567", Content: "START", ExternalID: "inbound-2"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		again, err := service.ReceiveInboundMessage(context.Background(), stop)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if again.ID != first.ID || len(inboundRepo.messages) != 2 {
+			t.Errorf("Expected the stored reply to be returned, got %+v", again)
+		}
+		if _, exists := suppressionRepo.suppressions["+905551234567"]; exists {
+			t.Error("Expected the later START to win over the redelivered STOP")
+		}
+	})
+
+	t.Run("custom keywords", func(t *testing.T) {
+		keywords, _ := message.NewKeywords([]string{"HALT"}, []string{"RESUME"})
+		suppressionRepo := newMockSuppressionRepository()
+		service := usecaseImpl.NewInboundMessageService(newMockMessageRepository(), &mockInboundMessageRepository{}, suppressionRepo, usecases.InboundConfig{Keywords: keywords})
+
+		for _, content := range []string{"STOP", "halt"} {
+			if _, err := service.ReceiveInboundMessage(context.Background(), usecases.ReceiveInboundMessageCommand{From: "+905551234567", Content: content}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		if got := suppressionRepo.suppressions["+905551234567"]; got.Reason != "replied HALT" {
+			t.Errorf("Expected only the custom keyword to suppress, got %+v", got)
+		}
+	})
+
+	t.Run("invalid sender is rejected", func(t *testing.T) {
+		inboundRepo := &mockInboundMessageRepository{}
+		service := usecaseImpl.NewInboundMessageService(newMockMessageRepository(), inboundRepo, newMockSuppressionRepository(), usecases.InboundConfig{})
+
+		var validationErr domainErrors.ValidationError
+		if _, err := service.ReceiveInboundMessage(context.Background(), usecases.ReceiveInboundMessageCommand{From: "12ab", Content: "STOP"}); !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+		if len(inboundRepo.messages) != 0 {
+			t.Errorf("Expected nothing to be stored, got %d", len(inboundRepo.messages))
+		}
+	})
+}
+
+func TestInboundMessageService_GetConversation(t *testing.T) {
+	messageRepo := newMockMessageRepository()
+	inboundRepo := &mockInboundMessageRepository{}
+	suppressionRepo := newMockSuppressionRepository()
+	service := usecaseImpl.NewInboundMessageService(messageRepo, inboundRepo, suppressionRepo, usecases.InboundConfig{DefaultRegion: "TR"})
+
+	start := time.Now().Add(-time.Hour)
+	for i, content := range []string{"Your code is 1234", "Your order shipped"} {
+		msg, err := message.NewMessage("+905551234567", message.Content(content))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		msg.CreatedAt = start.Add(time.Duration(i*2) * time.Minute) // Minutes 0 and 2
+		messageRepo.messages[msg.ID] = msg
+	}
+	other, _ := message.NewMessage("+905559876543", "Not part of the conversation")
+	messageRepo.messages[other.ID] = other
+
+	for _, reply := range []usecases.ReceiveInboundMessageCommand{
+		{From: "+905551234567", Content: "Thanks", ReceivedAt: start.Add(time.Minute)},       // Minute 1
+		{From: "+905551234567", Content: "STOP", ReceivedAt: start.Add(3 * time.Minute)},     // Minute 3
+		{From: "+905559876543", Content: "Not ours", ReceivedAt: start.Add(4 * time.Minute)}, // Other number
+	} {
+		if _, err := service.ReceiveInboundMessage(context.Background(), reply); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	t.Run("entries interleave by time, most recent first", func(t *testing.T) {
+		result, err := service.GetConversation(context.Background(), usecases.ConversationQuery{PhoneNumber: "0555 123 45 67"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var directions []string
+		for _, entry := range result.Entries {
+			directions = append(directions, entry.Direction)
+		}
+		expected := []string{"inbound", "outbound", "inbound", "outbound"}
+		if len(directions) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, directions)
+		}
+		for i := range expected {
+			if directions[i] != expected[i] {
+				t.Fatalf("Expected %v, got %v", expected, directions)
+			}
+		}
+
+		if result.Entries[0].Inbound.Content != "STOP" || result.Entries[3].Outbound.Content != "Your code is 1234" {
+			t.Errorf("Unexpected entry order %+v", result.Entries)
+		}
+		if result.Suppression == nil || result.Suppression.Source != "keyword" {
+			t.Errorf("Expected the keyword suppression, got %+v", result.Suppression)
+		}
+		if result.HasMore {
+			t.Error("Expected no more entries")
+		}
+	})
+
+	t.Run("pages through the merged timeline", func(t *testing.T) {
+		result, err := service.GetConversation(context.Background(), usecases.ConversationQuery{PhoneNumber: "+905551234567", Limit: 2, Offset: 1})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result.Entries) != 2 || !result.HasMore {
+			t.Fatalf("Expected 2 entries with more to come, got %d (hasMore %v)", len(result.Entries), result.HasMore)
+		}
+		if result.Entries[0].Outbound.Content != "Your order shipped" || result.Entries[1].Inbound.Content != "Thanks" {
+			t.Errorf("Unexpected page %+v", result.Entries)
+		}
+	})
+
+	t.Run("negative offset is rejected", func(t *testing.T) {
+		var validationErr domainErrors.ValidationError
+		_, err := service.GetConversation(context.Background(), usecases.ConversationQuery{PhoneNumber: "+905551234567", Offset: -1})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
@@ -2,11 +2,20 @@ package usecases
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
@@ -16,28 +25,65 @@ import (
 
 // Mock repository for testing
 type mockMessageRepository struct {
-	messages     map[message.MessageID]*message.Message
-	shouldFailOp string
-	callCount    map[string]int
+	mu            sync.Mutex
+	messages      map[message.MessageID]*message.Message
+	claimOverride []*message.Message // Claimed as-is by ClaimPendingMessages when set
+	leases        map[message.MessageID]string
+	history       map[message.MessageID][]message.StatusChange
+	shouldFailOp  string
+	callCount     map[string]int
 }
 
 func newMockMessageRepository() *mockMessageRepository {
 	return &mockMessageRepository{
 		messages:  make(map[message.MessageID]*message.Message),
+		leases:    make(map[message.MessageID]string),
+		history:   make(map[message.MessageID][]message.StatusChange),
 		callCount: make(map[string]int),
 	}
 }
 
+// saveStatusChanges mirrors the repository storing a message's pending status changes
+func (m *mockMessageRepository) saveStatusChanges(ctx context.Context, msg *message.Message) {
+	for _, change := range msg.PendingStatusChanges() {
+		change.Trigger = message.TriggerFromContext(ctx)
+		m.history[msg.ID] = append(m.history[msg.ID], change)
+	}
+	msg.ClearStatusChanges()
+}
+
 func (m *mockMessageRepository) Create(ctx context.Context, msg *message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["Create"]++
 	if m.shouldFailOp == "Create" {
 		return errors.New("mock create error")
 	}
 	m.messages[msg.ID] = msg
+	m.saveStatusChanges(ctx, msg)
+	if m.shouldFailOp == "CreateAfterInsert" {
+		return errors.New("mock create error after insert")
+	}
+	return nil
+}
+
+func (m *mockMessageRepository) CreateBatch(ctx context.Context, msgs []*message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["CreateBatch"]++
+	if m.shouldFailOp == "CreateBatch" {
+		return errors.New("mock create batch error")
+	}
+	for _, msg := range msgs {
+		m.messages[msg.ID] = msg
+		m.saveStatusChanges(ctx, msg)
+	}
 	return nil
 }
 
 func (m *mockMessageRepository) GetByID(ctx context.Context, id message.MessageID) (*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["GetByID"]++
 	if m.shouldFailOp == "GetByID" {
 		return nil, errors.New("mock get error")
@@ -50,6 +96,8 @@ func (m *mockMessageRepository) GetByID(ctx context.Context, id message.MessageI
 }
 
 func (m *mockMessageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["GetPendingMessages"]++
 	if m.shouldFailOp == "GetPendingMessages" {
 		return nil, errors.New("mock get pending error")
@@ -57,8 +105,9 @@ func (m *mockMessageRepository) GetPendingMessages(ctx context.Context, limit in
 
 	var pending []*message.Message
 	count := 0
+	now := time.Now()
 	for _, msg := range m.messages {
-		if msg.Status == message.StatusPending && count < limit {
+		if msg.Status == message.StatusPending && msg.IsReadyForAttempt(now) && !msg.IsExpired(now) && count < limit {
 			pending = append(pending, msg)
 			count++
 		}
@@ -66,20 +115,170 @@ func (m *mockMessageRepository) GetPendingMessages(ctx context.Context, limit in
 	return pending, nil
 }
 
+func (m *mockMessageRepository) ClaimPendingMessages(ctx context.Context, workerID string, priorities []message.Priority, limit int, leaseDuration time.Duration) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["ClaimPendingMessages"]++
+	if m.shouldFailOp == "ClaimPendingMessages" {
+		return nil, errors.New("mock claim error")
+	}
+
+	candidates := m.claimOverride
+	if candidates == nil {
+		now := time.Now()
+		for _, msg := range m.messages {
+			if msg.Status == message.StatusPending && msg.IsReadyForAttempt(now) && !msg.IsExpired(now) &&
+				(len(priorities) == 0 || slices.Contains(priorities, msg.Priority)) {
+				candidates = append(candidates, msg)
+			}
+		}
+
+		// Most urgent first, then oldest first
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Priority.Rank() != candidates[j].Priority.Rank() {
+				return candidates[i].Priority.Rank() < candidates[j].Priority.Rank()
+			}
+			return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+		})
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+	}
+
+	var claimed []*message.Message
+	for _, msg := range candidates {
+		if err := msg.Claim(workerID, time.Now().Add(leaseDuration)); err != nil {
+			continue
+		}
+		m.leases[msg.ID] = workerID
+		m.saveStatusChanges(ctx, msg)
+		claimed = append(claimed, msg)
+	}
+	return claimed, nil
+}
+
+func (m *mockMessageRepository) UpdateClaimed(ctx context.Context, msg *message.Message, workerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["UpdateClaimed"]++
+	if m.shouldFailOp == "UpdateClaimed" {
+		return errors.New("mock update claimed error")
+	}
+	if m.leases[msg.ID] != workerID {
+		return domainErrors.NewConflictError("message %s is no longer leased to worker %s", msg.ID, workerID)
+	}
+	if msg.Status != message.StatusProcessing {
+		delete(m.leases, msg.ID)
+	}
+	m.messages[msg.ID] = msg
+	m.saveStatusChanges(ctx, msg)
+	return nil
+}
+
+func (m *mockMessageRepository) UpdatePending(ctx context.Context, msg *message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["UpdatePending"]++
+	if m.shouldFailOp == "UpdatePending" {
+		return errors.New("mock update pending error")
+	}
+	if _, claimed := m.leases[msg.ID]; claimed {
+		return domainErrors.NewConflictError("message %s is no longer pending", msg.ID)
+	}
+	m.messages[msg.ID] = msg
+	m.saveStatusChanges(ctx, msg)
+	return nil
+}
+
+func (m *mockMessageRepository) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["ReleaseExpiredLeases"]++
+	if m.shouldFailOp == "ReleaseExpiredLeases" {
+		return 0, errors.New("mock release leases error")
+	}
+
+	released := int64(0)
+	now := time.Now()
+	for _, msg := range m.messages {
+		if msg.IsLeaseExpired(now) {
+			msg.Status = message.StatusPending
+			msg.LockedBy = nil
+			msg.LeaseUntil = nil
+			delete(m.leases, msg.ID)
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (m *mockMessageRepository) GetScheduledMessages(ctx context.Context, pagination repositories.Pagination) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["GetScheduledMessages"]++
+	if m.shouldFailOp == "GetScheduledMessages" {
+		return nil, errors.New("mock get scheduled error")
+	}
+
+	var scheduled []*message.Message
+	now := time.Now()
+	for _, msg := range m.messages {
+		if msg.IsScheduled(now) && len(scheduled) < pagination.Limit {
+			scheduled = append(scheduled, msg)
+		}
+	}
+	return scheduled, nil
+}
+
 func (m *mockMessageRepository) Update(ctx context.Context, msg *message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["Update"]++
 	if m.shouldFailOp == "Update" {
 		return errors.New("mock update error")
 	}
 	m.messages[msg.ID] = msg
+	m.saveStatusChanges(ctx, msg)
+	return nil
+}
+
+func (m *mockMessageRepository) GetByExternalID(ctx context.Context, externalID string) (*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["GetByExternalID"]++
+	for _, msg := range m.messages {
+		if msg.ExternalID != nil && *msg.ExternalID == externalID {
+			copied := *msg // Detached like a row read from the database
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.NewNotFoundError("message not found")
+}
+
+func (m *mockMessageRepository) UpdateSent(ctx context.Context, msg *message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["UpdateSent"]++
+	if m.shouldFailOp == "UpdateSent" {
+		return errors.New("mock update sent error")
+	}
+	if stored, exists := m.messages[msg.ID]; !exists || stored.Status != message.StatusSent {
+		return domainErrors.NewConflictError("message %s is no longer awaiting a delivery receipt", msg.ID)
+	}
+	m.messages[msg.ID] = msg
+	m.saveStatusChanges(ctx, msg)
 	return nil
 }
 
 func (m *mockMessageRepository) GetSentMessages(ctx context.Context, pagination repositories.Pagination) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return nil, nil // Not needed for these tests
 }
 
 func (m *mockMessageRepository) GetByStatus(ctx context.Context, status message.Status, pagination repositories.Pagination) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["GetByStatus"]++
 	if m.shouldFailOp == "GetByStatus" {
 		return nil, errors.New("mock get by status error")
@@ -99,10 +298,24 @@ func (m *mockMessageRepository) GetByStatus(ctx context.Context, status message.
 }
 
 func (m *mockMessageRepository) GetByPhoneNumber(ctx context.Context, phoneNumber message.PhoneNumber, pagination repositories.Pagination) ([]*message.Message, error) {
-	return nil, nil // Not needed for these tests
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*message.Message
+	for _, msg := range m.messages {
+		if msg.PhoneNumber == phoneNumber {
+			result = append(result, msg)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+
+	start := min(pagination.Offset, len(result))
+	end := min(start+pagination.Limit, len(result))
+	return result[start:end], nil
 }
 
 func (m *mockMessageRepository) CountByStatus(ctx context.Context, status message.Status) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.callCount["CountByStatus"]++
 	if m.shouldFailOp == "CountByStatus" {
 		return 0, errors.New("mock count error")
@@ -117,11 +330,461 @@ func (m *mockMessageRepository) CountByStatus(ctx context.Context, status messag
 	return count, nil
 }
 
+func (m *mockMessageRepository) ExpireOverdueMessages(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["ExpireOverdueMessages"]++
+	if m.shouldFailOp == "ExpireOverdueMessages" {
+		return 0, errors.New("mock expire error")
+	}
+
+	expired := int64(0)
+	now := time.Now()
+	for _, msg := range m.messages {
+		if msg.Status == message.StatusPending && msg.IsExpired(now) {
+			msg.Status = message.StatusExpired
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (m *mockMessageRepository) GetFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter, pagination repositories.Pagination) ([]*message.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["GetFailedMessages"]++
+	if m.shouldFailOp == "GetFailedMessages" {
+		return nil, errors.New("mock get failed error")
+	}
+
+	var result []*message.Message
+	for _, msg := range m.messages {
+		if matchesDeadLetterFilter(msg, filter) && len(result) < pagination.Limit {
+			result = append(result, msg)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMessageRepository) CountFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["CountFailedMessages"]++
+
+	count := int64(0)
+	for _, msg := range m.messages {
+		if matchesDeadLetterFilter(msg, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockMessageRepository) RequeueFailedMessages(ctx context.Context, filter repositories.DeadLetterFilter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["RequeueFailedMessages"]++
+	if m.shouldFailOp == "RequeueFailedMessages" {
+		return 0, errors.New("mock requeue error")
+	}
+
+	requeued := int64(0)
+	for _, msg := range m.messages {
+		if matchesDeadLetterFilter(msg, filter) {
+			msg.Requeue()
+			m.saveStatusChanges(ctx, msg)
+			requeued++
+		}
+	}
+	return requeued, nil
+}
+
+// matchesDeadLetterFilter mirrors the repository's dead-letter filtering
+func matchesDeadLetterFilter(msg *message.Message, filter repositories.DeadLetterFilter) bool {
+	if msg.Status != message.StatusFailed {
+		return false
+	}
+	if filter.PhoneNumber != nil && msg.PhoneNumber.String() != filter.PhoneNumber.String() {
+		return false
+	}
+	if filter.ErrorClass != nil && (msg.LastErrorClass == nil || *msg.LastErrorClass != *filter.ErrorClass) {
+		return false
+	}
+	if filter.FailedFrom != nil && msg.UpdatedAt.Before(*filter.FailedFrom) {
+		return false
+	}
+	if filter.FailedTo != nil && !msg.UpdatedAt.Before(*filter.FailedTo) {
+		return false
+	}
+	return true
+}
+
+func (m *mockMessageRepository) CountScheduled(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["CountScheduled"]++
+	if m.shouldFailOp == "CountScheduled" {
+		return 0, errors.New("mock count scheduled error")
+	}
+
+	count := int64(0)
+	now := time.Now()
+	for _, msg := range m.messages {
+		if msg.IsScheduled(now) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockMessageRepository) GetStatusHistory(ctx context.Context, id message.MessageID) ([]message.StatusChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount["GetStatusHistory"]++
+	if m.shouldFailOp == "GetStatusHistory" {
+		return nil, errors.New("mock get status history error")
+	}
+	return m.history[id], nil
+}
+
 func (m *mockMessageRepository) DeleteByID(ctx context.Context, id message.MessageID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.messages, id)
 	return nil
 }
 
+// Mock idempotency repository for testing
+type mockIdempotencyRepository struct {
+	records     map[string]*repositories.IdempotencyRecord
+	completeErr error
+}
+
+func newMockIdempotencyRepository() *mockIdempotencyRepository {
+	return &mockIdempotencyRepository{
+		records: make(map[string]*repositories.IdempotencyRecord),
+	}
+}
+
+func (m *mockIdempotencyRepository) Reserve(ctx context.Context, record *repositories.IdempotencyRecord) (bool, error) {
+	if _, exists := m.records[record.Key]; exists {
+		return false, nil
+	}
+	stored := *record
+	m.records[record.Key] = &stored
+	return true, nil
+}
+
+func (m *mockIdempotencyRepository) GetByKey(ctx context.Context, key string) (*repositories.IdempotencyRecord, error) {
+	record, exists := m.records[key]
+	if !exists {
+		return nil, errors.New("idempotency key not found")
+	}
+	return record, nil
+}
+
+func (m *mockIdempotencyRepository) Complete(ctx context.Context, key string, messageID message.MessageID, response []byte) error {
+	if m.completeErr != nil {
+		return m.completeErr
+	}
+	record, exists := m.records[key]
+	if !exists {
+		return errors.New("idempotency key not found")
+	}
+	record.MessageID = &messageID
+	record.Response = response
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Release(ctx context.Context, key string) error {
+	if record, exists := m.records[key]; exists && record.MessageID == nil {
+		delete(m.records, key)
+	}
+	return nil
+}
+
+// Mock delivery attempt repository for testing
+type mockAttemptRepository struct {
+	attempts []*message.Attempt
+}
+
+func newMockAttemptRepository() *mockAttemptRepository {
+	return &mockAttemptRepository{}
+}
+
+func (m *mockAttemptRepository) Create(ctx context.Context, attempt *message.Attempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+
+func (m *mockAttemptRepository) GetByMessageID(ctx context.Context, messageID message.MessageID, pagination repositories.Pagination) ([]*message.Attempt, error) {
+	var result []*message.Attempt
+	for _, attempt := range m.attempts {
+		if attempt.MessageID == messageID && len(result) < pagination.Limit {
+			result = append(result, attempt)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockAttemptRepository) CountByMessageID(ctx context.Context, messageID message.MessageID) (int64, error) {
+	count := int64(0)
+	for _, attempt := range m.attempts {
+		if attempt.MessageID == messageID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Mock import job repository for testing, safe for use by background imports
+type mockImportJobRepository struct {
+	mu        sync.Mutex
+	jobs      map[message.ImportJobID]message.ImportJob
+	rowErrors []message.ImportRowError
+	updates   int
+	failOp    string
+	messages  *mockMessageRepository // Stores the messages of saved chunks
+}
+
+func newMockImportJobRepository() *mockImportJobRepository {
+	return &mockImportJobRepository{
+		jobs:     make(map[message.ImportJobID]message.ImportJob),
+		messages: newMockMessageRepository(),
+	}
+}
+
+// newMockImportJobRepositoryFor creates an import job repository storing chunk messages in messages
+func newMockImportJobRepositoryFor(messages *mockMessageRepository) *mockImportJobRepository {
+	m := newMockImportJobRepository()
+	m.messages = messages
+	return m
+}
+
+func (m *mockImportJobRepository) Create(ctx context.Context, job *message.ImportJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = *job
+	return nil
+}
+
+func (m *mockImportJobRepository) Update(ctx context.Context, job *message.ImportJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failOp == "Update" {
+		return errors.New("mock update error")
+	}
+	if _, exists := m.jobs[job.ID]; !exists {
+		return domainErrors.NewNotFoundError("import job not found for update")
+	}
+	m.jobs[job.ID] = *job
+	m.updates++
+	return nil
+}
+
+func (m *mockImportJobRepository) GetByID(ctx context.Context, id message.ImportJobID) (*message.ImportJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, exists := m.jobs[id]
+	if !exists {
+		return nil, domainErrors.NewNotFoundError("import job not found")
+	}
+	return &job, nil
+}
+
+func (m *mockImportJobRepository) SaveChunk(ctx context.Context, job *message.ImportJob, msgs []*message.Message, rowErrors []message.ImportRowError) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failOp == "SaveChunk" {
+		return errors.New("mock save chunk error")
+	}
+	if err := m.messages.CreateBatch(ctx, msgs); err != nil {
+		return err
+	}
+	m.rowErrors = append(m.rowErrors, rowErrors...)
+	m.jobs[job.ID] = *job
+	m.updates++
+	return nil
+}
+
+func (m *mockImportJobRepository) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var failed int64
+	for id, job := range m.jobs {
+		if job.Status == message.ImportStatusProcessing && job.UpdatedAt.Before(updatedBefore) {
+			job.Fail(reason)
+			m.jobs[id] = job
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (m *mockImportJobRepository) GetRowErrors(ctx context.Context, id message.ImportJobID, pagination repositories.Pagination) ([]message.ImportRowError, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matching []message.ImportRowError
+	for _, rowErr := range m.rowErrors {
+		if rowErr.JobID == id {
+			matching = append(matching, rowErr)
+		}
+	}
+	if pagination.Offset >= len(matching) {
+		return nil, nil
+	}
+	return matching[pagination.Offset:min(pagination.Offset+pagination.Limit, len(matching))], nil
+}
+
+// Mock template repository for testing
+type mockTemplateRepository struct {
+	templates map[message.TemplateID]message.Template
+}
+
+func newMockTemplateRepository() *mockTemplateRepository {
+	return &mockTemplateRepository{
+		templates: make(map[message.TemplateID]message.Template),
+	}
+}
+
+func (m *mockTemplateRepository) Create(ctx context.Context, template *message.Template) error {
+	for _, existing := range m.templates {
+		if existing.Name == template.Name {
+			return domainErrors.NewConflictError("template named %s already exists", template.Name)
+		}
+	}
+	m.templates[template.ID] = *template
+	return nil
+}
+
+func (m *mockTemplateRepository) Update(ctx context.Context, template *message.Template) error {
+	existing, exists := m.templates[template.ID]
+	if !exists {
+		return domainErrors.NewNotFoundError("template not found")
+	}
+	if existing.Version >= template.Version {
+		return domainErrors.NewConflictError("template %s was changed concurrently", template.ID)
+	}
+	m.templates[template.ID] = *template
+	return nil
+}
+
+func (m *mockTemplateRepository) Delete(ctx context.Context, id message.TemplateID) error {
+	if _, exists := m.templates[id]; !exists {
+		return domainErrors.NewNotFoundError("template not found")
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *mockTemplateRepository) GetByID(ctx context.Context, id message.TemplateID) (*message.Template, error) {
+	template, exists := m.templates[id]
+	if !exists {
+		return nil, domainErrors.NewNotFoundError("template not found")
+	}
+	return &template, nil
+}
+
+func (m *mockTemplateRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Template, error) {
+	var result []*message.Template
+	for _, template := range m.templates {
+		template := template
+		result = append(result, &template)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	if pagination.Offset >= len(result) {
+		return nil, nil
+	}
+	return result[pagination.Offset:min(pagination.Offset+pagination.Limit, len(result))], nil
+}
+
+func (m *mockTemplateRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.templates)), nil
+}
+
+type mockSuppressionRepository struct {
+	mu           sync.Mutex
+	suppressions map[message.PhoneNumber]message.Suppression
+	failWith     error // Returned by Get when set
+}
+
+func newMockSuppressionRepository(phoneNumbers ...string) *mockSuppressionRepository {
+	m := &mockSuppressionRepository{
+		suppressions: make(map[message.PhoneNumber]message.Suppression),
+	}
+	for _, phoneNumber := range phoneNumbers {
+		m.suppressions[message.PhoneNumber(phoneNumber)] = message.Suppression{
+			PhoneNumber: message.PhoneNumber(phoneNumber),
+			Reason:      "replied STOP",
+			Source:      message.SuppressionSourceAPI,
+			CreatedAt:   time.Now(),
+		}
+	}
+	return m
+}
+
+func (m *mockSuppressionRepository) Add(ctx context.Context, suppression *message.Suppression) (bool, error) {
+	added, err := m.AddBatch(ctx, []*message.Suppression{suppression})
+	return added > 0, err
+}
+
+func (m *mockSuppressionRepository) AddBatch(ctx context.Context, suppressions []*message.Suppression) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var added int64
+	for _, suppression := range suppressions {
+		if _, exists := m.suppressions[suppression.PhoneNumber]; !exists {
+			m.suppressions[suppression.PhoneNumber] = *suppression
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (m *mockSuppressionRepository) Remove(ctx context.Context, phoneNumber message.PhoneNumber) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.suppressions[phoneNumber]; !exists {
+		return domainErrors.NewNotFoundError("phone number %s is not suppressed", phoneNumber)
+	}
+	delete(m.suppressions, phoneNumber)
+	return nil
+}
+
+func (m *mockSuppressionRepository) Get(ctx context.Context, phoneNumber message.PhoneNumber) (*message.Suppression, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	suppression, exists := m.suppressions[phoneNumber]
+	if !exists {
+		return nil, domainErrors.NewNotFoundError("suppression not found")
+	}
+	return &suppression, nil
+}
+
+func (m *mockSuppressionRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Suppression, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*message.Suppression
+	for _, suppression := range m.suppressions {
+		suppression := suppression
+		result = append(result, &suppression)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PhoneNumber < result[j].PhoneNumber })
+	if pagination.Offset >= len(result) {
+		return nil, nil
+	}
+	return result[pagination.Offset:min(pagination.Offset+pagination.Limit, len(result))], nil
+}
+
+func (m *mockSuppressionRepository) Count(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.suppressions)), nil
+}
+
 // Mock cache service for testing
 type mockCacheServiceForManagement struct {
 	data       map[string]interface{}
@@ -183,6 +846,12 @@ func (m *mockCacheServiceForManagement) GetJSON(ctx context.Context, key string,
 		return errors.New("cache getJSON failed")
 	}
 	if value, exists := m.data[key]; exists {
+		if stored, ok := value.(services.SentMessageCacheData); ok {
+			if cacheData, ok := dest.(*services.SentMessageCacheData); ok {
+				*cacheData = stored
+				return nil
+			}
+		}
 		if cacheData, ok := dest.(*services.SentMessageCacheData); ok && value != nil {
 			// Simple mock - return a dummy value
 			cacheData.MessageID = "test-id"
@@ -215,10 +884,20 @@ func (m *mockCacheServiceForManagement) ZRevRange(ctx context.Context, key strin
 	if m.shouldFail {
 		return nil, errors.New("cache zrevrange failed")
 	}
-	// Simple mock implementation - return empty slice
-	return []string{}, nil
-}
-
+	// Members by descending score
+	members := make([]string, 0, len(m.sortedSets[key]))
+	for member := range m.sortedSets[key] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return m.sortedSets[key][members[i]] > m.sortedSets[key][members[j]]
+	})
+	if start >= int64(len(members)) {
+		return []string{}, nil
+	}
+	return members[start:min(stop+1, int64(len(members)))], nil
+}
+
 func (m *mockCacheServiceForManagement) ZRem(ctx context.Context, key string, members ...string) error {
 	if m.shouldFail {
 		return errors.New("cache zrem failed")
@@ -321,6 +1000,82 @@ func TestMessageManagementService_CreateMessage(t *testing.T) {
 			expectError:    true,
 			errorContains:  "invalid phone number",
 		},
+		{
+			name: "scheduled creation",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				SendAt:      timePtr(time.Now().Add(time.Hour)),
+			},
+			shouldFailRepo: false,
+			expectError:    false,
+		},
+		{
+			name: "creation with ttl",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				TTL:         15 * time.Minute,
+			},
+			shouldFailRepo: false,
+			expectError:    false,
+		},
+		{
+			name: "expiry and ttl together",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				ExpiresAt:   timePtr(time.Now().Add(time.Hour)),
+				TTL:         15 * time.Minute,
+			},
+			shouldFailRepo: false,
+			expectError:    true,
+			errorContains:  "cannot be used together",
+		},
+		{
+			name: "expiry in the past",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				ExpiresAt:   timePtr(time.Now().Add(-time.Minute)),
+			},
+			shouldFailRepo: false,
+			expectError:    true,
+			errorContains:  "must be in the future",
+		},
+		{
+			name: "expiry before send time",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				SendAt:      timePtr(time.Now().Add(2 * time.Hour)),
+				ExpiresAt:   timePtr(time.Now().Add(time.Hour)),
+			},
+			shouldFailRepo: false,
+			expectError:    true,
+			errorContains:  "must be after send time",
+		},
+		{
+			name: "creation with priority",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				Priority:    "Critical",
+			},
+			shouldFailRepo: false,
+			expectError:    false,
+		},
+		{
+			name: "invalid priority",
+			command: usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     "Test message",
+				Priority:    "urgent",
+			},
+			shouldFailRepo: false,
+			expectError:    true,
+			errorContains:  "invalid priority",
+		},
 		{
 			name: "repository error",
 			command: usecases.CreateMessageCommand{
@@ -340,7 +1095,7 @@ func TestMessageManagementService_CreateMessage(t *testing.T) {
 				mockRepo.shouldFailOp = "Create"
 			}
 
-			service := usecaseImpl.NewMessageManagementService(mockRepo, newMockCacheServiceForManagement())
+			service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
 			ctx := context.Background()
 
 			result, err := service.CreateMessage(ctx, tt.command)
@@ -370,15 +1125,431 @@ func TestMessageManagementService_CreateMessage(t *testing.T) {
 					if result.Content != tt.command.Content {
 						t.Errorf("Expected content %s, got %s", tt.command.Content, result.Content)
 					}
+					if tt.command.SendAt != nil && (result.SendAt == nil || !result.SendAt.Equal(*tt.command.SendAt)) {
+						t.Errorf("Expected send at %v, got %v", tt.command.SendAt, result.SendAt)
+					}
+					if tt.command.TTL > 0 && result.ExpiresAt == nil {
+						t.Errorf("Expected expires at to be set from ttl")
+					}
+					expectedPriority := "normal"
+					if tt.command.Priority != "" {
+						expectedPriority = strings.ToLower(tt.command.Priority)
+					}
+					if result.Priority != expectedPriority {
+						t.Errorf("Expected priority %s, got %s", expectedPriority, result.Priority)
+					}
 				}
 			}
 		})
 	}
 }
 
+func TestMessageManagementService_CreateTemplatedMessage(t *testing.T) {
+	newService := func() (usecases.MessageManagementUseCase, *mockMessageRepository, *message.Template) {
+		mockRepo := newMockMessageRepository()
+		templateRepo := newMockTemplateRepository()
+		template, _ := message.NewTemplate("verification", "Your code is {{code}}, {{ name }}")
+		template.Version = 3
+		templateRepo.templates[template.ID] = *template
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), templateRepo, newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+		return service, mockRepo, template
+	}
+
+	t.Run("renders the template and records its version", func(t *testing.T) {
+		service, mockRepo, template := newService()
+		templateID := uuid.MustParse(template.ID.String())
+
+		result, err := service.CreateMessage(context.Background(), usecases.CreateMessageCommand{
+			PhoneNumber: "+905551234567",
+			TemplateID:  &templateID,
+			Variables:   map[string]string{"code": "4821", "name": "Ada", "unused": "x"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.Content != "Your code is 4821, Ada" {
+			t.Errorf("Expected rendered content, got %q", result.Content)
+		}
+		if result.TemplateID == nil || *result.TemplateID != templateID {
+			t.Errorf("Expected template ID %s, got %v", templateID, result.TemplateID)
+		}
+		if result.TemplateVersion == nil || *result.TemplateVersion != 3 {
+			t.Errorf("Expected template version 3, got %v", result.TemplateVersion)
+		}
+
+		stored := mockRepo.messages[message.MessageID(result.ID.String())]
+		if stored.TemplateVersion == nil || *stored.TemplateVersion != 3 {
+			t.Errorf("Expected stored message to remember template version 3, got %v", stored.TemplateVersion)
+		}
+	})
+
+	t.Run("rejects invalid template requests", func(t *testing.T) {
+		service, _, template := newService()
+		templateID := uuid.MustParse(template.ID.String())
+		unknownID := uuid.New()
+
+		tests := []struct {
+			name          string
+			command       usecases.CreateMessageCommand
+			errorContains string
+		}{
+			{
+				name:          "missing variables",
+				command:       usecases.CreateMessageCommand{PhoneNumber: "+905551234567", TemplateID: &templateID, Variables: map[string]string{"name": "Ada"}},
+				errorContains: "missing template variables: code",
+			},
+			{
+				name:          "content and template",
+				command:       usecases.CreateMessageCommand{PhoneNumber: "+905551234567", Content: "Hello", TemplateID: &templateID},
+				errorContains: "cannot be used together",
+			},
+			{
+				name:          "variables without template",
+				command:       usecases.CreateMessageCommand{PhoneNumber: "+905551234567", Content: "Hello", Variables: map[string]string{"code": "1"}},
+				errorContains: "variables can only be used with a templateId",
+			},
+			{
+				name:          "unknown template",
+				command:       usecases.CreateMessageCommand{PhoneNumber: "+905551234567", TemplateID: &unknownID},
+				errorContains: "not found",
+			},
+			{
+				name:          "rendered content too long",
+				command:       usecases.CreateMessageCommand{PhoneNumber: "+905551234567", TemplateID: &templateID, Variables: map[string]string{"code": strings.Repeat("9", 500), "name": "Ada"}},
+				errorContains: "too long",
+			},
+		}
+
+		for _, tt := range tests {
+			_, err := service.CreateMessage(context.Background(), tt.command)
+			var validationErr domainErrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("%s: expected validation error, got %v", tt.name, err)
+				continue
+			}
+			if !contains(err.Error(), tt.errorContains) {
+				t.Errorf("%s: expected error containing %q, got %q", tt.name, tt.errorContains, err.Error())
+			}
+		}
+	})
+}
+
+func TestMessageManagementService_CreateMessage_Segments(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxSegments int
+		content     string
+		encoding    string
+		segments    int
+		expectError bool
+	}{
+		{"single GSM-7 segment", 0, "Your order has shipped", "GSM-7", 1, false},
+		{"Turkish content is UCS-2", 0, "Siparişiniz kargoya verildi", "UCS-2", 1, false},
+		{"concatenated within the default limit", 0, strings.Repeat("a", 459), "GSM-7", 3, false},
+		{"over the default limit", 0, strings.Repeat("a", 460), "", 0, true},
+		{"over the configured limit", 1, strings.Repeat("ş", 71), "", 0, true},
+		{"configured limit above the domain maximum", 50, strings.Repeat("a", 10*153+1), "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{MaxSegments: tt.maxSegments})
+
+			result, err := service.CreateMessage(context.Background(), usecases.CreateMessageCommand{
+				PhoneNumber: "+905551234567",
+				Content:     tt.content,
+			})
+
+			if tt.expectError {
+				var validationErr domainErrors.ValidationError
+				if !errors.As(err, &validationErr) || !contains(err.Error(), "segments") {
+					t.Errorf("Expected segment limit validation error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Encoding != tt.encoding || result.Segments != tt.segments {
+				t.Errorf("Expected %s in %d segments, got %s in %d", tt.encoding, tt.segments, result.Encoding, result.Segments)
+			}
+		})
+	}
+}
+
+func TestMessageManagementService_CreateMessage_PhoneNormalization(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultRegion string
+		phoneNumber   string
+		expected      string
+		expectError   bool
+	}{
+		{"international with separators", "", "+90 555 123 4567", "+905551234567", false},
+		{"international without plus", "", "905551234567", "+905551234567", false},
+		{"national in the default region", "TR", "0555 123 45 67", "+905551234567", false},
+		{"national without a default region", "", "0555 123 45 67", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockMessageRepository()
+			service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{DefaultRegion: tt.defaultRegion})
+
+			result, err := service.CreateMessage(context.Background(), usecases.CreateMessageCommand{
+				PhoneNumber: tt.phoneNumber,
+				Content:     "Your order has shipped",
+			})
+
+			if tt.expectError {
+				var validationErr domainErrors.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("Expected validation error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.PhoneNumber != tt.expected {
+				t.Errorf("Expected phone number %s, got %s", tt.expected, result.PhoneNumber)
+			}
+			if result.CountryCode != 90 || result.Region != "TR" || result.NumberType != "mobile" {
+				t.Errorf("Expected a Turkish mobile number, got %d %s %s", result.CountryCode, result.Region, result.NumberType)
+			}
+
+			stored := mockRepo.messages[message.MessageID(result.ID.String())]
+			if stored.PhoneNumber.String() != tt.expected {
+				t.Errorf("Expected stored phone number %s, got %s", tt.expected, stored.PhoneNumber)
+			}
+		})
+	}
+}
+
+func TestMessageManagementService_CreateMessage_Idempotency(t *testing.T) {
+	ctx := context.Background()
+	command := usecases.CreateMessageCommand{
+		PhoneNumber:    "+905551234567",
+		Content:        "Test message",
+		IdempotencyKey: "client-key-1",
+	}
+
+	t.Run("replay returns original message", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		first, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		second, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Unexpected error on replay: %v", err)
+		}
+
+		if first.ID != second.ID {
+			t.Errorf("Expected replay to return message %s, got %s", first.ID, second.ID)
+		}
+
+		if mockRepo.callCount["Create"] != 1 {
+			t.Errorf("Expected Create to be called once, called %d times", mockRepo.callCount["Create"])
+		}
+	})
+
+	t.Run("same key with different body", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		if _, err := service.CreateMessage(ctx, command); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		changed := command
+		changed.Content = "Different message"
+		_, err := service.CreateMessage(ctx, changed)
+
+		var businessErr domainErrors.BusinessError
+		if !errors.As(err, &businessErr) {
+			t.Errorf("Expected BusinessError, got %v", err)
+		}
+	})
+
+	t.Run("key still in progress", func(t *testing.T) {
+		idempotencyRepo := newMockIdempotencyRepository()
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), idempotencyRepo, newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		// First request reserved the key but has not completed yet
+		first := command
+		first.IdempotencyKey = "in-flight-key"
+		reservedCmd, _ := json.Marshal(first)
+		sum := sha256.Sum256(reservedCmd)
+		idempotencyRepo.records[first.IdempotencyKey] = &repositories.IdempotencyRecord{
+			Key:         first.IdempotencyKey,
+			Fingerprint: hex.EncodeToString(sum[:]),
+		}
+
+		_, err := service.CreateMessage(ctx, first)
+
+		var conflictErr domainErrors.ConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Errorf("Expected ConflictError, got %v", err)
+		}
+	})
+
+	t.Run("failed completion replays the created message", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		idempotencyRepo := newMockIdempotencyRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, idempotencyRepo, newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		idempotencyRepo.completeErr = errors.New("mock complete error")
+		first, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Expected the created message despite the failed completion, got %v", err)
+		}
+
+		idempotencyRepo.completeErr = nil
+		second, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Unexpected error on replay: %v", err)
+		}
+
+		if first.ID != second.ID {
+			t.Errorf("Expected replay to return message %s, got %s", first.ID, second.ID)
+		}
+		if mockRepo.callCount["Create"] != 1 {
+			t.Errorf("Expected Create to be called once, called %d times", mockRepo.callCount["Create"])
+		}
+		if record := idempotencyRepo.records[command.IdempotencyKey]; !record.IsCompleted() {
+			t.Error("Expected the replay to complete the idempotency key")
+		}
+	})
+
+	t.Run("failed creation releases key", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		idempotencyRepo := newMockIdempotencyRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, idempotencyRepo, newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		mockRepo.shouldFailOp = "Create"
+		if _, err := service.CreateMessage(ctx, command); err == nil {
+			t.Fatalf("Expected error but got none")
+		}
+
+		if _, exists := idempotencyRepo.records[command.IdempotencyKey]; exists {
+			t.Errorf("Expected idempotency key to be released after failure")
+		}
+	})
+
+	t.Run("creation failing after the insert completes key", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		idempotencyRepo := newMockIdempotencyRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, idempotencyRepo, newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		mockRepo.shouldFailOp = "CreateAfterInsert"
+		result, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		record, exists := idempotencyRepo.records[command.IdempotencyKey]
+		if !exists || !record.IsCompleted() {
+			t.Fatalf("Expected idempotency key to be completed, got %+v", record)
+		}
+		if record.MessageID == nil || record.MessageID.String() != result.ID.String() {
+			t.Errorf("Expected idempotency key to name message %s, got %v", result.ID, record.MessageID)
+		}
+
+		mockRepo.shouldFailOp = ""
+		replayed, err := service.CreateMessage(ctx, command)
+		if err != nil {
+			t.Fatalf("Unexpected error on replay: %v", err)
+		}
+		if replayed.ID != result.ID {
+			t.Errorf("Expected replay to return message %s, got %s", result.ID, replayed.ID)
+		}
+		if len(mockRepo.messages) != 1 {
+			t.Errorf("Expected 1 message, got %d", len(mockRepo.messages))
+		}
+	})
+}
+
+func TestMessageManagementService_CreateMessages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates valid messages and reports invalid ones", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.CreateMessages(ctx, []usecases.CreateMessageCommand{
+			{PhoneNumber: "+905551234567", Content: "First"},
+			{PhoneNumber: "invalid", Content: "Bad phone"},
+			{PhoneNumber: "+905551234568", Content: "Second", SendAt: timePtr(time.Now().Add(time.Hour))},
+			{PhoneNumber: "+905551234569", Content: ""},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.CreatedCount != 2 || result.FailedCount != 2 {
+			t.Errorf("Expected 2 created and 2 failed, got %d and %d", result.CreatedCount, result.FailedCount)
+		}
+		for i, item := range result.Items {
+			if item.Index != i {
+				t.Errorf("Expected item %d to have index %d, got %d", i, i, item.Index)
+			}
+		}
+		if result.Items[0].Message == nil || result.Items[2].Message == nil {
+			t.Error("Expected valid items to be created")
+		}
+		var validationErr domainErrors.ValidationError
+		if !errors.As(result.Items[1].Error, &validationErr) || !errors.As(result.Items[3].Error, &validationErr) {
+			t.Errorf("Expected validation errors for invalid items, got %v and %v", result.Items[1].Error, result.Items[3].Error)
+		}
+
+		if mockRepo.callCount["CreateBatch"] != 1 || mockRepo.callCount["Create"] != 0 {
+			t.Errorf("Expected a single batch insert, got %d batch and %d single inserts", mockRepo.callCount["CreateBatch"], mockRepo.callCount["Create"])
+		}
+		if len(mockRepo.messages) != 2 {
+			t.Errorf("Expected 2 stored messages, got %d", len(mockRepo.messages))
+		}
+	})
+
+	t.Run("rejects empty and oversized batches", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{BatchMaxItems: 2})
+
+		batches := [][]usecases.CreateMessageCommand{
+			nil,
+			make([]usecases.CreateMessageCommand, 3),
+		}
+
+		for _, cmds := range batches {
+			_, err := service.CreateMessages(ctx, cmds)
+			var validationErr domainErrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected validation error for %d messages, got %v", len(cmds), err)
+			}
+		}
+	})
+
+	t.Run("repository error fails the whole batch", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		mockRepo.shouldFailOp = "CreateBatch"
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		_, err := service.CreateMessages(ctx, []usecases.CreateMessageCommand{
+			{PhoneNumber: "+905551234567", Content: "First"},
+		})
+		if err == nil {
+			t.Error("Expected error but got none")
+		}
+	})
+}
+
 func TestMessageManagementService_GetMessageByID(t *testing.T) {
 	mockRepo := newMockMessageRepository()
-	service := usecaseImpl.NewMessageManagementService(mockRepo, newMockCacheServiceForManagement())
+	service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
 	ctx := context.Background()
 
 	// Create a test message
@@ -427,9 +1598,146 @@ func TestMessageManagementService_GetMessageByID(t *testing.T) {
 	})
 }
 
+func TestMessageManagementService_CancelMessage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cancelled message is never claimed", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		msg := createTestMessage(t)
+		mockRepo.messages[msg.ID] = msg
+		id, _ := uuid.Parse(msg.ID.String())
+
+		result, err := service.CancelMessage(ctx, id)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Status != "CANCELLED" {
+			t.Errorf("Expected status CANCELLED, got %s", result.Status)
+		}
+
+		claimed, _ := mockRepo.ClaimPendingMessages(ctx, "worker-1", nil, 10, time.Minute)
+		if len(claimed) != 0 {
+			t.Errorf("Expected cancelled message not to be claimed, got %d", len(claimed))
+		}
+	})
+
+	t.Run("claimed message cannot be cancelled", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		msg := createTestMessage(t)
+		mockRepo.messages[msg.ID] = msg
+		mockRepo.ClaimPendingMessages(ctx, "worker-1", nil, 10, time.Minute)
+		id, _ := uuid.Parse(msg.ID.String())
+
+		_, err := service.CancelMessage(ctx, id)
+		var businessErr domainErrors.BusinessError
+		if !errors.As(err, &businessErr) {
+			t.Errorf("Expected business error, got %v", err)
+		}
+		if msg.Status != message.StatusProcessing {
+			t.Errorf("Expected status to remain PROCESSING, got %s", msg.Status)
+		}
+	})
+
+	t.Run("claim racing the cancellation wins", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		// The message still reads as pending but a worker leased it in the meantime
+		msg := createTestMessage(t)
+		mockRepo.messages[msg.ID] = msg
+		mockRepo.leases[msg.ID] = "worker-1"
+		id, _ := uuid.Parse(msg.ID.String())
+
+		_, err := service.CancelMessage(ctx, id)
+		var conflictErr domainErrors.ConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Errorf("Expected conflict error, got %v", err)
+		}
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		if _, err := service.CancelMessage(ctx, uuid.New()); err == nil {
+			t.Error("Expected error but got none")
+		}
+	})
+}
+
+func TestMessageManagementService_StatusHistory(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+	ctx := context.Background()
+
+	created, err := service.CreateMessage(ctx, usecases.CreateMessageCommand{
+		PhoneNumber: "+905551234567",
+		Content:     "Test message",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Fail a delivery from a scheduler tick, then requeue the message
+	schedulerCtx := message.WithTrigger(ctx, message.TriggerScheduler)
+	claimed, _ := mockRepo.ClaimPendingMessages(schedulerCtx, "worker-1", nil, 1, time.Minute)
+	if len(claimed) != 1 {
+		t.Fatalf("Expected 1 claimed message, got %d", len(claimed))
+	}
+	claimed[0].MarkAsFailed()
+	if err := mockRepo.UpdateClaimed(schedulerCtx, claimed[0], "worker-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := service.RequeueMessage(ctx, created.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := service.GetMessageByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		from, to string
+		trigger  message.Trigger
+	}{
+		{"", "PENDING", message.TriggerAPI},
+		{"PENDING", "PROCESSING", message.TriggerScheduler},
+		{"PROCESSING", "FAILED", message.TriggerScheduler},
+		{"FAILED", "PENDING", message.TriggerRequeue},
+	}
+
+	if len(result.StatusHistory) != len(expected) {
+		t.Fatalf("Expected %d status changes, got %d", len(expected), len(result.StatusHistory))
+	}
+	for i, want := range expected {
+		change := result.StatusHistory[i]
+		from := ""
+		if change.From != nil {
+			from = *change.From
+		}
+		if from != want.from || change.To != want.to || change.Trigger != want.trigger.String() {
+			t.Errorf("Change %d: expected %q -> %s by %s, got %q -> %s by %s", i, want.from, want.to, want.trigger, from, change.To, change.Trigger)
+		}
+	}
+
+	t.Run("history error", func(t *testing.T) {
+		mockRepo.shouldFailOp = "GetStatusHistory"
+		defer func() { mockRepo.shouldFailOp = "" }()
+
+		if _, err := service.GetMessageByID(ctx, created.ID); err == nil {
+			t.Error("Expected error but got none")
+		}
+	})
+}
+
 func TestMessageManagementService_ListMessages(t *testing.T) {
 	mockRepo := newMockMessageRepository()
-	service := usecaseImpl.NewMessageManagementService(mockRepo, newMockCacheServiceForManagement())
+	service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
 	ctx := context.Background()
 
 	// Create test messages
@@ -479,6 +1787,101 @@ func TestMessageManagementService_ListMessages(t *testing.T) {
 		}
 	})
 
+	t.Run("scheduled filter", func(t *testing.T) {
+		scheduledMsg := createTestMessage(t)
+		if err := scheduledMsg.Schedule(time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Failed to schedule message: %v", err)
+		}
+		mockRepo.messages[scheduledMsg.ID] = scheduledMsg
+		defer delete(mockRepo.messages, scheduledMsg.ID)
+
+		result, err := service.ListMessages(ctx, usecases.ListMessagesQuery{Scheduled: true, Limit: 10})
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if result == nil {
+			t.Errorf("Expected result but got nil")
+		} else {
+			if len(result.Messages) != 1 {
+				t.Errorf("Expected 1 scheduled message, got %d", len(result.Messages))
+			}
+			if result.TotalCount != 1 {
+				t.Errorf("Expected total count 1, got %d", result.TotalCount)
+			}
+		}
+	})
+
+	t.Run("scheduled filter with non-pending status", func(t *testing.T) {
+		status := message.StatusSent
+		result, err := service.ListMessages(ctx, usecases.ListMessagesQuery{Status: &status, Scheduled: true, Limit: 10})
+
+		if err == nil {
+			t.Errorf("Expected validation error but got none")
+		}
+		if result != nil {
+			t.Errorf("Expected nil result on error, got: %v", result)
+		}
+	})
+
+	t.Run("recently sent messages from cache exclude delivered messages", func(t *testing.T) {
+		messageRepo := newMockMessageRepository()
+		cache := newMockCacheServiceForManagement()
+		service := usecaseImpl.NewMessageManagementService(messageRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), cache, usecases.ManagementConfig{})
+
+		sentAt := time.Now()
+		pending := createSentTestMessage(t, messageRepo, "ext-pending")
+		delivered := createSentTestMessage(t, messageRepo, "ext-delivered")
+		for i, msg := range []*message.Message{pending, delivered} {
+			cache.ZAdd(ctx, "sent_messages", float64(sentAt.Unix()+int64(i)), msg.ID.String()+":"+*msg.ExternalID+":"+msg.PhoneNumber.String(), time.Hour)
+			cache.SetJSON(ctx, "message_detail:"+msg.ID.String(), services.SentMessageCacheData{MessageID: msg.ID.String(), Content: "Test message", Status: message.StatusSent.String(), SentAt: sentAt}, time.Hour)
+		}
+
+		receiptService := usecaseImpl.NewDeliveryReceiptService(messageRepo, newMockDeliveryReceiptRepository(), cache)
+		if _, err := receiptService.ReceiveDeliveryReceipt(ctx, usecases.ReceiveDeliveryReceiptCommand{ExternalID: "ext-delivered", Status: "delivered"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		status := message.StatusSent
+		result, err := service.ListMessages(ctx, usecases.ListMessagesQuery{Status: &status, Limit: 10})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result.Messages) != 1 {
+			t.Fatalf("Expected 1 sent message, got %d", len(result.Messages))
+		}
+		if result.Messages[0].ID.String() != pending.ID.String() || result.Messages[0].Status != message.StatusSent.String() {
+			t.Errorf("Expected only the pending message as SENT, got %+v", result.Messages[0])
+		}
+		if result.TotalCount != 1 || result.HasMore {
+			t.Errorf("Expected a total count of 1 without more pages, got %d (hasMore %v)", result.TotalCount, result.HasMore)
+		}
+	})
+
+	t.Run("recently sent messages from cache skip entries that are no longer sent", func(t *testing.T) {
+		cache := newMockCacheServiceForManagement()
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), cache, usecases.ManagementConfig{})
+
+		sentAt := time.Now()
+		for i, status := range []string{"", message.StatusDelivered.String()} {
+			id := uuid.New().String()
+			cache.ZAdd(ctx, "sent_messages", float64(sentAt.Unix()+int64(i)), id+":ext-"+id+":+905551234567", time.Hour)
+			cache.SetJSON(ctx, "message_detail:"+id, services.SentMessageCacheData{MessageID: id, Content: "Test message", Status: status, SentAt: sentAt}, time.Hour)
+		}
+
+		status := message.StatusSent
+		result, err := service.ListMessages(ctx, usecases.ListMessagesQuery{Status: &status, Limit: 10})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result.Messages) != 1 {
+			t.Fatalf("Expected 1 cached message, got %d", len(result.Messages))
+		}
+		if result.Messages[0].Status != message.StatusSent.String() {
+			t.Errorf("Expected an entry without a status to be SENT, got %s", result.Messages[0].Status)
+		}
+	})
+
 	t.Run("negative offset validation", func(t *testing.T) {
 		query := usecases.ListMessagesQuery{
 			Limit:  10,
@@ -496,6 +1899,440 @@ func TestMessageManagementService_ListMessages(t *testing.T) {
 	})
 }
 
+// Helper function to take the address of a time value
+func TestMessageManagementService_DeadLetters(t *testing.T) {
+	newFailedMessage := func(t *testing.T, class message.ErrorClass) *message.Message {
+		msg := createTestMessage(t)
+		msg.RecordDeliveryError(class, "webhook returned HTTP 503", 503)
+		msg.MarkAsFailed()
+		return msg
+	}
+
+	t.Run("lists failed messages filtered by error class", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		serverErr := newFailedMessage(t, message.ErrorClassServerError)
+		timeoutErr := newFailedMessage(t, message.ErrorClassTimeout)
+		pending := createTestMessage(t)
+		mockRepo.messages[serverErr.ID] = serverErr
+		mockRepo.messages[timeoutErr.ID] = timeoutErr
+		mockRepo.messages[pending.ID] = pending
+
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.ListDeadLetters(context.Background(), usecases.DeadLetterQuery{
+			Filter: usecases.DeadLetterFilter{ErrorClass: "http_5xx"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.TotalCount != 1 || len(result.Messages) != 1 {
+			t.Fatalf("Expected 1 dead letter, got %d (total %d)", len(result.Messages), result.TotalCount)
+		}
+
+		dead := result.Messages[0]
+		if dead.LastErrorClass == nil || *dead.LastErrorClass != "http_5xx" {
+			t.Errorf("Expected last error class http_5xx, got %v", dead.LastErrorClass)
+		}
+		if dead.LastHTTPStatus == nil || *dead.LastHTTPStatus != 503 {
+			t.Errorf("Expected last HTTP status 503, got %v", dead.LastHTTPStatus)
+		}
+		if dead.AttemptCount != 1 {
+			t.Errorf("Expected attempt count 1, got %d", dead.AttemptCount)
+		}
+	})
+
+	t.Run("rejects invalid filters", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+		now := time.Now()
+
+		filters := []usecases.DeadLetterFilter{
+			{ErrorClass: "bogus"},
+			{PhoneNumber: "not-a-phone"},
+			{FailedFrom: timePtr(now), FailedTo: timePtr(now.Add(-time.Hour))},
+		}
+
+		for _, filter := range filters {
+			_, err := service.RequeueFailedMessages(context.Background(), filter)
+
+			var validationErr domainErrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError for filter %+v, got %v", filter, err)
+			}
+		}
+	})
+
+	t.Run("requeues a single failed message with a fresh retry budget", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		failed := newFailedMessage(t, message.ErrorClassNetwork)
+		failed.RetryCount = message.MaxRetryAttempts
+		mockRepo.messages[failed.ID] = failed
+
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.RequeueMessage(context.Background(), uuid.MustParse(failed.ID.String()))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.Status != string(message.StatusPending) || result.RetryCount != 0 {
+			t.Errorf("Expected pending with 0 retries, got %s with %d", result.Status, result.RetryCount)
+		}
+
+		if result.LastError == nil {
+			t.Error("Expected last error to be kept after requeue")
+		}
+	})
+
+	t.Run("refuses to requeue a message that has not failed", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		pending := createTestMessage(t)
+		mockRepo.messages[pending.ID] = pending
+
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		_, err := service.RequeueMessage(context.Background(), uuid.MustParse(pending.ID.String()))
+
+		var businessErr domainErrors.BusinessError
+		if !errors.As(err, &businessErr) {
+			t.Errorf("Expected BusinessError, got %v", err)
+		}
+	})
+
+	t.Run("bulk requeue only touches matching failed messages", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		first := newFailedMessage(t, message.ErrorClassServerError)
+		second := newFailedMessage(t, message.ErrorClassServerError)
+		other := newFailedMessage(t, message.ErrorClassClientError)
+		for _, msg := range []*message.Message{first, second, other} {
+			mockRepo.messages[msg.ID] = msg
+		}
+
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.RequeueFailedMessages(context.Background(), usecases.DeadLetterFilter{ErrorClass: "http_5xx"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.RequeuedCount != 2 {
+			t.Errorf("Expected 2 requeued messages, got %d", result.RequeuedCount)
+		}
+
+		if other.Status != message.StatusFailed {
+			t.Errorf("Expected non-matching message to stay failed, got %s", other.Status)
+		}
+	})
+}
+
+func TestMessageManagementService_MessageAttempts(t *testing.T) {
+	t.Run("lists the attempts of a message", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		attemptRepo := newMockAttemptRepository()
+		msg := createTestMessage(t)
+		mockRepo.messages[msg.ID] = msg
+
+		failed := message.NewAttempt(msg.ID, 0, 1, time.Now())
+		failed.RecordResponse(503, "upstream unavailable")
+		failed.RecordError(message.ErrorClassServerError, "webhook returned HTTP 503")
+		failed.Finish(250 * time.Millisecond)
+		succeeded := message.NewAttempt(msg.ID, 0, 2, time.Now())
+		succeeded.RecordResponse(200, `{"messageId":"ext-1"}`)
+		other := message.NewAttempt(message.NewMessageID(), 0, 1, time.Now())
+		attemptRepo.attempts = []*message.Attempt{failed, succeeded, other}
+
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), attemptRepo, newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.ListMessageAttempts(context.Background(), usecases.MessageAttemptsQuery{
+			MessageID: uuid.MustParse(msg.ID.String()),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.TotalCount != 2 || len(result.Attempts) != 2 {
+			t.Fatalf("Expected 2 attempts, got %d (total %d)", len(result.Attempts), result.TotalCount)
+		}
+
+		first := result.Attempts[0]
+		if first.HTTPStatus == nil || *first.HTTPStatus != 503 {
+			t.Errorf("Expected HTTP status 503, got %v", first.HTTPStatus)
+		}
+		if first.ErrorClass == nil || *first.ErrorClass != "http_5xx" {
+			t.Errorf("Expected error class http_5xx, got %v", first.ErrorClass)
+		}
+		if first.DurationMs != 250 {
+			t.Errorf("Expected duration 250ms, got %d", first.DurationMs)
+		}
+		if result.Attempts[1].Error != nil {
+			t.Errorf("Expected successful attempt without error, got %v", *result.Attempts[1].Error)
+		}
+	})
+
+	t.Run("unknown message is reported", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		if _, err := service.ListMessageAttempts(context.Background(), usecases.MessageAttemptsQuery{MessageID: uuid.New()}); err == nil {
+			t.Error("Expected error for unknown message")
+		}
+	})
+}
+
+func TestMessageManagementService_ImportMessages(t *testing.T) {
+	// waitForImport polls the job until the background import finishes
+	waitForImport := func(t *testing.T, service usecases.MessageManagementUseCase, id uuid.UUID) *usecases.ImportJobResponse {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			job, err := service.GetImportJob(context.Background(), id)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if job.Status != message.ImportStatusProcessing.String() {
+				return job
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatal("Import did not finish in time")
+		return nil
+	}
+
+	t.Run("CSV rows are created in chunks and rejected rows are reported", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		importRepo := newMockImportJobRepositoryFor(mockRepo)
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), importRepo, newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{ImportChunkSize: 2})
+
+		file := "\ufeffPhone_Number,Content,TTL\n" +
+			"+905551234567,First,\n" +
+			"12345,Bad phone,\n" +
+			"+905551234568,Third,15m\n" +
+			"+905551234569,Bad ttl,soon\n" +
+			"+905551234570,Fifth,\n"
+
+		job, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+			Format: "csv",
+			Source: io.NopCloser(strings.NewReader(file)),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if job.Status != message.ImportStatusProcessing.String() {
+			t.Errorf("Expected a processing job, got %s", job.Status)
+		}
+
+		finished := waitForImport(t, service, job.ID)
+		if finished.Status != message.ImportStatusCompleted.String() {
+			t.Fatalf("Expected completed import, got %s (%v)", finished.Status, finished.Error)
+		}
+		if finished.ProcessedRows != 5 || finished.CreatedCount != 3 || finished.FailedCount != 2 {
+			t.Errorf("Expected 5 processed, 3 created, 2 failed, got %d, %d, %d",
+				finished.ProcessedRows, finished.CreatedCount, finished.FailedCount)
+		}
+		if finished.CompletedAt == nil {
+			t.Error("Expected completion time to be set")
+		}
+
+		mockRepo.mu.Lock()
+		created, batches := len(mockRepo.messages), mockRepo.callCount["CreateBatch"]
+		mockRepo.mu.Unlock()
+		if created != 3 {
+			t.Errorf("Expected 3 stored messages, got %d", created)
+		}
+		if batches != 3 {
+			t.Errorf("Expected 3 chunks of at most 2 rows, got %d", batches)
+		}
+
+		report, err := service.ListImportErrors(context.Background(), usecases.ImportErrorsQuery{JobID: job.ID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if report.TotalCount != 2 || len(report.Errors) != 2 || report.HasMore {
+			t.Fatalf("Expected 2 row errors, got %+v", report)
+		}
+		if report.Errors[0].Row != 2 || report.Errors[0].Data != "12345,Bad phone," {
+			t.Errorf("Expected row 2 with its raw data, got %+v", report.Errors[0])
+		}
+		if report.Errors[1].Row != 4 || !contains(report.Errors[1].Error, "ttl") {
+			t.Errorf("Expected row 4 to be rejected for its ttl, got %+v", report.Errors[1])
+		}
+	})
+
+	t.Run("NDJSON lines are imported and blank lines skipped", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepositoryFor(mockRepo), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		file := `{"phoneNumber":"+905551234567","content":"Hello"}` + "\n\n" +
+			`{"phoneNumber":"+905551234568","content":""}` + "\n" +
+			`not json` + "\n"
+
+		job, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+			Format: "ndjson",
+			Source: io.NopCloser(strings.NewReader(file)),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		finished := waitForImport(t, service, job.ID)
+		if finished.CreatedCount != 1 || finished.FailedCount != 2 {
+			t.Errorf("Expected 1 created and 2 failed, got %d and %d", finished.CreatedCount, finished.FailedCount)
+		}
+
+		mockRepo.mu.Lock()
+		defer mockRepo.mu.Unlock()
+		for _, msg := range mockRepo.messages {
+			history := mockRepo.history[msg.ID]
+			if len(history) != 1 || history[0].Trigger != message.TriggerImport {
+				t.Errorf("Expected creation to be attributed to the import, got %+v", history)
+			}
+		}
+	})
+
+	t.Run("CSV extra columns fill template variables", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		templateRepo := newMockTemplateRepository()
+		template, _ := message.NewTemplate("greeting", "Hi {{name}}, your code is {{code}}")
+		templateRepo.templates[template.ID] = *template
+		templateID := uuid.MustParse(template.ID.String())
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepositoryFor(mockRepo), templateRepo, newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		file := "phone,name,code,content\n" +
+			"+905551234567,Ada,1234,\n" +
+			"+905551234568,Bob,,\n" +
+			"+905551234569,,,Plain text\n"
+
+		job, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+			Format:     "csv",
+			Source:     io.NopCloser(strings.NewReader(file)),
+			TemplateID: &templateID,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		finished := waitForImport(t, service, job.ID)
+		if finished.CreatedCount != 3 || finished.FailedCount != 0 {
+			t.Fatalf("Expected 3 created and 0 failed, got %d and %d", finished.CreatedCount, finished.FailedCount)
+		}
+
+		mockRepo.mu.Lock()
+		defer mockRepo.mu.Unlock()
+		contents := make(map[string]*message.Message)
+		for _, msg := range mockRepo.messages {
+			contents[msg.Content.String()] = msg
+		}
+		if msg := contents["Hi Ada, your code is 1234"]; msg == nil || msg.TemplateVersion == nil || *msg.TemplateVersion != 1 {
+			t.Errorf("Expected a message rendered from template version 1, got %+v", contents)
+		}
+		if _, ok := contents["Hi Bob, your code is "]; !ok {
+			t.Errorf("Expected an empty column to render as an empty variable, got %+v", contents)
+		}
+		if msg := contents["Plain text"]; msg == nil || msg.TemplateID != nil {
+			t.Errorf("Expected a row with content not to use the template, got %+v", msg)
+		}
+	})
+
+	t.Run("unknown import template is rejected up front", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		templateID := uuid.New()
+		_, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+			Format:     "csv",
+			Source:     io.NopCloser(strings.NewReader("phone,name\n+905551234567,Ada\n")),
+			TemplateID: &templateID,
+		})
+		var validationErr domainErrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("store failure stops the import", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		mockRepo.shouldFailOp = "CreateBatch"
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepositoryFor(mockRepo), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		job, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+			Format: "csv",
+			Source: io.NopCloser(strings.NewReader("phone,content\n+905551234567,Hello\n")),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		finished := waitForImport(t, service, job.ID)
+		if finished.Status != message.ImportStatusFailed.String() || finished.Error == nil {
+			t.Errorf("Expected failed import with a reason, got %s", finished.Status)
+		}
+		if finished.ProcessedRows != 0 || finished.CreatedCount != 0 {
+			t.Errorf("Expected the failed chunk not to count as progress, got %d processed and %d created", finished.ProcessedRows, finished.CreatedCount)
+		}
+	})
+
+	t.Run("interrupted imports are failed on recovery", func(t *testing.T) {
+		importRepo := newMockImportJobRepository()
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), importRepo, newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{ImportStaleAfter: time.Minute})
+
+		stale, _ := message.NewImportJob(message.ImportFormatCSV)
+		stale.UpdatedAt = time.Now().Add(-time.Hour)
+		running, _ := message.NewImportJob(message.ImportFormatCSV)
+		importRepo.jobs[stale.ID] = *stale
+		importRepo.jobs[running.ID] = *running
+
+		failed, err := service.RecoverImportJobs(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if failed != 1 {
+			t.Errorf("Expected 1 failed import, got %d", failed)
+		}
+		if job := importRepo.jobs[stale.ID]; job.Status != message.ImportStatusFailed || job.Error == nil {
+			t.Errorf("Expected the stale import to fail with a reason, got %s", job.Status)
+		}
+		if job := importRepo.jobs[running.ID]; job.Status != message.ImportStatusProcessing {
+			t.Errorf("Expected the running import to keep processing, got %s", job.Status)
+		}
+	})
+
+	t.Run("invalid files are rejected up front", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		tests := []struct {
+			name   string
+			format string
+			file   string
+		}{
+			{"unknown format", "xlsx", "phone,content\n"},
+			{"empty CSV", "csv", ""},
+			{"CSV without content column", "csv", "phone,text\n+905551234567,Hello\n"},
+		}
+
+		for _, tt := range tests {
+			_, err := service.ImportMessages(context.Background(), usecases.ImportMessagesCommand{
+				Format: tt.format,
+				Source: io.NopCloser(strings.NewReader(tt.file)),
+			})
+			var validationErr domainErrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("%s: expected validation error, got %v", tt.name, err)
+			}
+		}
+	})
+
+	t.Run("unknown job is reported", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository(), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		var notFoundErr domainErrors.NotFoundError
+		if _, err := service.GetImportJob(context.Background(), uuid.New()); !errors.As(err, &notFoundErr) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
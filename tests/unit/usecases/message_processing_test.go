@@ -2,11 +2,15 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
 	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	usecasePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
 	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
 )
 
@@ -21,10 +25,15 @@ func (e *testError) Error() string {
 
 // Mock webhook service for testing
 type mockWebhookService struct {
+	mu         sync.Mutex
 	shouldFail bool
+	callCount  int
 }
 
 func (m *mockWebhookService) SendMessage(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callCount++
 	if m.shouldFail {
 		return nil, &testError{message: "webhook send failed"}
 	}
@@ -34,11 +43,14 @@ func (m *mockWebhookService) SendMessage(ctx context.Context, request services.W
 }
 
 func (m *mockWebhookService) IsHealthy(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return nil
 }
 
 // Mock cache service for testing
 type mockCacheServiceForProcessing struct {
+	mu         sync.Mutex
 	data       map[string]interface{}
 	sortedSets map[string]map[string]float64 // key -> member -> score
 	shouldFail bool
@@ -52,6 +64,8 @@ func newMockCacheServiceForProcessing() *mockCacheServiceForProcessing {
 }
 
 func (m *mockCacheServiceForProcessing) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache set failed"}
 	}
@@ -60,6 +74,8 @@ func (m *mockCacheServiceForProcessing) Set(ctx context.Context, key string, val
 }
 
 func (m *mockCacheServiceForProcessing) Get(ctx context.Context, key string) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return nil, &testError{message: "cache get failed"}
 	}
@@ -70,6 +86,8 @@ func (m *mockCacheServiceForProcessing) Get(ctx context.Context, key string) (in
 }
 
 func (m *mockCacheServiceForProcessing) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache delete failed"}
 	}
@@ -78,6 +96,8 @@ func (m *mockCacheServiceForProcessing) Delete(ctx context.Context, key string)
 }
 
 func (m *mockCacheServiceForProcessing) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return false, &testError{message: "cache exists failed"}
 	}
@@ -86,6 +106,8 @@ func (m *mockCacheServiceForProcessing) Exists(ctx context.Context, key string)
 }
 
 func (m *mockCacheServiceForProcessing) SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache setJSON failed"}
 	}
@@ -94,6 +116,8 @@ func (m *mockCacheServiceForProcessing) SetJSON(ctx context.Context, key string,
 }
 
 func (m *mockCacheServiceForProcessing) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache getJSON failed"}
 	}
@@ -102,10 +126,14 @@ func (m *mockCacheServiceForProcessing) GetJSON(ctx context.Context, key string,
 }
 
 func (m *mockCacheServiceForProcessing) IsHealthy(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return nil
 }
 
 func (m *mockCacheServiceForProcessing) ZAdd(ctx context.Context, key string, score float64, member string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache zadd failed"}
 	}
@@ -117,6 +145,8 @@ func (m *mockCacheServiceForProcessing) ZAdd(ctx context.Context, key string, sc
 }
 
 func (m *mockCacheServiceForProcessing) ZRevRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return nil, &testError{message: "cache zrevrange failed"}
 	}
@@ -125,6 +155,8 @@ func (m *mockCacheServiceForProcessing) ZRevRange(ctx context.Context, key strin
 }
 
 func (m *mockCacheServiceForProcessing) ZRem(ctx context.Context, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache zrem failed"}
 	}
@@ -137,6 +169,8 @@ func (m *mockCacheServiceForProcessing) ZRem(ctx context.Context, key string, me
 }
 
 func (m *mockCacheServiceForProcessing) ZCard(ctx context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return 0, &testError{message: "cache zcard failed"}
 	}
@@ -147,6 +181,8 @@ func (m *mockCacheServiceForProcessing) ZCard(ctx context.Context, key string) (
 }
 
 func (m *mockCacheServiceForProcessing) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.shouldFail {
 		return &testError{message: "cache zremrangebyrank failed"}
 	}
@@ -198,7 +234,7 @@ func TestMessageProcessingService_ProcessPendingMessages(t *testing.T) {
 			name:            "repository error",
 			batchSize:       2,
 			pendingMessages: 3,
-			shouldFailRepo:  "GetPendingMessages",
+			shouldFailRepo:  "ClaimPendingMessages",
 			expectError:     true,
 		},
 	}
@@ -217,7 +253,7 @@ func TestMessageProcessingService_ProcessPendingMessages(t *testing.T) {
 				mockRepo.shouldFailOp = tt.shouldFailRepo
 			}
 
-			service := usecaseImpl.NewMessageProcessingService(mockRepo, &mockWebhookService{}, newMockCacheServiceForProcessing())
+			service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
 			ctx := context.Background()
 
 			result, err := service.ProcessPendingMessages(ctx, tt.batchSize)
@@ -243,17 +279,17 @@ func TestMessageProcessingService_ProcessPendingMessages(t *testing.T) {
 				t.Errorf("Expected processed count %d, got %d", tt.expectedProcessed, result.ProcessedCount)
 			}
 
-			// Check that the sum of success and failed equals processed
-			if result.SuccessCount+result.FailedCount != result.ProcessedCount {
-				t.Errorf("Success count (%d) + Failed count (%d) should equal Processed count (%d)",
-					result.SuccessCount, result.FailedCount, result.ProcessedCount)
+			// Check that every processed message has exactly one outcome
+			outcomes := result.SuccessCount + result.FailedCount + result.ExpiredCount + result.RetriedCount + result.DeferredCount
+			if outcomes != result.ProcessedCount {
+				t.Errorf("Sum of outcomes (%d) should equal Processed count (%d)", outcomes, result.ProcessedCount)
 			}
 
 			// Verify repository calls
 			if tt.shouldFailRepo == "" {
-				if mockRepo.callCount["GetPendingMessages"] != 1 {
-					t.Errorf("Expected GetPendingMessages to be called once, called %d times",
-						mockRepo.callCount["GetPendingMessages"])
+				if mockRepo.callCount["ClaimPendingMessages"] != 1 {
+					t.Errorf("Expected ClaimPendingMessages to be called once, called %d times",
+						mockRepo.callCount["ClaimPendingMessages"])
 				}
 			}
 		})
@@ -309,7 +345,7 @@ func TestMessageProcessingService_GetProcessingStatus(t *testing.T) {
 				mockRepo.shouldFailOp = tt.shouldFailRepo
 			}
 
-			service := usecaseImpl.NewMessageProcessingService(mockRepo, &mockWebhookService{}, newMockCacheServiceForProcessing())
+			service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
 			ctx := context.Background()
 
 			result, err := service.GetProcessingStatus(ctx)
@@ -347,9 +383,9 @@ func TestMessageProcessingService_GetProcessingStatus(t *testing.T) {
 				t.Errorf("Expected NextProcessingAt to be set")
 			}
 
-			// Verify repository calls (3 calls to CountByStatus for each status)
+			// Verify repository calls (one CountByStatus call for each status)
 			if tt.shouldFailRepo == "" {
-				expectedCalls := 3 // pending, sent, failed
+				expectedCalls := 4 // pending, sent, failed, processing
 				if mockRepo.callCount["CountByStatus"] != expectedCalls {
 					t.Errorf("Expected CountByStatus to be called %d times, called %d times",
 						expectedCalls, mockRepo.callCount["CountByStatus"])
@@ -374,7 +410,7 @@ func TestMessageProcessingService_ProcessMessage_Integration(t *testing.T) {
 			testMessages = append(testMessages, testMsg)
 		}
 
-		service := usecaseImpl.NewMessageProcessingService(mockRepo, &mockWebhookService{}, newMockCacheServiceForProcessing())
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
 		ctx := context.Background()
 
 		// Process the messages
@@ -404,8 +440,723 @@ func TestMessageProcessingService_ProcessMessage_Integration(t *testing.T) {
 		}
 
 		// Verify repository calls
-		if mockRepo.callCount["Update"] < result.ProcessedCount {
-			t.Errorf("Expected at least %d Update calls, got %d", result.ProcessedCount, mockRepo.callCount["Update"])
+		if mockRepo.callCount["UpdateClaimed"] < result.ProcessedCount {
+			t.Errorf("Expected at least %d UpdateClaimed calls, got %d", result.ProcessedCount, mockRepo.callCount["UpdateClaimed"])
 		}
 	})
 }
+
+func TestMessageProcessingService_ExpiredMessages(t *testing.T) {
+	t.Run("expired message is not sent", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		webhook := &mockWebhookService{}
+
+		testMsg := createTestMessage(t)
+		if err := testMsg.SetExpiry(time.Now().Add(time.Millisecond)); err != nil {
+			t.Fatalf("Failed to set expiry: %v", err)
+		}
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+		// Bypass the repository filter to simulate expiry between fetch and send
+		time.Sleep(5 * time.Millisecond)
+		pending := []*message.Message{testMsg}
+		mockRepo.claimOverride = pending
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ExpiredCount != 1 {
+			t.Errorf("Expected 1 expired message, got %d", result.ExpiredCount)
+		}
+
+		if webhook.callCount != 0 {
+			t.Errorf("Expected webhook not to be called, called %d times", webhook.callCount)
+		}
+
+		if mockRepo.messages[testMsg.ID].Status != message.StatusExpired {
+			t.Errorf("Expected status %s, got %s", message.StatusExpired, mockRepo.messages[testMsg.ID].Status)
+		}
+	})
+
+	t.Run("sweeper expires overdue pending messages", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+
+		overdue := createTestMessage(t)
+		overdue.SetExpiry(time.Now().Add(time.Millisecond))
+		mockRepo.messages[overdue.ID] = overdue
+
+		fresh := createTestMessage(t)
+		fresh.SetExpiry(time.Now().Add(time.Hour))
+		mockRepo.messages[fresh.ID] = fresh
+
+		time.Sleep(5 * time.Millisecond)
+
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+		expired, err := service.ExpireOverdueMessages(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if expired != 1 {
+			t.Errorf("Expected 1 expired message, got %d", expired)
+		}
+
+		if mockRepo.messages[fresh.ID].Status != message.StatusPending {
+			t.Errorf("Expected fresh message to stay pending, got %s", mockRepo.messages[fresh.ID].Status)
+		}
+	})
+}
+
+func TestMessageProcessingService_Retries(t *testing.T) {
+	config := usecasePorts.ProcessingConfig{
+		RetryBackoffBase: time.Minute,
+		RetryBackoffMax:  10 * time.Minute,
+	}
+
+	t.Run("failed delivery is rescheduled with backoff", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{shouldFail: true}, newMockCacheServiceForProcessing(), config)
+
+		before := time.Now()
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.RetriedCount != 1 || result.FailedCount != 0 {
+			t.Errorf("Expected 1 retried and 0 failed, got %d retried and %d failed", result.RetriedCount, result.FailedCount)
+		}
+
+		if len(result.Errors) != 1 {
+			t.Errorf("Expected the webhook error to be reported, got %d errors", len(result.Errors))
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.Status != message.StatusPending {
+			t.Errorf("Expected status %s, got %s", message.StatusPending, stored.Status)
+		}
+
+		if stored.RetryCount != 1 {
+			t.Errorf("Expected retry count 1, got %d", stored.RetryCount)
+		}
+
+		// First retry waits between half and the full base delay
+		if stored.NextAttemptAt == nil {
+			t.Fatal("Expected next attempt time to be set")
+		}
+		delay := stored.NextAttemptAt.Sub(before)
+		if delay < 30*time.Second || delay > time.Minute+time.Second {
+			t.Errorf("Expected first retry delay within [30s, 1m], got %v", delay)
+		}
+	})
+
+	t.Run("message waiting out backoff is not picked up", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		if err := testMsg.ScheduleRetry(time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Failed to schedule retry: %v", err)
+		}
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		webhook := &mockWebhookService{}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ProcessedCount != 0 || webhook.callCount != 0 {
+			t.Errorf("Expected no processing before next attempt, processed %d with %d webhook calls",
+				result.ProcessedCount, webhook.callCount)
+		}
+	})
+
+	t.Run("message fails once retries are exhausted", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		testMsg.RetryCount = message.MaxRetryAttempts
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{shouldFail: true}, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.FailedCount != 1 || result.RetriedCount != 0 {
+			t.Errorf("Expected 1 failed and 0 retried, got %d failed and %d retried", result.FailedCount, result.RetriedCount)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.Status != message.StatusFailed {
+			t.Errorf("Expected status %s, got %s", message.StatusFailed, stored.Status)
+		}
+
+		if stored.NextAttemptAt != nil {
+			t.Error("Expected next attempt time to be cleared on failure")
+		}
+	})
+
+	t.Run("failure details of the webhook error are recorded", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		webhookErr := services.NewWebhookError(message.ErrorClassServerError, 503, &testError{message: "webhook returned HTTP 503"})
+		webhook := &failingWebhookService{err: webhookErr}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		if _, err := service.ProcessPendingMessages(context.Background(), 5); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.LastErrorClass == nil || *stored.LastErrorClass != message.ErrorClassServerError {
+			t.Errorf("Expected error class %s, got %v", message.ErrorClassServerError, stored.LastErrorClass)
+		}
+		if stored.LastHTTPStatus == nil || *stored.LastHTTPStatus != 503 {
+			t.Errorf("Expected HTTP status 503, got %v", stored.LastHTTPStatus)
+		}
+		if stored.LastError == nil || *stored.LastError != "webhook returned HTTP 503" {
+			t.Errorf("Expected last error to be recorded, got %v", stored.LastError)
+		}
+		if stored.AttemptCount != 1 {
+			t.Errorf("Expected attempt count 1, got %d", stored.AttemptCount)
+		}
+	})
+
+	t.Run("permanent failure is not retried", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		webhookErr := services.NewWebhookError(message.ErrorClassClientError, 400, &testError{message: "webhook returned HTTP 400: invalid number"})
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &failingWebhookService{err: webhookErr}, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.FailedCount != 1 || result.RetriedCount != 0 {
+			t.Errorf("Expected 1 failed and 0 retried, got %d failed and %d retried", result.FailedCount, result.RetriedCount)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.Status != message.StatusFailed || stored.RetryCount != 0 {
+			t.Errorf("Expected FAILED without retries, got %s after %d retries", stored.Status, stored.RetryCount)
+		}
+		if stored.LastHTTPStatus == nil || *stored.LastHTTPStatus != 400 {
+			t.Errorf("Expected HTTP status 400, got %v", stored.LastHTTPStatus)
+		}
+	})
+
+	t.Run("retry waits at least as long as the endpoint asked for", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		webhookErr := services.NewWebhookError(message.ErrorClassClientError, 429, &testError{message: "webhook returned HTTP 429"})
+		webhookErr.RetryAfter = time.Hour
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &failingWebhookService{err: webhookErr}, newMockCacheServiceForProcessing(), config)
+
+		before := time.Now()
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.RetriedCount != 1 {
+			t.Errorf("Expected 1 retried message, got %d", result.RetriedCount)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.NextAttemptAt == nil || stored.NextAttemptAt.Before(before.Add(time.Hour)) {
+			t.Errorf("Expected next attempt no sooner than the Retry-After delay, got %v", stored.NextAttemptAt)
+		}
+	})
+
+	for name, sendErr := range map[string]error{
+		"throttled delivery is deferred without using up a retry":           services.NewThrottledError(200 * time.Millisecond),
+		"delivery held back by an open circuit is deferred without a retry": services.NewCircuitOpenError(time.Now().Add(time.Minute)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := newMockMessageRepository()
+			testMsg := createTestMessage(t)
+			mockRepo.messages[testMsg.ID] = testMsg
+
+			webhook := &failingWebhookService{err: sendErr}
+			service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+			result, err := service.ProcessPendingMessages(context.Background(), 5)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.DeferredCount != 1 || result.RetriedCount != 0 || len(result.Errors) != 0 {
+				t.Errorf("Expected 1 deferred message without errors, got %+v", result)
+			}
+
+			stored := mockRepo.messages[testMsg.ID]
+			if stored.Status != message.StatusPending || stored.LockedBy != nil {
+				t.Errorf("Expected message back in queue without lease, got %s", stored.Status)
+			}
+			if stored.RetryCount != 0 || stored.AttemptCount != 0 || stored.NextAttemptAt != nil {
+				t.Errorf("Expected no retry to be scheduled, got %d retries and %d attempts", stored.RetryCount, stored.AttemptCount)
+			}
+		})
+	}
+}
+
+// failingWebhookService always fails with the given error
+type failingWebhookService struct {
+	err error
+}
+
+func (s *failingWebhookService) SendMessage(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+	return nil, s.err
+}
+
+func (s *failingWebhookService) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+func TestMessageProcessingService_Leases(t *testing.T) {
+	t.Run("messages of a crashed worker are reclaimed and sent", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		if err := testMsg.Claim("crashed-worker", time.Now().Add(-time.Second)); err != nil {
+			t.Fatalf("Failed to claim message: %v", err)
+		}
+		mockRepo.messages[testMsg.ID] = testMsg
+		mockRepo.leases[testMsg.ID] = "crashed-worker"
+
+		config := usecasePorts.ProcessingConfig{WorkerID: "worker-1"}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ReclaimedCount != 1 || result.SuccessCount != 1 {
+			t.Errorf("Expected 1 reclaimed and 1 sent, got %d reclaimed and %d sent", result.ReclaimedCount, result.SuccessCount)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.Status != message.StatusSent {
+			t.Errorf("Expected status %s, got %s", message.StatusSent, stored.Status)
+		}
+
+		if stored.LockedBy != nil || stored.LeaseUntil != nil {
+			t.Error("Expected lease to be cleared after sending")
+		}
+	})
+
+	t.Run("message held under a live lease is not claimed", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		if err := testMsg.Claim("other-worker", time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("Failed to claim message: %v", err)
+		}
+		mockRepo.messages[testMsg.ID] = testMsg
+		mockRepo.leases[testMsg.ID] = "other-worker"
+
+		webhook := &mockWebhookService{}
+		config := usecasePorts.ProcessingConfig{WorkerID: "worker-1"}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ProcessedCount != 0 || webhook.callCount != 0 {
+			t.Errorf("Expected leased message to be skipped, processed %d with %d webhook calls",
+				result.ProcessedCount, webhook.callCount)
+		}
+	})
+
+	t.Run("lost lease is reported as failure", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		// Another worker reclaims the message while this one is sending
+		webhook := &stealingWebhookService{repo: mockRepo, thief: "worker-2"}
+		config := usecasePorts.ProcessingConfig{WorkerID: "worker-1"}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.FailedCount != 1 || len(result.Errors) != 1 {
+			t.Errorf("Expected 1 failed message with an error, got %d failed and %d errors", result.FailedCount, len(result.Errors))
+		}
+
+		var conflictErr domainErrors.ConflictError
+		if len(result.Errors) == 1 && !errors.As(result.Errors[0], &conflictErr) {
+			t.Errorf("Expected ConflictError, got %T", result.Errors[0])
+		}
+	})
+}
+
+// stealingWebhookService hands every message's lease to another worker before succeeding
+type stealingWebhookService struct {
+	repo  *mockMessageRepository
+	thief string
+}
+
+func (s *stealingWebhookService) SendMessage(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+	s.repo.mu.Lock()
+	defer s.repo.mu.Unlock()
+
+	for id := range s.repo.leases {
+		s.repo.leases[id] = s.thief
+	}
+	return &services.WebhookResponse{MessageID: "webhook-123-message-id"}, nil
+}
+
+func (s *stealingWebhookService) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+// slowWebhookService delays each call and tracks how many calls run at once
+type slowWebhookService struct {
+	mu          sync.Mutex
+	delay       time.Duration
+	inFlight    int
+	maxInFlight int
+	callCount   int
+}
+
+func (s *slowWebhookService) SendMessage(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+	s.mu.Lock()
+	s.callCount++
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(s.delay):
+		return &services.WebhookResponse{MessageID: "webhook-123-message-id"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *slowWebhookService) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+func TestMessageProcessingService_WorkerPool(t *testing.T) {
+	t.Run("messages are delivered concurrently up to the configured limit", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		for i := 0; i < 8; i++ {
+			testMsg := createTestMessage(t)
+			mockRepo.messages[testMsg.ID] = testMsg
+		}
+
+		webhook := &slowWebhookService{delay: 20 * time.Millisecond}
+		config := usecasePorts.ProcessingConfig{Concurrency: 4, MaxBatchSize: 20}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ProcessedCount != 8 || result.SuccessCount != 8 {
+			t.Errorf("Expected 8 processed and sent, got %d processed and %d sent", result.ProcessedCount, result.SuccessCount)
+		}
+
+		if webhook.maxInFlight < 2 || webhook.maxInFlight > 4 {
+			t.Errorf("Expected between 2 and 4 concurrent deliveries, got %d", webhook.maxInFlight)
+		}
+	})
+
+	t.Run("batch size is capped by configuration", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		for i := 0; i < 5; i++ {
+			testMsg := createTestMessage(t)
+			mockRepo.messages[testMsg.ID] = testMsg
+		}
+
+		config := usecasePorts.ProcessingConfig{MaxBatchSize: 3}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ProcessedCount != 3 {
+			t.Errorf("Expected processed count 3, got %d", result.ProcessedCount)
+		}
+	})
+
+	t.Run("messages not started before the batch deadline go back to the queue", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		for i := 0; i < 3; i++ {
+			testMsg := createTestMessage(t)
+			mockRepo.messages[testMsg.ID] = testMsg
+		}
+
+		webhook := &slowWebhookService{delay: time.Second}
+		config := usecasePorts.ProcessingConfig{Concurrency: 1, BatchTimeout: 20 * time.Millisecond}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if webhook.callCount != 1 {
+			t.Errorf("Expected only the first message to reach the webhook, got %d calls", webhook.callCount)
+		}
+
+		if result.RetriedCount != 1 || result.DeferredCount != 2 {
+			t.Errorf("Expected 1 retried and 2 deferred, got %d retried and %d deferred", result.RetriedCount, result.DeferredCount)
+		}
+
+		for _, msg := range mockRepo.messages {
+			if msg.Status != message.StatusPending || msg.LockedBy != nil {
+				t.Errorf("Expected message %s back in queue without lease, got %s", msg.ID, msg.Status)
+			}
+		}
+	})
+}
+
+func TestMessageProcessingService_Suppression(t *testing.T) {
+	t.Run("recipient that opted out after creation is not sent to", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		webhook := &mockWebhookService{}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(testMsg.PhoneNumber.String()), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+		result, err := service.ProcessPendingMessages(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.SuppressedCount != 1 || result.SuccessCount != 0 || len(result.Errors) != 0 {
+			t.Errorf("Expected 1 suppressed message without errors, got %+v", result)
+		}
+		if webhook.callCount != 0 {
+			t.Errorf("Expected no webhook call, got %d", webhook.callCount)
+		}
+
+		stored := mockRepo.messages[testMsg.ID]
+		if stored.Status != message.StatusSuppressed || stored.LastError == nil {
+			t.Errorf("Expected SUPPRESSED with a reason, got %s (%v)", stored.Status, stored.LastError)
+		}
+		if stored.LockedBy != nil {
+			t.Error("Expected the lease to be released")
+		}
+	})
+
+	t.Run("suppression lookup failure returns the message to the queue", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		testMsg := createTestMessage(t)
+		mockRepo.messages[testMsg.ID] = testMsg
+
+		suppressionRepo := newMockSuppressionRepository()
+		suppressionRepo.failWith = errors.New("database unavailable")
+		webhook := &mockWebhookService{}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, suppressionRepo, newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+		result, err := service.ProcessPendingMessages(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.DeferredCount != 1 || len(result.Errors) != 1 {
+			t.Errorf("Expected 1 deferred message with an error, got %+v", result)
+		}
+		if webhook.callCount != 0 {
+			t.Errorf("Expected no webhook call, got %d", webhook.callCount)
+		}
+		if stored := mockRepo.messages[testMsg.ID]; stored.Status != message.StatusPending || stored.AttemptCount != 0 {
+			t.Errorf("Expected the message back in the queue without an attempt, got %s after %d attempts", stored.Status, stored.AttemptCount)
+		}
+	})
+}
+
+// Mock webhook service for testing priorities; the bulk message is held until the critical one is sent
+type priorityWebhookService struct {
+	bulkID       string
+	criticalID   string
+	bulkStarted  chan struct{}
+	criticalSent chan struct{}
+}
+
+func (p *priorityWebhookService) SendMessage(ctx context.Context, request services.WebhookRequest) (*services.WebhookResponse, error) {
+	switch request.MessageID {
+	case p.bulkID:
+		close(p.bulkStarted)
+		select {
+		case <-p.criticalSent:
+		case <-time.After(2 * time.Second):
+		}
+	case p.criticalID:
+		close(p.criticalSent)
+	}
+
+	return &services.WebhookResponse{MessageID: "webhook-123-message-id"}, nil
+}
+
+func (p *priorityWebhookService) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+func TestMessageProcessingService_Priorities(t *testing.T) {
+	t.Run("urgent messages are claimed before older regular ones", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		normalMsg := createTestMessage(t)
+		normalMsg.CreatedAt = time.Now().Add(-time.Hour)
+		mockRepo.messages[normalMsg.ID] = normalMsg
+
+		criticalMsg := createTestMessage(t)
+		if err := criticalMsg.SetPriority(message.PriorityCritical); err != nil {
+			t.Fatalf("Failed to set priority: %v", err)
+		}
+		mockRepo.messages[criticalMsg.ID] = criticalMsg
+
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), &mockWebhookService{}, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+		result, err := service.ProcessPendingMessages(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.SuccessCount != 1 {
+			t.Fatalf("Expected 1 sent message, got %d", result.SuccessCount)
+		}
+		if mockRepo.messages[criticalMsg.ID].Status != message.StatusSent || mockRepo.messages[normalMsg.ID].Status != message.StatusPending {
+			t.Error("Expected the critical message to be sent ahead of the older normal one")
+		}
+	})
+
+	t.Run("reserved workers deliver urgent messages while bulk ones are in flight", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		bulkMsg := createTestMessage(t)
+		if err := bulkMsg.SetPriority(message.PriorityBulk); err != nil {
+			t.Fatalf("Failed to set priority: %v", err)
+		}
+		mockRepo.messages[bulkMsg.ID] = bulkMsg
+
+		criticalMsg := createTestMessage(t)
+		if err := criticalMsg.SetPriority(message.PriorityCritical); err != nil {
+			t.Fatalf("Failed to set priority: %v", err)
+		}
+
+		webhook := &priorityWebhookService{
+			bulkID:       bulkMsg.ID.String(),
+			criticalID:   criticalMsg.ID.String(),
+			bulkStarted:  make(chan struct{}),
+			criticalSent: make(chan struct{}),
+		}
+
+		// The critical message is created once the bulk batch is already being delivered
+		go func() {
+			<-webhook.bulkStarted
+			mockRepo.mu.Lock()
+			mockRepo.messages[criticalMsg.ID] = criticalMsg
+			mockRepo.mu.Unlock()
+		}()
+
+		config := usecasePorts.ProcessingConfig{Concurrency: 2, UrgentPollInterval: 10 * time.Millisecond}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.ProcessedCount != 2 || result.SuccessCount != 2 {
+			t.Errorf("Expected 2 processed and sent, got %d processed and %d sent", result.ProcessedCount, result.SuccessCount)
+		}
+
+		select {
+		case <-webhook.criticalSent:
+		default:
+			t.Error("Expected the critical message to be sent while the bulk message was in flight")
+		}
+	})
+
+	t.Run("without regular messages all workers deliver urgent ones", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		for i := 0; i < 4; i++ {
+			testMsg := createTestMessage(t)
+			if err := testMsg.SetPriority(message.PriorityHigh); err != nil {
+				t.Fatalf("Failed to set priority: %v", err)
+			}
+			mockRepo.messages[testMsg.ID] = testMsg
+		}
+
+		webhook := &slowWebhookService{delay: 20 * time.Millisecond}
+		config := usecasePorts.ProcessingConfig{Concurrency: 4, UrgentReservedShare: 0.5}
+		service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), config)
+
+		result, err := service.ProcessPendingMessages(context.Background(), 4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.SuccessCount != 4 {
+			t.Errorf("Expected 4 sent messages, got %d", result.SuccessCount)
+		}
+		if webhook.maxInFlight < 3 {
+			t.Errorf("Expected urgent messages to use every worker, got %d concurrent deliveries", webhook.maxInFlight)
+		}
+	})
+}
+
+func TestMessageProcessingService_InvalidStoredPhoneNumber(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	testMsg := createTestMessage(t)
+	testMsg.PhoneNumber = message.PhoneNumber("+9055512345") // Stored before national lengths were checked
+	mockRepo.messages[testMsg.ID] = testMsg
+
+	webhook := &mockWebhookService{}
+	service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), webhook, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+	result, err := service.ProcessPendingMessages(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.FailedCount != 1 || len(result.Errors) != 0 {
+		t.Errorf("Expected 1 failed message without errors, got %+v", result)
+	}
+	if webhook.callCount != 0 {
+		t.Errorf("Expected no webhook call, got %d", webhook.callCount)
+	}
+
+	stored := mockRepo.messages[testMsg.ID]
+	if stored.Status != message.StatusFailed || stored.LastError == nil || stored.AttemptCount != 0 {
+		t.Errorf("Expected FAILED with a reason and no attempt, got %s (%v)", stored.Status, stored.LastError)
+	}
+}
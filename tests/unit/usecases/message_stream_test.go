@@ -0,0 +1,285 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/services"
+)
+
+// Mock status event repository for testing
+type mockStatusEventRepository struct {
+	mu     sync.Mutex
+	events []message.StatusEvent
+}
+
+func (m *mockStatusEventRepository) add(event message.StatusEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *mockStatusEventRepository) ListAfter(ctx context.Context, filter repositories.StatusEventFilter, limit int) ([]*message.StatusEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*message.StatusEvent
+	for i := range m.events {
+		event := m.events[i]
+		if event.ID <= filter.AfterID {
+			continue
+		}
+		if filter.Since != nil && event.OccurredAt.Before(*filter.Since) {
+			continue
+		}
+		if filter.PhoneNumber != nil && event.PhoneNumber != *filter.PhoneNumber {
+			continue
+		}
+		if len(filter.Statuses) > 0 {
+			matches := false
+			for _, status := range filter.Statuses {
+				matches = matches || status == event.To
+			}
+			if !matches {
+				continue
+			}
+		}
+		result = append(result, &event)
+	}
+
+	// Events are listed in ID order even when they were stored out of order
+	for i := 1; i < len(result); i++ {
+		for j := i; j > 0 && result[j].ID < result[j-1].ID; j-- {
+			result[j], result[j-1] = result[j-1], result[j]
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockStatusEventRepository) LatestID(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest int64
+	for _, event := range m.events {
+		if event.ID > latest {
+			latest = event.ID
+		}
+	}
+	return latest, nil
+}
+
+// Mock status event notifier for testing; notify signals the hub like a stored change would
+type mockStatusEventNotifier struct {
+	listening chan func()
+}
+
+func (m *mockStatusEventNotifier) Listen(ctx context.Context, notify func()) error {
+	m.listening <- notify
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func startTestStatusEventHub(t *testing.T, repo *mockStatusEventRepository) (*services.StatusEventHub, func()) {
+	t.Helper()
+
+	notifier := &mockStatusEventNotifier{listening: make(chan func(), 1)}
+	hub := services.NewStatusEventHub(repo, notifier, services.StatusEventHubConfig{
+		DefaultRegion: "TR",
+		ResumeWindow:  time.Hour,
+		PollInterval:  time.Hour, // Only notifications trigger reads
+		BufferSize:    16,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := hub.Start(ctx); err != nil {
+		t.Fatalf("Failed to start hub: %v", err)
+	}
+	t.Cleanup(func() {
+		hub.Stop()
+		cancel()
+	})
+
+	select {
+	case notify := <-notifier.listening:
+		return hub, notify
+	case <-time.After(time.Second):
+		t.Fatal("Expected the hub to listen for notifications")
+		return nil, nil
+	}
+}
+
+func receiveStatusEvent(t *testing.T, events <-chan usecases.StatusEventResponse) usecases.StatusEventResponse {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("Expected an event, the stream was closed")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Expected an event, got none")
+	}
+	return usecases.StatusEventResponse{}
+}
+
+func expectNoStatusEvent(t *testing.T, events <-chan usecases.StatusEventResponse) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		t.Errorf("Expected no event, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStatusEventHub_StreamStatusEvents(t *testing.T) {
+	t.Run("live changes are filtered by status and phone number", func(t *testing.T) {
+		repo := &mockStatusEventRepository{}
+		repo.add(createTestStatusEvent(1, message.StatusSent, time.Now()))
+		hub, notify := startTestStatusEventHub(t, repo)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := hub.StreamStatusEvents(ctx, usecases.StreamStatusEventsQuery{
+			Statuses:    []string{"sent"},
+			PhoneNumber: "0555 123 45 67",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		created := createTestStatusEvent(2, message.StatusPending, time.Now())
+		created.From = nil
+		repo.add(created)
+		repo.add(createTestStatusEvent(3, message.StatusSent, time.Now()))
+		other := createTestStatusEvent(4, message.StatusSent, time.Now())
+		other.PhoneNumber = message.PhoneNumber("+905559876543")
+		repo.add(other)
+		notify()
+
+		if event := receiveStatusEvent(t, events); event.ID != 3 || event.Type != usecases.MessageStatusChangedEvent || event.To != "SENT" {
+			t.Errorf("Expected event 3, got %+v", event)
+		}
+		expectNoStatusEvent(t, events)
+	})
+
+	t.Run("new messages are streamed as created", func(t *testing.T) {
+		repo := &mockStatusEventRepository{}
+		hub, notify := startTestStatusEventHub(t, repo)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, _ := hub.StreamStatusEvents(ctx, usecases.StreamStatusEventsQuery{})
+
+		created := createTestStatusEvent(1, message.StatusPending, time.Now())
+		created.From = nil
+		repo.add(created)
+		notify()
+
+		if event := receiveStatusEvent(t, events); event.Type != usecases.MessageCreatedEvent || event.From != nil {
+			t.Errorf("Expected a created event, got %+v", event)
+		}
+	})
+
+	t.Run("resuming sends the missed changes once", func(t *testing.T) {
+		repo := &mockStatusEventRepository{}
+		repo.add(createTestStatusEvent(1, message.StatusSent, time.Now().Add(-2*time.Hour))) // Outside the resume window
+		repo.add(createTestStatusEvent(2, message.StatusSent, time.Now()))
+		repo.add(createTestStatusEvent(3, message.StatusFailed, time.Now()))
+		hub, notify := startTestStatusEventHub(t, repo)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		lastEventID := int64(0)
+		events, err := hub.StreamStatusEvents(ctx, usecases.StreamStatusEventsQuery{LastEventID: &lastEventID})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		repo.add(createTestStatusEvent(4, message.StatusSent, time.Now()))
+		notify()
+
+		for _, expected := range []int64{2, 3, 4} {
+			if event := receiveStatusEvent(t, events); event.ID != expected {
+				t.Errorf("Expected event %d, got %d", expected, event.ID)
+			}
+		}
+		expectNoStatusEvent(t, events)
+	})
+
+	t.Run("changes committed out of order are still streamed", func(t *testing.T) {
+		repo := &mockStatusEventRepository{}
+		hub, notify := startTestStatusEventHub(t, repo)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, _ := hub.StreamStatusEvents(ctx, usecases.StreamStatusEventsQuery{})
+
+		repo.add(createTestStatusEvent(2, message.StatusSent, time.Now()))
+		notify()
+		if event := receiveStatusEvent(t, events); event.ID != 2 {
+			t.Errorf("Expected event 2, got %d", event.ID)
+		}
+
+		repo.add(createTestStatusEvent(1, message.StatusSent, time.Now()))
+		notify()
+		if event := receiveStatusEvent(t, events); event.ID != 1 {
+			t.Errorf("Expected event 1, got %d", event.ID)
+		}
+		expectNoStatusEvent(t, events)
+	})
+
+	t.Run("the stream ends with the context", func(t *testing.T) {
+		hub, _ := startTestStatusEventHub(t, &mockStatusEventRepository{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, _ := hub.StreamStatusEvents(ctx, usecases.StreamStatusEventsQuery{})
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Error("Expected the stream to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Error("Expected the stream to be closed")
+		}
+	})
+
+	t.Run("invalid filters are rejected", func(t *testing.T) {
+		hub, _ := startTestStatusEventHub(t, &mockStatusEventRepository{})
+
+		for _, query := range []usecases.StreamStatusEventsQuery{
+			{Statuses: []string{"ARCHIVED"}},
+			{PhoneNumber: "not-a-number"},
+		} {
+			_, err := hub.StreamStatusEvents(context.Background(), query)
+			var validationErr domainErrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected a validation error for %+v, got %v", query, err)
+			}
+		}
+	})
+
+	t.Run("streaming requires a running hub", func(t *testing.T) {
+		hub := services.NewStatusEventHub(&mockStatusEventRepository{}, &mockStatusEventNotifier{}, services.StatusEventHubConfig{})
+
+		_, err := hub.StreamStatusEvents(context.Background(), usecases.StreamStatusEventsQuery{})
+		var businessErr domainErrors.BusinessError
+		if !errors.As(err, &businessErr) {
+			t.Errorf("Expected a business error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,388 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/repositories"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+// Mock subscription repository for testing
+type mockSubscriptionRepository struct {
+	mu            sync.Mutex
+	subscriptions map[message.SubscriptionID]*message.Subscription
+	order         []message.SubscriptionID
+}
+
+func newMockSubscriptionRepository() *mockSubscriptionRepository {
+	return &mockSubscriptionRepository{
+		subscriptions: make(map[message.SubscriptionID]*message.Subscription),
+	}
+}
+
+func (m *mockSubscriptionRepository) Create(ctx context.Context, subscription *message.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *subscription
+	m.subscriptions[subscription.ID] = &copied
+	m.order = append(m.order, subscription.ID)
+	return nil
+}
+
+func (m *mockSubscriptionRepository) Update(ctx context.Context, subscription *message.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.subscriptions[subscription.ID]; !exists {
+		return domainErrors.NewNotFoundError("subscription not found for update")
+	}
+	copied := *subscription
+	m.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (m *mockSubscriptionRepository) Delete(ctx context.Context, id message.SubscriptionID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.subscriptions[id]; !exists {
+		return domainErrors.NewNotFoundError("subscription not found")
+	}
+	delete(m.subscriptions, id)
+	for i, stored := range m.order {
+		if stored == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockSubscriptionRepository) GetByID(ctx context.Context, id message.SubscriptionID) (*message.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscription, exists := m.subscriptions[id]
+	if !exists {
+		return nil, domainErrors.NewNotFoundError("subscription not found")
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (m *mockSubscriptionRepository) List(ctx context.Context, pagination repositories.Pagination) ([]*message.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var subscriptions []*message.Subscription
+	for i := pagination.Offset; i < len(m.order) && len(subscriptions) < pagination.Limit; i++ {
+		copied := *m.subscriptions[m.order[i]]
+		subscriptions = append(subscriptions, &copied)
+	}
+	return subscriptions, nil
+}
+
+func (m *mockSubscriptionRepository) Count(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.subscriptions)), nil
+}
+
+// Mock event delivery repository for testing; events stands in for the status history
+type mockEventDeliveryRepository struct {
+	mu         sync.Mutex
+	events     []message.StatusEvent
+	deliveries []*message.EventDelivery
+	leases     map[int64]string
+	nextID     int64
+}
+
+func newMockEventDeliveryRepository() *mockEventDeliveryRepository {
+	return &mockEventDeliveryRepository{
+		leases: make(map[int64]string),
+	}
+}
+
+// queue adds a pending delivery of the event to the subscription, as the status change trigger does
+func (m *mockEventDeliveryRepository) queue(subscription *message.Subscription, event message.StatusEvent) *message.EventDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	delivery := &message.EventDelivery{
+		ID:             m.nextID,
+		SubscriptionID: subscription.ID,
+		Event:          event,
+		Status:         message.EventDeliveryPending,
+		NextAttemptAt:  time.Now(),
+		CreatedAt:      time.Now(),
+	}
+	m.deliveries = append(m.deliveries, delivery)
+	return delivery
+}
+
+func (m *mockEventDeliveryRepository) ClaimDue(ctx context.Context, workerID string, limit int, leaseDuration time.Duration) ([]*message.EventDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []*message.EventDelivery
+	for _, delivery := range m.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status != message.EventDeliveryPending || delivery.NextAttemptAt.After(time.Now()) || m.leases[delivery.ID] != "" {
+			continue
+		}
+		m.leases[delivery.ID] = workerID
+		copied := *delivery
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (m *mockEventDeliveryRepository) UpdateClaimed(ctx context.Context, delivery *message.EventDelivery, workerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.leases[delivery.ID] != workerID {
+		return domainErrors.NewConflictError("event delivery %d is no longer leased to worker %s", delivery.ID, workerID)
+	}
+	delete(m.leases, delivery.ID)
+	for i, stored := range m.deliveries {
+		if stored.ID == delivery.ID {
+			copied := *delivery
+			m.deliveries[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (m *mockEventDeliveryRepository) ListBySubscription(ctx context.Context, id message.SubscriptionID, filter repositories.EventDeliveryFilter, pagination repositories.Pagination) ([]*message.EventDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matching []*message.EventDelivery
+	for i := len(m.deliveries) - 1; i >= 0; i-- {
+		delivery := m.deliveries[i]
+		if delivery.SubscriptionID == id && (filter.Status == nil || delivery.Status == *filter.Status) {
+			copied := *delivery
+			matching = append(matching, &copied)
+		}
+	}
+	if pagination.Offset >= len(matching) {
+		return nil, nil
+	}
+	return matching[pagination.Offset:min(len(matching), pagination.Offset+pagination.Limit)], nil
+}
+
+func (m *mockEventDeliveryRepository) CountBySubscription(ctx context.Context, id message.SubscriptionID, filter repositories.EventDeliveryFilter) (int64, error) {
+	deliveries, _ := m.ListBySubscription(ctx, id, filter, repositories.NewPagination(len(m.deliveries), 0))
+	return int64(len(deliveries)), nil
+}
+
+func (m *mockEventDeliveryRepository) CountEvents(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error) {
+	return int64(len(m.eventsInRange(subscription, from, to))), nil
+}
+
+func (m *mockEventDeliveryRepository) Replay(ctx context.Context, subscription *message.Subscription, from, to time.Time) (int64, error) {
+	events := m.eventsInRange(subscription, from, to)
+	for _, event := range events {
+		m.queue(subscription, event).Replay = true
+	}
+	return int64(len(events)), nil
+}
+
+func (m *mockEventDeliveryRepository) eventsInRange(subscription *message.Subscription, from, to time.Time) []message.StatusEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []message.StatusEvent
+	for _, event := range m.events {
+		if !event.OccurredAt.Before(from) && event.OccurredAt.Before(to) && subscription.Matches(event.To) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// createTestStatusEvent builds a status change of a message to the given status
+func createTestStatusEvent(id int64, to message.Status, occurredAt time.Time) message.StatusEvent {
+	from := message.StatusPending
+	return message.StatusEvent{
+		ID:          id,
+		PhoneNumber: message.PhoneNumber("+905551234567"),
+		StatusChange: message.StatusChange{
+			MessageID:  message.NewMessageID(),
+			From:       &from,
+			To:         to,
+			Trigger:    message.TriggerScheduler,
+			OccurredAt: occurredAt,
+		},
+	}
+}
+
+func TestSubscriptionManagementService_Lifecycle(t *testing.T) {
+	subscriptionRepo := newMockSubscriptionRepository()
+	service := usecaseImpl.NewSubscriptionManagementService(subscriptionRepo, newMockEventDeliveryRepository(), usecases.SubscriptionConfig{})
+	ctx := context.Background()
+
+	created, err := service.CreateSubscription(ctx, usecases.CreateSubscriptionCommand{
+		URL:         "https://crm.example.com/hooks",
+		Description: "CRM",
+		Statuses:    []string{"delivered", "undelivered"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.Secret == "" || !created.Active || strings.Join(created.Statuses, ",") != "DELIVERED,UNDELIVERED" {
+		t.Errorf("Expected an active subscription with its secret, got %+v", created)
+	}
+
+	fetched, err := service.GetSubscription(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetched.Secret != "" {
+		t.Error("Expected the secret to be left out after creation")
+	}
+
+	updated, err := service.UpdateSubscription(ctx, usecases.UpdateSubscriptionCommand{
+		ID:     created.ID,
+		URL:    "https://crm.example.com/hooks/v2",
+		Active: false,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.URL != "https://crm.example.com/hooks/v2" || updated.Active || len(updated.Statuses) != 0 {
+		t.Errorf("Expected the subscription to be replaced, got %+v", updated)
+	}
+	stored := subscriptionRepo.subscriptions[message.SubscriptionID(created.ID.String())]
+	if stored.Secret != created.Secret {
+		t.Error("Expected the secret to stay the same across updates")
+	}
+
+	list, err := service.ListSubscriptions(ctx, usecases.ListSubscriptionsQuery{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if list.TotalCount != 1 || len(list.Subscriptions) != 1 || list.HasMore {
+		t.Errorf("Expected one subscription, got %+v", list)
+	}
+
+	if err := service.DeleteSubscription(ctx, created.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := service.GetSubscription(ctx, created.ID); err == nil {
+		t.Error("Expected the deleted subscription to be gone")
+	}
+}
+
+func TestSubscriptionManagementService_Validation(t *testing.T) {
+	service := usecaseImpl.NewSubscriptionManagementService(newMockSubscriptionRepository(), newMockEventDeliveryRepository(), usecases.SubscriptionConfig{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"invalid URL", func() error {
+			_, err := service.CreateSubscription(ctx, usecases.CreateSubscriptionCommand{URL: "not a url"})
+			return err
+		}},
+		{"unknown status", func() error {
+			_, err := service.CreateSubscription(ctx, usecases.CreateSubscriptionCommand{URL: "https://crm.example.com/hooks", Statuses: []string{"READ"}})
+			return err
+		}},
+		{"empty ID", func() error {
+			_, err := service.GetSubscription(ctx, uuid.Nil)
+			return err
+		}},
+		{"negative offset", func() error {
+			_, err := service.ListSubscriptions(ctx, usecases.ListSubscriptionsQuery{Offset: -1})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr domainErrors.ValidationError
+			if err := tt.call(); !errors.As(err, &validationErr) {
+				t.Errorf("Expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubscriptionManagementService_ReplayEvents(t *testing.T) {
+	subscriptionRepo := newMockSubscriptionRepository()
+	deliveryRepo := newMockEventDeliveryRepository()
+	service := usecaseImpl.NewSubscriptionManagementService(subscriptionRepo, deliveryRepo, usecases.SubscriptionConfig{ReplayMaxEvents: 2})
+	ctx := context.Background()
+
+	created, err := service.CreateSubscription(ctx, usecases.CreateSubscriptionCommand{
+		URL:      "https://crm.example.com/hooks",
+		Statuses: []string{"SENT"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	deliveryRepo.events = []message.StatusEvent{
+		createTestStatusEvent(1, message.StatusSent, start.Add(time.Hour)),
+		createTestStatusEvent(2, message.StatusFailed, start.Add(2*time.Hour)),
+		createTestStatusEvent(3, message.StatusSent, start.Add(3*time.Hour)),
+		createTestStatusEvent(4, message.StatusSent, start.Add(25*time.Hour)),
+	}
+
+	t.Run("matching events in range are queued again", func(t *testing.T) {
+		result, err := service.ReplayEvents(ctx, usecases.ReplayEventsCommand{
+			SubscriptionID: created.ID,
+			From:           start,
+			To:             start.Add(24 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Queued != 2 {
+			t.Errorf("Expected 2 events queued, got %d", result.Queued)
+		}
+
+		log, err := service.ListDeliveries(ctx, usecases.ListEventDeliveriesQuery{SubscriptionID: created.ID, Status: "pending"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if log.TotalCount != 2 || !log.Deliveries[0].Replay || log.Deliveries[0].EventID != 3 || log.Deliveries[0].NextAttemptAt == nil {
+			t.Errorf("Expected the replayed deliveries, most recent first, got %+v", log)
+		}
+	})
+
+	t.Run("range holding too many events is rejected", func(t *testing.T) {
+		_, err := service.ReplayEvents(ctx, usecases.ReplayEventsCommand{
+			SubscriptionID: created.ID,
+			From:           start,
+			To:             start.Add(48 * time.Hour),
+		})
+		var validationErr domainErrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+
+	t.Run("empty range is rejected", func(t *testing.T) {
+		_, err := service.ReplayEvents(ctx, usecases.ReplayEventsCommand{SubscriptionID: created.ID, From: start, To: start})
+		var validationErr domainErrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+
+	t.Run("unknown delivery status is rejected", func(t *testing.T) {
+		_, err := service.ListDeliveries(ctx, usecases.ListEventDeliveriesQuery{SubscriptionID: created.ID, Status: "lost"})
+		var validationErr domainErrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+}
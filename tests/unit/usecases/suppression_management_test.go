@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+func TestSuppressionManagementService_AddAndRemove(t *testing.T) {
+	t.Run("normalizes the phone number and keeps the first entry", func(t *testing.T) {
+		suppressionRepo := newMockSuppressionRepository()
+		service := usecaseImpl.NewSuppressionManagementService(suppressionRepo, usecases.SuppressionConfig{DefaultRegion: "TR"})
+
+		first, err := service.AddSuppression(context.Background(), usecases.AddSuppressionCommand{PhoneNumber: "0555 123 45 67", Reason: "replied STOP"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if first.PhoneNumber != "+905551234567" || first.Source != "api" {
+			t.Errorf("Expected normalized API entry, got %+v", first)
+		}
+
+		again, err := service.AddSuppression(context.Background(), usecases.AddSuppressionCommand{PhoneNumber: "+90 555 123 4567", Reason: "complaint"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if again.Reason != "replied STOP" || len(suppressionRepo.suppressions) != 1 {
+			t.Errorf("Expected the original entry to be kept, got %+v", again)
+		}
+	})
+
+	t.Run("check and remove accept any spelling of the number", func(t *testing.T) {
+		service := usecaseImpl.NewSuppressionManagementService(newMockSuppressionRepository("+905551234567"), usecases.SuppressionConfig{DefaultRegion: "TR"})
+
+		if _, err := service.CheckSuppression(context.Background(), "05551234567"); err != nil {
+			t.Fatalf("Expected the number to be suppressed, got %v", err)
+		}
+
+		if err := service.RemoveSuppression(context.Background(), "905551234567"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var notFoundErr domainErrors.NotFoundError
+		if _, err := service.CheckSuppression(context.Background(), "+905551234567"); !errors.As(err, &notFoundErr) {
+			t.Errorf("Expected not found error after removal, got %v", err)
+		}
+		if err := service.RemoveSuppression(context.Background(), "+905551234567"); !errors.As(err, &notFoundErr) {
+			t.Errorf("Expected not found error when removing twice, got %v", err)
+		}
+	})
+
+	t.Run("invalid phone number is rejected", func(t *testing.T) {
+		service := usecaseImpl.NewSuppressionManagementService(newMockSuppressionRepository(), usecases.SuppressionConfig{})
+
+		var validationErr domainErrors.ValidationError
+		if _, err := service.AddSuppression(context.Background(), usecases.AddSuppressionCommand{PhoneNumber: "12ab"}); !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+		if _, err := service.CheckSuppression(context.Background(), ""); !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error for an empty number, got %v", err)
+		}
+	})
+}
+
+func TestSuppressionManagementService_ImportSuppressions(t *testing.T) {
+	t.Run("reports rejected and duplicate entries", func(t *testing.T) {
+		suppressionRepo := newMockSuppressionRepository("+905551234567")
+		service := usecaseImpl.NewSuppressionManagementService(suppressionRepo, usecases.SuppressionConfig{})
+
+		result, err := service.ImportSuppressions(context.Background(), usecases.ImportSuppressionsCommand{
+			Reason: "carrier list",
+			Suppressions: []usecases.AddSuppressionCommand{
+				{PhoneNumber: "+905551234567"},                       // Already suppressed
+				{PhoneNumber: "+905559876543", Reason: "own reason"}, // Added
+				{PhoneNumber: "not a number"},                        // Rejected
+				{PhoneNumber: "+90 555 987 65 43"},                   // Repeated in the import
+				{PhoneNumber: "+14155552671"},                        // Added with the default reason
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.AddedCount != 2 || result.DuplicateCount != 2 || result.RejectedCount != 1 {
+			t.Errorf("Expected 2 added, 2 duplicates and 1 rejected, got %+v", result)
+		}
+		if len(result.RejectedEntries) != 1 || result.RejectedEntries[0].Index != 2 {
+			t.Errorf("Expected entry 2 to be rejected, got %+v", result.RejectedEntries)
+		}
+		if got := suppressionRepo.suppressions["+14155552671"]; got.Reason != "carrier list" || got.Source != message.SuppressionSourceImport {
+			t.Errorf("Expected the default reason and import source, got %+v", got)
+		}
+		if got := suppressionRepo.suppressions["+905559876543"]; got.Reason != "own reason" {
+			t.Errorf("Expected the entry's own reason to win, got %q", got.Reason)
+		}
+	})
+
+	t.Run("enforces the item limit", func(t *testing.T) {
+		service := usecaseImpl.NewSuppressionManagementService(newMockSuppressionRepository(), usecases.SuppressionConfig{ImportMaxItems: 1})
+
+		var validationErr domainErrors.ValidationError
+		_, err := service.ImportSuppressions(context.Background(), usecases.ImportSuppressionsCommand{
+			Suppressions: []usecases.AddSuppressionCommand{{PhoneNumber: "+905551234567"}, {PhoneNumber: "+905559876543"}},
+		})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+
+		if _, err := service.ImportSuppressions(context.Background(), usecases.ImportSuppressionsCommand{}); !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error for an empty import, got %v", err)
+		}
+	})
+}
+
+func TestSuppressionManagementService_ListSuppressions(t *testing.T) {
+	service := usecaseImpl.NewSuppressionManagementService(newMockSuppressionRepository("+905551234567", "+905559876543", "+14155552671"), usecases.SuppressionConfig{})
+
+	result, err := service.ListSuppressions(context.Background(), usecases.ListSuppressionsQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Suppressions) != 2 || result.TotalCount != 3 || !result.HasMore {
+		t.Errorf("Expected first page of 2 out of 3, got %+v", result)
+	}
+}
+
+func TestMessageManagementService_CreateMessage_Suppressed(t *testing.T) {
+	t.Run("message to a suppressed recipient is stored as suppressed", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository("+905551234567"), newMockCacheServiceForManagement(), usecases.ManagementConfig{DefaultRegion: "TR"})
+
+		result, err := service.CreateMessage(context.Background(), usecases.CreateMessageCommand{
+			PhoneNumber: "0555 123 45 67",
+			Content:     "Your code is 1234",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.Status != string(message.StatusSuppressed) {
+			t.Errorf("Expected status SUPPRESSED, got %s", result.Status)
+		}
+		if result.LastError == nil || *result.LastError != "recipient is on the suppression list: replied STOP" {
+			t.Errorf("Expected the suppression reason, got %v", result.LastError)
+		}
+		if len(mockRepo.messages) != 1 {
+			t.Errorf("Expected the suppressed message to be stored, got %d messages", len(mockRepo.messages))
+		}
+	})
+
+	t.Run("batch suppresses only the opted-out recipients", func(t *testing.T) {
+		service := usecaseImpl.NewMessageManagementService(newMockMessageRepository(), newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), newMockSuppressionRepository("+905551234567"), newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		result, err := service.CreateMessages(context.Background(), []usecases.CreateMessageCommand{
+			{PhoneNumber: "+905551234567", Content: "Hello"},
+			{PhoneNumber: "+905559876543", Content: "Hello"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := result.Items[0].Message.Status; got != string(message.StatusSuppressed) {
+			t.Errorf("Expected first item SUPPRESSED, got %s", got)
+		}
+		if got := result.Items[1].Message.Status; got != string(message.StatusPending) {
+			t.Errorf("Expected second item PENDING, got %s", got)
+		}
+	})
+
+	t.Run("suppression lookup failure fails the request", func(t *testing.T) {
+		suppressionRepo := newMockSuppressionRepository()
+		suppressionRepo.failWith = errors.New("database unavailable")
+		mockRepo := newMockMessageRepository()
+		service := usecaseImpl.NewMessageManagementService(mockRepo, newMockIdempotencyRepository(), newMockAttemptRepository(), newMockImportJobRepository(), newMockTemplateRepository(), suppressionRepo, newMockCacheServiceForManagement(), usecases.ManagementConfig{})
+
+		if _, err := service.CreateMessage(context.Background(), usecases.CreateMessageCommand{PhoneNumber: "+905551234567", Content: "Hello"}); err == nil {
+			t.Error("Expected error when the suppression list cannot be checked")
+		}
+		if len(mockRepo.messages) != 0 {
+			t.Errorf("Expected no message to be stored, got %d", len(mockRepo.messages))
+		}
+	})
+}
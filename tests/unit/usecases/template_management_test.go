@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/errors"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+func TestTemplateManagementService_CreateTemplate(t *testing.T) {
+	t.Run("stores the first version with its variables", func(t *testing.T) {
+		templateRepo := newMockTemplateRepository()
+		service := usecaseImpl.NewTemplateManagementService(templateRepo)
+
+		result, err := service.CreateTemplate(context.Background(), usecases.CreateTemplateCommand{
+			Name: "  otp ",
+			Body: "Code {{code}} expires in {{minutes}} minutes. Code: {{code}}",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if result.Name != "otp" || result.Version != 1 {
+			t.Errorf("Expected trimmed name and version 1, got %q v%d", result.Name, result.Version)
+		}
+		if len(result.Variables) != 2 || result.Variables[0] != "code" || result.Variables[1] != "minutes" {
+			t.Errorf("Expected variables [code minutes], got %v", result.Variables)
+		}
+		if len(templateRepo.templates) != 1 {
+			t.Errorf("Expected 1 stored template, got %d", len(templateRepo.templates))
+		}
+	})
+
+	t.Run("duplicate name is a conflict", func(t *testing.T) {
+		service := usecaseImpl.NewTemplateManagementService(newMockTemplateRepository())
+		cmd := usecases.CreateTemplateCommand{Name: "welcome", Body: "Welcome!"}
+
+		if _, err := service.CreateTemplate(context.Background(), cmd); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var conflictErr domainErrors.ConflictError
+		if _, err := service.CreateTemplate(context.Background(), cmd); !errors.As(err, &conflictErr) {
+			t.Errorf("Expected conflict error, got %v", err)
+		}
+	})
+
+	t.Run("invalid body is rejected", func(t *testing.T) {
+		service := usecaseImpl.NewTemplateManagementService(newMockTemplateRepository())
+
+		var validationErr domainErrors.ValidationError
+		_, err := service.CreateTemplate(context.Background(), usecases.CreateTemplateCommand{Name: "broken", Body: "Hi {{first name}}"})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestTemplateManagementService_UpdateTemplate(t *testing.T) {
+	templateRepo := newMockTemplateRepository()
+	template, _ := message.NewTemplate("reminder", "See you at {{time}}")
+	templateRepo.templates[template.ID] = *template
+	id := uuid.MustParse(template.ID.String())
+	service := usecaseImpl.NewTemplateManagementService(templateRepo)
+
+	t.Run("changed body creates a new version", func(t *testing.T) {
+		result, err := service.UpdateTemplate(context.Background(), usecases.UpdateTemplateCommand{ID: id, Body: "See you at {{time}} in {{place}}"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Version != 2 {
+			t.Errorf("Expected version 2, got %d", result.Version)
+		}
+		if stored := templateRepo.templates[template.ID]; stored.Version != 2 {
+			t.Errorf("Expected stored version 2, got %d", stored.Version)
+		}
+	})
+
+	t.Run("unchanged body keeps the version", func(t *testing.T) {
+		result, err := service.UpdateTemplate(context.Background(), usecases.UpdateTemplateCommand{ID: id, Body: "See you at {{time}} in {{place}}"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Version != 2 {
+			t.Errorf("Expected version to stay 2, got %d", result.Version)
+		}
+	})
+
+	t.Run("unknown template is reported", func(t *testing.T) {
+		var notFoundErr domainErrors.NotFoundError
+		_, err := service.UpdateTemplate(context.Background(), usecases.UpdateTemplateCommand{ID: uuid.New(), Body: "Hello"})
+		if !errors.As(err, &notFoundErr) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestTemplateManagementService_ListAndDelete(t *testing.T) {
+	templateRepo := newMockTemplateRepository()
+	service := usecaseImpl.NewTemplateManagementService(templateRepo)
+
+	for _, name := range []string{"charlie", "alpha", "bravo"} {
+		if _, err := service.CreateTemplate(context.Background(), usecases.CreateTemplateCommand{Name: name, Body: "Hello"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	result, err := service.ListTemplates(context.Background(), usecases.ListTemplatesQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TotalCount != 3 || !result.HasMore || len(result.Templates) != 2 {
+		t.Fatalf("Expected first page of 2 out of 3, got %d of %d", len(result.Templates), result.TotalCount)
+	}
+	if result.Templates[0].Name != "alpha" {
+		t.Errorf("Expected templates ordered by name, got %s first", result.Templates[0].Name)
+	}
+
+	if err := service.DeleteTemplate(context.Background(), result.Templates[0].ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var notFoundErr domainErrors.NotFoundError
+	if _, err := service.GetTemplate(context.Background(), result.Templates[0].ID); !errors.As(err, &notFoundErr) {
+		t.Errorf("Expected deleted template to be gone, got %v", err)
+	}
+	if err := service.DeleteTemplate(context.Background(), result.Templates[0].ID); !errors.As(err, &notFoundErr) {
+		t.Errorf("Expected second delete to report not found, got %v", err)
+	}
+}
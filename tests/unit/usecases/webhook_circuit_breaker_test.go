@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	usecasePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/services"
+)
+
+// Mock webhook service for testing the circuit breaker; it fails with err until err is cleared
+type switchableWebhookService struct {
+	mu        sync.Mutex
+	err       error
+	callCount int
+}
+
+func (s *switchableWebhookService) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *switchableWebhookService) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.callCount
+}
+
+func (s *switchableWebhookService) SendMessage(ctx context.Context, request servicePorts.WebhookRequest) (*servicePorts.WebhookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callCount++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &servicePorts.WebhookResponse{MessageID: "webhook-123-message-id"}, nil
+}
+
+func (s *switchableWebhookService) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+func sendThroughBreaker(breaker *services.WebhookCircuitBreaker, times int) error {
+	var err error
+	for i := 0; i < times; i++ {
+		_, err = breaker.SendMessage(context.Background(), servicePorts.WebhookRequest{To: "+905551234567", Content: "Test message"})
+	}
+	return err
+}
+
+func TestWebhookCircuitBreaker(t *testing.T) {
+	serverErr := servicePorts.NewWebhookError(message.ErrorClassServerError, 503, errors.New("webhook returned HTTP 503"))
+
+	t.Run("consecutive transient failures open the circuit", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: serverErr}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 3, OpenDuration: time.Hour})
+
+		sendThroughBreaker(breaker, 3)
+		status := breaker.Status()
+		if !status.IsOpen() || status.ConsecutiveFailures != 3 || status.RetryAt == nil {
+			t.Fatalf("Expected an open circuit after 3 failures, got %+v", status)
+		}
+
+		err := sendThroughBreaker(breaker, 1)
+		var circuitOpenErr *servicePorts.CircuitOpenError
+		if !errors.As(err, &circuitOpenErr) {
+			t.Errorf("Expected a circuit open error, got %v", err)
+		}
+		if webhook.calls() != 3 {
+			t.Errorf("Expected the open circuit not to call the webhook, got %d calls", webhook.calls())
+		}
+	})
+
+	t.Run("a success resets the failure count", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: serverErr}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 3, OpenDuration: time.Hour})
+
+		sendThroughBreaker(breaker, 2)
+		webhook.fail(nil)
+		sendThroughBreaker(breaker, 1)
+		webhook.fail(serverErr)
+		sendThroughBreaker(breaker, 2)
+
+		if status := breaker.Status(); status.State != servicePorts.CircuitClosed || status.ConsecutiveFailures != 2 {
+			t.Errorf("Expected a closed circuit with 2 failures, got %+v", status)
+		}
+	})
+
+	t.Run("permanent and throttled failures do not open the circuit", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: servicePorts.NewWebhookError(message.ErrorClassClientError, 400, errors.New("webhook returned HTTP 400"))}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour})
+
+		sendThroughBreaker(breaker, 3)
+		webhook.fail(servicePorts.NewThrottledError(time.Second))
+		sendThroughBreaker(breaker, 3)
+
+		if status := breaker.Status(); status.State != servicePorts.CircuitClosed || status.ConsecutiveFailures != 0 {
+			t.Errorf("Expected a closed circuit without failures, got %+v", status)
+		}
+	})
+
+	t.Run("a successful trial closes the circuit", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: serverErr}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: 20 * time.Millisecond})
+
+		sendThroughBreaker(breaker, 1)
+		time.Sleep(30 * time.Millisecond)
+		if status := breaker.Status(); status.State != servicePorts.CircuitHalfOpen {
+			t.Fatalf("Expected a half-open circuit after the open duration, got %s", status.State)
+		}
+
+		webhook.fail(nil)
+		if err := sendThroughBreaker(breaker, 1); err != nil {
+			t.Fatalf("Expected the trial request to be sent, got %v", err)
+		}
+		if status := breaker.Status(); status.State != servicePorts.CircuitClosed {
+			t.Errorf("Expected the circuit to close, got %s", status.State)
+		}
+	})
+
+	t.Run("a failed trial reopens the circuit", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: serverErr}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: 20 * time.Millisecond})
+
+		sendThroughBreaker(breaker, 1)
+		time.Sleep(30 * time.Millisecond)
+		sendThroughBreaker(breaker, 1)
+
+		if status := breaker.Status(); !status.IsOpen() || webhook.calls() != 2 {
+			t.Errorf("Expected the circuit to reopen after one trial, got %s with %d calls", status.State, webhook.calls())
+		}
+	})
+}
+
+// Mock message processing use case for testing the scheduler
+type mockMessageProcessing struct {
+	mu           sync.Mutex
+	expireCalls  int
+	processCalls int
+}
+
+func (m *mockMessageProcessing) counts() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expireCalls, m.processCalls
+}
+
+func (m *mockMessageProcessing) ProcessPendingMessages(ctx context.Context, batchSize int) (*usecasePorts.ProcessingResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.processCalls++
+	return &usecasePorts.ProcessingResult{}, nil
+}
+
+func (m *mockMessageProcessing) ExpireOverdueMessages(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expireCalls++
+	return 0, nil
+}
+
+func (m *mockMessageProcessing) GetProcessingStatus(ctx context.Context) (*usecasePorts.ProcessingStatus, error) {
+	return &usecasePorts.ProcessingStatus{}, nil
+}
+
+// waitForCondition polls until condition holds or a second has passed
+func waitForCondition(condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestProcessingScheduler_CircuitBreaker(t *testing.T) {
+	t.Run("no messages are claimed while the circuit is open", func(t *testing.T) {
+		webhook := &switchableWebhookService{err: errors.New("connection refused")}
+		breaker := services.NewWebhookCircuitBreaker(webhook, services.CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+		sendThroughBreaker(breaker, 1)
+
+		processing := &mockMessageProcessing{}
+		scheduler := services.NewProcessingScheduler(processing, breaker, services.SchedulerConfig{Interval: time.Hour, BatchSize: 2})
+		if err := scheduler.Start(context.Background()); err != nil {
+			t.Fatalf("Failed to start scheduler: %v", err)
+		}
+		defer scheduler.Stop()
+
+		waitForCondition(func() bool { return scheduler.GetStats().SkippedRuns > 0 })
+
+		expireCalls, processCalls := processing.counts()
+		if expireCalls != 1 || processCalls != 0 {
+			t.Errorf("Expected expiry without claiming, got %d expiry and %d processing calls", expireCalls, processCalls)
+		}
+		if status := scheduler.CircuitStatus(); status == nil || !status.IsOpen() {
+			t.Errorf("Expected the scheduler to report the open circuit, got %+v", status)
+		}
+	})
+
+	t.Run("messages are claimed while the circuit is closed", func(t *testing.T) {
+		processing := &mockMessageProcessing{}
+		breaker := services.NewWebhookCircuitBreaker(&switchableWebhookService{}, services.CircuitBreakerConfig{})
+		scheduler := services.NewProcessingScheduler(processing, breaker, services.SchedulerConfig{Interval: time.Hour, BatchSize: 2})
+		if err := scheduler.Start(context.Background()); err != nil {
+			t.Fatalf("Failed to start scheduler: %v", err)
+		}
+		defer scheduler.Stop()
+
+		waitForCondition(func() bool { _, processCalls := processing.counts(); return processCalls > 0 })
+
+		if _, processCalls := processing.counts(); processCalls != 1 || scheduler.GetStats().SkippedRuns != 0 {
+			t.Errorf("Expected one processing run, got %d", processCalls)
+		}
+	})
+}
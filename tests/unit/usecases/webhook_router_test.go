@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/domain/message"
+	servicePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/services"
+	usecasePorts "github.com/svbnbyrk/go-message-dispatcher/internal/core/ports/usecases"
+	"github.com/svbnbyrk/go-message-dispatcher/internal/core/services"
+	usecaseImpl "github.com/svbnbyrk/go-message-dispatcher/internal/core/usecases"
+)
+
+func sendThroughRouter(router *services.WebhookRouter) (*servicePorts.WebhookResponse, error) {
+	return router.SendMessage(context.Background(), servicePorts.WebhookRequest{To: "+905551234567", Content: "Test message"})
+}
+
+func TestWebhookRouter(t *testing.T) {
+	serverErr := servicePorts.NewWebhookError(message.ErrorClassServerError, 503, errors.New("webhook returned HTTP 503"))
+
+	t.Run("the first provider sends while it is up", func(t *testing.T) {
+		primary, backup := &switchableWebhookService{}, &switchableWebhookService{}
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: primary},
+			{Name: "backup", Service: backup},
+		})
+
+		response, err := sendThroughRouter(router)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if response.Provider != "primary" || backup.calls() != 0 {
+			t.Errorf("Expected only the primary provider to be used, got %s with %d backup calls", response.Provider, backup.calls())
+		}
+	})
+
+	t.Run("transient failure fails over to the next provider", func(t *testing.T) {
+		primary, backup := &switchableWebhookService{err: serverErr}, &switchableWebhookService{}
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: primary},
+			{Name: "backup", Service: backup},
+		})
+
+		response, err := sendThroughRouter(router)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if response.Provider != "backup" || primary.calls() != 1 {
+			t.Errorf("Expected the backup provider to send after one primary call, got %s with %d primary calls", response.Provider, primary.calls())
+		}
+	})
+
+	t.Run("open circuit fails over without calling the provider", func(t *testing.T) {
+		primary, backup := &switchableWebhookService{err: serverErr}, &switchableWebhookService{}
+		breaker := services.NewWebhookCircuitBreaker(primary, services.CircuitBreakerConfig{Name: "primary", FailureThreshold: 1, OpenDuration: time.Hour})
+		sendThroughBreaker(breaker, 1)
+
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: breaker, Circuit: breaker},
+			{Name: "backup", Service: backup},
+		})
+
+		response, err := sendThroughRouter(router)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if response.Provider != "backup" || primary.calls() != 1 {
+			t.Errorf("Expected the backup provider to send without calling the primary again, got %s with %d primary calls", response.Provider, primary.calls())
+		}
+		if status := router.Status(); status.IsOpen() {
+			t.Errorf("Expected the router to stay closed while the backup is available, got %s", status.State)
+		}
+	})
+
+	t.Run("permanent failure does not fail over", func(t *testing.T) {
+		permanentErr := servicePorts.NewWebhookError(message.ErrorClassClientError, 400, errors.New("webhook returned HTTP 400"))
+		primary, backup := &switchableWebhookService{err: permanentErr}, &switchableWebhookService{}
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: primary},
+			{Name: "backup", Service: backup},
+		})
+
+		if _, err := sendThroughRouter(router); !errors.Is(err, permanentErr) {
+			t.Errorf("Expected the permanent failure, got %v", err)
+		}
+		if backup.calls() != 0 {
+			t.Errorf("Expected no failover, got %d backup calls", backup.calls())
+		}
+	})
+
+	t.Run("throttled provider fails over to the next provider", func(t *testing.T) {
+		primary, backup := &switchableWebhookService{err: servicePorts.NewThrottledError(time.Second)}, &switchableWebhookService{}
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: primary},
+			{Name: "backup", Service: backup},
+		})
+
+		response, err := sendThroughRouter(router)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if response.Provider != "backup" {
+			t.Errorf("Expected the backup provider to send, got %s", response.Provider)
+		}
+	})
+
+	t.Run("the shortest wait is returned once every provider is throttled", func(t *testing.T) {
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: &switchableWebhookService{err: servicePorts.NewThrottledError(time.Second)}},
+			{Name: "backup", Service: &switchableWebhookService{err: servicePorts.NewThrottledError(200 * time.Millisecond)}},
+		})
+
+		var throttledErr *servicePorts.ThrottledError
+		if _, err := sendThroughRouter(router); !errors.As(err, &throttledErr) || throttledErr.RetryAfter != 200*time.Millisecond {
+			t.Errorf("Expected a throttled error with the shortest wait, got %v", err)
+		}
+	})
+
+	t.Run("the last failure is returned once every provider failed", func(t *testing.T) {
+		backupErr := servicePorts.NewWebhookError(message.ErrorClassTimeout, 0, errors.New("webhook request timed out"))
+		router := services.NewWebhookRouter([]services.WebhookProvider{
+			{Name: "primary", Service: &switchableWebhookService{err: serverErr}},
+			{Name: "backup", Service: &switchableWebhookService{err: backupErr}},
+		})
+
+		if _, err := sendThroughRouter(router); !errors.Is(err, backupErr) {
+			t.Errorf("Expected the backup failure, got %v", err)
+		}
+	})
+
+	t.Run("every circuit open", func(t *testing.T) {
+		var providers []services.WebhookProvider
+		for _, name := range []string{"primary", "backup"} {
+			breaker := services.NewWebhookCircuitBreaker(&switchableWebhookService{err: serverErr}, services.CircuitBreakerConfig{Name: name, FailureThreshold: 1, OpenDuration: time.Hour})
+			sendThroughBreaker(breaker, 1)
+			providers = append(providers, services.WebhookProvider{Name: name, Service: breaker, Circuit: breaker})
+		}
+		router := services.NewWebhookRouter(providers)
+
+		var circuitOpenErr *servicePorts.CircuitOpenError
+		if _, err := sendThroughRouter(router); !errors.As(err, &circuitOpenErr) {
+			t.Errorf("Expected a circuit open error, got %v", err)
+		}
+		if status := router.Status(); !status.IsOpen() {
+			t.Errorf("Expected the router to report an open circuit, got %s", status.State)
+		}
+	})
+}
+
+func TestMessageProcessingService_RecordsProvider(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	testMsg := createTestMessage(t)
+	mockRepo.messages[testMsg.ID] = testMsg
+
+	serverErr := servicePorts.NewWebhookError(message.ErrorClassServerError, 503, errors.New("webhook returned HTTP 503"))
+	router := services.NewWebhookRouter([]services.WebhookProvider{
+		{Name: "primary", Service: &switchableWebhookService{err: serverErr}},
+		{Name: "backup", Service: &switchableWebhookService{}},
+	})
+	service := usecaseImpl.NewMessageProcessingService(mockRepo, newMockSuppressionRepository(), newMockDeliveryReceiptRepository(), router, newMockCacheServiceForProcessing(), usecasePorts.ProcessingConfig{})
+
+	result, err := service.ProcessPendingMessages(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.SuccessCount != 1 {
+		t.Fatalf("Expected 1 sent message, got %+v", result)
+	}
+
+	stored := mockRepo.messages[testMsg.ID]
+	if stored.Provider == nil || *stored.Provider != "backup" {
+		t.Errorf("Expected the message to record the backup provider, got %v", stored.Provider)
+	}
+}